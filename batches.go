@@ -178,6 +178,34 @@ type FeedReply struct {
 	FeedRecordID string
 }
 
+// FeedOptions definition.
+type FeedOptions struct {
+	// ReadinessCheckStation is the station to check the production readiness
+	// of the work order before feeding. There is no check if empty.
+	ReadinessCheckStation string
+}
+
+// FeedOption definition.
+type FeedOption func(*FeedOptions)
+
+// ParseFeedOptions parses the feed options.
+func ParseFeedOptions(opts []FeedOption) FeedOptions {
+	var o FeedOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithProductionReadinessCheck checks the production readiness of the work
+// order at the station before feeding, see CheckProductionReadiness for the
+// details.
+func WithProductionReadinessCheck(station string) FeedOption {
+	return func(o *FeedOptions) {
+		o.ReadinessCheckStation = station
+	}
+}
+
 // BatchID definition.
 type BatchID struct {
 	WorkOrder string
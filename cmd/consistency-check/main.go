@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"go.uber.org/zap"
+
+	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	patches "gitlab.kenda.com.tw/kenda/mcom/cmd/patches/common"
+	"gitlab.kenda.com.tw/kenda/mcom/impl"
+)
+
+func main() {
+	dbConnectionPath := flag.String("dbConnection", "", "path of db connection parameters yaml file")
+	repair := flag.Bool("repair", false, "repair the found issues")
+	user := flag.String("user", "ADMIN", "the user to update the repaired rows")
+	flag.Parse()
+
+	file, err := os.Open(*dbConnectionPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	dbConfigs, err := patches.DecodeDBConnectionYaml(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// #region log context
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		log.Fatal(err)
+	}
+	ctx := commonsCtx.WithLogger(context.Background(), logger)
+	ctx = commonsCtx.WithUserID(ctx, *user)
+	// #endregion log context
+
+	dm, err := impl.New(ctx, impl.PGConfig{
+		Address:  dbConfigs.Address,
+		Port:     dbConfigs.Port,
+		UserName: dbConfigs.UserName,
+		Password: dbConfigs.Password,
+		Database: dbConfigs.Name,
+	}, impl.WithPostgreSQLSchema(dbConfigs.Schema))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer dm.Close()
+
+	rep, err := dm.CheckConsistency(ctx, mcom.CheckConsistencyRequest{Repair: *repair})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("checked %d resources, %d site contents and %d stocks.\n", rep.CheckedResources, rep.CheckedSiteContents, rep.CheckedStocks)
+	for _, issue := range rep.Issues {
+		fmt.Println(issue)
+	}
+	fmt.Printf("%d issues found.\n", len(rep.Issues))
+}
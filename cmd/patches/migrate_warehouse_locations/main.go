@@ -0,0 +1,116 @@
+// The patch widens the warehouse location columns from varchar(2) to
+// varchar(32) and creates the warehouse locations from the existing
+// two-character locations.
+//
+// The created locations are top-level bins without capacity and product type
+// limitations, so the stocking behaves as before except that the locations
+// out of the master are rejected. Reorganize them into zones and racks by
+// creating the new locations afterwards.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"gorm.io/gorm/clause"
+
+	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
+
+	patches "gitlab.kenda.com.tw/kenda/mcom/cmd/patches/common"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+)
+
+func main() {
+	dbConnectionPath := flag.String("dbConnection", "", "path of db connection parameters yaml file")
+	user := flag.String("user", "ADMIN", "the user to create the locations")
+	flag.Parse()
+
+	file, err := os.Open(*dbConnectionPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	dbConfigs, err := patches.DecodeDBConnectionYaml(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	db, err := dbConfigs.ToGormDB()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// #region log context
+	ctx := context.Background()
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		log.Fatal(err)
+	}
+	ctx = commonsCtx.WithLogger(ctx, logger)
+	// #endregion log context
+
+	db = db.WithContext(ctx)
+	for _, column := range []struct{ table, name string }{
+		{table: "warehouse_stock", name: "location"},
+		{table: "material_resource", name: "warehouse_location"},
+		{table: "resource_transport_record", name: "old_location"},
+		{table: "resource_transport_record", name: "new_location"},
+	} {
+		sql := fmt.Sprintf("ALTER TABLE %s.%s ALTER COLUMN %s TYPE varchar(32);", dbConfigs.Schema, column.table, column.name)
+		if err := db.Exec(sql).Error; err != nil {
+			log.Fatal(err)
+		}
+	}
+	if err := db.AutoMigrate(&models.WarehouseLocation{}); err != nil {
+		log.Fatal(err)
+	}
+
+	// the locations in use of the existing warehouses.
+	var locations []struct {
+		WarehouseID string
+		Location    string
+	}
+	if err := db.Raw(`SELECT id AS warehouse_id, location FROM warehouse_stock
+		UNION SELECT warehouse_id, warehouse_location FROM material_resource`).
+		Scan(&locations).Error; err != nil {
+		log.Fatal(err)
+	}
+	var warehouses []models.Warehouse
+	if err := db.Find(&warehouses).Error; err != nil {
+		log.Fatal(err)
+	}
+	existed := make(map[string]bool, len(warehouses))
+	for _, warehouse := range warehouses {
+		existed[warehouse.ID] = true
+	}
+
+	rows := []models.WarehouseLocation{}
+	for _, location := range locations {
+		if !existed[location.WarehouseID] || location.Location == "" {
+			continue
+		}
+		rows = append(rows, models.WarehouseLocation{
+			WarehouseID:  location.WarehouseID,
+			ID:           location.Location,
+			Type:         models.WarehouseLocationBin,
+			Capacity:     decimal.Zero,
+			ProductTypes: []string{},
+			UpdatedBy:    *user,
+			CreatedBy:    *user,
+		})
+	}
+	if len(rows) != 0 {
+		if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error; err != nil {
+			log.Fatal(err)
+		}
+	}
+	logger.Info("warehouse locations created", zap.Int("count", len(rows)))
+
+	patches.PrintDragon()
+}
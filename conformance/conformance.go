@@ -0,0 +1,60 @@
+// Package conformance provides a test suite to verify that an implementation
+// of gitlab.kenda.com.tw/kenda/mcom DataManager interface behaves as documented.
+//
+// The suite only uses the methods of the DataManager interface, so it could be
+// run against any implementation, for example:
+//
+//	func TestConformance(t *testing.T) {
+//		conformance.Run(t, func(t *testing.T) (mcom.DataManager, func()) {
+//			dm := newDataManager(t)
+//			return dm, func() { clearData(t); dm.Close() }
+//		})
+//	}
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+)
+
+const testUser = "conformance"
+
+// Factory returns a DataManager without any station, site or resource data and
+// a function to release it.
+//
+// The release function should remove the data created by the suite and close
+// the DataManager.
+type Factory func(t *testing.T) (dm mcom.DataManager, release func())
+
+// Run runs all the conformance tests against the DataManager returned by newDM.
+func Run(t *testing.T, newDM Factory) {
+	t.Run("UserErrors", func(t *testing.T) { runCase(t, newDM, testUserErrors) })
+	t.Run("PaginationAndOrder", func(t *testing.T) { runCase(t, newDM, testPaginationAndOrder) })
+	t.Run("SiteBind", func(t *testing.T) { runCase(t, newDM, testSiteBind) })
+}
+
+func runCase(t *testing.T, newDM Factory, test func(context.Context, *testing.T, mcom.DataManager)) {
+	dm, release := newDM(t)
+	defer release()
+
+	test(commonsCtx.WithUserID(context.Background(), testUser), t, dm)
+}
+
+// assertCode asserts that err is a USER_ERROR with the specified code.
+//
+// The details of the error are implementation-specific so they are not compared.
+func assertCode(t *testing.T, err error, code mcomErr.Code, msgAndArgs ...interface{}) bool {
+	t.Helper()
+	e, ok := mcomErr.As(err)
+	if !ok {
+		return assert.Fail(t, "not a USER_ERROR", "expected code: %v, actual error: %v", code, err)
+	}
+	return assert.Equal(t, code, e.Code, msgAndArgs...)
+}
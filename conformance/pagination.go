@@ -0,0 +1,83 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+)
+
+func testPaginationAndOrder(ctx context.Context, t *testing.T, dm mcom.DataManager) {
+	ids := []string{"CONFORMANCE-1", "CONFORMANCE-2", "CONFORMANCE-3", "CONFORMANCE-4", "CONFORMANCE-5"}
+	for _, id := range ids {
+		if !assert.NoError(t, dm.CreateStation(ctx, mcom.CreateStationRequest{
+			ID:            id,
+			DepartmentOID: testDepartment,
+		})) {
+			return
+		}
+	}
+
+	listIDs := func(rep mcom.ListStationsReply) []string {
+		res := make([]string, len(rep.Stations))
+		for i, s := range rep.Stations {
+			res[i] = s.ID
+		}
+		return res
+	}
+
+	{ // order by ID.
+		rep, err := dm.ListStations(ctx, mcom.ListStationsRequest{DepartmentOID: testDepartment}.
+			WithOrder(mcom.Order{Name: "id"}))
+		if assert.NoError(t, err) {
+			assert.Equal(t, ids, listIDs(rep))
+		}
+	}
+	{ // order by ID descending.
+		rep, err := dm.ListStations(ctx, mcom.ListStationsRequest{DepartmentOID: testDepartment}.
+			WithOrder(mcom.Order{Name: "id", Descending: true}))
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{ids[4], ids[3], ids[2], ids[1], ids[0]}, listIDs(rep))
+		}
+	}
+	{ // invalid order field.
+		_, err := dm.ListStations(ctx, mcom.ListStationsRequest{DepartmentOID: testDepartment}.
+			WithOrder(mcom.Order{Name: "not_orderable"}))
+		assertCode(t, err, mcomErr.Code_BAD_REQUEST)
+	}
+	{ // the second page.
+		rep, err := dm.ListStations(ctx, mcom.ListStationsRequest{DepartmentOID: testDepartment}.
+			WithPagination(mcom.PaginationRequest{PageCount: 2, ObjectsPerPage: 2}).
+			WithOrder(mcom.Order{Name: "id"}))
+		if assert.NoError(t, err) {
+			assert.Equal(t, ids[2:4], listIDs(rep))
+			assert.Equal(t, int64(len(ids)), rep.AmountOfData)
+		}
+	}
+	{ // the last page is not full.
+		rep, err := dm.ListStations(ctx, mcom.ListStationsRequest{DepartmentOID: testDepartment}.
+			WithPagination(mcom.PaginationRequest{PageCount: 3, ObjectsPerPage: 2}).
+			WithOrder(mcom.Order{Name: "id"}))
+		if assert.NoError(t, err) {
+			assert.Equal(t, ids[4:], listIDs(rep))
+			assert.Equal(t, int64(len(ids)), rep.AmountOfData)
+		}
+	}
+	{ // out of range.
+		rep, err := dm.ListStations(ctx, mcom.ListStationsRequest{DepartmentOID: testDepartment}.
+			WithPagination(mcom.PaginationRequest{PageCount: 10, ObjectsPerPage: 2}).
+			WithOrder(mcom.Order{Name: "id"}))
+		if assert.NoError(t, err) {
+			assert.Empty(t, rep.Stations)
+			assert.Equal(t, int64(len(ids)), rep.AmountOfData)
+		}
+	}
+	{ // invalid pagination request.
+		_, err := dm.ListStations(ctx, mcom.ListStationsRequest{DepartmentOID: testDepartment}.
+			WithPagination(mcom.PaginationRequest{PageCount: 1}))
+		assertCode(t, err, mcomErr.Code_INSUFFICIENT_REQUEST)
+	}
+}
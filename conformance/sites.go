@@ -0,0 +1,124 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/bindtype"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
+)
+
+func testSiteBind(ctx context.Context, t *testing.T, dm mcom.DataManager) {
+	if !createTestStation(ctx, t, dm, testStation) || !createTestResources(ctx, t, dm, decimal.NewFromInt(100)) {
+		return
+	}
+
+	site := func(name string) models.UniqueSite {
+		return models.UniqueSite{SiteID: models.SiteID{Name: name}, Station: testStation}
+	}
+	resource := func(id string, quantity int32) mcom.BindMaterialResource {
+		return mcom.BindMaterialResource{
+			Material:    models.Material{ID: testProductID},
+			Quantity:    types.Decimal.NewFromInt32(quantity),
+			ResourceID:  id,
+			ProductType: testProductType,
+		}
+	}
+	listSiteMaterials := func(name string) []string {
+		rep, err := dm.ListSiteMaterials(ctx, mcom.ListSiteMaterialsRequest{
+			Station: testStation,
+			Site:    models.SiteID{Name: name},
+		})
+		assert.NoError(t, err)
+		res := make([]string, len(rep))
+		for i, m := range rep {
+			res[i] = m.ResourceID
+		}
+		return res
+	}
+
+	{ // insufficient request.
+		assertCode(t, dm.MaterialResourceBindV2(ctx, mcom.MaterialResourceBindRequestV2{}), mcomErr.Code_INSUFFICIENT_REQUEST)
+	}
+	{ // site not found.
+		assertCode(t, dm.MaterialResourceBindV2(ctx, mcom.MaterialResourceBindRequestV2{
+			Details: []mcom.MaterialBindRequestDetailV2{{
+				Type:      bindtype.BindType_RESOURCE_BINDING_SLOT_BIND,
+				Site:      site(testNotFoundData),
+				Resources: []mcom.BindMaterialResource{resource(testResourceA, 10)},
+			}},
+		}), mcomErr.Code_STATION_SITE_NOT_FOUND)
+	}
+	{ // site type mismatch.
+		assertCode(t, dm.MaterialResourceBindV2(ctx, mcom.MaterialResourceBindRequestV2{
+			Details: []mcom.MaterialBindRequestDetailV2{{
+				Type:      bindtype.BindType_RESOURCE_BINDING_CONTAINER_BIND,
+				Site:      site(testSlot),
+				Resources: []mcom.BindMaterialResource{resource(testResourceA, 10)},
+			}},
+		}), mcomErr.Code_BAD_REQUEST)
+	}
+	{ // container bind.
+		assert.NoError(t, dm.MaterialResourceBindV2(ctx, mcom.MaterialResourceBindRequestV2{
+			Details: []mcom.MaterialBindRequestDetailV2{{
+				Type:      bindtype.BindType_RESOURCE_BINDING_CONTAINER_BIND,
+				Site:      site(testContainer),
+				Resources: []mcom.BindMaterialResource{resource(testResourceA, 10), resource(testResourceB, 20)},
+			}},
+		}))
+		assert.ElementsMatch(t, []string{testResourceA, testResourceB}, listSiteMaterials(testContainer))
+	}
+	{ // container bind replaces the existing contents.
+		assert.NoError(t, dm.MaterialResourceBindV2(ctx, mcom.MaterialResourceBindRequestV2{
+			Details: []mcom.MaterialBindRequestDetailV2{{
+				Type:      bindtype.BindType_RESOURCE_BINDING_CONTAINER_BIND,
+				Site:      site(testContainer),
+				Resources: []mcom.BindMaterialResource{resource(testResourceB, 20)},
+			}},
+		}))
+		assert.Equal(t, []string{testResourceB}, listSiteMaterials(testContainer))
+	}
+	{ // container clear.
+		assert.NoError(t, dm.MaterialResourceBindV2(ctx, mcom.MaterialResourceBindRequestV2{
+			Details: []mcom.MaterialBindRequestDetailV2{{
+				Type: bindtype.BindType_RESOURCE_BINDING_CONTAINER_CLEAR,
+				Site: site(testContainer),
+			}},
+		}))
+		assert.Empty(t, listSiteMaterials(testContainer))
+	}
+	{ // slot bind.
+		assert.NoError(t, dm.MaterialResourceBindV2(ctx, mcom.MaterialResourceBindRequestV2{
+			Details: []mcom.MaterialBindRequestDetailV2{{
+				Type:      bindtype.BindType_RESOURCE_BINDING_SLOT_BIND,
+				Site:      site(testSlot),
+				Resources: []mcom.BindMaterialResource{resource(testResourceA, 10)},
+			}},
+		}))
+		assert.Equal(t, []string{testResourceA}, listSiteMaterials(testSlot))
+
+		rep, err := dm.GetSite(ctx, mcom.GetSiteRequest{StationID: testStation, SiteName: testSlot})
+		if assert.NoError(t, err) && assert.NotNil(t, rep.Content.Slot) && assert.NotNil(t, rep.Content.Slot.Material) {
+			assert.Equal(t, testResourceA, rep.Content.Slot.Material.ResourceID)
+			assert.True(t, decimal.NewFromInt(10).Equal(*rep.Content.Slot.Material.Quantity))
+		}
+	}
+	{ // slot clear.
+		assert.NoError(t, dm.MaterialResourceBindV2(ctx, mcom.MaterialResourceBindRequestV2{
+			Details: []mcom.MaterialBindRequestDetailV2{{
+				Type: bindtype.BindType_RESOURCE_BINDING_SLOT_CLEAR,
+				Site: site(testSlot),
+			}},
+		}))
+		assert.Empty(t, listSiteMaterials(testSlot))
+	}
+	{ // the station could be deleted after all the sites are cleared.
+		assert.NoError(t, dm.DeleteStation(ctx, mcom.DeleteStationRequest{StationID: testStation}))
+	}
+}
@@ -0,0 +1,144 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/bindtype"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/sites"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/stations"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
+)
+
+const (
+	testDepartment   = "CONFORMANCE"
+	testStation      = "CONFORMANCE-A"
+	testContainer    = "CONTAINER"
+	testSlot         = "SLOT"
+	testQueue        = "QUEUE"
+	testResourceA    = "CONFORMANCE-RES-A"
+	testResourceB    = "CONFORMANCE-RES-B"
+	testProductType  = "CONFORMANCE"
+	testProductID    = "CONFORMANCE-PRODUCT"
+	testNotFoundData = "CONFORMANCE-NOT-FOUND"
+)
+
+// createTestStation creates a station with a container, a slot and a queue
+// material site.
+func createTestStation(ctx context.Context, t *testing.T, dm mcom.DataManager, id string) bool {
+	return assert.NoError(t, dm.CreateStation(ctx, mcom.CreateStationRequest{
+		ID:            id,
+		DepartmentOID: testDepartment,
+		Sites: []mcom.SiteInformation{
+			{Name: testContainer, Index: 0, Type: sites.Type_CONTAINER, SubType: sites.SubType_MATERIAL},
+			{Name: testSlot, Index: 0, Type: sites.Type_SLOT, SubType: sites.SubType_MATERIAL},
+			{Name: testQueue, Index: 0, Type: sites.Type_QUEUE, SubType: sites.SubType_MATERIAL},
+		},
+		State: stations.State_IDLE,
+	}))
+}
+
+// createTestResources creates resources testResourceA and testResourceB with
+// the specified quantity.
+func createTestResources(ctx context.Context, t *testing.T, dm mcom.DataManager, quantity decimal.Decimal) bool {
+	_, err := dm.CreateMaterialResources(ctx, mcom.CreateMaterialResourcesRequest{
+		Materials: []mcom.CreateMaterialResourcesRequestDetail{
+			{Type: testProductType, ID: testProductID, Quantity: quantity, ResourceID: testResourceA},
+			{Type: testProductType, ID: testProductID, Quantity: quantity, ResourceID: testResourceB},
+		},
+	})
+	return assert.NoError(t, err)
+}
+
+func testUserErrors(ctx context.Context, t *testing.T, dm mcom.DataManager) {
+	{ // GetStation: insufficient request.
+		_, err := dm.GetStation(ctx, mcom.GetStationRequest{})
+		assertCode(t, err, mcomErr.Code_INSUFFICIENT_REQUEST)
+	}
+	{ // GetStation: station not found.
+		_, err := dm.GetStation(ctx, mcom.GetStationRequest{ID: testNotFoundData})
+		assertCode(t, err, mcomErr.Code_STATION_NOT_FOUND)
+	}
+	{ // CreateStation: insufficient request.
+		assertCode(t, dm.CreateStation(ctx, mcom.CreateStationRequest{ID: testStation}), mcomErr.Code_INSUFFICIENT_REQUEST)
+	}
+	{ // UpdateStation: station not found.
+		assertCode(t, dm.UpdateStation(ctx, mcom.UpdateStationRequest{ID: testNotFoundData}), mcomErr.Code_STATION_NOT_FOUND)
+	}
+	{ // DeleteStation: insufficient request.
+		assertCode(t, dm.DeleteStation(ctx, mcom.DeleteStationRequest{}), mcomErr.Code_INSUFFICIENT_REQUEST)
+	}
+	{ // DeleteStation: station not found.
+		assertCode(t, dm.DeleteStation(ctx, mcom.DeleteStationRequest{StationID: testNotFoundData}), mcomErr.Code_STATION_NOT_FOUND)
+	}
+
+	if !createTestStation(ctx, t, dm, testStation) {
+		return
+	}
+	{ // GetStation: good case.
+		rep, err := dm.GetStation(ctx, mcom.GetStationRequest{ID: testStation})
+		if assert.NoError(t, err) {
+			assert.Equal(t, testStation, rep.ID)
+			assert.Equal(t, stations.State_IDLE, rep.State)
+			assert.Len(t, rep.Sites, 3)
+		}
+	}
+	{ // CreateStation: station already exists.
+		assertCode(t, dm.CreateStation(ctx, mcom.CreateStationRequest{
+			ID:            testStation,
+			DepartmentOID: testDepartment,
+		}), mcomErr.Code_STATION_ALREADY_EXISTS)
+	}
+	{ // GetSite: site not found.
+		_, err := dm.GetSite(ctx, mcom.GetSiteRequest{
+			StationID: testStation,
+			SiteName:  testNotFoundData,
+		})
+		assertCode(t, err, mcomErr.Code_STATION_SITE_NOT_FOUND)
+	}
+	{ // Feed: the queue site is empty.
+		_, err := dm.Feed(ctx, mcom.FeedRequest{
+			FeedContent: []mcom.FeedPerSite{mcom.FeedPerSiteType1{
+				Site: models.UniqueSite{
+					SiteID:  models.SiteID{Name: testQueue},
+					Station: testStation,
+				},
+				Quantity: decimal.NewFromInt(1),
+			}},
+		})
+		assertCode(t, err, mcomErr.Code_RESOURCE_MATERIAL_SHORTAGE)
+	}
+	{ // DeleteStation: remaining objects in the sites.
+		if !createTestResources(ctx, t, dm, decimal.NewFromInt(10)) {
+			return
+		}
+		if !assert.NoError(t, dm.MaterialResourceBindV2(ctx, mcom.MaterialResourceBindRequestV2{
+			Details: []mcom.MaterialBindRequestDetailV2{{
+				Type: bindtype.BindType_RESOURCE_BINDING_CONTAINER_BIND,
+				Site: models.UniqueSite{
+					SiteID:  models.SiteID{Name: testContainer},
+					Station: testStation,
+				},
+				Resources: []mcom.BindMaterialResource{{
+					Material:    models.Material{ID: testProductID},
+					Quantity:    types.Decimal.NewFromInt32(10),
+					ResourceID:  testResourceA,
+					ProductType: testProductType,
+				}},
+			}},
+		})) {
+			return
+		}
+		assertCode(t, dm.DeleteStation(ctx, mcom.DeleteStationRequest{StationID: testStation}), mcomErr.Code_STATION_SITE_REMAINING_OBJECTS)
+
+		// the station should remain.
+		_, err := dm.GetStation(ctx, mcom.GetStationRequest{ID: testStation})
+		assert.NoError(t, err)
+	}
+}
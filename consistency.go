@@ -0,0 +1,98 @@
+package mcom
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+)
+
+// ConsistencyIssueType is the type of the mismatch between the site contents,
+// the material resources and the warehouse stocks.
+type ConsistencyIssueType string
+
+// ConsistencyIssueType enumeration.
+const (
+	// ConsistencyIssueMountedResourceNotOnSite is a MOUNTED resource which is
+	// not bound to any site. It is repaired by setting the resource AVAILABLE.
+	ConsistencyIssueMountedResourceNotOnSite ConsistencyIssueType = "MOUNTED_RESOURCE_NOT_ON_SITE"
+	// ConsistencyIssueSiteQuantityExceeded is a resource which quantity bound
+	// to the sites exceeds its remaining quantity, or which remaining quantity
+	// is negative since more than its quantity has been fed. It is repaired by
+	// setting the negative remaining quantity to zero and reducing the bound
+	// quantities to the remaining quantity, from the last bound one.
+	ConsistencyIssueSiteQuantityExceeded ConsistencyIssueType = "SITE_QUANTITY_EXCEEDED"
+	// ConsistencyIssueStockMismatch is a warehouse stock which quantity is not
+	// the sum of the remaining quantities of the resources in the location.
+	// It is repaired by setting the stock to the sum.
+	ConsistencyIssueStockMismatch ConsistencyIssueType = "STOCK_MISMATCH"
+)
+
+// CheckConsistencyRequest definition.
+type CheckConsistencyRequest struct {
+	// Repair repairs the found issues if true, otherwise it only reports them.
+	Repair bool
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req CheckConsistencyRequest) CheckInsufficiency() error {
+	return nil
+}
+
+// ConsistencyIssue definition.
+type ConsistencyIssue struct {
+	Type ConsistencyIssueType
+	// ResourceID and ProductType are set for the resource issues.
+	ResourceID  string
+	ProductType string
+	// Sites are the sites where the resource is bound.
+	Sites []models.UniqueSite
+	// Warehouse and ProductID are set for the stock issues.
+	Warehouse Warehouse
+	ProductID string
+	// Expected and Actual are the quantities for SITE_QUANTITY_EXCEEDED and
+	// STOCK_MISMATCH. Expected is the remaining quantity of the resource and
+	// Actual is the sum of the quantities bound to the sites for
+	// SITE_QUANTITY_EXCEEDED.
+	Expected decimal.Decimal
+	Actual   decimal.Decimal
+	// Repaired is true if the issue has been repaired.
+	Repaired bool
+}
+
+// String implements fmt.Stringer interface.
+func (issue ConsistencyIssue) String() string {
+	var s string
+	switch issue.Type {
+	case ConsistencyIssueMountedResourceNotOnSite:
+		s = fmt.Sprintf("%s: resource: %s, product type: %s", issue.Type, issue.ResourceID, issue.ProductType)
+	case ConsistencyIssueSiteQuantityExceeded:
+		sites := make([]string, len(issue.Sites))
+		for i, site := range issue.Sites {
+			sites[i] = fmt.Sprintf("%s/%s/%d", site.Station, site.SiteID.Name, site.SiteID.Index)
+		}
+		s = fmt.Sprintf("%s: resource: %s, product type: %s, quantity: %s, bound: %s, sites: [%s]",
+			issue.Type, issue.ResourceID, issue.ProductType, issue.Expected, issue.Actual, strings.Join(sites, ", "))
+	case ConsistencyIssueStockMismatch:
+		s = fmt.Sprintf("%s: warehouse: %s, location: %s, product: %s, expected: %s, actual: %s",
+			issue.Type, issue.Warehouse.ID, issue.Warehouse.Location, issue.ProductID, issue.Expected, issue.Actual)
+	default:
+		s = string(issue.Type)
+	}
+	if issue.Repaired {
+		s += " (repaired)"
+	}
+	return s
+}
+
+// CheckConsistencyReply definition.
+type CheckConsistencyReply struct {
+	// the number of the checked rows.
+	CheckedResources    int
+	CheckedSiteContents int
+	CheckedStocks       int
+
+	Issues []ConsistencyIssue
+}
@@ -0,0 +1,38 @@
+package mcom
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+)
+
+func TestConsistencyIssue_String(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("MOUNTED_RESOURCE_NOT_ON_SITE: resource: A, product type: T (repaired)", ConsistencyIssue{
+		Type:        ConsistencyIssueMountedResourceNotOnSite,
+		ResourceID:  "A",
+		ProductType: "T",
+		Repaired:    true,
+	}.String())
+	assert.Equal("SITE_QUANTITY_EXCEEDED: resource: A, product type: T, quantity: -3, bound: 5, sites: [S/SITE/1]", ConsistencyIssue{
+		Type:        ConsistencyIssueSiteQuantityExceeded,
+		ResourceID:  "A",
+		ProductType: "T",
+		Sites: []models.UniqueSite{{
+			SiteID:  models.SiteID{Name: "SITE", Index: 1},
+			Station: "S",
+		}},
+		Expected: decimal.NewFromInt(-3),
+		Actual:   decimal.NewFromInt(5),
+	}.String())
+	assert.Equal("STOCK_MISMATCH: warehouse: W, location: 01, product: P, expected: 10, actual: 7", ConsistencyIssue{
+		Type:      ConsistencyIssueStockMismatch,
+		Warehouse: Warehouse{ID: "W", Location: "01"},
+		ProductID: "P",
+		Expected:  decimal.NewFromInt(10),
+		Actual:    decimal.NewFromInt(7),
+	}.String())
+}
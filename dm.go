@@ -141,6 +141,33 @@ type DataManager interface {
 	//  - Code_DEPARTMENT_NOT_FOUND
 	UpdateDepartment(context.Context, UpdateDepartmentRequest) error
 
+	// SetShiftCalendar creates or replaces the shift calendar of the department.
+	// The following input arguments are required:
+	//  - DepartmentID
+	//  - Shifts
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_BAD_REQUEST
+	SetShiftCalendar(context.Context, SetShiftCalendarRequest) error
+
+	// GetShiftCalendar needs the following required input:
+	//  - DepartmentID
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_SHIFT_CALENDAR_NOT_FOUND
+	GetShiftCalendar(context.Context, GetShiftCalendarRequest) (GetShiftCalendarReply, error)
+
+	// ResolveWorkDate returns the work date, shift and group of the specified
+	// time in the shift calendar of the department of the station.
+	// The following input arguments are required:
+	//  - Station
+	//  - Time
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_STATION_NOT_FOUND
+	//  - Code_SHIFT_CALENDAR_NOT_FOUND
+	ResolveWorkDate(context.Context, ResolveWorkDateRequest) (ResolveWorkDateReply, error)
+
 	// ListAllDepartment lists all departments.
 	//
 	// the reply will be ordered by department id.
@@ -184,6 +211,8 @@ type DataManager interface {
 	// The returned USER_ERROR would be as below:
 	//  - Code_INSUFFICIENT_REQUEST
 	//  - Code_STATION_NOT_FOUND
+	//
+	// The remaining capacity is reported in the sites which have capacity limits.
 	GetStation(context.Context, GetStationRequest) (GetStationReply, error)
 
 	// CreateStation needs the following required input:
@@ -193,17 +222,68 @@ type DataManager interface {
 	// The returned USER_ERROR would be as below:
 	//  - Code_INSUFFICIENT_REQUEST
 	//  - Code_STATION_ALREADY_EXISTS
-	// Notice that the default station state is SHUTDOWN.
+	// Notice that the default station state is SHUTDOWN. The initial state is
+	// recorded in the state history.
 	CreateStation(context.Context, CreateStationRequest) error
 
 	// UpdateStation needs the following required input:
 	//  - ID
+	//  - StateReason: only required while changing to MALFUNCTION or REPAIRING.
 	// others are optional.
 	// The returned USER_ERROR would be as below:
 	//  - Code_INSUFFICIENT_REQUEST
 	//  - Code_STATION_NOT_FOUND
+	//  - Code_STATION_STATE_TRANSITION_NOT_ALLOWED
+	//
+	// Notice that the state transition is checked and recorded in the state
+	// history as ChangeStationState does.
 	UpdateStation(context.Context, UpdateStationRequest) error
 
+	// ChangeStationState changes the state of the station if the transition
+	// is allowed and records it in the state history.
+	//
+	// ChangeStationState needs the following required input:
+	//  - ID
+	//  - State
+	//  - Reason: only required while changing to MALFUNCTION or REPAIRING.
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_STATION_NOT_FOUND
+	//  - Code_STATION_STATE_TRANSITION_NOT_ALLOWED
+	ChangeStationState(context.Context, ChangeStationStateRequest) error
+
+	// ListStationStateHistory lists the state transitions of the station in
+	// the specified time range.
+	//
+	// ListStationStateHistory needs the following required input:
+	//  - Station
+	//  - Since
+	//
+	// the reply will be ordered by created time.
+	//
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_BAD_REQUEST
+	//  - Code_STATION_NOT_FOUND
+	ListStationStateHistory(context.Context, ListStationStateHistoryRequest) (ListStationStateHistoryReply, error)
+
+	// GetStationOEE computes the availability, performance and quality of the
+	// station from the state histories, the collect records and the batches in
+	// the specified time range, and splits the time range into periods by the
+	// granularity or by the shifts of the shift calendar of the department of
+	// the station. See StationOEE for the details of the computation.
+	//
+	// GetStationOEE needs the following required input:
+	//  - Station
+	//  - From
+	//  - To
+	//
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_BAD_REQUEST
+	//  - Code_STATION_NOT_FOUND
+	GetStationOEE(context.Context, GetStationOEERequest) (GetStationOEEReply, error)
+
 	// DeleteStation deletes the specified station and the sites that belong to it.
 	// DeleteStation needs the following required input:
 	//  - StationID
@@ -212,27 +292,58 @@ type DataManager interface {
 	//  - Code_STATION_NOT_FOUND
 	DeleteStation(context.Context, DeleteStationRequest) error
 
+	// CreateStationGroup creates a station group. Station groups could be
+	// nested by Groups, e.g. plant → area → line → station.
+	//
 	// CreateStationGroup needs the following required input:
-	//  - all fields in request
+	//  - ID
+	//  - Stations or Groups
 	// The returned USER_ERROR would be as below:
 	//  - Code_INSUFFICIENT_REQUEST
 	//  - Code_STATION_GROUP_ALREADY_EXISTS
+	//  - Code_STATION_GROUP_ID_NOT_FOUND: a child group is not found.
+	//  - Code_STATION_GROUP_CYCLE_DETECTED
 	CreateStationGroup(context.Context, StationGroupRequest) error
 
 	// UpdateStationGroup needs the following required input:
-	//  - all fields in request
+	//  - ID
+	//  - Stations or Groups
 	// The returned USER_ERROR would be as below:
 	//  - Code_INSUFFICIENT_REQUEST
 	//  - Code_STATION_GROUP_ID_NOT_FOUND
+	//  - Code_STATION_GROUP_CYCLE_DETECTED
 	UpdateStationGroup(context.Context, StationGroupRequest) error
 
+	// DeleteStationGroup deletes the station group and removes it from its
+	// parent groups.
+	//
 	// DeleteStationGroup needs the following required input:
 	//  - GroupID
 	// The returned USER_ERROR would be as below:
 	//  - Code_INSUFFICIENT_REQUEST
 	DeleteStationGroup(context.Context, DeleteStationGroupRequest) error
 
-	// SetStationConfiguration sets configs of the station.
+	// ListGroupStations returns all the stations under the station group and
+	// its descendant groups.
+	//
+	// ListGroupStations needs the following required input:
+	//  - GroupID
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_STATION_GROUP_ID_NOT_FOUND
+	ListGroupStations(context.Context, ListGroupStationsRequest) (ListGroupStationsReply, error)
+
+	// ListStationAncestors returns the ancestor groups of the station.
+	//
+	// ListStationAncestors needs the following required input:
+	//  - StationID
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	ListStationAncestors(context.Context, ListStationAncestorsRequest) (ListStationAncestorsReply, error)
+
+	// SetStationConfiguration sets configs of the station as a new version.
+	// The versions of the station are serialized, so the concurrent calls get
+	// consecutive versions.
 	//
 	// SetStationConfiguration needs the following required input:
 	//  - StationID
@@ -254,6 +365,92 @@ type DataManager interface {
 	//  - Code_INSUFFICIENT_REQUEST
 	GetStationConfiguration(context.Context, GetStationConfigurationRequest) (GetStationConfigurationReply, error)
 
+	// ListStationConfigurationVersions lists all the versions of the station
+	// configs.
+	//
+	// ListStationConfigurationVersions needs the following required input:
+	//  - StationID
+	//
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	ListStationConfigurationVersions(context.Context, ListStationConfigurationVersionsRequest) (ListStationConfigurationVersionsReply, error)
+
+	// DiffStationConfigurationVersions returns the different fields between
+	// two versions of the station configs.
+	//
+	// All the fields in the request are required.
+	//
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_STATION_CONFIGURATION_VERSION_NOT_FOUND
+	DiffStationConfigurationVersions(context.Context, DiffStationConfigurationVersionsRequest) (DiffStationConfigurationVersionsReply, error)
+
+	// RollbackStationConfiguration sets the station configs to the specified
+	// version. The rollback is recorded as a new version.
+	//
+	// All the fields in the request are required.
+	//
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_STATION_CONFIGURATION_VERSION_NOT_FOUND
+	RollbackStationConfiguration(context.Context, RollbackStationConfigurationRequest) error
+
+	// CreateStationTemplate creates a template of the station sites and the
+	// station configuration.
+	//
+	// CreateStationTemplate needs the following required input:
+	//  - ID
+	//  - Sites.Type and Sites.SubType of the sites without Station
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_STATION_TEMPLATE_ALREADY_EXISTS
+	CreateStationTemplate(context.Context, CreateStationTemplateRequest) error
+
+	// GetStationTemplate needs the following required input:
+	//  - ID
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_STATION_TEMPLATE_NOT_FOUND
+	GetStationTemplate(context.Context, GetStationTemplateRequest) (GetStationTemplateReply, error)
+
+	// DeleteStationTemplate needs the following required input:
+	//  - ID
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	DeleteStationTemplate(context.Context, DeleteStationTemplateRequest) error
+
+	// CreateStationFromTemplate creates a station with the sites and the
+	// configuration of the template. Nothing is created if DryRun is true.
+	// The reply is what will be created, or has been created.
+	//
+	// CreateStationFromTemplate needs the following required input:
+	//  - TemplateID
+	//  - ID
+	//  - DepartmentOID
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_STATION_TEMPLATE_NOT_FOUND
+	//  - Code_STATION_ALREADY_EXISTS
+	//  - Code_STATION_SITE_ALREADY_EXISTS
+	//  - Code_STATION_SITE_NOT_FOUND: a shared site is not found.
+	CreateStationFromTemplate(context.Context, CreateStationFromTemplateRequest) (CreateStationFromTemplateReply, error)
+
+	// CloneStation creates a station with the same sites and configuration
+	// as the source station, but without the site contents. The shared sites
+	// of the source station are shared with the new station as well. Nothing
+	// is created if DryRun is true. The reply is what will be created, or has
+	// been created.
+	//
+	// CloneStation needs the following required input:
+	//  - SourceID
+	//  - NewID
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_BAD_REQUEST: clone to the same station.
+	//  - Code_STATION_NOT_FOUND
+	//  - Code_STATION_ALREADY_EXISTS
+	CloneStation(context.Context, CloneStationRequest) (CloneStationReply, error)
+
 	// GetSite
 	//
 	// GetSite needs the following required input:
@@ -265,6 +462,45 @@ type DataManager interface {
 	//  - Code_STATION_SITE_NOT_FOUND
 	GetSite(context.Context, GetSiteRequest) (GetSiteReply, error)
 
+	// UpdateSiteLimitation replaces the limitation of the specified site.
+	//
+	// UpdateSiteLimitation needs the following required input:
+	//  - SiteName
+	//  - SiteIndex (0 is allowed)
+	// StationID is optional for a shared site.
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_STATION_SITE_NOT_FOUND
+	//
+	// An element of the limitation could be a product id, a pattern in path.Match
+	// syntax or the product id of a product group. The children of the product
+	// groups and the substitutions of the allowed products are allowed as well.
+	// The limitation is only checked in MaterialResourceBind and MaterialResourceBindV2
+	// if Enforced is true.
+	UpdateSiteLimitation(context.Context, UpdateSiteLimitationRequest) error
+
+	// UpdateSiteFeedPolicy replaces the feed policy of the specified site.
+	//
+	// UpdateSiteFeedPolicy needs the following required input:
+	//  - StationID
+	//  - SiteName
+	//  - SiteIndex (0 is allowed)
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_BAD_REQUEST: undefined order or expiry action.
+	//  - Code_STATION_SITE_NOT_FOUND
+	//
+	// The feed policy is applied in Feed:
+	//  - FIFO, FEFO and LIFO decide which resources of a container, collection,
+	//    queue or colqueue site are fed first for FeedPerSiteType1.
+	//  - FeedOrderExplicit only accepts FeedPerSiteType1 with FeedAll, the
+	//    resources must be specified by FeedPerSiteType2 otherwise.
+	//  - the expired resources of all types of sites, including the shared
+	//    sites, are skipped or rejected according to the expiry action. The
+	//    resources specified by FeedPerSiteType2 are rejected unless the
+	//    expired resources are allowed.
+	UpdateSiteFeedPolicy(context.Context, UpdateSiteFeedPolicyRequest) error
+
 	// ListAssociatedStations gets associated stations according to specified site.
 	//
 	// This method will NOT check if the specified site exists or not
@@ -281,9 +517,13 @@ type DataManager interface {
 	//  - Site : operator sites only (Type: Slot, Sub Type: Operator)
 	//  - WorkDate
 	//  - Group
+	// The user must hold all the qualifications required by the station and its
+	// ancestor groups, see SetQualificationRequirement.
 	//
 	// Options to set:
 	//  - WithVerifyWorkDateHandler: verify if the work date is valid. Default is passed.
+	//  - WithShiftCalendarWorkDateVerification: verify if the work date is the
+	//    current work date in the shift calendar of the department of the station.
 	//
 	// The returned USER_ERROR would be as below:
 	//  - Code_INSUFFICIENT_REQUEST
@@ -291,6 +531,8 @@ type DataManager interface {
 	//  - Code_STATION_NOT_FOUND
 	//  - Code_RESOURCE_NOT_FOUND
 	//  - Code_BAD_WORK_DATE
+	//  - Code_SHIFT_CALENDAR_NOT_FOUND
+	//  - Code_USER_STATION_SIGN_ON_FORBIDDEN
 	//  - Code_PREVIOUS_USER_NOT_SIGNED_OUT
 	SignInStation(context.Context, SignInStationRequest, ...SignInStationOption) error
 
@@ -313,6 +555,96 @@ type DataManager interface {
 	//  - Code_INSUFFICIENT_REQUEST
 	SignOutStations(context.Context, SignOutStationsRequest) error
 
+	// ListOperatorSessions lists the sign-in sessions of the operator sites
+	// overlapping the specified time range. A session starts at SignInStation
+	// and ends at SignOutStation, SignOutStations or the next SignInStation to
+	// the same site.
+	// The following input arguments are required:
+	//  - Since
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_BAD_REQUEST
+	ListOperatorSessions(context.Context, ListOperatorSessionsRequest) (ListOperatorSessionsReply, error)
+
+	// ListLaborHours sums up the durations of the operator sessions per user,
+	// station and work date. The sessions in progress are counted until now.
+	// The following input arguments are required:
+	//  - Since
+	//  - Until
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_BAD_REQUEST
+	ListLaborHours(context.Context, ListLaborHoursRequest) (ListLaborHoursReply, error)
+
+	// SignOutStaleOperators signs out the operators whose shift has ended for
+	// longer than the grace period, according to the shift calendar of the
+	// department of the station. The operators of the stations whose
+	// department has no shift calendar are signed out if they have signed in
+	// for longer than MaxDuration. The reason is recorded as the end reason of
+	// the operator sessions.
+	//
+	// It could be scheduled by the caller or run in the background with the
+	// implementation option, e.g. impl.WithStaleOperatorSweeper.
+	//
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	SignOutStaleOperators(context.Context, SignOutStaleOperatorsRequest) (SignOutStaleOperatorsReply, error)
+
+	// SetUserQualification grants the qualification to the user, or replaces
+	// the expiry of the qualification the user holds.
+	// The following input arguments are required:
+	//  - UserID
+	//  - Qualification
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_USER_NOT_FOUND
+	SetUserQualification(context.Context, SetUserQualificationRequest) error
+
+	// DeleteUserQualification needs the following required input:
+	//  - UserID
+	//  - Qualification
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	DeleteUserQualification(context.Context, DeleteUserQualificationRequest) error
+
+	// ListUserQualifications lists the qualifications of the user including the
+	// expired ones.
+	// The following input arguments are required:
+	//  - UserID
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	ListUserQualifications(context.Context, ListUserQualificationsRequest) (ListUserQualificationsReply, error)
+
+	// SetQualificationRequirement sets the qualifications required to operate
+	// the station or the stations in the group, including the stations in its
+	// descendant groups.
+	// The following input arguments are required:
+	//  - one of StationID and GroupID
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_STATION_NOT_FOUND
+	//  - Code_STATION_GROUP_ID_NOT_FOUND
+	SetQualificationRequirement(context.Context, SetQualificationRequirementRequest) error
+
+	// ListStationRequiredQualifications lists the qualifications required by the
+	// station and its ancestor groups.
+	// The following input arguments are required:
+	//  - StationID
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_STATION_NOT_FOUND
+	ListStationRequiredQualifications(context.Context, ListStationRequiredQualificationsRequest) (ListStationRequiredQualificationsReply, error)
+
+	// ListQualifiedUsers lists the in-service users who hold all the valid
+	// qualifications required by the station and have not signed in to any
+	// station.
+	// The following input arguments are required:
+	//  - StationID
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_STATION_NOT_FOUND
+	ListQualifiedUsers(context.Context, ListQualifiedUsersRequest) (ListQualifiedUsersReply, error)
+
 	// CreateProductPlan creates a product plan for production.
 	//
 	// @Param: req - all fields in req are required.
@@ -331,6 +663,11 @@ type DataManager interface {
 	//  - DepartmentOID
 	//  - Date
 	//  - ProductType
+	// Date is resolved to the work date by the shift calendar of the department
+	// if ResolveWorkDate is true.
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_SHIFT_CALENDAR_NOT_FOUND
 	ListProductPlans(context.Context, ListProductPlansRequest) (ListProductPlansReply, error)
 
 	// IsProductExisted needs the following required input:
@@ -375,9 +712,14 @@ type DataManager interface {
 	//  -  Station
 	//  -  ResourceOID
 	//  -  Quantity must greater than 0
+	// The usage cycles of the tools bound to the station are increased by
+	// BatchCount, or 1 if BatchCount is not specified, only if the work order
+	// has no batches since each batch has been counted by CreateBatch. It is
+	// rejected if any of the tools has reached its life limit.
 	// The returned USER_ERROR would be as below:
 	//  - Code_INSUFFICIENT_REQUEST
 	//  - Code_RECORD_ALREADY_EXISTS
+	//  - Code_TOOL_LIFE_EXCEEDED
 	CreateCollectRecord(context.Context, CreateCollectRecordRequest) error
 
 	// CreateWorkOrders needs the following required input:
@@ -427,9 +769,12 @@ type DataManager interface {
 	//  - Since
 	//  - one of Station or DepartmentID
 	// ID is optional.
+	// Since and Until are resolved to the work dates by the shift calendar of the
+	// department if ResolveWorkDate is true.
 	// The returned USER_ERROR would be as below:
 	//  - Code_INSUFFICIENT_REQUEST
 	//  - Code_BAD_REQUEST
+	//  - Code_SHIFT_CALENDAR_NOT_FOUND
 	ListWorkOrdersByDuration(context.Context, ListWorkOrdersByDurationRequest) (ListWorkOrdersByDurationReply, error)
 
 	// ListWorkOrdersByIDs lists work orders according to given ids and station.
@@ -499,6 +844,17 @@ type DataManager interface {
 	//  - Code_PROCESS_NOT_FOUND
 	GetProcessDefinition(context.Context, GetProcessDefinitionRequest) (GetProcessDefinitionReply, error)
 
+	// ListProcessStations returns the stations allowed in a recipe process. The
+	// station groups in the process configs are resolved through the station
+	// group hierarchy.
+	//
+	// All the fields in the request are required.
+	//
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_PROCESS_NOT_FOUND
+	ListProcessStations(context.Context, ListProcessStationsRequest) (ListProcessStationsReply, error)
+
 	// ListSubstitutions lists substitutions by given product ID and grade.
 	// the replied substitutions are ordered by name then by grade.
 	// required arguments:
@@ -601,6 +957,8 @@ type DataManager interface {
 	//  - Code_STATION_NOT_FOUND
 	//  - Code_STATION_SITE_NOT_FOUND
 	//  - Code_RESOURCE_NOT_FOUND
+	//  - Code_TOOL_LIFE_EXCEEDED
+	//  - Code_TOOL_RETIRED
 	//
 	// Deprecated: use V2 instead
 	ToolResourceBind(context.Context, ToolResourceBindRequest) error
@@ -622,8 +980,117 @@ type DataManager interface {
 	//  - Code_STATION_NOT_FOUND
 	//  - Code_STATION_SITE_NOT_FOUND
 	//  - Code_RESOURCE_NOT_FOUND
+	//  - Code_TOOL_LIFE_EXCEEDED
+	//  - Code_TOOL_RETIRED
 	ToolResourceBindV2(context.Context, ToolResourceBindRequestV2) error
 
+	// SetToolLifePolicy sets the life limit and the maintenance interval in
+	// usage cycles of the tools of the tool ID. A tool reaching its life limit
+	// could not be bound to a site, and blocks the collects and the batches of
+	// the station it is bound to.
+	// The following input arguments are required:
+	//  - ToolID
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	SetToolLifePolicy(context.Context, SetToolLifePolicyRequest) error
+
+	// RecordToolMaintenance resets the usage cycles since the last maintenance
+	// of the tool.
+	// The following input arguments are required:
+	//  - ResourceID
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_RESOURCE_NOT_FOUND
+	RecordToolMaintenance(context.Context, RecordToolMaintenanceRequest) error
+
+	// GetToolLife returns the usage cycles and the life status of the tool.
+	// The following input arguments are required:
+	//  - ResourceID
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_RESOURCE_NOT_FOUND
+	GetToolLife(context.Context, GetToolLifeRequest) (GetToolLifeReply, error)
+
+	// ListToolsDueForMaintenance lists the tools reaching their life limits or
+	// maintenance intervals, and the tools within the warning cycles if
+	// IncludeWarnings is true. The retired tools are excluded.
+	ListToolsDueForMaintenance(context.Context, ListToolsDueForMaintenanceRequest) (ListToolsDueForMaintenanceReply, error)
+
+	// CreateToolTypes creates the tool type master data.
+	// The following input arguments are required:
+	//  - Types: with ID
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_TOOL_TYPE_ALREADY_EXISTS
+	CreateToolTypes(context.Context, CreateToolTypesRequest) error
+
+	// UpdateToolType updates the description of the tool type.
+	// The following input arguments are required:
+	//  - ID
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_TOOL_TYPE_NOT_FOUND
+	UpdateToolType(context.Context, UpdateToolTypeRequest) error
+
+	// ListToolTypes lists all the tool types.
+	ListToolTypes(context.Context) (ListToolTypesReply, error)
+
+	// CreateToolResources registers new tools. The tool IDs must be in the
+	// tool type master data.
+	// The following input arguments are required:
+	//  - Resources: with ResourceID and ToolID
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_BAD_REQUEST: duplicate resource IDs in the request.
+	//  - Code_TOOL_TYPE_NOT_FOUND
+	//  - Code_RESOURCE_EXISTED
+	CreateToolResources(context.Context, CreateToolResourcesRequest) error
+
+	// UpdateToolResource changes the tool ID of the tool.
+	// The following input arguments are required:
+	//  - ResourceID
+	//  - ToolID
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_RESOURCE_NOT_FOUND
+	//  - Code_TOOL_RETIRED
+	//  - Code_TOOL_TYPE_NOT_FOUND
+	UpdateToolResource(context.Context, UpdateToolResourceRequest) error
+
+	// RetireToolResource retires the tool. A retired tool is kept with its
+	// histories but could not be bound to a site or updated any more. The
+	// tool must be unbound before the retirement.
+	// The following input arguments are required:
+	//  - ResourceID
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_RESOURCE_NOT_FOUND
+	//  - Code_RESOURCE_UNAVAILABLE: the tool is bound to a site.
+	//  - Code_TOOL_RETIRED
+	RetireToolResource(context.Context, RetireToolResourceRequest) error
+
+	// ListToolResourceHistory lists the creation, the updates and the
+	// retirement of the tool, ordered by created time.
+	// The following input arguments are required:
+	//  - ResourceID
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	ListToolResourceHistory(context.Context, ListToolResourceHistoryRequest) (ListToolResourceHistoryReply, error)
+
+	// ListToolBindHistory lists the periods the tools are bound to the sites,
+	// ordered by bound time, with the collect records and the batches at the
+	// station during each period. Every bind and unbind by ToolResourceBind
+	// and ToolResourceBindV2 is recorded with the registered tool ID of the
+	// resource.
+	// One of the following input arguments is required:
+	//  - ResourceID
+	//  - ToolID
+	//  - Site
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_BAD_REQUEST
+	ListToolBindHistory(context.Context, ListToolBindHistoryRequest) (ListToolBindHistoryReply, error)
+
 	// MaterialResourceBind:
 	// MaterialResourceBindRequest needs the following required input:
 	//  - Station : use "" to specify a shared site
@@ -684,6 +1151,13 @@ type DataManager interface {
 	//  - Code_BAD_REQUEST
 	//  - Code_INVALID_NUMBER
 	//  - Code_STATION_SITE_NOT_FOUND
+	//  - Code_STATION_SITE_CAPACITY_EXCEEDED
+	//  - Code_PRODUCT_ID_MISMATCH
+	//
+	// The limitation of a site is checked if it is enforced, see UpdateSiteLimitation.
+	//
+	// The capacity of a site is only checked when resources are bound to the site,
+	// so that the resources in an overloaded site could still be removed.
 	MaterialResourceBindV2(context.Context, MaterialResourceBindRequestV2) error
 
 	// BindRecordsCheck checks if the specified materials were ever bound in the specified site.
@@ -697,6 +1171,49 @@ type DataManager interface {
 	//  - Code_STATION_SITE_BIND_RECORD_NOT_FOUND
 	BindRecordsCheck(context.Context, BindRecordsCheckRequest) error
 
+	// ListSiteBindHistory lists the binding histories of the specified site
+	// in the specified time range. Each history contains the bind type, the
+	// resources with quantities, the operator, the time and the site contents
+	// before and after binding, so the contents of the site at any moment could
+	// be reconstructed from the last history before it.
+	//
+	// ListSiteBindHistory needs the following required input:
+	//  - Site.Name
+	//  - Site.Index (0 is allowed)
+	//  - Since
+	// Station is optional for a shared site.
+	//
+	// the reply will be ordered by created time.
+	//
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_BAD_REQUEST
+	//  - Code_STATION_NOT_FOUND
+	//  - Code_STATION_SITE_NOT_FOUND
+	ListSiteBindHistory(context.Context, ListSiteBindHistoryRequest) (ListSiteBindHistoryReply, error)
+
+	// TransferSiteContents moves all or the selected resources from a site to
+	// another in a transaction. The quantities of the resources are kept and
+	// the warehouse stock is not changed. The transfer is recorded in the bind
+	// histories of the both sites as TRANSFER_OUT and TRANSFER_IN, and the
+	// moved resources are added to the bind records of the To site.
+	//
+	// TransferSiteContents needs the following required input:
+	//  - From.Station
+	//  - To.Station
+	//
+	// Notice that the both sites must be material sites of the same type.
+	//
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_BAD_REQUEST
+	//  - Code_STATION_SITE_NOT_FOUND
+	//  - Code_STATION_SITE_SUB_TYPE_MISMATCH
+	//  - Code_STATION_SITE_CAPACITY_EXCEEDED
+	//  - Code_PRODUCT_ID_MISMATCH
+	//  - Code_RESOURCE_NOT_FOUND: the selected resources are not in the From site.
+	TransferSiteContents(context.Context, TransferSiteContentsRequest) error
+
 	// ListSiteMaterials needs the following required input:
 	//  - Station
 	//  - Site.Name
@@ -716,23 +1233,71 @@ type DataManager interface {
 	// It also offers stock in resource into warehouse as OPTIONAL request.
 	//
 	// It will return a list of ResourceID which have been created.
+	// The stock in is checked against the warehouse locations as
+	// WarehousingStock does.
+	//
 	// The returned USER_ERROR would be as below:
 	//  - Code_INSUFFICIENT_REQUEST
 	//  - Code_RESOURCE_EXISTED
 	//  - Code_INVALID_NUMBER
+	//  - Code_WAREHOUSE_LOCATION_NOT_FOUND
+	//  - Code_WAREHOUSE_LOCATION_PRODUCT_TYPE_NOT_ALLOWED
+	//  - Code_WAREHOUSE_LOCATION_CAPACITY_EXCEEDED
 	CreateMaterialResources(ctx context.Context, req CreateMaterialResourcesRequest, opts ...CreateMaterialResourcesOption) (CreateMaterialResourcesReply, error)
 
 	// WarehousingStock updates warehouse stock transactions and records into a corresponding warehouse & location.
 	// Notice that this method does not check whether the warehouse exists or not.
 	//
+	// If any location of the warehouse has been created, the location must be
+	// one of them, the product types of the resources must be allowed and the
+	// capacities must not be exceeded in the location and its ancestors.
+	// Otherwise, the location is free-form as before.
+	//
 	// Requirement:
 	//  - Warehouse (all fields are required)
 	//  - ResourceIDs (at least a data is provided)
 	// The returned USER_ERROR would be as below:
 	//  - Code_INSUFFICIENT_REQUEST
 	//  - Code_RESOURCE_NOT_FOUND
+	//  - Code_WAREHOUSE_LOCATION_NOT_FOUND
+	//  - Code_WAREHOUSE_LOCATION_PRODUCT_TYPE_NOT_ALLOWED
+	//  - Code_WAREHOUSE_LOCATION_CAPACITY_EXCEEDED
 	WarehousingStock(ctx context.Context, req WarehousingStockRequest) error
 
+	// CreateWarehouseLocations creates the locations of the warehouses, such as
+	// the zones, the racks and the bins. A parent must be created before or
+	// earlier in the same request than its children.
+	//
+	// Once a location of a warehouse is created, the stocking to the
+	// warehouse is checked against its locations, see WarehousingStock.
+	//
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_BAD_REQUEST: invalid type or the location is its own parent.
+	//  - Code_INVALID_NUMBER: negative capacity.
+	//  - Code_WAREHOUSE_NOT_FOUND
+	//  - Code_WAREHOUSE_LOCATION_NOT_FOUND: the parent is not found.
+	//  - Code_WAREHOUSE_LOCATION_ALREADY_EXISTS
+	CreateWarehouseLocations(context.Context, CreateWarehouseLocationsRequest) error
+
+	// UpdateWarehouseLocation replaces the capacity and the allowed product
+	// types of the location. The stocks already in the location are kept even
+	// if they exceed the new limitations.
+	//
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_INVALID_NUMBER: negative capacity.
+	//  - Code_WAREHOUSE_LOCATION_NOT_FOUND
+	UpdateWarehouseLocation(context.Context, UpdateWarehouseLocationRequest) error
+
+	// ListWarehouseLocations lists the locations of the warehouse with their
+	// current occupancies, the total quantities of the stocks in the locations
+	// and their descendants.
+	//
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	ListWarehouseLocations(context.Context, ListWarehouseLocationsRequest) (ListWarehouseLocationsReply, error)
+
 	// GetMaterialResource queries warehouse data of the corresponding resource.
 	//
 	// The returned USER_ERROR would be as below:
@@ -782,15 +1347,169 @@ type DataManager interface {
 	//  - Code_WAREHOUSE_RESOURCE_NOT_FOUND
 	GetResourceWarehouse(context.Context, GetResourceWarehouseRequest) (GetResourceWarehouseReply, error)
 
+	// ListResourceTransportRecords lists the transport records written by
+	// WarehousingStock by the specified conditions, see
+	// ListResourceTransportRecordsRequest.
+	//
+	// this function is able to be paginated. 🗐
+	//
+	// this function is orderable with following fields, and it is ordered by
+	// "id" by default:
+	//  - "id"
+	//  - "created_at"
+	//
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST: Location without WarehouseID.
+	//  - Code_BAD_REQUEST: invalid time range.
+	ListResourceTransportRecords(context.Context, ListResourceTransportRecordsRequest) (ListResourceTransportRecordsReply, error)
+
+	// GetResourceLocationAt returns the warehouse where the resource was at the
+	// specified time by the transport records. It is the warehouse where the
+	// resource was stocked in when created if the resource has never been
+	// transported.
+	//
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_RESOURCE_NOT_FOUND: the resource is not found or created after the time.
+	GetResourceLocationAt(context.Context, GetResourceLocationAtRequest) (GetResourceLocationAtReply, error)
+
+	// CreateStockCount starts a stock count of the warehouse, or of the
+	// location and its descendants in the warehouse locations. The resources
+	// in stock in the counted locations are frozen as the snapshot to compare
+	// with the counts.
+	//
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_WAREHOUSE_NOT_FOUND
+	//  - Code_WAREHOUSE_LOCATION_NOT_FOUND
+	CreateStockCount(context.Context, CreateStockCountRequest) (CreateStockCountReply, error)
+
+	// SubmitStockCount submits the resources and the quantities scanned by the
+	// PDAs. A resource submitted again replaces its previous count. The
+	// resources which are not in the snapshot are counted as UNEXPECTED.
+	//
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST: missing location or ambiguous product type.
+	//  - Code_INVALID_NUMBER: negative quantity.
+	//  - Code_BAD_REQUEST: the location is out of the stock count.
+	//  - Code_WAREHOUSE_LOCATION_NOT_FOUND
+	//  - Code_RESOURCE_NOT_FOUND
+	//  - Code_STOCK_COUNT_NOT_FOUND
+	//  - Code_STOCK_COUNT_CLOSED
+	SubmitStockCount(context.Context, SubmitStockCountRequest) error
+
+	// ListStockCountVariances lists the differences between the counts and the
+	// snapshot, ordered by resource id and product type. The resources which
+	// are not counted yet are listed as MISSING.
+	//
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_STOCK_COUNT_NOT_FOUND
+	ListStockCountVariances(context.Context, ListStockCountVariancesRequest) (ListStockCountVariancesReply, error)
+
+	// PostStockCount adjusts the resources and the warehouse stocks by the
+	// approved variances and closes the stock count:
+	//  - the resources counted in another location are transported there.
+	//  - the differences between the counted and the snapshot quantities are
+	//    applied to the current quantities, and the MISSING resources are
+	//    reduced by their snapshot quantities.
+	// The adjusted resources are recorded in the transport records with the
+	// note "stock count: {ID}".
+	//
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_RESOURCE_NOT_FOUND
+	//  - Code_STOCK_COUNT_NOT_FOUND
+	//  - Code_STOCK_COUNT_CLOSED
+	PostStockCount(context.Context, PostStockCountRequest) error
+
+	// CancelStockCount closes the stock count without any adjustment.
+	//
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_STOCK_COUNT_NOT_FOUND
+	//  - Code_STOCK_COUNT_CLOSED
+	CancelStockCount(context.Context, CancelStockCountRequest) error
+
+	// CheckConsistency finds the mismatches between the site contents, the
+	// material resources and the warehouse stocks, which may drift apart after
+	// partial failures or manual modifications:
+	//  - MOUNTED resources which are not bound to any site.
+	//  - resources which quantity bound to sites exceeds their remaining
+	//    quantity, or which remaining quantity is negative.
+	//  - warehouse stocks which are not the sum of the resources in the location.
+	// The issues are repaired and reported as repaired if Repair is true, see
+	// ConsistencyIssueType for the repair of each issue.
+	//
+	// All the site contents, resources and stocks are scanned and they are
+	// locked while repairing, it is meant for maintenance.
+	CheckConsistency(context.Context, CheckConsistencyRequest) (CheckConsistencyReply, error)
+
+	// TraceBackward lists the lots consumed for producing the specified resource
+	// recursively, by the feed records of the resources.
+	//
+	// TraceBackward needs the following required input:
+	//  - ResourceID
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_RESOURCE_NOT_FOUND
+	//
+	// The consumed lots which are not found in the mes system are returned
+	// as the nodes with Found false, and they are not traced further.
+	TraceBackward(context.Context, TraceRequest) (TraceReply, error)
+
+	// TraceForward lists the lots produced from the specified resource
+	// recursively, by the feed records which consumed the resources.
+	//
+	// TraceForward needs the following required input:
+	//  - ResourceID
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_RESOURCE_NOT_FOUND
+	//
+	// The feed records from which nothing has been produced yet are returned
+	// as the edges with an empty To.
+	TraceForward(context.Context, TraceRequest) (TraceReply, error)
+
+	// HoldLotCascade holds the specified resource and the resources produced
+	// from it recursively, see TraceForward, which are still in stock or
+	// mounted, i.e. the remaining quantity is positive or the status is MOUNTED.
+	// The held resources are recorded in a hold case with their previous
+	// statuses.
+	//
+	// HoldLotCascade needs the following required input:
+	//  - ResourceID
+	//  - Reason
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_RESOURCE_NOT_FOUND
+	HoldLotCascade(context.Context, HoldLotCascadeRequest) (HoldLotCascadeReply, error)
+
+	// ReleaseHoldCase restores the previous statuses of the resources held by
+	// the specified case. The resources are kept on HOLD if they are held by
+	// the other open cases or their statuses have been changed since.
+	//
+	// ReleaseHoldCase needs the following required input:
+	//  - CaseID
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_HOLD_CASE_NOT_FOUND
+	//  - Code_HOLD_CASE_RELEASED
+	ReleaseHoldCase(context.Context, ReleaseHoldCaseRequest) error
+
 	// CreateBatch needs the following required input:
 	//  - WorkOrder
 	//  - Number
 	//
 	// the default status of batch is preparing.
 	//
+	// The usage cycles of the tools bound to the station of the work order are
+	// increased by 1.
+	//
 	// The returned USER_ERROR would be as below:
 	//  - Code_INSUFFICIENT_REQUEST
 	//  - Code_BATCH_ALREADY_EXISTS
+	//  - Code_TOOL_LIFE_EXCEEDED
 	CreateBatch(context.Context, CreateBatchRequest) error
 
 	// ListBatches needs the following required input:
@@ -852,10 +1571,46 @@ type DataManager interface {
 	//  - ResourceID
 	//  - ProductType
 	//
+	// Feed options:
+	//  - WithProductionReadinessCheck: check the production readiness of the
+	//    work order at the station before feeding, see CheckProductionReadiness.
+	//    The check is in the same transaction as the feed, and the state of the
+	//    station can not be changed until the feed is done.
+	//
 	// The returned USER_ERROR would be as below:
 	//  - Code_INSUFFICIENT_REQUEST
 	//  - Code_STATION_SITE_NOT_FOUND
-	Feed(context.Context, FeedRequest) (FeedReply, error)
+	//  - Code_PRODUCTION_NOT_READY: with WithProductionReadinessCheck option.
+	//  - Code_RESOURCE_EXPIRED: the site rejects the expired resources, see
+	//    UpdateSiteFeedPolicy.
+	//  - Code_RESOURCE_MATERIAL_SHORTAGE: no resource to feed in a queue site.
+	//  - Code_BAD_REQUEST: feeding a quantity from a site with FeedOrderExplicit.
+	Feed(context.Context, FeedRequest, ...FeedOption) (FeedReply, error)
+
+	// CheckProductionReadiness checks if the station is ready for the
+	// production of the work order and returns the blockers as below:
+	//  - ProductionBlockerStationState: the station is not IDLE or RUNNING.
+	//  - ProductionBlockerOperatorNotSignedIn: no operator signed in to the
+	//    operator sites of the station.
+	//  - ProductionBlockerStationNotInProcess: the station is not one of the
+	//    stations of the process of the work order, the tools and the materials
+	//    are not checked.
+	//  - ProductionBlockerToolNotMounted: a required tool in the process config
+	//    is not bound to the tool sites of the station.
+	//  - ProductionBlockerMaterialNotBound: a material in the steps of the
+	//    process config is not bound to the specified site, or any site of the
+	//    station if the site is not specified.
+	//
+	// CheckProductionReadiness needs the following required input:
+	//  - WorkOrder
+	//  - Station
+	//
+	// The returned USER_ERROR would be as below:
+	//  - Code_INSUFFICIENT_REQUEST
+	//  - Code_WORKORDER_NOT_FOUND
+	//  - Code_STATION_NOT_FOUND
+	//  - Code_PROCESS_NOT_FOUND
+	CheckProductionReadiness(context.Context, CheckProductionReadinessRequest) (CheckProductionReadinessReply, error)
 
 	// ListUnauthorizedUsers needs the following required input:
 	//  - departmentOID
@@ -36,28 +36,48 @@ const (
 	Code_ACCOUNT_ALREADY_EXISTS            Code = 11000
 	Code_ACCOUNT_NOT_FOUND                 Code = 12000
 	// 13xxx for user sign in/out errors
-	Code_PREVIOUS_USER_NOT_SIGNED_OUT          Code = 13000
-	Code_USER_HAS_NOT_SIGNED_IN                Code = 13100
-	Code_STATION_OPERATOR_NOT_MATCH            Code = 13200
-	Code_STATION_NOT_FOUND                     Code = 20000
-	Code_STATION_ALREADY_EXISTS                Code = 20200
-	Code_STATION_PRINTER_NOT_DEFINED           Code = 21000
-	Code_STATION_GROUP_ALREADY_EXISTS          Code = 25100
-	Code_STATION_GROUP_ID_NOT_FOUND            Code = 25200
+	Code_PREVIOUS_USER_NOT_SIGNED_OUT Code = 13000
+	Code_USER_HAS_NOT_SIGNED_IN       Code = 13100
+	Code_STATION_OPERATOR_NOT_MATCH   Code = 13200
+	Code_STATION_NOT_FOUND            Code = 20000
+	Code_STATION_ALREADY_EXISTS       Code = 20200
+	// STATION_STATE_TRANSITION_NOT_ALLOWED the station could not be changed
+	// from the current state to the specified state.
+	Code_STATION_STATE_TRANSITION_NOT_ALLOWED    Code = 20300
+	Code_STATION_TEMPLATE_NOT_FOUND              Code = 20400
+	Code_STATION_TEMPLATE_ALREADY_EXISTS         Code = 20410
+	Code_STATION_CONFIGURATION_VERSION_NOT_FOUND Code = 20500
+	Code_STATION_PRINTER_NOT_DEFINED             Code = 21000
+	Code_STATION_GROUP_ALREADY_EXISTS            Code = 25100
+	Code_STATION_GROUP_ID_NOT_FOUND              Code = 25200
+	// STATION_GROUP_CYCLE_DETECTED the station group would contain itself
+	// through its descendant groups.
+	Code_STATION_GROUP_CYCLE_DETECTED          Code = 25300
 	Code_STATION_SITE_NOT_FOUND                Code = 26000
 	Code_STATION_SITE_BIND_RECORD_NOT_FOUND    Code = 26010
 	Code_STATION_SITE_REMAINING_OBJECTS        Code = 27000
 	Code_STATION_SITE_ALREADY_EXISTS           Code = 28000
 	Code_STATION_SITE_SUB_TYPE_MISMATCH        Code = 29000
+	Code_STATION_SITE_CAPACITY_EXCEEDED        Code = 29100
 	Code_RESOURCE_NOT_FOUND                    Code = 30000
 	Code_RESOURCE_MATERIAL_SHORTAGE            Code = 30010
 	Code_RESOURCE_UNAVAILABLE                  Code = 30020
 	Code_RESOURCE_EXPIRED                      Code = 30100
 	Code_RESOURCE_CONTROL_ABOVE_EXTENDED_COUNT Code = 30401
 	Code_RESOURCE_EXISTED                      Code = 30500
-	Code_RESOURCES_COUNT_MISMATCH              Code = 30700
+	// HOLD_CASE_NOT_FOUND the hold case of the resources is not found.
+	Code_HOLD_CASE_NOT_FOUND      Code = 30600
+	Code_RESOURCES_COUNT_MISMATCH Code = 30700
+	// HOLD_CASE_RELEASED the hold case has been released.
+	Code_HOLD_CASE_RELEASED Code = 30800
 	// 31xxx for resource site errors
 	Code_RESOURCE_SITE_NOT_SHARED Code = 31100
+	// TOOL_LIFE_EXCEEDED the tool reaches its life limit.
+	Code_TOOL_LIFE_EXCEEDED Code = 32000
+	// TOOL_RETIRED the tool has been retired.
+	Code_TOOL_RETIRED             Code = 32100
+	Code_TOOL_TYPE_NOT_FOUND      Code = 32200
+	Code_TOOL_TYPE_ALREADY_EXISTS Code = 32300
 	Code_WORKORDER_NOT_FOUND      Code = 40000
 	// WORKORDER_BAD_BATCH the batch is not allowed to be operated. e.g. CLOSED batch can
 	// not be closed.
@@ -73,11 +93,15 @@ const (
 	Code_BATCH_NOT_FOUND        Code = 60000
 	Code_BATCH_ALREADY_EXISTS   Code = 60200
 	// BATCH_NOT_READY e.g. not enough fed batch to collect.
-	Code_BATCH_NOT_READY             Code = 60100
-	Code_DEPARTMENT_NOT_FOUND        Code = 70000
-	Code_DEPARTMENT_ALREADY_EXISTS   Code = 70200
-	Code_PRODUCTION_PLAN_NOT_FOUND   Code = 80000
-	Code_PRODUCTION_PLAN_EXISTED     Code = 80100
+	Code_BATCH_NOT_READY           Code = 60100
+	Code_DEPARTMENT_NOT_FOUND      Code = 70000
+	Code_DEPARTMENT_ALREADY_EXISTS Code = 70200
+	Code_SHIFT_CALENDAR_NOT_FOUND  Code = 70300
+	Code_PRODUCTION_PLAN_NOT_FOUND Code = 80000
+	Code_PRODUCTION_PLAN_EXISTED   Code = 80100
+	// PRODUCTION_NOT_READY the station is not ready for the production of the
+	// work order, the blockers are provided in details.
+	Code_PRODUCTION_NOT_READY        Code = 80200
 	Code_RECORD_NOT_FOUND            Code = 81000
 	Code_RECORD_ALREADY_EXISTS       Code = 81100
 	Code_RECIPE_NOT_FOUND            Code = 90000
@@ -85,19 +109,32 @@ const (
 	Code_PRODUCT_ID_NOT_FOUND        Code = 91000
 	Code_SUBSTITUTION_ALREADY_EXISTS Code = 91010
 	// duplicated product type in limitary_hour table.
-	Code_LIMITARY_HOUR_ALREADY_EXISTS Code = 91110
-	Code_LIMITARY_HOUR_NOT_FOUND      Code = 91111
-	Code_PROCESS_NOT_FOUND            Code = 92000
-	Code_PROCESS_ALREADY_EXISTS       Code = 92100
-	Code_INSUFFICIENT_REQUEST         Code = 100000
-	Code_INVALID_NUMBER               Code = 100100
-	Code_BAD_REQUEST                  Code = 100200
-	Code_PRODUCT_ID_MISMATCH          Code = 100300
-	Code_BAD_WORK_DATE                Code = 100400
-	Code_FAILED_TO_PRINT_RESOURCE     Code = 100500
-	Code_WAREHOUSE_NOT_FOUND          Code = 101000
+	Code_LIMITARY_HOUR_ALREADY_EXISTS      Code = 91110
+	Code_LIMITARY_HOUR_NOT_FOUND           Code = 91111
+	Code_PROCESS_NOT_FOUND                 Code = 92000
+	Code_PROCESS_ALREADY_EXISTS            Code = 92100
+	Code_INSUFFICIENT_REQUEST              Code = 100000
+	Code_INVALID_NUMBER                    Code = 100100
+	Code_BAD_REQUEST                       Code = 100200
+	Code_PRODUCT_ID_MISMATCH               Code = 100300
+	Code_BAD_WORK_DATE                     Code = 100400
+	Code_FAILED_TO_PRINT_RESOURCE          Code = 100500
+	Code_WAREHOUSE_NOT_FOUND               Code = 101000
+	Code_WAREHOUSE_LOCATION_NOT_FOUND      Code = 101100
+	Code_WAREHOUSE_LOCATION_ALREADY_EXISTS Code = 101110
+	// WAREHOUSE_LOCATION_CAPACITY_EXCEEDED the stocks exceed the capacity of
+	// the location or one of its ancestors.
+	Code_WAREHOUSE_LOCATION_CAPACITY_EXCEEDED Code = 101120
+	// WAREHOUSE_LOCATION_PRODUCT_TYPE_NOT_ALLOWED the product type is not
+	// allowed in the location or one of its ancestors.
+	Code_WAREHOUSE_LOCATION_PRODUCT_TYPE_NOT_ALLOWED Code = 101130
+	Code_STOCK_COUNT_NOT_FOUND                       Code = 101200
+	// STOCK_COUNT_CLOSED the stock count has been posted or cancelled.
+	Code_STOCK_COUNT_CLOSED Code = 101210
 	// USER_STATION_MISMATCH the user is not the station/site operator.
 	Code_USER_STATION_MISMATCH Code = 120100
+	// USER_STATION_SIGN_ON_FORBIDDEN e.g. not qualified/registered to operate the station.
+	Code_USER_STATION_SIGN_ON_FORBIDDEN Code = 120300
 	// STATION_WORKORDER_MISMATCH the work order is not being executed the station
 	Code_STATION_WORKORDER_MISMATCH Code = 240100
 	// RESOURCE_WORKORDER_QUANTITY_BELOW_MIN the used quantity is less than the minimum
@@ -131,22 +168,34 @@ var Code_name = map[int32]string{
 	13200:  "STATION_OPERATOR_NOT_MATCH",
 	20000:  "STATION_NOT_FOUND",
 	20200:  "STATION_ALREADY_EXISTS",
+	20300:  "STATION_STATE_TRANSITION_NOT_ALLOWED",
+	20400:  "STATION_TEMPLATE_NOT_FOUND",
+	20410:  "STATION_TEMPLATE_ALREADY_EXISTS",
+	20500:  "STATION_CONFIGURATION_VERSION_NOT_FOUND",
 	21000:  "STATION_PRINTER_NOT_DEFINED",
 	25100:  "STATION_GROUP_ALREADY_EXISTS",
 	25200:  "STATION_GROUP_ID_NOT_FOUND",
+	25300:  "STATION_GROUP_CYCLE_DETECTED",
 	26000:  "STATION_SITE_NOT_FOUND",
 	26010:  "STATION_SITE_BIND_RECORD_NOT_FOUND",
 	27000:  "STATION_SITE_REMAINING_OBJECTS",
 	28000:  "STATION_SITE_ALREADY_EXISTS",
 	29000:  "STATION_SITE_SUB_TYPE_MISMATCH",
+	29100:  "STATION_SITE_CAPACITY_EXCEEDED",
 	30000:  "RESOURCE_NOT_FOUND",
 	30010:  "RESOURCE_MATERIAL_SHORTAGE",
 	30020:  "RESOURCE_UNAVAILABLE",
 	30100:  "RESOURCE_EXPIRED",
 	30401:  "RESOURCE_CONTROL_ABOVE_EXTENDED_COUNT",
 	30500:  "RESOURCE_EXISTED",
+	30600:  "HOLD_CASE_NOT_FOUND",
 	30700:  "RESOURCES_COUNT_MISMATCH",
+	30800:  "HOLD_CASE_RELEASED",
 	31100:  "RESOURCE_SITE_NOT_SHARED",
+	32000:  "TOOL_LIFE_EXCEEDED",
+	32100:  "TOOL_RETIRED",
+	32200:  "TOOL_TYPE_NOT_FOUND",
+	32300:  "TOOL_TYPE_ALREADY_EXISTS",
 	40000:  "WORKORDER_NOT_FOUND",
 	40100:  "WORKORDER_BAD_BATCH",
 	40300:  "WORKORDER_BAD_STATUS",
@@ -158,8 +207,10 @@ var Code_name = map[int32]string{
 	60100:  "BATCH_NOT_READY",
 	70000:  "DEPARTMENT_NOT_FOUND",
 	70200:  "DEPARTMENT_ALREADY_EXISTS",
+	70300:  "SHIFT_CALENDAR_NOT_FOUND",
 	80000:  "PRODUCTION_PLAN_NOT_FOUND",
 	80100:  "PRODUCTION_PLAN_EXISTED",
+	80200:  "PRODUCTION_NOT_READY",
 	81000:  "RECORD_NOT_FOUND",
 	81100:  "RECORD_ALREADY_EXISTS",
 	90000:  "RECIPE_NOT_FOUND",
@@ -177,7 +228,14 @@ var Code_name = map[int32]string{
 	100400: "BAD_WORK_DATE",
 	100500: "FAILED_TO_PRINT_RESOURCE",
 	101000: "WAREHOUSE_NOT_FOUND",
+	101100: "WAREHOUSE_LOCATION_NOT_FOUND",
+	101110: "WAREHOUSE_LOCATION_ALREADY_EXISTS",
+	101120: "WAREHOUSE_LOCATION_CAPACITY_EXCEEDED",
+	101130: "WAREHOUSE_LOCATION_PRODUCT_TYPE_NOT_ALLOWED",
+	101200: "STOCK_COUNT_NOT_FOUND",
+	101210: "STOCK_COUNT_CLOSED",
 	120100: "USER_STATION_MISMATCH",
+	120300: "USER_STATION_SIGN_ON_FORBIDDEN",
 	240100: "STATION_WORKORDER_MISMATCH",
 	340201: "RESOURCE_WORKORDER_QUANTITY_BELOW_MIN",
 	340202: "RESOURCE_WORKORDER_QUANTITY_ABOVE_MAX",
@@ -188,76 +246,97 @@ var Code_name = map[int32]string{
 }
 
 var Code_value = map[string]int32{
-	"NONE":                                   0,
-	"ACCOUNT_NOT_FOUND_OR_BAD_PASSWORD":      10000,
-	"USER_NO_PERMISSION":                     10100,
-	"USER_UNKNOWN_TOKEN":                     10300,
-	"USER_ALREADY_EXISTS":                    10400,
-	"ACCOUNT_ROLES_NOT_PERMIT":               10500,
-	"ACCOUNT_SAME_AS_OLD_PASSWORD":           10600,
-	"ACCOUNT_BAD_OLD_PASSWORD":               10700,
-	"USER_NOT_FOUND":                         10800,
-	"ACCOUNT_ALREADY_EXISTS":                 11000,
-	"ACCOUNT_NOT_FOUND":                      12000,
-	"PREVIOUS_USER_NOT_SIGNED_OUT":           13000,
-	"USER_HAS_NOT_SIGNED_IN":                 13100,
-	"STATION_OPERATOR_NOT_MATCH":             13200,
-	"STATION_NOT_FOUND":                      20000,
-	"STATION_ALREADY_EXISTS":                 20200,
-	"STATION_PRINTER_NOT_DEFINED":            21000,
-	"STATION_GROUP_ALREADY_EXISTS":           25100,
-	"STATION_GROUP_ID_NOT_FOUND":             25200,
-	"STATION_SITE_NOT_FOUND":                 26000,
-	"STATION_SITE_BIND_RECORD_NOT_FOUND":     26010,
-	"STATION_SITE_REMAINING_OBJECTS":         27000,
-	"STATION_SITE_ALREADY_EXISTS":            28000,
-	"STATION_SITE_SUB_TYPE_MISMATCH":         29000,
-	"RESOURCE_NOT_FOUND":                     30000,
-	"RESOURCE_MATERIAL_SHORTAGE":             30010,
-	"RESOURCE_UNAVAILABLE":                   30020,
-	"RESOURCE_EXPIRED":                       30100,
-	"RESOURCE_CONTROL_ABOVE_EXTENDED_COUNT":  30401,
-	"RESOURCE_EXISTED":                       30500,
-	"RESOURCES_COUNT_MISMATCH":               30700,
-	"RESOURCE_SITE_NOT_SHARED":               31100,
-	"WORKORDER_NOT_FOUND":                    40000,
-	"WORKORDER_BAD_BATCH":                    40100,
-	"WORKORDER_BAD_STATUS":                   40300,
-	"CARRIER_NOT_FOUND":                      50000,
-	"CARRIER_IN_USE":                         50100,
-	"CARRIER_QUANTITY_LIMIT":                 50400,
-	"BATCH_NOT_FOUND":                        60000,
-	"BATCH_ALREADY_EXISTS":                   60200,
-	"BATCH_NOT_READY":                        60100,
-	"DEPARTMENT_NOT_FOUND":                   70000,
-	"DEPARTMENT_ALREADY_EXISTS":              70200,
-	"PRODUCTION_PLAN_NOT_FOUND":              80000,
-	"PRODUCTION_PLAN_EXISTED":                80100,
-	"RECORD_NOT_FOUND":                       81000,
-	"RECORD_ALREADY_EXISTS":                  81100,
-	"RECIPE_NOT_FOUND":                       90000,
-	"RECIPE_ALREADY_EXISTS":                  90100,
-	"PRODUCT_ID_NOT_FOUND":                   91000,
-	"SUBSTITUTION_ALREADY_EXISTS":            91010,
-	"LIMITARY_HOUR_ALREADY_EXISTS":           91110,
-	"LIMITARY_HOUR_NOT_FOUND":                91111,
-	"PROCESS_NOT_FOUND":                      92000,
-	"PROCESS_ALREADY_EXISTS":                 92100,
-	"INSUFFICIENT_REQUEST":                   100000,
-	"INVALID_NUMBER":                         100100,
-	"BAD_REQUEST":                            100200,
-	"PRODUCT_ID_MISMATCH":                    100300,
-	"BAD_WORK_DATE":                          100400,
-	"FAILED_TO_PRINT_RESOURCE":               100500,
-	"WAREHOUSE_NOT_FOUND":                    101000,
-	"USER_STATION_MISMATCH":                  120100,
-	"STATION_WORKORDER_MISMATCH":             240100,
-	"RESOURCE_WORKORDER_QUANTITY_BELOW_MIN":  340201,
-	"RESOURCE_WORKORDER_QUANTITY_ABOVE_MAX":  340202,
-	"RESOURCE_WORKORDER_BAD_GRADE":           340301,
-	"RESOURCE_WORKORDER_RESOURCE_UNEXPECTED": 340400,
-	"RESOURCE_WORKORDER_RESOURCE_MISSING":    340500,
-	"BLOB_ALREADY_EXIST":                     341000,
+	"NONE":                                        0,
+	"ACCOUNT_NOT_FOUND_OR_BAD_PASSWORD":           10000,
+	"USER_NO_PERMISSION":                          10100,
+	"USER_UNKNOWN_TOKEN":                          10300,
+	"USER_ALREADY_EXISTS":                         10400,
+	"ACCOUNT_ROLES_NOT_PERMIT":                    10500,
+	"ACCOUNT_SAME_AS_OLD_PASSWORD":                10600,
+	"ACCOUNT_BAD_OLD_PASSWORD":                    10700,
+	"USER_NOT_FOUND":                              10800,
+	"ACCOUNT_ALREADY_EXISTS":                      11000,
+	"ACCOUNT_NOT_FOUND":                           12000,
+	"PREVIOUS_USER_NOT_SIGNED_OUT":                13000,
+	"USER_HAS_NOT_SIGNED_IN":                      13100,
+	"STATION_OPERATOR_NOT_MATCH":                  13200,
+	"STATION_NOT_FOUND":                           20000,
+	"STATION_ALREADY_EXISTS":                      20200,
+	"STATION_STATE_TRANSITION_NOT_ALLOWED":        20300,
+	"STATION_TEMPLATE_NOT_FOUND":                  20400,
+	"STATION_TEMPLATE_ALREADY_EXISTS":             20410,
+	"STATION_CONFIGURATION_VERSION_NOT_FOUND":     20500,
+	"STATION_PRINTER_NOT_DEFINED":                 21000,
+	"STATION_GROUP_ALREADY_EXISTS":                25100,
+	"STATION_GROUP_ID_NOT_FOUND":                  25200,
+	"STATION_GROUP_CYCLE_DETECTED":                25300,
+	"STATION_SITE_NOT_FOUND":                      26000,
+	"STATION_SITE_BIND_RECORD_NOT_FOUND":          26010,
+	"STATION_SITE_REMAINING_OBJECTS":              27000,
+	"STATION_SITE_ALREADY_EXISTS":                 28000,
+	"STATION_SITE_SUB_TYPE_MISMATCH":              29000,
+	"STATION_SITE_CAPACITY_EXCEEDED":              29100,
+	"RESOURCE_NOT_FOUND":                          30000,
+	"RESOURCE_MATERIAL_SHORTAGE":                  30010,
+	"RESOURCE_UNAVAILABLE":                        30020,
+	"RESOURCE_EXPIRED":                            30100,
+	"RESOURCE_CONTROL_ABOVE_EXTENDED_COUNT":       30401,
+	"RESOURCE_EXISTED":                            30500,
+	"HOLD_CASE_NOT_FOUND":                         30600,
+	"RESOURCES_COUNT_MISMATCH":                    30700,
+	"HOLD_CASE_RELEASED":                          30800,
+	"RESOURCE_SITE_NOT_SHARED":                    31100,
+	"TOOL_LIFE_EXCEEDED":                          32000,
+	"TOOL_RETIRED":                                32100,
+	"TOOL_TYPE_NOT_FOUND":                         32200,
+	"TOOL_TYPE_ALREADY_EXISTS":                    32300,
+	"WORKORDER_NOT_FOUND":                         40000,
+	"WORKORDER_BAD_BATCH":                         40100,
+	"WORKORDER_BAD_STATUS":                        40300,
+	"CARRIER_NOT_FOUND":                           50000,
+	"CARRIER_IN_USE":                              50100,
+	"CARRIER_QUANTITY_LIMIT":                      50400,
+	"BATCH_NOT_FOUND":                             60000,
+	"BATCH_ALREADY_EXISTS":                        60200,
+	"BATCH_NOT_READY":                             60100,
+	"DEPARTMENT_NOT_FOUND":                        70000,
+	"DEPARTMENT_ALREADY_EXISTS":                   70200,
+	"SHIFT_CALENDAR_NOT_FOUND":                    70300,
+	"PRODUCTION_PLAN_NOT_FOUND":                   80000,
+	"PRODUCTION_PLAN_EXISTED":                     80100,
+	"PRODUCTION_NOT_READY":                        80200,
+	"RECORD_NOT_FOUND":                            81000,
+	"RECORD_ALREADY_EXISTS":                       81100,
+	"RECIPE_NOT_FOUND":                            90000,
+	"RECIPE_ALREADY_EXISTS":                       90100,
+	"PRODUCT_ID_NOT_FOUND":                        91000,
+	"SUBSTITUTION_ALREADY_EXISTS":                 91010,
+	"LIMITARY_HOUR_ALREADY_EXISTS":                91110,
+	"LIMITARY_HOUR_NOT_FOUND":                     91111,
+	"PROCESS_NOT_FOUND":                           92000,
+	"PROCESS_ALREADY_EXISTS":                      92100,
+	"INSUFFICIENT_REQUEST":                        100000,
+	"INVALID_NUMBER":                              100100,
+	"BAD_REQUEST":                                 100200,
+	"PRODUCT_ID_MISMATCH":                         100300,
+	"BAD_WORK_DATE":                               100400,
+	"FAILED_TO_PRINT_RESOURCE":                    100500,
+	"WAREHOUSE_NOT_FOUND":                         101000,
+	"WAREHOUSE_LOCATION_NOT_FOUND":                101100,
+	"WAREHOUSE_LOCATION_ALREADY_EXISTS":           101110,
+	"WAREHOUSE_LOCATION_CAPACITY_EXCEEDED":        101120,
+	"WAREHOUSE_LOCATION_PRODUCT_TYPE_NOT_ALLOWED": 101130,
+	"STOCK_COUNT_NOT_FOUND":                       101200,
+	"STOCK_COUNT_CLOSED":                          101210,
+	"USER_STATION_MISMATCH":                       120100,
+	"USER_STATION_SIGN_ON_FORBIDDEN":              120300,
+	"STATION_WORKORDER_MISMATCH":                  240100,
+	"RESOURCE_WORKORDER_QUANTITY_BELOW_MIN":       340201,
+	"RESOURCE_WORKORDER_QUANTITY_ABOVE_MAX":       340202,
+	"RESOURCE_WORKORDER_BAD_GRADE":                340301,
+	"RESOURCE_WORKORDER_RESOURCE_UNEXPECTED":      340400,
+	"RESOURCE_WORKORDER_RESOURCE_MISSING":         340500,
+	"BLOB_ALREADY_EXIST":                          341000,
 }
 
 func (x Code) String() string {
@@ -275,80 +354,96 @@ func init() {
 func init() { proto.RegisterFile("code.proto", fileDescriptor_6e9b0151640170c3) }
 
 var fileDescriptor_6e9b0151640170c3 = []byte{
-	// 1188 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x56, 0xdb, 0x8b, 0x1c, 0xc5,
-	0x17, 0xfe, 0xf5, 0xce, 0x25, 0x4d, 0xfd, 0x70, 0xad, 0xad, 0xed, 0x6c, 0x36, 0x77, 0x33, 0x9a,
-	0x78, 0x65, 0xf3, 0xe0, 0x5f, 0x50, 0xdd, 0x5d, 0x3b, 0x53, 0xa6, 0xa7, 0xaa, 0x53, 0x55, 0xbd,
-	0x17, 0x41, 0x8a, 0x5c, 0xd6, 0x20, 0x1a, 0x47, 0xd6, 0x80, 0xaf, 0x22, 0x1b, 0x5d, 0x41, 0xe3,
-	0x0a, 0x11, 0x82, 0x18, 0x08, 0xb2, 0x0f, 0x62, 0x7c, 0x58, 0xc1, 0x87, 0x98, 0x08, 0x51, 0x58,
-	0x4c, 0x20, 0x51, 0x83, 0x46, 0x09, 0x92, 0x87, 0x4c, 0x0c, 0xba, 0xee, 0x8e, 0x9a, 0x48, 0x1e,
-	0x56, 0xf0, 0x41, 0xaa, 0x67, 0x7a, 0xa6, 0xbb, 0x13, 0xf4, 0xad, 0xb7, 0xbe, 0xaf, 0xce, 0xf9,
-	0x4e, 0x9d, 0xf3, 0x9d, 0x1d, 0x00, 0xf6, 0x35, 0xf6, 0x4f, 0x8d, 0xbc, 0x30, 0xdd, 0x38, 0xd4,
-	0x40, 0xe5, 0xa9, 0xe9, 0xe9, 0xc6, 0xf4, 0x8b, 0x8f, 0x7c, 0x3c, 0x00, 0x8a, 0x5e, 0x63, 0xff,
-	0x14, 0xb2, 0x41, 0x91, 0x71, 0x46, 0xe0, 0xff, 0xd0, 0x0e, 0xb0, 0x0d, 0x7b, 0x1e, 0x8f, 0x98,
-	0xd2, 0x8c, 0x2b, 0x3d, 0xca, 0x23, 0xe6, 0x6b, 0x2e, 0xb4, 0x8b, 0x7d, 0x1d, 0x62, 0x29, 0xc7,
-	0xb9, 0xf0, 0xe1, 0x1c, 0x43, 0xeb, 0x00, 0x8a, 0x24, 0x11, 0x9a, 0x71, 0x1d, 0x12, 0x51, 0xa7,
-	0x52, 0x52, 0xce, 0xe0, 0xed, 0x1e, 0x10, 0xb1, 0x5d, 0x8c, 0x8f, 0x33, 0xad, 0xf8, 0x2e, 0xc2,
-	0xe0, 0x67, 0x21, 0x1a, 0x06, 0x83, 0x31, 0x80, 0x03, 0x41, 0xb0, 0x3f, 0xa9, 0xc9, 0x04, 0x95,
-	0x4a, 0xc2, 0x13, 0xbb, 0xd1, 0x66, 0x30, 0x9c, 0xe4, 0x14, 0x3c, 0x20, 0x32, 0xce, 0x1c, 0x47,
-	0x55, 0x70, 0x46, 0xa0, 0x6d, 0x60, 0x53, 0x02, 0x4b, 0x5c, 0x27, 0x1a, 0x4b, 0xcd, 0x83, 0x94,
-	0x9a, 0x25, 0x91, 0x8e, 0x60, 0x84, 0x66, 0xe0, 0x8b, 0x12, 0x0d, 0x82, 0xfe, 0x8e, 0xd8, 0x4e,
-	0x45, 0x70, 0x41, 0xa1, 0x8d, 0x60, 0x28, 0xb9, 0x93, 0x93, 0xb4, 0x1a, 0xa1, 0x21, 0x30, 0x70,
-	0xc7, 0x33, 0xc0, 0x6b, 0x4f, 0x19, 0x2d, 0xa1, 0x20, 0x63, 0x94, 0x47, 0x52, 0x77, 0x43, 0x4a,
-	0x5a, 0x65, 0xc4, 0xd7, 0x3c, 0x52, 0xf0, 0xfc, 0x94, 0x89, 0x1b, 0x23, 0x35, 0x2c, 0xd3, 0x28,
-	0x65, 0xf0, 0xa3, 0xa7, 0xd1, 0x56, 0xb0, 0x41, 0x2a, 0xac, 0x28, 0x67, 0x9a, 0x87, 0x44, 0x60,
-	0xc5, 0xdb, 0x21, 0xea, 0x58, 0x79, 0x35, 0x38, 0x77, 0x00, 0xad, 0x03, 0x03, 0x09, 0xa1, 0x97,
-	0xf8, 0xc4, 0x7b, 0x16, 0xda, 0x04, 0x86, 0x12, 0x20, 0x27, 0x77, 0xe9, 0xb8, 0x85, 0xb6, 0x81,
-	0x8d, 0x09, 0x1a, 0x0a, 0xca, 0x54, 0x47, 0x99, 0x4f, 0x46, 0x29, 0x23, 0x3e, 0x9c, 0x9d, 0xb7,
-	0x50, 0x05, 0x6c, 0x4a, 0x28, 0x55, 0xc1, 0xa3, 0x30, 0x1f, 0xe6, 0x8d, 0x45, 0x0b, 0xdd, 0xd7,
-	0x93, 0xd7, 0xe6, 0x50, 0x3f, 0x25, 0xe3, 0xe6, 0x62, 0x46, 0x86, 0xa4, 0x8a, 0xa4, 0xd0, 0xb9,
-	0x0b, 0x16, 0x7a, 0x08, 0x54, 0x32, 0xa8, 0x4b, 0x99, 0xaf, 0x05, 0xf1, 0xb8, 0x48, 0xc7, 0x79,
-	0xf7, 0x82, 0x85, 0x1e, 0x00, 0x5b, 0x32, 0x4c, 0x41, 0xea, 0x98, 0x32, 0xca, 0xaa, 0x9a, 0xbb,
-	0x4f, 0x10, 0xcf, 0x74, 0xe1, 0xdb, 0x4c, 0x59, 0x31, 0x2b, 0x27, 0xf9, 0xda, 0x8f, 0x77, 0x06,
-	0x92, 0x91, 0xab, 0xd5, 0x64, 0x48, 0x74, 0x9d, 0xca, 0xf6, 0xab, 0x9e, 0xbf, 0x6e, 0xa1, 0x61,
-	0x80, 0x04, 0x91, 0x3c, 0x12, 0x5e, 0x5a, 0xf2, 0xc2, 0x72, 0x5c, 0x72, 0x17, 0xa9, 0x63, 0x45,
-	0x04, 0xc5, 0x81, 0x96, 0x35, 0x2e, 0x14, 0xae, 0x12, 0x78, 0x7a, 0xd9, 0x42, 0x1b, 0x80, 0xd3,
-	0x65, 0x44, 0x0c, 0x8f, 0x61, 0x1a, 0x60, 0x37, 0x20, 0x70, 0x71, 0xd9, 0x42, 0x43, 0x00, 0x76,
-	0x31, 0x32, 0x11, 0x52, 0x41, 0x7c, 0x78, 0x74, 0xc5, 0x42, 0x8f, 0x82, 0xed, 0xdd, 0x73, 0x8f,
-	0x33, 0x25, 0x78, 0xa0, 0xb1, 0xcb, 0xc7, 0x0c, 0x4b, 0x11, 0xe6, 0x13, 0x5f, 0xc7, 0xd3, 0x05,
-	0xbf, 0xf8, 0x2d, 0x1f, 0x84, 0x4a, 0x45, 0x7c, 0x38, 0xff, 0xbb, 0x85, 0xb6, 0x80, 0xe1, 0xe4,
-	0x5c, 0xb6, 0xe9, 0xbd, 0xa2, 0x5a, 0x7f, 0x64, 0xf0, 0x5e, 0x33, 0x64, 0x0d, 0x1b, 0x11, 0x7f,
-	0xff, 0x69, 0xa1, 0xf5, 0x60, 0x70, 0x9c, 0x8b, 0x5d, 0x5c, 0xf8, 0x99, 0xd9, 0xff, 0xfc, 0x54,
-	0x5f, 0x16, 0x32, 0x96, 0x71, 0xe3, 0xa8, 0xf3, 0x9f, 0xf6, 0x99, 0x72, 0xb3, 0x90, 0x79, 0xde,
-	0x48, 0xc2, 0xd6, 0xe9, 0x3e, 0x33, 0x9d, 0x1e, 0x16, 0x82, 0x66, 0xe2, 0x5d, 0x7a, 0xb5, 0x80,
-	0x1c, 0xd0, 0x9f, 0x00, 0x94, 0x19, 0x67, 0xc0, 0x4f, 0x5e, 0x2b, 0x98, 0x61, 0x49, 0x4e, 0x77,
-	0x47, 0x98, 0x29, 0xaa, 0x26, 0x75, 0x40, 0x8d, 0xad, 0xaf, 0xbd, 0x5e, 0x40, 0x6b, 0xc1, 0xbd,
-	0x71, 0xd6, 0xb4, 0xc3, 0x2e, 0x17, 0x4c, 0xfe, 0xf6, 0x71, 0xae, 0xd9, 0x1f, 0xfc, 0x90, 0xbb,
-	0x12, 0xa3, 0x70, 0xf1, 0xfb, 0xf8, 0x8a, 0x4f, 0x42, 0x2c, 0x54, 0x9d, 0x64, 0x0c, 0x7b, 0xf3,
-	0xfd, 0x22, 0xda, 0x0a, 0xd6, 0xa7, 0xb0, 0x5c, 0xcc, 0x53, 0xf3, 0x31, 0x21, 0x14, 0xdc, 0x8f,
-	0xbc, 0xb6, 0x7b, 0x02, 0x9c, 0x76, 0xde, 0xcb, 0xb7, 0x8a, 0x68, 0x33, 0x58, 0x97, 0x27, 0x24,
-	0x5d, 0xba, 0x71, 0xab, 0xd8, 0xee, 0x5e, 0x6e, 0xc2, 0x97, 0x56, 0x8b, 0x68, 0x23, 0x58, 0xdb,
-	0x39, 0xcf, 0x25, 0xbd, 0xf8, 0x57, 0x72, 0x89, 0x86, 0x19, 0x03, 0x9d, 0x2d, 0x75, 0x2e, 0x99,
-	0xf3, 0xdc, 0xa5, 0xdb, 0x67, 0x4b, 0xa6, 0xcc, 0x8e, 0x90, 0xac, 0x2f, 0x57, 0xbf, 0x2c, 0xc5,
-	0x4e, 0x89, 0x5c, 0xa9, 0xa8, 0x8a, 0xee, 0xb6, 0x23, 0x5e, 0x39, 0x57, 0x32, 0x0b, 0x20, 0x7e,
-	0x7c, 0x2c, 0x26, 0x75, 0x8d, 0x47, 0x77, 0x6c, 0xe2, 0x9f, 0xcf, 0x95, 0x4c, 0xad, 0x59, 0x4e,
-	0x2f, 0xcb, 0x2f, 0xe7, 0x4a, 0xa6, 0xff, 0xa1, 0xe0, 0x1e, 0x91, 0x32, 0xdd, 0xb4, 0xaf, 0x4b,
-	0xa6, 0xd3, 0x09, 0x90, 0x8b, 0xba, 0xf8, 0x4d, 0x2c, 0x9c, 0x32, 0x19, 0x8d, 0x8e, 0x52, 0x8f,
-	0x9a, 0x2e, 0x08, 0xb2, 0x3b, 0x22, 0x52, 0xc1, 0x13, 0x6f, 0x96, 0xcd, 0xe4, 0x50, 0x36, 0x86,
-	0x03, 0x53, 0x51, 0x54, 0x77, 0x89, 0x80, 0x33, 0x47, 0xca, 0x68, 0x00, 0xfc, 0xdf, 0x8c, 0x5e,
-	0x42, 0x5c, 0x3a, 0x52, 0x36, 0x23, 0x9b, 0xaa, 0xbe, 0x6b, 0x84, 0x8b, 0x6f, 0x95, 0xd1, 0x20,
-	0xb8, 0xc7, 0xb0, 0xcd, 0xd8, 0x6a, 0x1f, 0x2b, 0x02, 0x17, 0xe6, 0xca, 0xc6, 0x1d, 0xa3, 0x98,
-	0x06, 0xc4, 0xd7, 0x8a, 0xb7, 0x97, 0xa2, 0x4e, 0xdc, 0x02, 0x8f, 0xbe, 0x1d, 0xc7, 0x1b, 0xc7,
-	0x82, 0xd4, 0x78, 0x24, 0xd3, 0x5d, 0x98, 0x7d, 0xa7, 0x6c, 0xba, 0x10, 0xaf, 0xf0, 0x64, 0xb1,
-	0x74, 0x93, 0xcd, 0x7f, 0xb8, 0x26, 0xbd, 0x23, 0x7b, 0x3e, 0xe9, 0x32, 0x6e, 0x7c, 0xd7, 0x9f,
-	0x31, 0x7f, 0x8f, 0xd2, 0x75, 0x80, 0x4b, 0x02, 0x3e, 0xae, 0xeb, 0x94, 0xc1, 0x5f, 0x9b, 0xce,
-	0x7f, 0x91, 0xdb, 0x4b, 0xa3, 0x8e, 0x27, 0xe0, 0x72, 0xd3, 0x31, 0x2d, 0xbc, 0x0b, 0xd9, 0xd4,
-	0x5e, 0x15, 0xd8, 0x27, 0xf0, 0xab, 0xeb, 0x0e, 0x7a, 0x0c, 0xec, 0xb8, 0x0b, 0x27, 0xb5, 0xc1,
-	0xc8, 0x44, 0x48, 0x3c, 0x33, 0xbd, 0x0b, 0x3f, 0x39, 0xe8, 0x61, 0x70, 0xff, 0xbf, 0xb1, 0xe3,
-	0x7f, 0xec, 0xac, 0x0a, 0x8f, 0xde, 0x70, 0xcc, 0x0e, 0x75, 0x03, 0xee, 0x66, 0x1b, 0x0c, 0x67,
-	0x97, 0x9c, 0x4a, 0xd9, 0xbe, 0xc2, 0xe1, 0x15, 0x5e, 0xb1, 0xed, 0x99, 0xe3, 0x16, 0x9c, 0x39,
-	0x6e, 0x55, 0x6c, 0x7b, 0x75, 0xc5, 0x82, 0xab, 0x2b, 0xe6, 0xeb, 0x6a, 0xcb, 0x82, 0x57, 0x5b,
-	0xe6, 0xeb, 0xd2, 0x99, 0x3e, 0x78, 0xe9, 0x4c, 0x5f, 0xc5, 0xb6, 0x8f, 0xcd, 0x16, 0xe0, 0xb1,
-	0xd9, 0x42, 0xc5, 0xb6, 0x5b, 0x27, 0xd7, 0xc0, 0xd6, 0xc9, 0x35, 0x15, 0xdb, 0xbe, 0x7c, 0xb8,
-	0x1f, 0x5e, 0x3e, 0xdc, 0x6f, 0xa2, 0x34, 0x1d, 0x38, 0xd3, 0x74, 0x2a, 0xb6, 0xbd, 0xd2, 0x74,
-	0xe0, 0x4a, 0xfc, 0xd5, 0x6a, 0x3a, 0xb0, 0xd5, 0x74, 0xdc, 0x07, 0x9f, 0xdc, 0x7e, 0xe0, 0x99,
-	0x43, 0xcf, 0xed, 0xd9, 0x3b, 0xf2, 0xec, 0xd4, 0xf3, 0xfb, 0xf7, 0x8c, 0xec, 0x6b, 0x1c, 0x1c,
-	0x39, 0xf4, 0xd2, 0xce, 0xf8, 0x8f, 0x9d, 0x07, 0xf7, 0x35, 0x0e, 0xee, 0x6c, 0xff, 0xb8, 0xd9,
-	0x5b, 0x8e, 0x7f, 0xeb, 0x3c, 0xfe, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x79, 0xc0, 0xda, 0xda,
-	0xf9, 0x08, 0x00, 0x00,
+	// 1453 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x56, 0xed, 0x6f, 0x14, 0xc5,
+	0x1f, 0xff, 0x6d, 0x7b, 0x3d, 0x2e, 0xf3, 0xd3, 0x3a, 0x4c, 0x8f, 0x52, 0x9e, 0xed, 0xc9, 0x83,
+	0x82, 0x96, 0x18, 0xff, 0x82, 0xb9, 0xdd, 0xb9, 0xde, 0xd8, 0xbd, 0x99, 0x65, 0x66, 0xb6, 0x0f,
+	0x26, 0x66, 0xc2, 0x43, 0x25, 0x46, 0xb1, 0xa6, 0x92, 0xf8, 0x96, 0x98, 0xa2, 0xd5, 0x28, 0xd6,
+	0xa4, 0x10, 0x62, 0x40, 0x1a, 0x53, 0x13, 0xa3, 0xbc, 0xe0, 0x85, 0x2f, 0x10, 0x4c, 0xd0, 0xa4,
+	0x4a, 0x13, 0xaa, 0x36, 0x5a, 0x0d, 0x21, 0xbc, 0xa0, 0xd0, 0x68, 0x6d, 0x4f, 0x79, 0x08, 0x31,
+	0x15, 0x79, 0x61, 0x66, 0xaf, 0x7b, 0xb7, 0xbb, 0x6d, 0xf4, 0xd5, 0xed, 0xed, 0xe7, 0xf3, 0xfd,
+	0xce, 0xf7, 0x69, 0x3f, 0xdf, 0x01, 0x60, 0x6f, 0xdf, 0xbe, 0xde, 0xb6, 0x97, 0xfb, 0xfb, 0x0e,
+	0xf6, 0xa1, 0x74, 0x6f, 0x7f, 0x7f, 0x5f, 0xff, 0x2b, 0xdb, 0x17, 0x9a, 0x41, 0xca, 0xee, 0xdb,
+	0xd7, 0x8b, 0x32, 0x20, 0xc5, 0x38, 0x23, 0xf0, 0x7f, 0x68, 0x2b, 0x68, 0xc5, 0xb6, 0xcd, 0x7d,
+	0xa6, 0x34, 0xe3, 0x4a, 0x17, 0xb8, 0xcf, 0x1c, 0xcd, 0x85, 0xce, 0x63, 0x47, 0x7b, 0x58, 0xca,
+	0x2e, 0x2e, 0x1c, 0x38, 0xc4, 0xd0, 0x6a, 0x80, 0x7c, 0x49, 0x84, 0x66, 0x5c, 0x7b, 0x44, 0x94,
+	0xa8, 0x94, 0x94, 0x33, 0x78, 0xb7, 0x06, 0xf8, 0xac, 0x83, 0xf1, 0x2e, 0xa6, 0x15, 0xef, 0x20,
+	0x0c, 0x7e, 0xe1, 0xa1, 0x16, 0xd0, 0x14, 0x00, 0xd8, 0x15, 0x04, 0x3b, 0x3d, 0x9a, 0x74, 0x53,
+	0xa9, 0x24, 0x1c, 0xd9, 0x85, 0x36, 0x80, 0x96, 0xf0, 0x4c, 0xc1, 0x5d, 0x22, 0x83, 0x93, 0x03,
+	0xaf, 0x0a, 0x0e, 0x08, 0xd4, 0x0a, 0xd6, 0x87, 0xb0, 0xc4, 0x25, 0xa2, 0xb1, 0xd4, 0xdc, 0x8d,
+	0x44, 0x33, 0x2b, 0xa2, 0x1e, 0x4c, 0xa0, 0x31, 0x78, 0x42, 0xa2, 0x26, 0xd0, 0xb8, 0x18, 0xec,
+	0x62, 0x46, 0xf0, 0x8c, 0x42, 0xeb, 0x40, 0x73, 0x68, 0x93, 0x08, 0x69, 0xc1, 0x47, 0xcd, 0x60,
+	0xe5, 0x92, 0x32, 0xc0, 0x6b, 0xcf, 0x9a, 0x58, 0x3c, 0x41, 0x3a, 0x29, 0xf7, 0xa5, 0xae, 0xba,
+	0x94, 0xb4, 0x9d, 0x11, 0x47, 0x73, 0x5f, 0xc1, 0xf1, 0x5e, 0xe3, 0x37, 0x40, 0x8a, 0x58, 0x46,
+	0x51, 0xca, 0xe0, 0xe9, 0xe7, 0xd0, 0x26, 0xb0, 0x56, 0x2a, 0xac, 0x28, 0x67, 0x9a, 0x7b, 0x44,
+	0x60, 0xc5, 0x2b, 0x2e, 0x4a, 0x58, 0xd9, 0x45, 0x38, 0xb4, 0x1f, 0xad, 0x06, 0x2b, 0x43, 0x42,
+	0xed, 0xe0, 0x91, 0x13, 0x16, 0x5a, 0x0f, 0x9a, 0x43, 0x20, 0x11, 0xee, 0xec, 0x49, 0x0b, 0x6d,
+	0x07, 0x9b, 0x43, 0xd4, 0xfc, 0x12, 0xad, 0x04, 0x66, 0x92, 0x56, 0xfd, 0x60, 0xd7, 0xe5, 0x5d,
+	0xc4, 0x81, 0x13, 0x1f, 0x58, 0xe8, 0xe1, 0x5a, 0x0c, 0x8a, 0x94, 0x3c, 0xd7, 0xd0, 0x23, 0x95,
+	0x39, 0x65, 0xa1, 0x2d, 0x60, 0xd3, 0x12, 0x46, 0xe2, 0xd0, 0x73, 0xa7, 0x2c, 0xf4, 0x04, 0xd8,
+	0x16, 0xd2, 0x6c, 0xce, 0x0a, 0xb4, 0xdd, 0x17, 0x95, 0x7f, 0x9d, 0x44, 0xc8, 0x78, 0x06, 0xc3,
+	0x23, 0x16, 0x6a, 0x05, 0xeb, 0x42, 0xba, 0x27, 0x28, 0x53, 0x8b, 0xd5, 0x73, 0x48, 0x81, 0x32,
+	0xe2, 0xc0, 0xc1, 0x51, 0x0b, 0xe5, 0xc0, 0xfa, 0x90, 0xd2, 0x2e, 0xb8, 0xef, 0x25, 0x4f, 0x7d,
+	0x7b, 0x2c, 0x16, 0x7e, 0x85, 0x43, 0x9d, 0xc8, 0x41, 0xb7, 0xc6, 0x96, 0xf1, 0x62, 0xf7, 0xd8,
+	0x2e, 0xd1, 0x0e, 0x51, 0xc4, 0x56, 0xc4, 0x81, 0x53, 0x5f, 0xc7, 0xca, 0x29, 0x69, 0xac, 0x00,
+	0x43, 0x97, 0x2c, 0xf4, 0x28, 0xc8, 0xc5, 0xd0, 0x3c, 0x65, 0x8e, 0x16, 0xc4, 0xe6, 0x22, 0x7a,
+	0xd6, 0xfb, 0x97, 0x2c, 0xb4, 0x19, 0x6c, 0x8c, 0x31, 0x05, 0x29, 0x61, 0xca, 0x28, 0x6b, 0xd7,
+	0x3c, 0xff, 0x34, 0xb1, 0xcd, 0x34, 0xfd, 0x10, 0x4b, 0x3d, 0x60, 0x25, 0xd2, 0xba, 0x76, 0x65,
+	0xa9, 0x23, 0xe9, 0xe7, 0xb5, 0xea, 0xf1, 0x88, 0x2e, 0x51, 0x59, 0x99, 0x8e, 0xf1, 0xeb, 0x4b,
+	0x59, 0x36, 0xf6, 0xb0, 0x4d, 0x95, 0xf1, 0x64, 0x13, 0xe2, 0x10, 0x07, 0x9e, 0xbe, 0x61, 0xa1,
+	0x16, 0x80, 0x04, 0x91, 0xdc, 0x17, 0x76, 0xac, 0xb3, 0x73, 0x41, 0xf1, 0xaa, 0x48, 0x09, 0x2b,
+	0x22, 0x28, 0x76, 0xb5, 0x2c, 0x72, 0xa1, 0x70, 0x3b, 0x81, 0xe7, 0xe6, 0x2c, 0xb4, 0x16, 0x64,
+	0xab, 0x0c, 0x9f, 0xe1, 0x4e, 0x4c, 0x5d, 0x9c, 0x77, 0x09, 0x1c, 0x9b, 0xb3, 0x50, 0x33, 0x80,
+	0x55, 0x8c, 0x74, 0x7b, 0x54, 0x10, 0x07, 0x0e, 0xcf, 0x5b, 0x68, 0x07, 0xd8, 0x52, 0x7d, 0x6f,
+	0x73, 0xa6, 0x04, 0x77, 0x35, 0xce, 0xf3, 0x4e, 0xc3, 0x52, 0x84, 0x39, 0xc4, 0xd1, 0xc1, 0xb7,
+	0x04, 0xbf, 0xfa, 0x3d, 0xe9, 0x84, 0x4a, 0xd3, 0x91, 0xd1, 0x3f, 0x2c, 0xb4, 0x06, 0x34, 0x15,
+	0xcd, 0x87, 0x6b, 0x63, 0x19, 0x8d, 0x7a, 0xf0, 0xa6, 0x85, 0x36, 0x82, 0x96, 0xd0, 0x44, 0x56,
+	0x3c, 0xd5, 0xaa, 0x52, 0xbe, 0x19, 0xe4, 0x5b, 0x33, 0x15, 0xc4, 0x25, 0x58, 0x12, 0x07, 0x4e,
+	0xde, 0x8a, 0x59, 0xd6, 0xfa, 0x2c, 0x8b, 0xd8, 0x44, 0x7e, 0xff, 0x4e, 0x60, 0xa9, 0x38, 0x77,
+	0xb5, 0x4b, 0x0b, 0xa4, 0x56, 0xc3, 0x43, 0xf7, 0x2c, 0x84, 0xc0, 0x03, 0x01, 0x22, 0x88, 0x0a,
+	0xf2, 0x9c, 0xb9, 0x17, 0x84, 0x18, 0xbc, 0x0b, 0xfa, 0x52, 0x0b, 0x71, 0xfc, 0xef, 0xe0, 0xa0,
+	0x1a, 0x94, 0x68, 0xef, 0xe9, 0xfb, 0x81, 0x69, 0x17, 0x17, 0x1d, 0x5c, 0x38, 0x31, 0x1d, 0xfa,
+	0xf2, 0x6c, 0x5d, 0x1c, 0x32, 0xf2, 0x95, 0x0f, 0x12, 0x1b, 0xfd, 0xbc, 0xce, 0x34, 0x23, 0x0e,
+	0x99, 0xe6, 0xfb, 0x12, 0x96, 0xcf, 0xd5, 0x19, 0xa5, 0xb0, 0xb1, 0x10, 0x34, 0xe6, 0x6f, 0xf2,
+	0xf5, 0x7a, 0x94, 0x05, 0x8d, 0x21, 0x40, 0x99, 0x51, 0x29, 0xf8, 0xd9, 0x1b, 0xf5, 0x66, 0xe0,
+	0xc3, 0xb7, 0xbb, 0x7c, 0xcc, 0x94, 0x19, 0x1a, 0x97, 0x1a, 0x89, 0xbd, 0xf6, 0x66, 0x3d, 0x5a,
+	0x05, 0x1e, 0x0a, 0x4e, 0x8d, 0xaa, 0xdd, 0x54, 0xbd, 0x39, 0xbf, 0xf2, 0x3a, 0x91, 0xd1, 0xc7,
+	0x3f, 0x27, 0x4c, 0x02, 0x14, 0x8e, 0xfd, 0x14, 0x98, 0x38, 0xc4, 0xc3, 0x42, 0x95, 0x48, 0x4c,
+	0x3c, 0x6f, 0x7d, 0x98, 0x42, 0x9b, 0xc0, 0x9a, 0x08, 0x96, 0xf0, 0x79, 0x76, 0x34, 0x65, 0xaa,
+	0x28, 0x8b, 0xb4, 0xa0, 0xb4, 0x8d, 0x5d, 0xc2, 0x1c, 0x1c, 0x4d, 0xed, 0xc4, 0x47, 0x81, 0x03,
+	0x4f, 0x70, 0xc7, 0xb7, 0x2b, 0x2a, 0xe2, 0xe2, 0xa8, 0xc6, 0x1c, 0xba, 0x9d, 0x42, 0x1b, 0xc0,
+	0xea, 0x24, 0x21, 0x9c, 0xb1, 0x99, 0xdb, 0x29, 0x13, 0x5c, 0x04, 0xae, 0x05, 0x3e, 0x7e, 0x27,
+	0x55, 0x99, 0xcb, 0xc4, 0x17, 0x3e, 0xbb, 0x90, 0x42, 0xeb, 0xc0, 0xaa, 0xc5, 0xf7, 0x89, 0x80,
+	0x27, 0xfe, 0x0a, 0x8d, 0x68, 0x6c, 0x1c, 0x86, 0x2e, 0x34, 0x2c, 0x1a, 0xd1, 0xa5, 0xb3, 0x70,
+	0xf7, 0x42, 0x43, 0x24, 0x8a, 0xb8, 0x76, 0x2d, 0x7c, 0xd3, 0x10, 0x28, 0x85, 0x9f, 0x97, 0x8a,
+	0x2a, 0x7f, 0x39, 0xad, 0x7f, 0xed, 0x62, 0x83, 0x91, 0xb7, 0xa0, 0x71, 0x58, 0xf4, 0xe8, 0x22,
+	0xf7, 0x97, 0x6c, 0xd4, 0x5f, 0x2e, 0x36, 0x98, 0x3a, 0xc4, 0x39, 0xb5, 0x53, 0x7e, 0xbd, 0xd8,
+	0x60, 0x66, 0xc7, 0x13, 0xdc, 0x26, 0x52, 0x46, 0x1b, 0xfe, 0x5d, 0x83, 0x99, 0x92, 0x10, 0x48,
+	0x78, 0x1d, 0xfb, 0x3e, 0x08, 0x9c, 0x32, 0xe9, 0x17, 0x0a, 0xd4, 0xa6, 0xa6, 0x83, 0x82, 0xec,
+	0xf2, 0x89, 0x54, 0x70, 0xe4, 0x9d, 0xb4, 0x99, 0x3a, 0xca, 0x3a, 0xb1, 0x6b, 0x32, 0xf2, 0x4b,
+	0x79, 0x22, 0xe0, 0xc0, 0x91, 0x34, 0x5a, 0x09, 0xfe, 0x6f, 0xc6, 0x36, 0x24, 0xce, 0x1e, 0x49,
+	0x9b, 0x71, 0x8f, 0x64, 0x5f, 0xfd, 0x8e, 0x27, 0xde, 0x4d, 0xa3, 0x26, 0xf0, 0xa0, 0x61, 0x9b,
+	0x91, 0xd7, 0x0e, 0x56, 0x04, 0x9e, 0x19, 0x4a, 0x9b, 0x99, 0x28, 0x60, 0xea, 0x12, 0x47, 0x2b,
+	0x5e, 0x59, 0x1c, 0x3a, 0xfc, 0xa4, 0xe1, 0xf0, 0x7b, 0x81, 0xbf, 0x2e, 0x2c, 0x48, 0x91, 0xfb,
+	0x71, 0xdd, 0x38, 0x9a, 0x36, 0x95, 0xaa, 0x41, 0x2e, 0xb7, 0x93, 0x7b, 0xb5, 0x7c, 0x34, 0x8d,
+	0xb6, 0x81, 0xd6, 0x65, 0x38, 0x89, 0xe4, 0xff, 0x3c, 0x9a, 0x36, 0x2b, 0x76, 0x19, 0xe2, 0x52,
+	0x01, 0x3e, 0x74, 0x2c, 0x8d, 0x9e, 0x04, 0x3b, 0x96, 0xe1, 0x86, 0x69, 0x57, 0xe5, 0x23, 0xdc,
+	0xca, 0x6f, 0x1d, 0x4b, 0x9b, 0x89, 0x91, 0x8a, 0xdb, 0x1d, 0x3a, 0x79, 0xeb, 0x98, 0x3c, 0x96,
+	0x36, 0x32, 0x15, 0x05, 0x6d, 0x97, 0x1b, 0x81, 0xbb, 0x52, 0x31, 0x0b, 0x6e, 0x1b, 0xe1, 0x56,
+	0xa8, 0xd6, 0x73, 0xf4, 0x93, 0x15, 0x66, 0x5b, 0xc4, 0x40, 0x73, 0x15, 0xd1, 0x9c, 0xe9, 0x02,
+	0x17, 0x79, 0xea, 0x38, 0x84, 0xc1, 0xf2, 0xa7, 0x2b, 0xa2, 0x0b, 0xb5, 0x26, 0x36, 0x55, 0x3f,
+	0x33, 0x3f, 0x36, 0xc6, 0xf4, 0xbd, 0x46, 0xa9, 0xca, 0x48, 0x9e, 0xb8, 0xbc, 0x4b, 0x97, 0x28,
+	0x83, 0xbf, 0x4d, 0x67, 0xff, 0x8b, 0x5c, 0xd9, 0x0b, 0x25, 0xdc, 0x0d, 0xe7, 0xa6, 0xb3, 0xa6,
+	0x43, 0xcb, 0x90, 0xcd, 0x10, 0xb4, 0x0b, 0xec, 0x10, 0xf8, 0xed, 0xf5, 0x2c, 0x7a, 0x1c, 0x6c,
+	0x5d, 0x86, 0x13, 0x59, 0x52, 0xa4, 0xdb, 0xab, 0x2c, 0xf6, 0x33, 0x37, 0xb2, 0xe8, 0x31, 0xf0,
+	0xc8, 0xbf, 0xb1, 0x83, 0x9b, 0x2a, 0x6b, 0x87, 0xc3, 0x33, 0x59, 0x53, 0xd5, 0xbc, 0xcb, 0xf3,
+	0xf1, 0x66, 0xc3, 0xc1, 0xd9, 0x6c, 0x2e, 0x9d, 0xb9, 0xcc, 0xe1, 0x65, 0x9e, 0xcb, 0x64, 0x06,
+	0x4e, 0x5a, 0x70, 0xe0, 0xa4, 0x95, 0xcb, 0x64, 0x16, 0xe6, 0x2d, 0xb8, 0x30, 0x6f, 0x9e, 0xae,
+	0x96, 0x2d, 0x78, 0xb5, 0x6c, 0x9e, 0x26, 0xcf, 0xd7, 0xc1, 0xc9, 0xf3, 0x75, 0xb9, 0x4c, 0xe6,
+	0xf8, 0x60, 0x3d, 0x3c, 0x3e, 0x58, 0x9f, 0xcb, 0x64, 0xa6, 0x0e, 0x37, 0xc2, 0xa9, 0xc3, 0x8d,
+	0xc6, 0x76, 0x3a, 0x0b, 0x07, 0xa6, 0xb3, 0xb9, 0x4c, 0x66, 0x7e, 0x3a, 0x0b, 0xe7, 0x83, 0xa7,
+	0xf2, 0x74, 0x16, 0x96, 0xa7, 0xb3, 0xf9, 0x6d, 0xcf, 0x6c, 0xd9, 0xff, 0xfc, 0xc1, 0x17, 0x77,
+	0xef, 0x69, 0x7b, 0xa1, 0xf7, 0xa5, 0x7d, 0xbb, 0xdb, 0xf6, 0xf6, 0x1d, 0x68, 0x3b, 0xf8, 0xea,
+	0xce, 0xe0, 0xcf, 0xce, 0x03, 0x7b, 0xfb, 0x0e, 0xec, 0xac, 0xdc, 0xd1, 0xf7, 0xa4, 0x83, 0x2b,
+	0xfb, 0x53, 0xff, 0x0c, 0x00, 0x54, 0xcf, 0x3d, 0xf7, 0xc0, 0x0b, 0x00, 0x00,
 }
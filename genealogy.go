@@ -0,0 +1,82 @@
+package mcom
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/resources"
+)
+
+// TraceRequest definition.
+type TraceRequest struct {
+	ResourceID string `validate:"required"`
+	// ProductType is optional, all the resources with the ResourceID are
+	// traced if it is empty.
+	ProductType string
+	// MaxDepth is the max number of the levels to trace, it is unlimited if
+	// it is zero.
+	MaxDepth int `validate:"min=0"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req TraceRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// TraceNode is a lot in the genealogy.
+type TraceNode struct {
+	models.UniqueMaterialResource
+	// Found is false if the resource is fed but not found in the mes system,
+	// and only ProductID and Grade are set in the following fields.
+	Found     bool
+	ProductID string
+	Grade     string
+	LotNumber string
+	Status    resources.MaterialStatus
+	// Quantity is the remaining quantity of the resource.
+	Quantity decimal.Decimal
+
+	// WorkOrder, Station and Operator are where and by whom the resource was
+	// collected. They are empty if the resource was not collected in mes.
+	WorkOrder string
+	Station   string
+	Operator  string
+	// ProducedAt is when the resource was collected or created.
+	ProducedAt time.Time
+}
+
+// TraceEdge is the consumption of a lot for producing another lot.
+type TraceEdge struct {
+	// From is the consumed resource.
+	From models.UniqueMaterialResource
+	// To is the produced resource. It is empty if nothing has been produced
+	// from the feed record yet, in TraceForward only.
+	To models.UniqueMaterialResource
+
+	FeedRecordID string
+	// WorkOrder and BatchNumber are empty if the feed record does not belong
+	// to any batch.
+	WorkOrder   string
+	BatchNumber int16
+	// Site is where the resource was fed from. Site.Station is the station of
+	// the work order if the resource was fed directly without a site.
+	Site     models.UniqueSite
+	Operator string
+	Quantity decimal.Decimal
+	FedAt    time.Time
+}
+
+// TraceReply definition.
+type TraceReply struct {
+	// Roots are the resources of the request.
+	Roots []models.UniqueMaterialResource
+	// Nodes are in the order of the depth from the roots, including the roots.
+	Nodes []TraceNode
+	Edges []TraceEdge
+}
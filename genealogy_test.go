@@ -0,0 +1,28 @@
+package mcom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+)
+
+func Test_TraceRequest(t *testing.T) {
+	assert := assert.New(t)
+	{ // good case.
+		assert.NoError(TraceRequest{ResourceID: "R", MaxDepth: 2}.CheckInsufficiency())
+	}
+	{ // missing resource id.
+		assert.ErrorIs(TraceRequest{ProductType: "T"}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "Key: 'TraceRequest.ResourceID' Error:Field validation for 'ResourceID' failed on the 'required' tag",
+		})
+	}
+	{ // negative depth.
+		assert.ErrorIs(TraceRequest{ResourceID: "R", MaxDepth: -1}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "Key: 'TraceRequest.MaxDepth' Error:Field validation for 'MaxDepth' failed on the 'min' tag",
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package mcom
+
+import (
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/resources"
+)
+
+// HoldLotCascadeRequest definition.
+type HoldLotCascadeRequest struct {
+	ResourceID string `validate:"required"`
+	// ProductType is optional, all the resources with the ResourceID are held
+	// if it is empty.
+	ProductType string
+	Reason      string `validate:"required"`
+	// Depth is the max depth of the lineage to hold, it is unlimited if zero.
+	Depth int `validate:"min=0"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req HoldLotCascadeRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// HeldResource definition.
+type HeldResource struct {
+	models.UniqueMaterialResource
+	// PreviousStatus is the status before it is held.
+	PreviousStatus resources.MaterialStatus
+}
+
+// HoldLotCascadeReply definition.
+type HoldLotCascadeReply struct {
+	CaseID    string
+	Resources []HeldResource
+}
+
+// ReleaseHoldCaseRequest definition.
+type ReleaseHoldCaseRequest struct {
+	CaseID string `validate:"required"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req ReleaseHoldCaseRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package mcom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+)
+
+func Test_HoldLotCascadeRequest(t *testing.T) {
+	assert := assert.New(t)
+	{ // good case.
+		assert.NoError(HoldLotCascadeRequest{ResourceID: "R", Reason: "defective"}.CheckInsufficiency())
+	}
+	{ // missing reason.
+		assert.ErrorIs(HoldLotCascadeRequest{ResourceID: "R"}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "Key: 'HoldLotCascadeRequest.Reason' Error:Field validation for 'Reason' failed on the 'required' tag",
+		})
+	}
+	{ // negative depth.
+		assert.ErrorIs(HoldLotCascadeRequest{ResourceID: "R", Reason: "defective", Depth: -1}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "Key: 'HoldLotCascadeRequest.Depth' Error:Field validation for 'Depth' failed on the 'min' tag",
+		})
+	}
+}
+
+func Test_ReleaseHoldCaseRequest(t *testing.T) {
+	assert := assert.New(t)
+	{ // good case.
+		assert.NoError(ReleaseHoldCaseRequest{CaseID: "C"}.CheckInsufficiency())
+	}
+	{ // missing case id.
+		assert.ErrorIs(ReleaseHoldCaseRequest{}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "Key: 'ReleaseHoldCaseRequest.CaseID' Error:Field validation for 'CaseID' failed on the 'required' tag",
+		})
+	}
+}
@@ -37,14 +37,29 @@ func (dm *DataManager) CreateBatch(ctx context.Context, req mcom.CreateBatchRequ
 		UpdatedBy: commonsCtx.UserID(ctx),
 	}
 
-	session := dm.newSession(ctx)
-	if err := session.db.Create(&newBatch).Error; err != nil {
+	tx := dm.beginTx(ctx)
+	defer tx.Rollback() // nolint: errcheck
+	if err := tx.db.Create(&newBatch).Error; err != nil {
 		if IsPqError(err, UniqueViolation) {
 			return mcomErr.Error{Code: mcomErr.Code_BATCH_ALREADY_EXISTS}
 		}
 		return err
 	}
-	return nil
+
+	// each batch is a usage cycle of the tools on the station of the work order.
+	var stations []string
+	if err := tx.db.
+		Model(&models.WorkOrder{}).
+		Where(`id = ?`, req.WorkOrder).
+		Pluck(`station`, &stations).Error; err != nil {
+		return err
+	}
+	if len(stations) > 0 && stations[0] != "" {
+		if err := tx.increaseToolCycles(stations[0], 1); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
 }
 
 func (dm *DataManager) GetBatch(ctx context.Context, req mcom.GetBatchRequest) (mcom.GetBatchReply, error) {
@@ -189,16 +204,26 @@ func parseBatchInfo(batch models.Batch, records []models.FeedRecord) mcom.BatchI
 }
 
 // Feed implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
-func (dm *DataManager) Feed(ctx context.Context, req mcom.FeedRequest) (mcom.FeedReply, error) {
+func (dm *DataManager) Feed(ctx context.Context, req mcom.FeedRequest, opts ...mcom.FeedOption) (mcom.FeedReply, error) {
 	if err := req.CheckInsufficiency(); err != nil {
 		return mcom.FeedReply{}, err
 	}
+	opt := mcom.ParseFeedOptions(opts)
 
 	session := dm.newSession(ctx)
-
 	tx := session.beginTx()
 	defer tx.Rollback() // nolint: errcheck
 
+	if opt.ReadinessCheckStation != "" {
+		blockers, err := tx.checkProductionReadiness(req.Batch.WorkOrder, opt.ReadinessCheckStation)
+		if err != nil {
+			return mcom.FeedReply{}, err
+		}
+		if err := blockers.Err(); err != nil {
+			return mcom.FeedReply{}, err
+		}
+	}
+
 	feedRecord := make([]models.FeedDetail, len(req.FeedContent))
 
 	toUpdateResources := []models.MaterialsWithoutQuantity{}
@@ -332,19 +357,40 @@ func (tx *txDataManager) feedType1(fps mcom.FeedPerSiteType1, feedDetail *models
 	if err != nil {
 		return models.MaterialsWithoutQuantity{}, err
 	}
+	policy, now := site.Attributes.FeedPolicy, time.Now()
+	if policy.Order == models.FeedOrderExplicit && !fps.FeedAll {
+		return models.MaterialsWithoutQuantity{}, mcomErr.Error{
+			Code:    mcomErr.Code_BAD_REQUEST,
+			Details: "the resource to feed must be specified for the site: " + fps.Site.SiteID.Name,
+		}
+	}
 	switch site.Attributes.Type {
 	case sites.Type_CONTAINER:
 		if fps.FeedAll {
-			records = append(records, contents.Content.Container.FeedAll()...)
+			recs, err := contents.Content.Container.FeedAllByPolicy(policy, now)
+			if err != nil {
+				return models.MaterialsWithoutQuantity{}, err
+			}
+			records = append(records, recs...)
 		} else {
-			record, _ := contents.Content.Container.Feed(fps.Quantity)
+			record, _, err := contents.Content.Container.FeedByPolicy(fps.Quantity, policy, now)
+			if err != nil {
+				return models.MaterialsWithoutQuantity{}, err
+			}
 			records = append(records, record...)
 		}
 	case sites.Type_SLOT:
 		if fps.FeedAll {
-			records = append(records, contents.Content.Slot.FeedAll()...)
+			recs, err := contents.Content.Slot.FeedAllByPolicy(policy, now)
+			if err != nil {
+				return models.MaterialsWithoutQuantity{}, err
+			}
+			records = append(records, recs...)
 		} else {
-			record, _, toUpdateResource := contents.Content.Slot.Feed(fps.Quantity)
+			record, _, toUpdateResource, err := contents.Content.Slot.FeedByPolicy(fps.Quantity, policy, now)
+			if err != nil {
+				return models.MaterialsWithoutQuantity{}, err
+			}
 			if toUpdateResource {
 				materialsWithoutQuantity = models.MaterialsWithoutQuantity{
 					ResourceID:  record[0].ResourceID,
@@ -356,19 +402,23 @@ func (tx *txDataManager) feedType1(fps mcom.FeedPerSiteType1, feedDetail *models
 		}
 	case sites.Type_COLLECTION:
 		if fps.FeedAll {
-			records = append(records, contents.Content.Collection.FeedAll()...)
+			recs, err := contents.Content.Collection.FeedAllByPolicy(policy, now)
+			if err != nil {
+				return models.MaterialsWithoutQuantity{}, err
+			}
+			records = append(records, recs...)
 		} else {
 			return models.MaterialsWithoutQuantity{}, mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "a collection site can only feed all materials"}
 		}
 	case sites.Type_QUEUE:
 		if fps.FeedAll {
-			recs, err := contents.Content.Queue.FeedAll()
+			recs, err := contents.Content.Queue.FeedAllByPolicy(policy, now)
 			if err != nil {
 				return models.MaterialsWithoutQuantity{}, err
 			}
 			records = append(records, recs...)
 		} else {
-			recs, _, toUpdateResource, err := contents.Content.Queue.Feed(fps.Quantity)
+			recs, _, toUpdateResource, err := contents.Content.Queue.FeedByPolicy(fps.Quantity, policy, now)
 			if err != nil {
 				return models.MaterialsWithoutQuantity{}, err
 			}
@@ -383,7 +433,7 @@ func (tx *txDataManager) feedType1(fps mcom.FeedPerSiteType1, feedDetail *models
 		}
 	case sites.Type_COLQUEUE:
 		if fps.FeedAll {
-			recs, err := contents.Content.Colqueue.FeedAll()
+			recs, err := contents.Content.Colqueue.FeedAllByPolicy(policy, now)
 			if err != nil {
 				return models.MaterialsWithoutQuantity{}, err
 			}
@@ -425,6 +475,9 @@ func (tx *txDataManager) feedType2(fps mcom.FeedPerSiteType2, feedDetail *models
 	if err != nil {
 		return models.MaterialsWithoutQuantity{}, err
 	}
+	if err := tx.checkFedResourceExpiry(fps.Site, record); err != nil {
+		return models.MaterialsWithoutQuantity{}, err
+	}
 
 	resource := models.FeedResource{
 		ResourceID:  record.ResourceID,
@@ -444,6 +497,32 @@ func (tx *txDataManager) feedType2(fps mcom.FeedPerSiteType2, feedDetail *models
 	return models.MaterialsWithoutQuantity{}, nil
 }
 
+// checkFedResourceExpiry returns Code_RESOURCE_EXPIRED if the fed resource is
+// expired and the feed policy of the site does not allow the expired resources.
+// The site is a shared site if its station is empty, and the resource is not
+// fed from any site if its name is empty.
+func (tx *txDataManager) checkFedResourceExpiry(site models.UniqueSite, record models.FedMaterial) error {
+	if site.SiteID.Name == "" || record.ExpiryTime.UnixNano() == 0 || time.Now().Before(record.ExpiryTime) {
+		return nil
+	}
+
+	var s models.Site
+	if err := tx.db.Where(`station = ? AND name = ? AND index = ?`, site.Station, site.SiteID.Name, site.SiteID.Index).
+		Take(&s).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return mcomErr.Error{Code: mcomErr.Code_STATION_SITE_NOT_FOUND}
+		}
+		return err
+	}
+	if s.Attributes.FeedPolicy.Expired != models.ExpiredResourceAllowed {
+		return mcomErr.Error{
+			Code:    mcomErr.Code_RESOURCE_EXPIRED,
+			Details: "resource: " + record.ResourceID,
+		}
+	}
+	return nil
+}
+
 func (tx *txDataManager) feedType3(fps mcom.FeedPerSiteType3, feedDetail *models.FeedDetail) (models.MaterialsWithoutQuantity, error) {
 	return tx.feedType2(mcom.FeedPerSiteType2{
 		Quantity:    fps.Quantity,
@@ -0,0 +1,33 @@
+package impl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	"gitlab.kenda.com.tw/kenda/mcom/conformance"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+)
+
+func TestDataManager_Conformance(t *testing.T) {
+	conformance.Run(t, func(t *testing.T) (mcom.DataManager, func()) {
+		dm, db, err := newTestDataManager()
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		cm := newClearMaster(db,
+			&models.MaterialResource{},
+			&models.SiteContents{},
+			&models.Site{},
+			&models.Station{},
+			&models.WarehouseStock{},
+			&models.BindRecords{},
+		)
+		assert.NoError(t, cm.Clear())
+		return dm, func() {
+			assert.NoError(t, cm.Clear())
+			assert.NoError(t, dm.Close())
+		}
+	})
+}
@@ -0,0 +1,224 @@
+package impl
+
+import (
+	"context"
+	"sort"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/resources"
+)
+
+// CheckConsistency implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) CheckConsistency(ctx context.Context, req mcom.CheckConsistencyRequest) (mcom.CheckConsistencyReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.CheckConsistencyReply{}, err
+	}
+
+	tx := dm.beginTx(ctx)
+	defer tx.Rollback() // nolint: errcheck
+
+	reply, err := tx.checkConsistency(req.Repair)
+	if err != nil {
+		return mcom.CheckConsistencyReply{}, err
+	}
+	if !req.Repair {
+		return reply, nil
+	}
+	return reply, tx.Commit()
+}
+
+// checkConsistency finds the mismatches between the site contents, the
+// material resources and the warehouse stocks, and repairs them if repair is
+// true. The rows are locked while repairing.
+func (tx *txDataManager) checkConsistency(repair bool) (mcom.CheckConsistencyReply, error) {
+	query := func() *gorm.DB {
+		if repair {
+			return tx.db.Clauses(clause.Locking{Strength: "UPDATE"})
+		}
+		return tx.db
+	}
+
+	var contents []models.SiteContents
+	if err := query().Find(&contents).Error; err != nil {
+		return mcom.CheckConsistencyReply{}, err
+	}
+	var materialResources []models.MaterialResource
+	if err := query().
+		Select(`oid`, `id`, `product_type`, `product_id`, `quantity`, `status`, `warehouse_id`, `warehouse_location`).
+		Order(`id, product_type`).
+		Find(&materialResources).Error; err != nil {
+		return mcom.CheckConsistencyReply{}, err
+	}
+	var stocks []models.WarehouseStock
+	if err := query().Find(&stocks).Error; err != nil {
+		return mcom.CheckConsistencyReply{}, err
+	}
+
+	boundSites := make(map[models.UniqueMaterialResource][]models.UniqueSite)
+	boundQuantities := make(map[models.UniqueMaterialResource]decimal.Decimal)
+	siteContents := make(map[models.UniqueSite]*models.SiteContents, len(contents))
+	for i, content := range contents {
+		site := models.UniqueSite{
+			SiteID:  models.SiteID{Name: content.Name, Index: content.Index},
+			Station: content.Station,
+		}
+		siteContents[site] = &contents[i]
+		for _, material := range content.Content.Materials() {
+			key := models.UniqueMaterialResource{ResourceID: material.ResourceID, ProductType: material.ProductType}
+			if sites := boundSites[key]; len(sites) == 0 || sites[len(sites)-1] != site {
+				boundSites[key] = append(sites, site)
+			}
+			if material.Quantity != nil {
+				boundQuantities[key] = boundQuantities[key].Add(*material.Quantity)
+			}
+		}
+	}
+	// the site contents to save after repairing.
+	repairedSites := make(map[models.UniqueSite]struct{})
+
+	updatedBy := commonsCtx.UserID(tx.ctx)
+	issues := []mcom.ConsistencyIssue{}
+	expectedStocks := make(map[updatedWarehouseStock]decimal.Decimal)
+	for _, resource := range materialResources {
+		key := models.UniqueMaterialResource{ResourceID: resource.ID, ProductType: resource.ProductType}
+		sites := boundSites[key]
+
+		if resource.Status == resources.MaterialStatus_MOUNTED && len(sites) == 0 {
+			issue := mcom.ConsistencyIssue{
+				Type:        mcom.ConsistencyIssueMountedResourceNotOnSite,
+				ResourceID:  resource.ID,
+				ProductType: resource.ProductType,
+			}
+			if repair {
+				if err := tx.db.Model(&models.MaterialResource{}).
+					Where(`oid = ?`, resource.OID).
+					Updates(models.MaterialResource{
+						Status:    resources.MaterialStatus_AVAILABLE,
+						UpdatedBy: updatedBy,
+					}).Error; err != nil {
+					return mcom.CheckConsistencyReply{}, err
+				}
+				issue.Repaired = true
+			}
+			issues = append(issues, issue)
+		}
+
+		if bound := boundQuantities[key]; bound.GreaterThan(resource.Quantity) {
+			issue := mcom.ConsistencyIssue{
+				Type:        mcom.ConsistencyIssueSiteQuantityExceeded,
+				ResourceID:  resource.ID,
+				ProductType: resource.ProductType,
+				Sites:       sites,
+				Expected:    resource.Quantity,
+				Actual:      bound,
+			}
+			if repair {
+				quantity := resource.Quantity
+				if quantity.IsNegative() {
+					quantity = decimal.Zero
+					if err := tx.db.Model(&models.MaterialResource{}).
+						Where(`oid = ?`, resource.OID).
+						Updates(map[string]interface{}{
+							"quantity":   decimal.Zero,
+							"updated_by": updatedBy,
+						}).Error; err != nil {
+						return mcom.CheckConsistencyReply{}, err
+					}
+				}
+				// the quantities bound last are reduced first.
+				excess := bound.Sub(quantity)
+				for i := len(sites) - 1; i >= 0 && excess.IsPositive(); i-- {
+					excess = excess.Sub(siteContents[sites[i]].Content.ReduceQuantity(key, excess))
+					repairedSites[sites[i]] = struct{}{}
+				}
+				issue.Repaired = true
+			}
+			issues = append(issues, issue)
+		}
+
+		if resource.HasStockedIn() && resource.Quantity.IsPositive() {
+			key := updatedWarehouseStock{
+				ID:        resource.WarehouseID,
+				Location:  resource.WarehouseLocation,
+				ProductID: resource.ProductID,
+			}
+			expectedStocks[key] = expectedStocks[key].Add(resource.Quantity)
+		}
+	}
+
+	for site := range repairedSites {
+		content := siteContents[site]
+		if err := tx.db.Model(&models.SiteContents{}).
+			Where(`station = ? AND name = ? AND index = ?`, content.Station, content.Name, content.Index).
+			Updates(models.SiteContents{Content: content.Content, UpdatedBy: updatedBy}).Error; err != nil {
+			return mcom.CheckConsistencyReply{}, err
+		}
+	}
+
+	actualStocks := make(map[updatedWarehouseStock]decimal.Decimal, len(stocks))
+	for _, stock := range stocks {
+		actualStocks[updatedWarehouseStock{ID: stock.ID, Location: stock.Location, ProductID: stock.ProductID}] = stock.Quantity
+	}
+	keys := make([]updatedWarehouseStock, 0, len(expectedStocks)+len(actualStocks))
+	for key := range expectedStocks {
+		keys = append(keys, key)
+	}
+	for key := range actualStocks {
+		if _, ok := expectedStocks[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].ID != keys[j].ID {
+			return keys[i].ID < keys[j].ID
+		}
+		if keys[i].Location != keys[j].Location {
+			return keys[i].Location < keys[j].Location
+		}
+		return keys[i].ProductID < keys[j].ProductID
+	})
+
+	for _, key := range keys {
+		expected, actual := expectedStocks[key], actualStocks[key]
+		if expected.Equal(actual) {
+			continue
+		}
+		issue := mcom.ConsistencyIssue{
+			Type:      mcom.ConsistencyIssueStockMismatch,
+			Warehouse: mcom.Warehouse{ID: key.ID, Location: key.Location},
+			ProductID: key.ProductID,
+			Expected:  expected,
+			Actual:    actual,
+		}
+		if repair {
+			// the stock is deleted by the trigger if the quantity is not positive.
+			if err := tx.db.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "id"}, {Name: "location"}, {Name: "product_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"quantity"}),
+			}).Create(&models.WarehouseStock{
+				ID:        key.ID,
+				Location:  key.Location,
+				ProductID: key.ProductID,
+				Quantity:  expected,
+			}).Error; err != nil {
+				return mcom.CheckConsistencyReply{}, err
+			}
+			issue.Repaired = true
+		}
+		issues = append(issues, issue)
+	}
+
+	return mcom.CheckConsistencyReply{
+		CheckedResources:    len(materialResources),
+		CheckedSiteContents: len(contents),
+		CheckedStocks:       len(stocks),
+		Issues:              issues,
+	}, nil
+}
@@ -0,0 +1,220 @@
+package impl
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/resources"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/sites"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/stations"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
+)
+
+func TestDataManager_CheckConsistency(t *testing.T) {
+	assert := assert.New(t)
+	ctx, dm, db := initializeDB(t)
+	defer dm.Close()
+	ctx = commonsCtx.WithUserID(ctx, testUser)
+	cm := newClearMaster(db,
+		&models.Station{},
+		&models.Site{},
+		&models.SiteContents{},
+		&models.MaterialResource{},
+		&models.WarehouseStock{},
+	)
+	assert.NoError(cm.Clear())
+	defer func() { assert.NoError(cm.Clear()) }()
+
+	const (
+		site          = "SLOT"
+		containerSite = "CONTAINER"
+		productID     = "PRODUCT"
+		otherStock    = "OTHER"
+	)
+	assert.NoError(dm.CreateStation(ctx, mcom.CreateStationRequest{
+		ID:            testStationA,
+		DepartmentOID: testDepartmentA,
+		Sites: []mcom.SiteInformation{{
+			Name:    site,
+			Type:    sites.Type_SLOT,
+			SubType: sites.SubType_MATERIAL,
+		}, {
+			Name:    containerSite,
+			Type:    sites.Type_CONTAINER,
+			SubType: sites.SubType_MATERIAL,
+		}},
+		State: stations.State_IDLE,
+	}))
+	assert.NoError(db.Create([]models.MaterialResource{{
+		// mounted but not on any site.
+		ID:            "A",
+		ProductID:     productID,
+		ProductType:   "T",
+		Quantity:      decimal.NewFromInt(10),
+		Status:        resources.MaterialStatus_MOUNTED,
+		FeedRecordsID: []string{},
+	}, {
+		// bound more than its quantity.
+		ID:            "B",
+		ProductID:     productID,
+		ProductType:   "T",
+		Quantity:      decimal.NewFromInt(-2),
+		Status:        resources.MaterialStatus_MOUNTED,
+		FeedRecordsID: []string{},
+	}, {
+		ID:                "C",
+		ProductID:         productID,
+		ProductType:       "T",
+		Quantity:          decimal.NewFromInt(5),
+		Status:            resources.MaterialStatus_AVAILABLE,
+		WarehouseID:       testWarehouseID,
+		WarehouseLocation: testWarehouseLocation,
+		FeedRecordsID:     []string{},
+	}, {
+		// bound more than its remaining quantity.
+		ID:            "D",
+		ProductID:     productID,
+		ProductType:   "T",
+		Quantity:      decimal.NewFromInt(4),
+		Status:        resources.MaterialStatus_MOUNTED,
+		FeedRecordsID: []string{},
+	}}).Error)
+	assert.NoError(db.Create([]models.WarehouseStock{{
+		ID:        testWarehouseID,
+		Location:  testWarehouseLocation,
+		ProductID: productID,
+		Quantity:  decimal.NewFromInt(8),
+	}, {
+		ID:        testWarehouseID,
+		Location:  testWarehouseLocation,
+		ProductID: otherStock,
+		Quantity:  decimal.NewFromInt(3),
+	}}).Error)
+	assert.NoError(db.Model(&models.SiteContents{}).
+		Where(`station = ? AND name = ? AND index = ?`, testStationA, site, 0).
+		Updates(models.SiteContents{Content: models.SiteContent{Slot: &models.Slot{Material: &models.MaterialSite{
+			Material:    models.Material{ID: productID},
+			Quantity:    types.Decimal.NewFromInt32(12),
+			ResourceID:  "B",
+			ProductType: "T",
+		}}}}).Error)
+	newResourceD := func(quantity int32) models.BoundResource {
+		return models.BoundResource{Material: &models.MaterialSite{
+			Material:    models.Material{ID: productID},
+			Quantity:    types.Decimal.NewFromInt32(quantity),
+			ResourceID:  "D",
+			ProductType: "T",
+		}}
+	}
+	assert.NoError(db.Model(&models.SiteContents{}).
+		Where(`station = ? AND name = ? AND index = ?`, testStationA, containerSite, 0).
+		Updates(models.SiteContents{Content: models.SiteContent{Container: &models.Container{
+			newResourceD(3),
+			newResourceD(3),
+		}}}).Error)
+
+	expected := mcom.CheckConsistencyReply{
+		CheckedResources:    4,
+		CheckedSiteContents: 2,
+		CheckedStocks:       2,
+		Issues: []mcom.ConsistencyIssue{{
+			Type:        mcom.ConsistencyIssueMountedResourceNotOnSite,
+			ResourceID:  "A",
+			ProductType: "T",
+		}, {
+			Type:        mcom.ConsistencyIssueSiteQuantityExceeded,
+			ResourceID:  "B",
+			ProductType: "T",
+			Sites: []models.UniqueSite{{
+				SiteID:  models.SiteID{Name: site},
+				Station: testStationA,
+			}},
+			Expected: decimal.NewFromInt(-2),
+			Actual:   decimal.NewFromInt(12),
+		}, {
+			Type:        mcom.ConsistencyIssueSiteQuantityExceeded,
+			ResourceID:  "D",
+			ProductType: "T",
+			Sites: []models.UniqueSite{{
+				SiteID:  models.SiteID{Name: containerSite},
+				Station: testStationA,
+			}},
+			Expected: decimal.NewFromInt(4),
+			Actual:   decimal.NewFromInt(6),
+		}, {
+			Type:      mcom.ConsistencyIssueStockMismatch,
+			Warehouse: mcom.Warehouse{ID: testWarehouseID, Location: testWarehouseLocation},
+			ProductID: otherStock,
+			Actual:    decimal.NewFromInt(3),
+		}, {
+			Type:      mcom.ConsistencyIssueStockMismatch,
+			Warehouse: mcom.Warehouse{ID: testWarehouseID, Location: testWarehouseLocation},
+			ProductID: productID,
+			Expected:  decimal.NewFromInt(5),
+			Actual:    decimal.NewFromInt(8),
+		}},
+	}
+	equalReply := func(expected, actual mcom.CheckConsistencyReply) {
+		assert.Equal(expected.CheckedResources, actual.CheckedResources)
+		assert.Equal(expected.CheckedSiteContents, actual.CheckedSiteContents)
+		assert.Equal(expected.CheckedStocks, actual.CheckedStocks)
+		if assert.Len(actual.Issues, len(expected.Issues)) {
+			for i, issue := range actual.Issues {
+				assert.True(expected.Issues[i].Expected.Equal(issue.Expected), issue)
+				assert.True(expected.Issues[i].Actual.Equal(issue.Actual), issue)
+				issue.Expected, issue.Actual = expected.Issues[i].Expected, expected.Issues[i].Actual
+				assert.Equal(expected.Issues[i], issue)
+			}
+		}
+	}
+
+	{ // report only.
+		actual, err := dm.CheckConsistency(ctx, mcom.CheckConsistencyRequest{})
+		assert.NoError(err)
+		equalReply(expected, actual)
+
+		// nothing is repaired.
+		actual, err = dm.CheckConsistency(ctx, mcom.CheckConsistencyRequest{})
+		assert.NoError(err)
+		equalReply(expected, actual)
+	}
+	{ // repair.
+		for i := range expected.Issues {
+			expected.Issues[i].Repaired = true
+		}
+		actual, err := dm.CheckConsistency(ctx, mcom.CheckConsistencyRequest{Repair: true})
+		assert.NoError(err)
+		equalReply(expected, actual)
+
+		var resource models.MaterialResource
+		assert.NoError(db.Where(`id = ?`, "A").Take(&resource).Error)
+		assert.Equal(resources.MaterialStatus_AVAILABLE, resource.Status)
+		assert.Equal(testUser, resource.UpdatedBy)
+
+		// the quantities bound last are reduced.
+		rep, err := dm.GetSite(ctx, mcom.GetSiteRequest{StationID: testStationA, SiteName: containerSite})
+		if assert.NoError(err) && assert.Len(*rep.Content.Container, 2) {
+			assert.True(decimal.NewFromInt(3).Equal(*(*rep.Content.Container)[0].Material.Quantity))
+			assert.True(decimal.NewFromInt(1).Equal(*(*rep.Content.Container)[1].Material.Quantity))
+		}
+		rep, err = dm.GetSite(ctx, mcom.GetSiteRequest{StationID: testStationA, SiteName: site})
+		if assert.NoError(err) {
+			assert.True(rep.Content.Slot.Material.Quantity.IsZero())
+		}
+
+		actual, err = dm.CheckConsistency(ctx, mcom.CheckConsistencyRequest{})
+		assert.NoError(err)
+		equalReply(mcom.CheckConsistencyReply{
+			CheckedResources:    4,
+			CheckedSiteContents: 2,
+			CheckedStocks:       1,
+			Issues:              []mcom.ConsistencyIssue{},
+		}, actual)
+	}
+}
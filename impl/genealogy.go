@@ -0,0 +1,431 @@
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/lib/pq"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+)
+
+// TraceBackward implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) TraceBackward(ctx context.Context, req mcom.TraceRequest) (mcom.TraceReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.TraceReply{}, err
+	}
+
+	session := dm.newSession(ctx)
+	g, level, err := session.newGenealogy(req)
+	if err != nil {
+		return mcom.TraceReply{}, err
+	}
+	for depth := 0; len(level) != 0 && (req.MaxDepth == 0 || depth < req.MaxDepth); depth++ {
+		if level, err = g.traceBackward(level); err != nil {
+			return mcom.TraceReply{}, err
+		}
+	}
+	return g.reply(), nil
+}
+
+// TraceForward implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) TraceForward(ctx context.Context, req mcom.TraceRequest) (mcom.TraceReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.TraceReply{}, err
+	}
+
+	session := dm.newSession(ctx)
+	g, err := session.traceDescendants(req)
+	if err != nil {
+		return mcom.TraceReply{}, err
+	}
+	return g.reply(), nil
+}
+
+// traceDescendants returns the genealogy of the lots produced from the
+// resource of the request.
+func (session *session) traceDescendants(req mcom.TraceRequest) (*genealogy, error) {
+	g, level, err := session.newGenealogy(req)
+	if err != nil {
+		return nil, err
+	}
+	for depth := 0; len(level) != 0 && (req.MaxDepth == 0 || depth < req.MaxDepth); depth++ {
+		if level, err = g.traceForward(level); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+// genealogy is the graph of the traced lots.
+type genealogy struct {
+	session *session
+
+	roots   []models.UniqueMaterialResource
+	nodes   []mcom.TraceNode
+	edges   []mcom.TraceEdge
+	visited map[models.UniqueMaterialResource]struct{}
+}
+
+// newGenealogy returns the genealogy with the root resources of the request
+// and the roots as the first level to trace.
+func (session *session) newGenealogy(req mcom.TraceRequest) (*genealogy, []models.MaterialResource, error) {
+	query := session.db.Where(`id = ?`, req.ResourceID)
+	if req.ProductType != "" {
+		query = query.Where(`product_type = ?`, req.ProductType)
+	}
+	var roots []models.MaterialResource
+	if err := query.Order(`product_type`).Find(&roots).Error; err != nil {
+		return nil, nil, err
+	}
+	if len(roots) == 0 {
+		return nil, nil, mcomErr.Error{
+			Code:    mcomErr.Code_RESOURCE_NOT_FOUND,
+			Details: "resource: " + req.ResourceID,
+		}
+	}
+
+	g := &genealogy{
+		session: session,
+		visited: make(map[models.UniqueMaterialResource]struct{}),
+	}
+	keys := make([]models.UniqueMaterialResource, len(roots))
+	for i, root := range roots {
+		keys[i] = resourceKey(root)
+		g.visited[keys[i]] = struct{}{}
+	}
+	g.roots = keys
+	if err := g.addNodes(roots, keys, nil); err != nil {
+		return nil, nil, err
+	}
+	return g, roots, nil
+}
+
+func resourceKey(resource models.MaterialResource) models.UniqueMaterialResource {
+	return models.UniqueMaterialResource{ResourceID: resource.ID, ProductType: resource.ProductType}
+}
+
+func feedResourceKey(resource models.FeedResource) models.UniqueMaterialResource {
+	return models.UniqueMaterialResource{ResourceID: resource.ResourceID, ProductType: resource.ProductType}
+}
+
+// traceBackward adds the lots consumed by the specified level and returns the
+// found lots as the next level.
+func (g *genealogy) traceBackward(level []models.MaterialResource) ([]models.MaterialResource, error) {
+	var recordIDs []string
+	for _, resource := range level {
+		recordIDs = append(recordIDs, resource.FeedRecordsID...)
+	}
+	records, err := g.session.listTraceFeedRecords(recordIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var next []models.UniqueMaterialResource
+	fed := make(map[models.UniqueMaterialResource]models.FeedResource)
+	for _, resource := range level {
+		for _, id := range resource.FeedRecordsID {
+			record, ok := records[id]
+			if !ok {
+				continue
+			}
+			record.walk(func(site models.UniqueSite, consumed models.FeedResource) {
+				key := feedResourceKey(consumed)
+				g.edges = append(g.edges, record.edge(key, resourceKey(resource), site, consumed))
+				if _, ok := g.visited[key]; !ok {
+					g.visited[key] = struct{}{}
+					next = append(next, key)
+					fed[key] = consumed
+				}
+			})
+		}
+	}
+	return g.loadNodes(next, fed)
+}
+
+// traceForward adds the lots produced from the specified level and returns
+// the found lots as the next level.
+func (g *genealogy) traceForward(level []models.MaterialResource) ([]models.MaterialResource, error) {
+	consumed := make(map[models.UniqueMaterialResource]struct{}, len(level))
+	for _, resource := range level {
+		consumed[resourceKey(resource)] = struct{}{}
+	}
+	records, err := g.session.listTraceFeedRecordsByResources(level)
+	if err != nil {
+		return nil, err
+	}
+
+	recordIDs := make([]string, len(records))
+	for i, record := range records {
+		recordIDs[i] = record.ID
+	}
+	var products []models.MaterialResource
+	if len(recordIDs) != 0 {
+		if err := g.session.db.Where(`feed_records_id && ?`, pq.StringArray(recordIDs)).
+			Order(`id, product_type`).
+			Find(&products).Error; err != nil {
+			return nil, err
+		}
+	}
+	productsByRecord := make(map[string][]models.UniqueMaterialResource)
+	for _, product := range products {
+		for _, id := range product.FeedRecordsID {
+			productsByRecord[id] = append(productsByRecord[id], resourceKey(product))
+		}
+	}
+
+	var next []models.UniqueMaterialResource
+	for _, record := range records {
+		record.walk(func(site models.UniqueSite, fed models.FeedResource) {
+			from := feedResourceKey(fed)
+			if _, ok := consumed[from]; !ok {
+				return
+			}
+			targets := productsByRecord[record.ID]
+			if len(targets) == 0 {
+				g.edges = append(g.edges, record.edge(from, models.UniqueMaterialResource{}, site, fed))
+				return
+			}
+			for _, to := range targets {
+				g.edges = append(g.edges, record.edge(from, to, site, fed))
+				if _, ok := g.visited[to]; !ok {
+					g.visited[to] = struct{}{}
+					next = append(next, to)
+				}
+			}
+		})
+	}
+	return g.loadNodes(next, nil)
+}
+
+// loadNodes adds the nodes of the specified resources and returns the found
+// resources. The fed resources are used for the resources which are not found.
+func (g *genealogy) loadNodes(keys []models.UniqueMaterialResource, fed map[models.UniqueMaterialResource]models.FeedResource) ([]models.MaterialResource, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	condition := make([][]string, len(keys))
+	for i, key := range keys {
+		condition[i] = []string{key.ResourceID, key.ProductType}
+	}
+	var found []models.MaterialResource
+	if err := g.session.db.Where(`(id, product_type) IN ?`, condition).Find(&found).Error; err != nil {
+		return nil, err
+	}
+	return found, g.addNodes(found, keys, fed)
+}
+
+// addNodes adds the nodes of the keys in order.
+func (g *genealogy) addNodes(found []models.MaterialResource, keys []models.UniqueMaterialResource, fed map[models.UniqueMaterialResource]models.FeedResource) error {
+	resources := make(map[models.UniqueMaterialResource]models.MaterialResource, len(found))
+	oids := make([]string, len(found))
+	for i, resource := range found {
+		resources[resourceKey(resource)] = resource
+		oids[i] = resource.OID
+	}
+
+	var collectRecords []models.CollectRecord
+	if len(oids) != 0 {
+		if err := g.session.db.Where(`resource_oid IN ?`, oids).Order(`created_at`).Find(&collectRecords).Error; err != nil {
+			return err
+		}
+	}
+	collected := make(map[string]models.CollectRecord, len(collectRecords))
+	for _, record := range collectRecords {
+		if _, ok := collected[record.ResourceOID]; !ok {
+			collected[record.ResourceOID] = record
+		}
+	}
+
+	for _, key := range keys {
+		resource, ok := resources[key]
+		if !ok {
+			g.nodes = append(g.nodes, mcom.TraceNode{
+				UniqueMaterialResource: key,
+				ProductID:              fed[key].ProductID,
+				Grade:                  fed[key].Grade,
+			})
+			continue
+		}
+
+		node := mcom.TraceNode{
+			UniqueMaterialResource: key,
+			Found:                  true,
+			ProductID:              resource.ProductID,
+			Grade:                  resource.Info.Grade,
+			LotNumber:              resource.Info.LotNumber,
+			Status:                 resource.Status,
+			Quantity:               resource.Quantity,
+			ProducedAt:             resource.CreatedAt.Time(),
+		}
+		if record, ok := collected[resource.OID]; ok {
+			node.WorkOrder = record.WorkOrder
+			node.Station = record.Station
+			node.Operator = record.Detail.OperatorID
+			node.ProducedAt = record.CreatedAt.Time()
+		}
+		g.nodes = append(g.nodes, node)
+	}
+	return nil
+}
+
+func (g *genealogy) reply() mcom.TraceReply {
+	edges := g.edges
+	if edges == nil {
+		edges = []mcom.TraceEdge{}
+	}
+	return mcom.TraceReply{
+		Roots: g.roots,
+		Nodes: g.nodes,
+		Edges: edges,
+	}
+}
+
+// traceFeedRecord is a feed record with its batch.
+type traceFeedRecord struct {
+	models.FeedRecord
+	WorkOrder   string
+	BatchNumber int16
+	// Station is the station of the work order.
+	Station string
+}
+
+// walk calls f for every fed resource in the record.
+func (record traceFeedRecord) walk(f func(site models.UniqueSite, fed models.FeedResource)) {
+	for _, detail := range record.Materials {
+		site := detail.UniqueSite
+		if site.Station == "" {
+			site.Station = record.Station
+		}
+		for _, resource := range detail.Resources {
+			f(site, resource)
+		}
+	}
+}
+
+func (record traceFeedRecord) edge(from, to models.UniqueMaterialResource, site models.UniqueSite, fed models.FeedResource) mcom.TraceEdge {
+	return mcom.TraceEdge{
+		From:         from,
+		To:           to,
+		FeedRecordID: record.ID,
+		WorkOrder:    record.WorkOrder,
+		BatchNumber:  record.BatchNumber,
+		Site:         site,
+		Operator:     record.OperatorID,
+		Quantity:     fed.Quantity,
+		FedAt:        record.Time,
+	}
+}
+
+// listTraceFeedRecords returns the feed records of the ids with their batches.
+func (session *session) listTraceFeedRecords(ids []string) (map[string]traceFeedRecord, error) {
+	if len(ids) == 0 {
+		return map[string]traceFeedRecord{}, nil
+	}
+	var records []models.FeedRecord
+	if err := session.db.Where(`id IN ?`, uniqueStrings(ids)).Find(&records).Error; err != nil {
+		return nil, err
+	}
+	list, err := session.withBatches(records)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]traceFeedRecord, len(list))
+	for _, record := range list {
+		res[record.ID] = record
+	}
+	return res, nil
+}
+
+// listTraceFeedRecordsByResources returns the feed records which feed any of
+// the resources in the order of the feeding time.
+func (session *session) listTraceFeedRecordsByResources(resources []models.MaterialResource) ([]traceFeedRecord, error) {
+	if len(resources) == 0 {
+		return nil, nil
+	}
+
+	query := session.db.Model(&models.FeedRecord{})
+	for i, resource := range resources {
+		contained, err := json.Marshal([]map[string]interface{}{{
+			"feed_resources": []map[string]string{{
+				"resource_id":  resource.ID,
+				"product_type": resource.ProductType,
+			}},
+		}})
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			query = query.Where(`materials @> ?::jsonb`, string(contained))
+		} else {
+			query = query.Or(`materials @> ?::jsonb`, string(contained))
+		}
+	}
+	var records []models.FeedRecord
+	if err := query.Find(&records).Error; err != nil {
+		return nil, err
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].Time.Equal(records[j].Time) {
+			return records[i].Time.Before(records[j].Time)
+		}
+		return records[i].ID < records[j].ID
+	})
+	return session.withBatches(records)
+}
+
+// withBatches returns the feed records with their batches and the stations
+// of their work orders.
+func (session *session) withBatches(records []models.FeedRecord) ([]traceFeedRecord, error) {
+	ids := make([]string, len(records))
+	for i, record := range records {
+		ids[i] = record.ID
+	}
+
+	var batches []models.Batch
+	if len(ids) != 0 {
+		if err := session.db.Where(`records_id && ?`, pq.StringArray(ids)).Find(&batches).Error; err != nil {
+			return nil, err
+		}
+	}
+	type batchID struct {
+		workOrder string
+		number    int16
+	}
+	recordBatches := make(map[string]batchID)
+	workOrderIDs := make([]string, len(batches))
+	for i, batch := range batches {
+		for _, id := range batch.RecordsID {
+			recordBatches[id] = batchID{workOrder: batch.WorkOrder, number: batch.Number}
+		}
+		workOrderIDs[i] = batch.WorkOrder
+	}
+
+	var workOrders []models.WorkOrder
+	if len(workOrderIDs) != 0 {
+		if err := session.db.Select(`id`, `station`).Where(`id IN ?`, uniqueStrings(workOrderIDs)).Find(&workOrders).Error; err != nil {
+			return nil, err
+		}
+	}
+	stations := make(map[string]string, len(workOrders))
+	for _, workOrder := range workOrders {
+		stations[workOrder.ID] = workOrder.Station
+	}
+
+	res := make([]traceFeedRecord, len(records))
+	for i, record := range records {
+		batch := recordBatches[record.ID]
+		res[i] = traceFeedRecord{
+			FeedRecord:  record,
+			WorkOrder:   batch.workOrder,
+			BatchNumber: batch.number,
+			Station:     stations[batch.workOrder],
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,177 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+)
+
+func TestDataManager_Trace(t *testing.T) {
+	assert := assert.New(t)
+	ctx, dm, db := initializeDB(t)
+	defer dm.Close()
+	cm := newClearMaster(db,
+		&models.WorkOrder{},
+		&models.Batch{},
+		&models.FeedRecord{},
+		&models.CollectRecord{},
+		&models.MaterialResource{},
+	)
+	assert.NoError(cm.Clear())
+	defer func() { assert.NoError(cm.Clear()) }()
+
+	const operator = "OPERATOR"
+	var (
+		raw     = models.UniqueMaterialResource{ResourceID: "RAW", ProductType: "R"}
+		unknown = models.UniqueMaterialResource{ResourceID: "UNKNOWN", ProductType: "R"}
+		product = models.UniqueMaterialResource{ResourceID: "PRODUCT", ProductType: "P"}
+		site    = models.UniqueSite{SiteID: models.SiteID{Name: "SITE"}, Station: testStationA}
+	)
+
+	workOrder := models.WorkOrder{
+		ProcessOID:   testProcessOID,
+		ProcessName:  testProcessName,
+		ProcessType:  testProcessType,
+		DepartmentID: testDepartmentA,
+		Station:      testStationB,
+		ReservedDate: time.Now(),
+	}
+	assert.NoError(db.Create(&workOrder).Error)
+	assert.NoError(db.Create([]models.FeedRecord{{
+		ID:         "F1",
+		OperatorID: operator,
+		Materials: models.FeedDetails{{
+			Resources: []models.FeedResource{{
+				ResourceID:  raw.ResourceID,
+				ProductID:   "RUBBER",
+				ProductType: raw.ProductType,
+				Quantity:    decimal.NewFromInt(5),
+			}, {
+				ResourceID:  unknown.ResourceID,
+				ProductID:   "OIL",
+				ProductType: unknown.ProductType,
+				Quantity:    decimal.NewFromInt(1),
+			}},
+			UniqueSite: site,
+		}},
+		Time: time.Now(),
+	}, {
+		// fed directly without a site.
+		ID:         "F2",
+		OperatorID: operator,
+		Materials: models.FeedDetails{{
+			Resources: []models.FeedResource{{
+				ResourceID:  product.ResourceID,
+				ProductID:   "COMPOUND",
+				ProductType: product.ProductType,
+				Quantity:    decimal.NewFromInt(2),
+			}},
+		}},
+		Time: time.Now().Add(time.Minute),
+	}}).Error)
+	assert.NoError(db.Create(&models.Batch{
+		WorkOrder: workOrder.ID,
+		Number:    1,
+		RecordsID: []string{"F1", "F2"},
+	}).Error)
+	resources := []models.MaterialResource{{
+		ID:            raw.ResourceID,
+		ProductID:     "RUBBER",
+		ProductType:   raw.ProductType,
+		Quantity:      decimal.NewFromInt(5),
+		FeedRecordsID: []string{},
+	}, {
+		ID:            product.ResourceID,
+		ProductID:     "COMPOUND",
+		ProductType:   product.ProductType,
+		Quantity:      decimal.NewFromInt(4),
+		FeedRecordsID: []string{"F1"},
+	}}
+	assert.NoError(db.Create(&resources).Error)
+	assert.NoError(db.Create(&models.CollectRecord{
+		WorkOrder:   workOrder.ID,
+		Sequence:    1,
+		Station:     testStationB,
+		ResourceOID: resources[1].OID,
+		Detail:      models.CollectRecordDetail{OperatorID: operator},
+	}).Error)
+
+	nodeKeys := func(nodes []mcom.TraceNode) []models.UniqueMaterialResource {
+		res := make([]models.UniqueMaterialResource, len(nodes))
+		for i, node := range nodes {
+			res[i] = node.UniqueMaterialResource
+		}
+		return res
+	}
+	type edge struct {
+		from, to     models.UniqueMaterialResource
+		feedRecordID string
+		site         models.UniqueSite
+	}
+	edges := func(edges []mcom.TraceEdge) []edge {
+		res := make([]edge, len(edges))
+		for i, e := range edges {
+			assert.Equal(workOrder.ID, e.WorkOrder)
+			assert.Equal(int16(1), e.BatchNumber)
+			assert.Equal(operator, e.Operator)
+			res[i] = edge{from: e.From, to: e.To, feedRecordID: e.FeedRecordID, site: e.Site}
+		}
+		return res
+	}
+
+	{ // insufficient request.
+		_, err := dm.TraceBackward(ctx, mcom.TraceRequest{})
+		assert.ErrorIs(err, mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "Key: 'TraceRequest.ResourceID' Error:Field validation for 'ResourceID' failed on the 'required' tag",
+		})
+	}
+	{ // resource not found.
+		_, err := dm.TraceForward(ctx, mcom.TraceRequest{ResourceID: unknown.ResourceID})
+		assert.ErrorIs(err, mcomErr.Error{Code: mcomErr.Code_RESOURCE_NOT_FOUND, Details: "resource: " + unknown.ResourceID})
+	}
+	{ // backward.
+		rep, err := dm.TraceBackward(ctx, mcom.TraceRequest{ResourceID: product.ResourceID})
+		assert.NoError(err)
+		assert.Equal([]models.UniqueMaterialResource{product}, rep.Roots)
+		assert.Equal([]models.UniqueMaterialResource{product, raw, unknown}, nodeKeys(rep.Nodes))
+		assert.Equal([]edge{
+			{from: raw, to: product, feedRecordID: "F1", site: site},
+			{from: unknown, to: product, feedRecordID: "F1", site: site},
+		}, edges(rep.Edges))
+
+		// the collected product.
+		assert.True(rep.Nodes[0].Found)
+		assert.Equal(workOrder.ID, rep.Nodes[0].WorkOrder)
+		assert.Equal(testStationB, rep.Nodes[0].Station)
+		assert.Equal(operator, rep.Nodes[0].Operator)
+		// the lot which is not found.
+		assert.False(rep.Nodes[2].Found)
+		assert.Equal("OIL", rep.Nodes[2].ProductID)
+	}
+	{ // forward.
+		rep, err := dm.TraceForward(ctx, mcom.TraceRequest{ResourceID: raw.ResourceID, ProductType: raw.ProductType})
+		assert.NoError(err)
+		assert.Equal([]models.UniqueMaterialResource{raw}, rep.Roots)
+		assert.Equal([]models.UniqueMaterialResource{raw, product}, nodeKeys(rep.Nodes))
+		assert.Equal([]edge{
+			{from: raw, to: product, feedRecordID: "F1", site: site},
+			// nothing is produced from F2 yet and the station of the work order is used.
+			{from: product, feedRecordID: "F2", site: models.UniqueSite{Station: testStationB}},
+		}, edges(rep.Edges))
+	}
+	{ // forward with the max depth.
+		rep, err := dm.TraceForward(ctx, mcom.TraceRequest{ResourceID: raw.ResourceID, MaxDepth: 1})
+		assert.NoError(err)
+		assert.Equal([]models.UniqueMaterialResource{raw, product}, nodeKeys(rep.Nodes))
+		assert.Equal([]edge{
+			{from: raw, to: product, feedRecordID: "F1", site: site},
+		}, edges(rep.Edges))
+	}
+}
@@ -0,0 +1,197 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/resources"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
+)
+
+// HoldLotCascade implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) HoldLotCascade(ctx context.Context, req mcom.HoldLotCascadeRequest) (mcom.HoldLotCascadeReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.HoldLotCascadeReply{}, err
+	}
+
+	session := dm.newSession(ctx)
+	g, err := session.traceDescendants(mcom.TraceRequest{
+		ResourceID:  req.ResourceID,
+		ProductType: req.ProductType,
+		MaxDepth:    req.Depth,
+	})
+	if err != nil {
+		return mcom.HoldLotCascadeReply{}, err
+	}
+	var condition [][]string
+	for _, node := range g.nodes {
+		if node.Found {
+			condition = append(condition, []string{node.ResourceID, node.ProductType})
+		}
+	}
+
+	tx := session.beginTx()
+	defer tx.Rollback() // nolint: errcheck
+
+	// the resources still in stock or mounted.
+	var targets []models.MaterialResource
+	if err := tx.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(`(id, product_type) IN ?`, condition).
+		Where(`(quantity > 0 OR status = ?)`, resources.MaterialStatus_MOUNTED).
+		Find(&targets).Error; err != nil {
+		return mcom.HoldLotCascadeReply{}, err
+	}
+	statuses := make(map[models.UniqueMaterialResource]resources.MaterialStatus, len(targets))
+	for _, target := range targets {
+		statuses[resourceKey(target)] = target.Status
+	}
+
+	userID := commonsCtx.UserID(ctx)
+	holdCase := models.HoldCase{
+		ResourceID:  req.ResourceID,
+		ProductType: req.ProductType,
+		Reason:      req.Reason,
+		Depth:       int32(req.Depth),
+		CreatedBy:   userID,
+	}
+	if err := tx.db.Create(&holdCase).Error; err != nil {
+		return mcom.HoldLotCascadeReply{}, err
+	}
+
+	// in the order of the lineage.
+	held := []mcom.HeldResource{}
+	var records []models.HoldCaseResource
+	for _, node := range g.nodes {
+		status, ok := statuses[node.UniqueMaterialResource]
+		if !ok {
+			continue
+		}
+		held = append(held, mcom.HeldResource{
+			UniqueMaterialResource: node.UniqueMaterialResource,
+			PreviousStatus:         status,
+		})
+		records = append(records, models.HoldCaseResource{
+			CaseID:         holdCase.ID,
+			ResourceID:     node.ResourceID,
+			ProductType:    node.ProductType,
+			PreviousStatus: status,
+		})
+	}
+	if len(records) != 0 {
+		if err := tx.db.Create(&records).Error; err != nil {
+			return mcom.HoldLotCascadeReply{}, err
+		}
+		if err := tx.db.Model(&models.MaterialResource{}).
+			Where(`(id, product_type) IN ?`, condition).
+			Where(`(quantity > 0 OR status = ?) AND status <> ?`, resources.MaterialStatus_MOUNTED, resources.MaterialStatus_HOLD).
+			Updates(models.MaterialResource{
+				Status:    resources.MaterialStatus_HOLD,
+				UpdatedBy: userID,
+			}).Error; err != nil {
+			return mcom.HoldLotCascadeReply{}, err
+		}
+	}
+
+	return mcom.HoldLotCascadeReply{
+		CaseID:    holdCase.ID,
+		Resources: held,
+	}, tx.Commit()
+}
+
+// ReleaseHoldCase implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) ReleaseHoldCase(ctx context.Context, req mcom.ReleaseHoldCaseRequest) error {
+	if err := req.CheckInsufficiency(); err != nil {
+		return err
+	}
+
+	tx := dm.beginTx(ctx)
+	defer tx.Rollback() // nolint: errcheck
+
+	var holdCase models.HoldCase
+	if err := tx.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(`id = ?`, req.CaseID).
+		Take(&holdCase).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return mcomErr.Error{Code: mcomErr.Code_HOLD_CASE_NOT_FOUND, Details: "case: " + req.CaseID}
+		}
+		return err
+	}
+	if holdCase.IsReleased() {
+		return mcomErr.Error{Code: mcomErr.Code_HOLD_CASE_RELEASED, Details: "case: " + req.CaseID}
+	}
+
+	var held []models.HoldCaseResource
+	if err := tx.db.Where(`case_id = ?`, req.CaseID).Find(&held).Error; err != nil {
+		return err
+	}
+	// the resources held by the other open cases.
+	var others []models.HoldCaseResource
+	if err := tx.db.
+		Where(`case_id IN (?)`, tx.db.Model(&models.HoldCase{}).Select(`id`).Where(`released_at = 0 AND id <> ?`, req.CaseID)).
+		Order(`case_id`).
+		Find(&others).Error; err != nil {
+		return err
+	}
+	heldByOthers := make(map[models.UniqueMaterialResource][]models.HoldCaseResource)
+	for _, other := range others {
+		key := models.UniqueMaterialResource{ResourceID: other.ResourceID, ProductType: other.ProductType}
+		heldByOthers[key] = append(heldByOthers[key], other)
+	}
+
+	userID := commonsCtx.UserID(ctx)
+	for _, resource := range held {
+		key := models.UniqueMaterialResource{ResourceID: resource.ResourceID, ProductType: resource.ProductType}
+		if others, ok := heldByOthers[key]; ok {
+			// the resource is still held by the other cases, which restore
+			// the previous status while being released.
+			if err := tx.handOverPreviousStatus(resource, others); err != nil {
+				return err
+			}
+			continue
+		}
+		if resource.PreviousStatus == resources.MaterialStatus_HOLD {
+			continue
+		}
+		if err := tx.db.Model(&models.MaterialResource{}).
+			Where(`id = ? AND product_type = ? AND status = ?`, resource.ResourceID, resource.ProductType, resources.MaterialStatus_HOLD).
+			Updates(models.MaterialResource{
+				Status:    resource.PreviousStatus,
+				UpdatedBy: userID,
+			}).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := tx.db.Model(&models.HoldCase{}).
+		Where(`id = ?`, req.CaseID).
+		Updates(models.HoldCase{
+			ReleasedAt: types.ToTimeNano(time.Now()),
+			ReleasedBy: userID,
+		}).Error; err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// handOverPreviousStatus hands over the previous status of the released
+// resource to one of the other cases if none of them knows the status before
+// the resource was held.
+func (tx *txDataManager) handOverPreviousStatus(released models.HoldCaseResource, others []models.HoldCaseResource) error {
+	for _, other := range others {
+		if other.PreviousStatus != resources.MaterialStatus_HOLD {
+			return nil
+		}
+	}
+	return tx.db.Model(&models.HoldCaseResource{}).
+		Where(`case_id = ? AND resource_id = ? AND product_type = ?`, others[0].CaseID, released.ResourceID, released.ProductType).
+		Update(`previous_status`, released.PreviousStatus).Error
+}
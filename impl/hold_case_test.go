@@ -0,0 +1,136 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/resources"
+)
+
+func TestDataManager_HoldLotCascade(t *testing.T) {
+	assert := assert.New(t)
+	ctx, dm, db := initializeDB(t)
+	defer dm.Close()
+	ctx = commonsCtx.WithUserID(ctx, testUser)
+	cm := newClearMaster(db,
+		&models.FeedRecord{},
+		&models.MaterialResource{},
+		&models.HoldCase{},
+		&models.HoldCaseResource{},
+	)
+	assert.NoError(cm.Clear())
+	defer func() { assert.NoError(cm.Clear()) }()
+
+	// RAW is fed in F1 for P1 and P3, and P1 is fed in F2 for P2.
+	var (
+		raw = models.UniqueMaterialResource{ResourceID: "RAW", ProductType: "R"}
+		p1  = models.UniqueMaterialResource{ResourceID: "P1", ProductType: "P"}
+		p2  = models.UniqueMaterialResource{ResourceID: "P2", ProductType: "P"}
+		p3  = models.UniqueMaterialResource{ResourceID: "P3", ProductType: "P"}
+	)
+	feedRecord := func(id string, fed models.UniqueMaterialResource) models.FeedRecord {
+		return models.FeedRecord{
+			ID: id,
+			Materials: models.FeedDetails{{
+				Resources: []models.FeedResource{{
+					ResourceID:  fed.ResourceID,
+					ProductType: fed.ProductType,
+					Quantity:    decimal.NewFromInt(1),
+				}},
+			}},
+			Time: time.Now(),
+		}
+	}
+	assert.NoError(db.Create([]models.FeedRecord{feedRecord("F1", raw), feedRecord("F2", p1)}).Error)
+	resource := func(key models.UniqueMaterialResource, quantity int64, status resources.MaterialStatus, feedRecordsID ...string) models.MaterialResource {
+		if feedRecordsID == nil {
+			feedRecordsID = []string{}
+		}
+		return models.MaterialResource{
+			ID:            key.ResourceID,
+			ProductType:   key.ProductType,
+			Quantity:      decimal.NewFromInt(quantity),
+			Status:        status,
+			FeedRecordsID: feedRecordsID,
+		}
+	}
+	assert.NoError(db.Create([]models.MaterialResource{
+		resource(raw, 5, resources.MaterialStatus_AVAILABLE),
+		// consumed and not mounted.
+		resource(p1, 0, resources.MaterialStatus_AVAILABLE, "F1"),
+		resource(p2, 3, resources.MaterialStatus_AVAILABLE, "F2"),
+		resource(p3, 0, resources.MaterialStatus_MOUNTED, "F1"),
+	}).Error)
+	statusOf := func(key models.UniqueMaterialResource) resources.MaterialStatus {
+		var r models.MaterialResource
+		assert.NoError(db.Where(`id = ? AND product_type = ?`, key.ResourceID, key.ProductType).Take(&r).Error)
+		return r.Status
+	}
+
+	{ // insufficient request.
+		_, err := dm.HoldLotCascade(ctx, mcom.HoldLotCascadeRequest{ResourceID: raw.ResourceID})
+		assert.ErrorIs(err, mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "Key: 'HoldLotCascadeRequest.Reason' Error:Field validation for 'Reason' failed on the 'required' tag",
+		})
+	}
+	{ // resource not found.
+		_, err := dm.HoldLotCascade(ctx, mcom.HoldLotCascadeRequest{ResourceID: "NOT_FOUND", Reason: "defective"})
+		assert.ErrorIs(err, mcomErr.Error{Code: mcomErr.Code_RESOURCE_NOT_FOUND, Details: "resource: NOT_FOUND"})
+	}
+	{ // release a case not found.
+		assert.ErrorIs(dm.ReleaseHoldCase(ctx, mcom.ReleaseHoldCaseRequest{CaseID: "NOT_FOUND"}), mcomErr.Error{
+			Code:    mcomErr.Code_HOLD_CASE_NOT_FOUND,
+			Details: "case: NOT_FOUND",
+		})
+	}
+
+	first, err := dm.HoldLotCascade(ctx, mcom.HoldLotCascadeRequest{ResourceID: raw.ResourceID, Reason: "defective"})
+	assert.NoError(err)
+	assert.NotEmpty(first.CaseID)
+	assert.Equal([]mcom.HeldResource{
+		{UniqueMaterialResource: raw, PreviousStatus: resources.MaterialStatus_AVAILABLE},
+		{UniqueMaterialResource: p3, PreviousStatus: resources.MaterialStatus_MOUNTED},
+		{UniqueMaterialResource: p2, PreviousStatus: resources.MaterialStatus_AVAILABLE},
+	}, first.Resources)
+	for _, key := range []models.UniqueMaterialResource{raw, p2, p3} {
+		assert.Equal(resources.MaterialStatus_HOLD, statusOf(key), key)
+	}
+	assert.Equal(resources.MaterialStatus_AVAILABLE, statusOf(p1))
+
+	var holdCase models.HoldCase
+	assert.NoError(db.Where(`id = ?`, first.CaseID).Take(&holdCase).Error)
+	assert.Equal("defective", holdCase.Reason)
+	assert.Equal(testUser, holdCase.CreatedBy)
+
+	// P2 is held by both cases.
+	second, err := dm.HoldLotCascade(ctx, mcom.HoldLotCascadeRequest{ResourceID: p1.ResourceID, Reason: "suspect", Depth: 1})
+	assert.NoError(err)
+	assert.Equal([]mcom.HeldResource{
+		{UniqueMaterialResource: p2, PreviousStatus: resources.MaterialStatus_HOLD},
+	}, second.Resources)
+
+	{ // release the first case.
+		assert.NoError(dm.ReleaseHoldCase(ctx, mcom.ReleaseHoldCaseRequest{CaseID: first.CaseID}))
+		assert.Equal(resources.MaterialStatus_AVAILABLE, statusOf(raw))
+		assert.Equal(resources.MaterialStatus_MOUNTED, statusOf(p3))
+		assert.Equal(resources.MaterialStatus_HOLD, statusOf(p2))
+
+		assert.ErrorIs(dm.ReleaseHoldCase(ctx, mcom.ReleaseHoldCaseRequest{CaseID: first.CaseID}), mcomErr.Error{
+			Code:    mcomErr.Code_HOLD_CASE_RELEASED,
+			Details: "case: " + first.CaseID,
+		})
+	}
+	{ // release the second case.
+		assert.NoError(dm.ReleaseHoldCase(ctx, mcom.ReleaseHoldCaseRequest{CaseID: second.CaseID}))
+		assert.Equal(resources.MaterialStatus_AVAILABLE, statusOf(p2))
+	}
+}
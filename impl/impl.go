@@ -17,6 +17,7 @@ import (
 	"gitlab.kenda.com.tw/kenda/mcom"
 	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
 	"gitlab.kenda.com.tw/kenda/mcom/impl/pda"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/stations"
 )
 
 // PGConfig is connection configuration for postgreSQL.
@@ -48,6 +49,11 @@ type options struct {
 	migrateCloudTables bool
 	adAuth             bool
 	adConfig           ADConfig
+
+	stationStateTransitions map[stations.State][]stations.State
+
+	staleOperatorSweepInterval time.Duration
+	staleOperatorSweepRequest  mcom.SignOutStaleOperatorsRequest
 }
 
 func parseOptions(opts []Option) options {
@@ -96,6 +102,24 @@ func ADAuth(adConfig ADConfig) Option {
 	}
 }
 
+// WithStationStateTransitions replaces the allowed transitions of the station
+// states which are DefaultStationStateTransitions by default.
+func WithStationStateTransitions(transitions map[stations.State][]stations.State) Option {
+	return func(o *options) {
+		o.stationStateTransitions = transitions
+	}
+}
+
+// WithStaleOperatorSweeper signs out the stale operators every interval in
+// the background until the data manager is closed, see SignOutStaleOperators.
+// The sessions are ended by SystemUserID.
+func WithStaleOperatorSweeper(interval time.Duration, req mcom.SignOutStaleOperatorsRequest) Option {
+	return func(o *options) {
+		o.staleOperatorSweepInterval = interval
+		o.staleOperatorSweepRequest = req
+	}
+}
+
 // DataManager definition.
 type DataManager struct {
 	db *gorm.DB
@@ -105,6 +129,12 @@ type DataManager struct {
 	agent *commonsAccount.ADAgent
 
 	lockTimeout time.Duration
+
+	stationStateTransitions map[stations.State][]stations.State
+
+	// stopSweeper stops the stale operator sweeper and waits for it to return
+	// if it is not nil.
+	stopSweeper func()
 }
 
 func newDataManager(cfg PGConfig, o options) (*DataManager, error) {
@@ -145,6 +175,11 @@ func New(
 
 	dm.pdaService = pda.NewWebService(o.pdaServiceEndpoint)
 
+	dm.stationStateTransitions = o.stationStateTransitions
+	if dm.stationStateTransitions == nil {
+		dm.stationStateTransitions = DefaultStationStateTransitions
+	}
+
 	if o.adAuth {
 		agent, err := commonsAccount.NewADAgent(commonsAccount.ADConfig{
 			Host:          o.adConfig.Host,
@@ -160,6 +195,13 @@ func New(
 		dm.agent = agent
 	}
 
+	if o.staleOperatorSweepInterval > 0 {
+		if err := o.staleOperatorSweepRequest.CheckInsufficiency(); err != nil {
+			return nil, err
+		}
+		dm.startStaleOperatorSweeper(ctx, o.staleOperatorSweepInterval, o.staleOperatorSweepRequest)
+	}
+
 	return dm, nil
 }
 
@@ -176,7 +218,10 @@ func (dm *DataManager) maybeMigrate(migrateCloudTable bool) error {
 	if err := maybeMigrateFunctions(dm.db, ms...); err != nil {
 		return err
 	}
-	return maybeMigrateTriggers(dm.db, ms...)
+	if err := maybeMigrateTriggers(dm.db, ms...); err != nil {
+		return err
+	}
+	return maybeMigrateData(dm.db, ms...)
 }
 
 // maybeMigrateTables attempts to create tables automatically if implement
@@ -219,8 +264,26 @@ func maybeMigrateTriggers(db *gorm.DB, ms ...models.Model) error {
 	return nil
 }
 
+// maybeMigrateData attempts to migrate the existing data automatically if
+// implement models.DataMigration interface.
+func maybeMigrateData(db *gorm.DB, ms ...models.Model) error {
+	for _, m := range ms {
+		d, ok := m.(models.DataMigration)
+		if !ok {
+			continue
+		}
+		if err := d.MigrateData(db); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Close implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
 func (dm *DataManager) Close() error {
+	if dm.stopSweeper != nil {
+		dm.stopSweeper()
+	}
 	db, err := dm.db.DB()
 	if err != nil {
 		return err
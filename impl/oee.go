@@ -0,0 +1,366 @@
+package impl
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	pbWorkOrder "gitlab.kenda.com.tw/kenda/commons/v2/proto/golang/mes/v2/workorder"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/resources"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/stations"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
+)
+
+// oeeRatioPrecision is the number of the decimal places of the OEE ratios.
+const oeeRatioPrecision = 4
+
+type stationStatePeriod struct {
+	start  time.Time
+	end    time.Time
+	state  stations.State
+	reason string
+}
+
+type collectedQuantity struct {
+	time     time.Time
+	quantity decimal.Decimal
+	planned  decimal.Decimal
+	defect   bool
+}
+
+// GetStationOEE implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) GetStationOEE(ctx context.Context, req mcom.GetStationOEERequest) (mcom.GetStationOEEReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.GetStationOEEReply{}, err
+	}
+
+	session := dm.newSession(ctx)
+	station, err := session.getStation(req.Station)
+	if err != nil {
+		return mcom.GetStationOEEReply{}, err
+	}
+
+	periods, err := session.listStationStatePeriods(station, req.From, req.To)
+	if err != nil {
+		return mcom.GetStationOEEReply{}, err
+	}
+
+	collects, err := session.listCollectedQuantities(req.Station, req.From, req.To)
+	if err != nil {
+		return mcom.GetStationOEEReply{}, err
+	}
+
+	var splits []oeePeriod
+	if req.ByShift {
+		if splits, err = session.splitOEEPeriodsByShift(station.AdminDepartmentID, req.From, req.To); err != nil {
+			return mcom.GetStationOEEReply{}, err
+		}
+	} else {
+		splits = splitOEEPeriods(req.From, req.To, req.Granularity)
+	}
+
+	res := mcom.GetStationOEEReply{
+		Total:   newStationOEE(req.From, req.To, periods, collects),
+		Periods: make([]mcom.StationOEE, len(splits)),
+	}
+	for i, split := range splits {
+		res.Periods[i] = newStationOEE(split.from, split.to, periods, collects)
+		res.Periods[i].WorkDate = split.shift.WorkDate
+		res.Periods[i].Shift = split.shift.Shift
+	}
+	return res, nil
+}
+
+// oeePeriod is a period of a GetStationOEE request.
+type oeePeriod struct {
+	from  time.Time
+	to    time.Time
+	shift models.ShiftAssignment
+}
+
+// splitOEEPeriods splits the time range into the periods of the granularity.
+// The whole time range is a single period if the granularity is zero.
+func splitOEEPeriods(from, to time.Time, granularity time.Duration) []oeePeriod {
+	if granularity == 0 {
+		granularity = to.Sub(from)
+	}
+	res := []oeePeriod{}
+	for start := from; start.Before(to); start = start.Add(granularity) {
+		end := start.Add(granularity)
+		if end.After(to) {
+			end = to
+		}
+		res = append(res, oeePeriod{from: start, to: end})
+	}
+	return res
+}
+
+// splitOEEPeriodsByShift splits the time range by the shifts of the shift
+// calendar of the department. The whole time range is a single period if the
+// department has no shift calendar.
+func (session *session) splitOEEPeriodsByShift(departmentID string, from, to time.Time) ([]oeePeriod, error) {
+	calendar, err := session.getShiftCalendar(departmentID)
+	if err != nil {
+		if e, ok := mcomErr.As(err); ok && e.Code == mcomErr.Code_SHIFT_CALENDAR_NOT_FOUND {
+			return splitOEEPeriods(from, to, 0), nil
+		}
+		return nil, err
+	}
+	if len(calendar.Shifts) == 0 {
+		return splitOEEPeriods(from, to, 0), nil
+	}
+
+	res := []oeePeriod{}
+	for start := from; start.Before(to); {
+		if len(res) >= mcom.MaxOEEPeriods {
+			return nil, mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "too many periods"}
+		}
+		shift, err := calendar.Resolve(start)
+		if err != nil {
+			return nil, err
+		}
+		end, err := calendar.ShiftEnd(start)
+		if err != nil {
+			return nil, err
+		}
+		if end.After(to) {
+			end = to
+		}
+		res = append(res, oeePeriod{from: start, to: end, shift: shift})
+		start = end
+	}
+	return res, nil
+}
+
+// listStationStatePeriods returns the continuous state periods of the station
+// between from and to according to the state histories.
+func (session *session) listStationStatePeriods(station models.Station, from, to time.Time) ([]stationStatePeriod, error) {
+	var last []models.StationStateHistory
+	if err := session.db.
+		Where(`station = ? AND created_at <= ?`, station.ID, types.ToTimeNano(from)).
+		Order(`created_at DESC, id DESC`).
+		Limit(1).
+		Find(&last).Error; err != nil {
+		return nil, err
+	}
+
+	var histories []models.StationStateHistory
+	if err := session.db.
+		Where(`station = ? AND created_at > ? AND created_at < ?`, station.ID, types.ToTimeNano(from), types.ToTimeNano(to)).
+		Order(`created_at, id`).
+		Find(&histories).Error; err != nil {
+		return nil, err
+	}
+
+	// the state at from is the state after the last history at or before from,
+	// otherwise the state before the first history after from. The current
+	// state is used only if the station has no histories at all.
+	current := stationStatePeriod{start: from, state: station.State}
+	if len(last) != 0 {
+		current.state, current.reason = last[0].To, last[0].Reason
+	} else {
+		var next []models.StationStateHistory
+		if err := session.db.
+			Where(`station = ? AND created_at > ?`, station.ID, types.ToTimeNano(from)).
+			Order(`created_at, id`).
+			Limit(1).
+			Find(&next).Error; err != nil {
+			return nil, err
+		}
+		if len(next) != 0 {
+			current.state = next[0].From
+		}
+	}
+
+	res := []stationStatePeriod{}
+	for _, history := range histories {
+		current.end = history.CreatedAt.Time()
+		res = append(res, current)
+		current = stationStatePeriod{
+			start:  current.end,
+			state:  history.To,
+			reason: history.Reason,
+		}
+	}
+	current.end = to
+	return append(res, current), nil
+}
+
+// listCollectedQuantities returns the collected quantities and the batches of
+// the station between from and to with their planned quantities. The batch
+// counts of the collect records are planned only if their work orders have no
+// batches, so that a batch is not planned twice.
+func (session *session) listCollectedQuantities(station string, from, to time.Time) ([]collectedQuantity, error) {
+	var records []models.CollectRecord
+	if err := session.db.
+		Where(`station = ? AND created_at >= ? AND created_at < ?`, station, types.ToTimeNano(from), types.ToTimeNano(to)).
+		Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	var batches []models.Batch
+	if err := session.db.
+		Where(`work_order IN (?)`, session.db.
+			Model(&models.WorkOrder{}).
+			Select(`id`).
+			Where(`station = ?`, station)).
+		Where(`created_at >= ? AND created_at < ? AND status <> ?`, types.ToTimeNano(from), types.ToTimeNano(to), pbWorkOrder.BatchStatus_BATCH_CANCELLED).
+		Find(&batches).Error; err != nil {
+		return nil, err
+	}
+	if len(records) == 0 && len(batches) == 0 {
+		return []collectedQuantity{}, nil
+	}
+
+	workOrderIDs := make([]string, 0, len(records)+len(batches))
+	resourceOIDs := make([]string, len(records))
+	for i, record := range records {
+		workOrderIDs = append(workOrderIDs, record.WorkOrder)
+		resourceOIDs[i] = record.ResourceOID
+	}
+	for _, batch := range batches {
+		workOrderIDs = append(workOrderIDs, batch.WorkOrder)
+	}
+	workOrderIDs = uniqueStrings(workOrderIDs)
+
+	var workOrders []models.WorkOrder
+	if err := session.db.Where(`id IN ?`, workOrderIDs).Find(&workOrders).Error; err != nil {
+		return nil, err
+	}
+	plannedPerBatch := make(map[string]decimal.Decimal, len(workOrders))
+	for _, workOrder := range workOrders {
+		plannedPerBatch[workOrder.ID] = workOrder.Information.PlanQuantityPerBatch()
+	}
+
+	var batchedWorkOrders []string
+	if err := session.db.
+		Model(&models.Batch{}).
+		Where(`work_order IN ? AND status <> ?`, workOrderIDs, pbWorkOrder.BatchStatus_BATCH_CANCELLED).
+		Distinct(`work_order`).
+		Pluck(`work_order`, &batchedWorkOrders).Error; err != nil {
+		return nil, err
+	}
+	hasBatches := make(map[string]bool, len(batchedWorkOrders))
+	for _, workOrder := range batchedWorkOrders {
+		hasBatches[workOrder] = true
+	}
+
+	var defects []models.MaterialResource
+	if err := session.db.
+		Where(`oid IN ? AND status IN ?`, resourceOIDs, []resources.MaterialStatus{resources.MaterialStatus_HOLD, resources.MaterialStatus_UNAVAILABLE}).
+		Find(&defects).Error; err != nil {
+		return nil, err
+	}
+	isDefect := make(map[string]bool, len(defects))
+	for _, resource := range defects {
+		isDefect[resource.OID] = true
+	}
+
+	res := make([]collectedQuantity, 0, len(records)+len(batches))
+	for _, record := range records {
+		collect := collectedQuantity{
+			time:     record.CreatedAt.Time(),
+			quantity: record.Detail.Quantity,
+			defect:   isDefect[record.ResourceOID],
+		}
+		if !hasBatches[record.WorkOrder] {
+			collect.planned = plannedPerBatch[record.WorkOrder].Mul(decimal.NewFromInt(int64(record.Detail.BatchCount)))
+		}
+		res = append(res, collect)
+	}
+	for _, batch := range batches {
+		res = append(res, collectedQuantity{
+			time:    batch.CreatedAt.Time(),
+			planned: plannedPerBatch[batch.WorkOrder],
+		})
+	}
+	return res, nil
+}
+
+func isPlannedStationState(state stations.State) bool {
+	switch state {
+	case stations.State_SHUTDOWN, stations.State_MAINTENANCE, stations.State_DISPOSAL:
+		return false
+	}
+	return true
+}
+
+// newStationOEE computes the OEE between from and to.
+func newStationOEE(from, to time.Time, periods []stationStatePeriod, collects []collectedQuantity) mcom.StationOEE {
+	res := mcom.StationOEE{
+		From:      from,
+		To:        to,
+		Downtimes: []mcom.StationDowntime{},
+	}
+
+	type downtimeKey struct {
+		state  stations.State
+		reason string
+	}
+	downtimes := make(map[downtimeKey]time.Duration)
+	for _, period := range periods {
+		start, end := period.start, period.end
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if !end.After(start) {
+			continue
+		}
+
+		duration := end.Sub(start)
+		if isPlannedStationState(period.state) {
+			res.PlannedTime += duration
+		}
+		if period.state == stations.State_RUNNING {
+			res.RunTime += duration
+		} else {
+			downtimes[downtimeKey{state: period.state, reason: period.reason}] += duration
+		}
+	}
+	for key, duration := range downtimes {
+		res.Downtimes = append(res.Downtimes, mcom.StationDowntime{
+			State:    key.state,
+			Reason:   key.reason,
+			Duration: duration,
+		})
+	}
+	sort.Slice(res.Downtimes, func(i, j int) bool {
+		if res.Downtimes[i].State == res.Downtimes[j].State {
+			return res.Downtimes[i].Reason < res.Downtimes[j].Reason
+		}
+		return res.Downtimes[i].State < res.Downtimes[j].State
+	})
+
+	for _, collect := range collects {
+		if collect.time.Before(from) || !collect.time.Before(to) {
+			continue
+		}
+		res.Quantity = res.Quantity.Add(collect.quantity)
+		res.PlannedQuantity = res.PlannedQuantity.Add(collect.planned)
+		if collect.defect {
+			res.DefectQuantity = res.DefectQuantity.Add(collect.quantity)
+		}
+	}
+
+	res.Availability = oeeRatio(decimal.NewFromInt(int64(res.RunTime)), decimal.NewFromInt(int64(res.PlannedTime)))
+	res.Performance = oeeRatio(res.Quantity, res.PlannedQuantity)
+	res.Quality = oeeRatio(res.Quantity.Sub(res.DefectQuantity), res.Quantity)
+	res.OEE = res.Availability.Mul(res.Performance).Mul(res.Quality).Round(oeeRatioPrecision)
+	return res
+}
+
+func oeeRatio(numerator, denominator decimal.Decimal) decimal.Decimal {
+	if denominator.IsZero() {
+		return decimal.Zero
+	}
+	return numerator.DivRound(denominator, oeeRatioPrecision)
+}
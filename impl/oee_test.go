@@ -0,0 +1,249 @@
+package impl
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	pbWorkOrder "gitlab.kenda.com.tw/kenda/commons/v2/proto/golang/mes/v2/workorder"
+	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/stations"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/workorder"
+)
+
+func Test_newStationOEE(t *testing.T) {
+	assert := assert.New(t)
+
+	from := time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local)
+	periods := []stationStatePeriod{
+		{start: from.Add(-time.Hour), end: from.Add(time.Hour), state: stations.State_SHUTDOWN},
+		{start: from.Add(time.Hour), end: from.Add(5 * time.Hour), state: stations.State_RUNNING},
+		{start: from.Add(5 * time.Hour), end: from.Add(6 * time.Hour), state: stations.State_MALFUNCTION, reason: "M01"},
+		{start: from.Add(6 * time.Hour), end: from.Add(10 * time.Hour), state: stations.State_RUNNING},
+	}
+	collects := []collectedQuantity{
+		{time: from.Add(2 * time.Hour), quantity: decimal.NewFromInt(90), planned: decimal.NewFromInt(100)},
+		{time: from.Add(7 * time.Hour), quantity: decimal.NewFromInt(90), planned: decimal.NewFromInt(100), defect: true},
+		{time: from.Add(9 * time.Hour), quantity: decimal.NewFromInt(20), planned: decimal.NewFromInt(20)}, // out of range.
+	}
+
+	res := newStationOEE(from, from.Add(8*time.Hour), periods, collects)
+	assert.Equal(7*time.Hour, res.PlannedTime)
+	assert.Equal(6*time.Hour, res.RunTime)
+	assert.Equal([]mcom.StationDowntime{
+		{State: stations.State_SHUTDOWN, Duration: time.Hour},
+		{State: stations.State_MALFUNCTION, Reason: "M01", Duration: time.Hour},
+	}, res.Downtimes)
+	assert.True(decimal.NewFromInt(200).Equal(res.PlannedQuantity))
+	assert.True(decimal.NewFromInt(180).Equal(res.Quantity))
+	assert.True(decimal.NewFromInt(90).Equal(res.DefectQuantity))
+	assert.Equal("0.8571", res.Availability.String())
+	assert.Equal("0.9", res.Performance.String())
+	assert.Equal("0.5", res.Quality.String())
+	assert.Equal("0.3857", res.OEE.String())
+
+	// no planned time and no collected quantity.
+	res = newStationOEE(from.Add(-time.Hour), from, periods, collects)
+	assert.Equal(time.Duration(0), res.PlannedTime)
+	assert.True(res.Availability.IsZero())
+	assert.True(res.Performance.IsZero())
+	assert.True(res.Quality.IsZero())
+	assert.True(res.OEE.IsZero())
+}
+
+func TestDataManager_GetStationOEE(t *testing.T) {
+	assert := assert.New(t)
+	ctx := commonsCtx.WithUserID(context.Background(), testUser)
+
+	dm, db, err := newTestDataManager()
+	if !assert.NoError(err) {
+		return
+	}
+	if !assert.NotNil(dm) {
+		return
+	}
+	defer dm.Close()
+	assert.NoError(clearStationsData(db))
+
+	from := time.Now()
+	{ // station not found.
+		_, err := dm.GetStationOEE(ctx, mcom.GetStationOEERequest{
+			Station: testStationA,
+			From:    from,
+			To:      from.Add(time.Hour),
+		})
+		assert.ErrorIs(err, mcomErr.Error{
+			Code:    mcomErr.Code_STATION_NOT_FOUND,
+			Details: "station not found, id: " + testStationA,
+		})
+	}
+
+	assert.NoError(dm.CreateStation(ctx, mcom.CreateStationRequest{
+		ID:            testStationA,
+		DepartmentOID: testDepartmentA,
+	}))
+	from = time.Now()
+	assert.NoError(dm.ChangeStationState(ctx, mcom.ChangeStationStateRequest{
+		ID:    testStationA,
+		State: stations.State_IDLE,
+	}))
+	assert.NoError(dm.ChangeStationState(ctx, mcom.ChangeStationStateRequest{
+		ID:    testStationA,
+		State: stations.State_RUNNING,
+	}))
+	assert.NoError(dm.ChangeStationState(ctx, mcom.ChangeStationStateRequest{
+		ID:     testStationA,
+		State:  stations.State_MALFUNCTION,
+		Reason: "M01",
+	}))
+	to := time.Now()
+
+	{ // good case.
+		rep, err := dm.GetStationOEE(ctx, mcom.GetStationOEERequest{
+			Station:     testStationA,
+			From:        from,
+			To:          to,
+			Granularity: to.Sub(from) / 2,
+		})
+		if assert.NoError(err) {
+			assert.Len(rep.Periods, 2)
+			assert.Equal(from, rep.Total.From)
+			assert.Equal(to, rep.Total.To)
+			assert.True(rep.Total.RunTime > 0)
+			assert.True(rep.Total.PlannedTime > rep.Total.RunTime)
+			states := make([]stations.State, len(rep.Total.Downtimes))
+			for i, downtime := range rep.Total.Downtimes {
+				states[i] = downtime.State
+			}
+			assert.Equal([]stations.State{stations.State_SHUTDOWN, stations.State_IDLE, stations.State_MALFUNCTION}, states)
+			assert.True(rep.Total.Quantity.IsZero())
+			assert.True(rep.Total.OEE.IsZero())
+		}
+	}
+	{ // by shift.
+		shifts := make([]mcom.Shift, 24)
+		for i := range shifts {
+			shifts[i] = mcom.Shift{Name: strconv.Itoa(i), Start: time.Duration(i) * time.Hour}
+		}
+		assert.NoError(dm.SetShiftCalendar(ctx, mcom.SetShiftCalendarRequest{
+			DepartmentID: testDepartmentA,
+			TimeZone:     "UTC",
+			Shifts:       shifts,
+		}))
+
+		hour := time.Now().UTC().Truncate(time.Hour)
+		rep, err := dm.GetStationOEE(ctx, mcom.GetStationOEERequest{
+			Station: testStationA,
+			From:    hour.Add(-30 * time.Minute),
+			To:      hour.Add(90 * time.Minute),
+			ByShift: true,
+		})
+		if assert.NoError(err) && assert.Len(rep.Periods, 3) {
+			assert.True(hour.Equal(rep.Periods[1].From))
+			assert.True(hour.Add(time.Hour).Equal(rep.Periods[1].To))
+			assert.Equal(strconv.Itoa(hour.Hour()), rep.Periods[1].Shift)
+			assert.Equal(time.Date(hour.Year(), hour.Month(), hour.Day(), 0, 0, 0, 0, time.UTC), rep.Periods[1].WorkDate)
+			assert.True(hour.Add(90 * time.Minute).Equal(rep.Periods[2].To))
+		}
+	}
+	{ // the batches are planned.
+		workOrder := models.WorkOrder{
+			ProcessOID:   testProcessOID,
+			ProcessName:  testProcessName,
+			ProcessType:  testProcessType,
+			DepartmentID: testDepartmentA,
+			Station:      testStationA,
+			ReservedDate: time.Now(),
+			Information: models.WorkOrderInformation{
+				BatchQuantityDetails: models.BatchQuantityDetails{
+					BatchQuantityType:  workorder.BatchSize_PER_BATCH_QUANTITIES,
+					QuantityForBatches: []decimal.Decimal{decimal.NewFromInt(10), decimal.NewFromInt(10)},
+				},
+			},
+		}
+		assert.NoError(db.Create(&workOrder).Error)
+
+		from := time.Now()
+		assert.NoError(dm.CreateBatch(ctx, mcom.CreateBatchRequest{WorkOrder: workOrder.ID, Number: 1}))
+		assert.NoError(dm.CreateBatch(ctx, mcom.CreateBatchRequest{WorkOrder: workOrder.ID, Number: 2}))
+		assert.NoError(dm.CreateBatch(ctx, mcom.CreateBatchRequest{
+			WorkOrder: workOrder.ID,
+			Number:    3,
+			Status:    pbWorkOrder.BatchStatus_BATCH_CANCELLED,
+		}))
+		// the batch count is not planned again.
+		assert.NoError(dm.CreateCollectRecord(ctx, mcom.CreateCollectRecordRequest{
+			WorkOrder:   workOrder.ID,
+			Sequence:    1,
+			LotNumber:   "LOT",
+			Station:     testStationA,
+			ResourceOID: "OID",
+			Quantity:    decimal.NewFromInt(15),
+			BatchCount:  2,
+		}))
+
+		rep, err := dm.GetStationOEE(ctx, mcom.GetStationOEERequest{
+			Station: testStationA,
+			From:    from,
+			To:      time.Now(),
+		})
+		if assert.NoError(err) {
+			assert.Equal("20", rep.Total.PlannedQuantity.String())
+			assert.Equal("15", rep.Total.Quantity.String())
+			assert.Equal("0.75", rep.Total.Performance.String())
+		}
+	}
+
+	{ // the state is changed after the time range.
+		assert.NoError(dm.CreateStation(ctx, mcom.CreateStationRequest{
+			ID:            testStationB,
+			DepartmentOID: testDepartmentA,
+		}))
+		// the station created before the state histories have no baseline.
+		assert.NoError(db.Where(`station = ?`, testStationB).Delete(&models.StationStateHistory{}).Error)
+
+		from := time.Now()
+		to := from.Add(time.Minute)
+		monkey.Patch(time.Now, func() time.Time { return to.Add(time.Minute) })
+		defer monkey.UnpatchAll()
+		assert.NoError(dm.ChangeStationState(ctx, mcom.ChangeStationStateRequest{
+			ID:    testStationB,
+			State: stations.State_IDLE,
+		}))
+
+		rep, err := dm.GetStationOEE(ctx, mcom.GetStationOEERequest{
+			Station: testStationB,
+			From:    from,
+			To:      to,
+		})
+		if assert.NoError(err) && assert.Len(rep.Total.Downtimes, 1) {
+			assert.Equal(stations.State_SHUTDOWN, rep.Total.Downtimes[0].State)
+			assert.Equal(time.Minute, rep.Total.Downtimes[0].Duration)
+		}
+	}
+
+	assert.NoError(clearStationsData(db))
+	assert.NoError(newClearMaster(db, &models.ShiftCalendar{}, &models.WorkOrder{}, &models.Batch{}, &models.CollectRecord{}).Clear())
+}
+
+func Test_splitOEEPeriods(t *testing.T) {
+	assert := assert.New(t)
+
+	from := time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local)
+	assert.Equal([]oeePeriod{
+		{from: from, to: from.Add(8 * time.Hour)},
+		{from: from.Add(8 * time.Hour), to: from.Add(10 * time.Hour)},
+	}, splitOEEPeriods(from, from.Add(10*time.Hour), 8*time.Hour))
+	assert.Equal([]oeePeriod{
+		{from: from, to: from.Add(10 * time.Hour)},
+	}, splitOEEPeriods(from, from.Add(10*time.Hour), 0))
+}
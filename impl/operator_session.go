@@ -0,0 +1,350 @@
+package impl
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm/clause"
+
+	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
+)
+
+// startOperatorSession ends the session in progress on the site and starts a
+// new session of the operator.
+func (tx *txDataManager) startOperatorSession(site models.UniqueSite, operator models.OperatorSite, startedBy string) error {
+	now := time.Now()
+	if err := tx.endOperatorSessions([][3]string{uniqueSiteCondition(site)}, now, startedBy, ""); err != nil {
+		return err
+	}
+	return tx.db.Create(&models.OperatorSession{
+		UserID:    operator.EmployeeID,
+		Station:   site.Station,
+		SiteName:  site.SiteID.Name,
+		SiteIndex: site.SiteID.Index,
+		Group:     operator.Group,
+		WorkDate:  operator.WorkDate,
+		StartedAt: types.ToTimeNano(now),
+	}).Error
+}
+
+// endOperatorSessions ends the sessions in progress on the sites which are
+// in the form of (station, site name, site index). The reason is empty unless
+// the system signs out the operators.
+func (tx *txDataManager) endOperatorSessions(sites [][3]string, endedAt time.Time, endedBy, reason string) error {
+	if len(sites) == 0 {
+		return nil
+	}
+	return tx.db.
+		Model(&models.OperatorSession{}).
+		Where(`(station, site_name, site_index) IN ? AND ended_at = 0`, sites).
+		Updates(map[string]interface{}{
+			"ended_at":   types.ToTimeNano(endedAt),
+			"ended_by":   endedBy,
+			"end_reason": reason,
+		}).Error
+}
+
+func uniqueSiteCondition(site models.UniqueSite) [3]string {
+	return [3]string{site.Station, site.SiteID.Name, strconv.Itoa(int(site.SiteID.Index))}
+}
+
+// ListOperatorSessions implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) ListOperatorSessions(ctx context.Context, req mcom.ListOperatorSessionsRequest) (mcom.ListOperatorSessionsReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.ListOperatorSessionsReply{}, err
+	}
+
+	session := dm.newSession(ctx)
+	query := session.db.
+		Where(`ended_at = 0 OR ended_at >= ?`, types.ToTimeNano(req.Since))
+	if !req.Until.IsZero() {
+		query = query.Where(`started_at <= ?`, types.ToTimeNano(req.Until))
+	}
+	if req.UserID != "" {
+		query = query.Where(`user_id = ?`, req.UserID)
+	}
+	if req.Station != "" {
+		query = query.Where(`station = ?`, req.Station)
+	}
+
+	var sessions []models.OperatorSession
+	if err := query.Order(`started_at, id`).Find(&sessions).Error; err != nil {
+		return mcom.ListOperatorSessionsReply{}, err
+	}
+
+	res := make([]mcom.OperatorSession, len(sessions))
+	for i, s := range sessions {
+		res[i] = mcom.OperatorSession{
+			UserID:  s.UserID,
+			Station: s.Station,
+			Site: models.SiteID{
+				Name:  s.SiteName,
+				Index: s.SiteIndex,
+			},
+			Group:     int32(s.Group),
+			WorkDate:  s.WorkDate,
+			StartedAt: s.StartedAt.Time(),
+			EndedBy:   s.EndedBy,
+			EndReason: s.EndReason,
+		}
+		if s.EndedAt != 0 {
+			res[i].EndedAt = s.EndedAt.Time()
+		}
+	}
+	return mcom.ListOperatorSessionsReply{Sessions: res}, nil
+}
+
+// ListLaborHours implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) ListLaborHours(ctx context.Context, req mcom.ListLaborHoursRequest) (mcom.ListLaborHoursReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.ListLaborHoursReply{}, err
+	}
+	since, err := parseDate(req.Since)
+	if err != nil {
+		return mcom.ListLaborHoursReply{}, err
+	}
+	until, err := parseDate(req.Until)
+	if err != nil {
+		return mcom.ListLaborHoursReply{}, err
+	}
+
+	session := dm.newSession(ctx)
+	query := session.db.
+		Where(`work_date BETWEEN ? AND ?`, since, until)
+	if req.UserID != "" {
+		query = query.Where(`user_id = ?`, req.UserID)
+	}
+	if req.Station != "" {
+		query = query.Where(`station = ?`, req.Station)
+	}
+	if req.DepartmentID != "" {
+		query = query.Where(`user_id IN (?)`, session.db.
+			Model(&models.User{}).
+			Select(`id`).
+			Where(`department_id = ?`, req.DepartmentID))
+	}
+
+	var sessions []models.OperatorSession
+	if err := query.Find(&sessions).Error; err != nil {
+		return mcom.ListLaborHoursReply{}, err
+	}
+
+	type laborKey struct {
+		userID   string
+		station  string
+		workDate time.Time
+	}
+	now := time.Now()
+	durations := make(map[laborKey]time.Duration)
+	for _, s := range sessions {
+		key := laborKey{
+			userID:   s.UserID,
+			station:  s.Station,
+			workDate: s.WorkDate,
+		}
+		durations[key] += s.Duration(now)
+	}
+
+	res := make([]mcom.LaborHours, 0, len(durations))
+	for key, duration := range durations {
+		res = append(res, mcom.LaborHours{
+			UserID:   key.userID,
+			Station:  key.station,
+			WorkDate: key.workDate,
+			Duration: duration,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].UserID != res[j].UserID {
+			return res[i].UserID < res[j].UserID
+		}
+		if !res[i].WorkDate.Equal(res[j].WorkDate) {
+			return res[i].WorkDate.Before(res[j].WorkDate)
+		}
+		return res[i].Station < res[j].Station
+	})
+	return mcom.ListLaborHoursReply{LaborHours: res}, nil
+}
+
+// SignOutStaleOperators implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) SignOutStaleOperators(ctx context.Context, req mcom.SignOutStaleOperatorsRequest) (mcom.SignOutStaleOperatorsReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.SignOutStaleOperatorsReply{}, err
+	}
+
+	tx := dm.beginTx(ctx)
+	defer tx.Rollback() // nolint: errcheck
+	operators, err := tx.signOutStaleOperators(commonsCtx.UserID(ctx), req, time.Now())
+	if err != nil {
+		return mcom.SignOutStaleOperatorsReply{}, err
+	}
+	return mcom.SignOutStaleOperatorsReply{Operators: operators}, tx.Commit()
+}
+
+func (tx *txDataManager) signOutStaleOperators(userID string, req mcom.SignOutStaleOperatorsRequest, now time.Time) ([]mcom.SignedOutOperator, error) {
+	query := tx.db.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(`content -> 'slot' -> 'operator' ->> 'employee_id' <> ''`)
+	if req.DepartmentID != "" {
+		query = query.Where(`station IN (?)`, tx.db.
+			Model(&models.Station{}).
+			Select(`id`).
+			Where(`admin_department_id = ?`, req.DepartmentID))
+	}
+	var contents []models.SiteContents
+	if err := query.Order(`station, name, index`).Find(&contents).Error; err != nil {
+		return nil, err
+	}
+	if len(contents) == 0 {
+		return []mcom.SignedOutOperator{}, nil
+	}
+
+	stationIDs := make([]string, len(contents))
+	for i, sc := range contents {
+		stationIDs[i] = sc.Station
+	}
+	stationIDs = uniqueStrings(stationIDs)
+
+	var stations []models.Station
+	if err := tx.db.
+		Select(`id`, `admin_department_id`).
+		Where(`id IN ?`, stationIDs).
+		Find(&stations).Error; err != nil {
+		return nil, err
+	}
+	departments := make(map[string]string, len(stations))
+	departmentIDs := make([]string, len(stations))
+	for i, station := range stations {
+		departments[station.ID] = station.AdminDepartmentID
+		departmentIDs[i] = station.AdminDepartmentID
+	}
+
+	var calendarList []models.ShiftCalendar
+	if err := tx.db.
+		Where(`department_id IN ?`, uniqueStrings(departmentIDs)).
+		Find(&calendarList).Error; err != nil {
+		return nil, err
+	}
+	calendars := make(map[string]models.ShiftCalendar, len(calendarList))
+	for _, calendar := range calendarList {
+		calendars[calendar.DepartmentID] = calendar
+	}
+
+	var sessions []models.OperatorSession
+	if err := tx.db.
+		Where(`station IN ? AND ended_at = 0`, stationIDs).
+		Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+	startedAt := make(map[[3]string]time.Time, len(sessions))
+	for _, s := range sessions {
+		startedAt[[3]string{s.Station, s.SiteName, strconv.Itoa(int(s.SiteIndex))}] = s.StartedAt.Time()
+	}
+
+	res := []mcom.SignedOutOperator{}
+	sitesByReason := make(map[string][][3]string)
+	var sitesToClear [][3]string
+	for _, sc := range contents {
+		site := models.UniqueSite{
+			SiteID: models.SiteID{
+				Name:  sc.Name,
+				Index: sc.Index,
+			},
+			Station: sc.Station,
+		}
+		condition := uniqueSiteCondition(site)
+		// the sign-in time is unknown for the operators who signed in before
+		// the sessions are recorded.
+		since, ok := startedAt[condition]
+		if !ok {
+			since = sc.UpdatedAt.Time()
+		}
+
+		reason := ""
+		if calendar, ok := calendars[departments[sc.Station]]; ok {
+			end, err := calendar.ShiftEnd(since)
+			if err != nil {
+				return nil, err
+			}
+			if !end.IsZero() && !now.Before(end.Add(req.GracePeriod)) {
+				reason = mcom.SignOutReasonShiftEnded
+			}
+		} else if req.MaxDuration > 0 && now.Sub(since) >= req.MaxDuration {
+			reason = mcom.SignOutReasonExpired
+		}
+		if reason == "" {
+			continue
+		}
+
+		sitesByReason[reason] = append(sitesByReason[reason], condition)
+		sitesToClear = append(sitesToClear, condition)
+		res = append(res, mcom.SignedOutOperator{
+			Site:   site,
+			UserID: sc.Content.Slot.Operator.Current().EmployeeID,
+			Reason: reason,
+		})
+	}
+	if len(sitesToClear) == 0 {
+		return res, nil
+	}
+
+	if err := tx.db.Model(&models.SiteContents{}).
+		Where(`(station,name,index) IN ?`, sitesToClear).
+		Updates(map[string]interface{}{
+			"content": models.SiteContent{
+				Slot: &models.Slot{
+					Operator: new(models.OperatorSite),
+				},
+			},
+			"updated_by": userID,
+		}).Error; err != nil {
+		return nil, err
+	}
+	for reason, sites := range sitesByReason {
+		if err := tx.endOperatorSessions(sites, now, userID, reason); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// SystemUserID is the user who signs out the stale operators in the
+// background, see WithStaleOperatorSweeper.
+const SystemUserID = "SYSTEM"
+
+// startStaleOperatorSweeper signs out the stale operators periodically until
+// the data manager is closed. The sweeper runs on its own context which only
+// carries the logger of ctx, so it outlives the context passed to New.
+func (dm *DataManager) startStaleOperatorSweeper(ctx context.Context, interval time.Duration, req mcom.SignOutStaleOperatorsRequest) {
+	sweepCtx := commonsCtx.WithLogger(context.Background(), commonsCtx.Logger(ctx))
+	sweepCtx = commonsCtx.WithUserID(sweepCtx, SystemUserID)
+	sweepCtx, cancel := context.WithCancel(sweepCtx)
+
+	done := make(chan struct{})
+	dm.stopSweeper = func() {
+		cancel()
+		<-done
+	}
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-sweepCtx.Done():
+				return
+			case <-ticker.C:
+				if _, err := dm.SignOutStaleOperators(sweepCtx, req); err != nil {
+					commonsCtx.Logger(sweepCtx).Warn("failed to sign out stale operators", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,304 @@
+package impl
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/stretchr/testify/assert"
+
+	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/sites"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
+)
+
+func TestDataManager_OperatorSessions(t *testing.T) {
+	assert := assert.New(t)
+	ctx, dm, db := initializeDB(t)
+	defer dm.Close()
+	cm := newClearMaster(db,
+		&models.OperatorSession{},
+		&models.User{},
+		&models.Station{},
+		&models.Site{},
+		&models.SiteContents{},
+	)
+	assert.NoError(cm.Clear())
+
+	const (
+		operatorA = "OPERATOR_A"
+		operatorB = "OPERATOR_B"
+	)
+	ctxA := commonsCtx.WithUserID(ctx, operatorA)
+	ctxB := commonsCtx.WithUserID(ctx, operatorB)
+
+	assert.NoError(dm.CreateUsers(ctx, mcom.CreateUsersRequest{
+		Users: []mcom.User{
+			{ID: operatorA, DepartmentID: testDepartmentA},
+			{ID: operatorB, DepartmentID: testDepartmentB},
+		},
+	}))
+	assert.NoError(dm.CreateStation(ctxA, mcom.CreateStationRequest{
+		ID:            testStationA,
+		DepartmentOID: testDepartmentA,
+		Sites: []mcom.SiteInformation{{
+			Name:    testSiteA,
+			Index:   0,
+			Type:    sites.Type_SLOT,
+			SubType: sites.SubType_OPERATOR,
+		}},
+	}))
+
+	since := time.Now()
+	workDate := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	site := models.SiteID{Name: testSiteA}
+
+	assert.NoError(dm.SignInStation(ctxA, mcom.SignInStationRequest{
+		Station:  testStationA,
+		Site:     site,
+		Group:    1,
+		WorkDate: workDate,
+	}))
+	assert.NoError(dm.SignOutStation(ctxA, mcom.SignOutStationRequest{
+		Station: testStationA,
+		Site:    site,
+	}))
+	assert.NoError(dm.SignInStation(ctxA, mcom.SignInStationRequest{
+		Station:  testStationA,
+		Site:     site,
+		Group:    1,
+		WorkDate: workDate,
+	}))
+	// force sign-in ends the session of the previous operator.
+	assert.NoError(dm.SignInStation(ctxB, mcom.SignInStationRequest{
+		Station:  testStationA,
+		Site:     site,
+		Group:    2,
+		WorkDate: workDate,
+	}, mcom.ForceSignIn()))
+
+	{ // ListOperatorSessions: all.
+		actual, err := dm.ListOperatorSessions(ctx, mcom.ListOperatorSessionsRequest{Since: since})
+		assert.NoError(err)
+		if assert.Len(actual.Sessions, 3) {
+			assert.Equal(operatorA, actual.Sessions[0].UserID)
+			assert.Equal(operatorA, actual.Sessions[0].EndedBy)
+			assert.False(actual.Sessions[0].EndedAt.IsZero())
+
+			assert.Equal(operatorA, actual.Sessions[1].UserID)
+			assert.Equal(operatorB, actual.Sessions[1].EndedBy)
+			assert.False(actual.Sessions[1].EndedAt.IsZero())
+
+			assert.Equal(mcom.OperatorSession{
+				UserID:    operatorB,
+				Station:   testStationA,
+				Site:      site,
+				Group:     2,
+				WorkDate:  workDate,
+				StartedAt: actual.Sessions[2].StartedAt,
+			}, actual.Sessions[2])
+		}
+	}
+	{ // ListOperatorSessions: filter by user.
+		actual, err := dm.ListOperatorSessions(ctx, mcom.ListOperatorSessionsRequest{
+			UserID: operatorB,
+			Since:  since,
+		})
+		assert.NoError(err)
+		assert.Len(actual.Sessions, 1)
+	}
+	{ // ListOperatorSessions: out of the time range.
+		actual, err := dm.ListOperatorSessions(ctx, mcom.ListOperatorSessionsRequest{
+			Since: since.Add(-time.Hour),
+			Until: since.Add(-time.Minute),
+		})
+		assert.NoError(err)
+		assert.Empty(actual.Sessions)
+	}
+
+	assert.NoError(dm.SignOutStations(ctxB, mcom.SignOutStationsRequest{
+		Sites: []models.UniqueSite{{Station: testStationA, SiteID: site}},
+	}))
+
+	{ // ListLaborHours: good case.
+		actual, err := dm.ListLaborHours(ctx, mcom.ListLaborHoursRequest{
+			Since: workDate,
+			Until: workDate,
+		})
+		assert.NoError(err)
+		if assert.Len(actual.LaborHours, 2) {
+			assert.Equal(operatorA, actual.LaborHours[0].UserID)
+			assert.Equal(testStationA, actual.LaborHours[0].Station)
+			assert.True(workDate.Equal(actual.LaborHours[0].WorkDate))
+			assert.Equal(operatorB, actual.LaborHours[1].UserID)
+		}
+	}
+	{ // ListLaborHours: filter by department.
+		actual, err := dm.ListLaborHours(ctx, mcom.ListLaborHoursRequest{
+			Since:        workDate,
+			Until:        workDate,
+			DepartmentID: testDepartmentB,
+		})
+		assert.NoError(err)
+		if assert.Len(actual.LaborHours, 1) {
+			assert.Equal(operatorB, actual.LaborHours[0].UserID)
+		}
+	}
+
+	assert.NoError(cm.Clear())
+}
+
+func TestDataManager_SignOutStaleOperators(t *testing.T) {
+	assert := assert.New(t)
+	ctx, dm, db := initializeDB(t)
+	defer dm.Close()
+	cm := newClearMaster(db,
+		&models.OperatorSession{},
+		&models.ShiftCalendar{},
+		&models.Station{},
+		&models.Site{},
+		&models.SiteContents{},
+	)
+	assert.NoError(cm.Clear())
+
+	// in the middle of an hour so that the shift of station B does not end
+	// during the test.
+	timeNow := time.Now().UTC().Truncate(time.Hour).Add(30 * time.Minute)
+	monkey.Patch(time.Now, func() time.Time {
+		return timeNow
+	})
+	defer monkey.UnpatchAll()
+
+	const (
+		operatorA = "OPERATOR_A"
+		operatorB = "OPERATOR_B"
+		operatorC = "OPERATOR_C"
+	)
+	for _, station := range []struct {
+		id         string
+		department string
+	}{
+		{id: testStationA, department: testDepartmentA},
+		{id: testStationB, department: testDepartmentA},
+		{id: testStationC, department: testDepartmentB},
+	} {
+		assert.NoError(dm.CreateStation(ctx, mcom.CreateStationRequest{
+			ID:            station.id,
+			DepartmentOID: station.department,
+			Sites: []mcom.SiteInformation{{
+				Name:    testSiteA,
+				Index:   0,
+				Type:    sites.Type_SLOT,
+				SubType: sites.SubType_OPERATOR,
+			}},
+		}))
+	}
+	// a shift every hour.
+	shifts := make([]mcom.Shift, 24)
+	for i := range shifts {
+		shifts[i] = mcom.Shift{Name: strconv.Itoa(i), Start: time.Duration(i) * time.Hour}
+	}
+	assert.NoError(dm.SetShiftCalendar(ctx, mcom.SetShiftCalendarRequest{
+		DepartmentID: testDepartmentA,
+		TimeZone:     "UTC",
+		Shifts:       shifts,
+	}))
+
+	site := models.SiteID{Name: testSiteA}
+	for station, operator := range map[string]string{
+		testStationA: operatorA,
+		testStationB: operatorB,
+		testStationC: operatorC,
+	} {
+		assert.NoError(dm.SignInStation(commonsCtx.WithUserID(ctx, operator), mcom.SignInStationRequest{
+			Station:  station,
+			Site:     site,
+			WorkDate: time.Now(),
+		}))
+	}
+	// the operator of station A signed in two hours ago.
+	assert.NoError(db.Model(&models.OperatorSession{}).
+		Where(`station = ?`, testStationA).
+		Update("started_at", types.ToTimeNano(time.Now().Add(-2*time.Hour))).Error)
+	// the operator of station C signed in a day ago.
+	assert.NoError(db.Model(&models.OperatorSession{}).
+		Where(`station = ?`, testStationC).
+		Update("started_at", types.ToTimeNano(time.Now().AddDate(0, 0, -1))).Error)
+
+	{ // good case.
+		actual, err := dm.SignOutStaleOperators(ctx, mcom.SignOutStaleOperatorsRequest{
+			MaxDuration: 12 * time.Hour,
+		})
+		assert.NoError(err)
+		assert.Equal(mcom.SignOutStaleOperatorsReply{
+			Operators: []mcom.SignedOutOperator{{
+				Site:   models.UniqueSite{Station: testStationA, SiteID: site},
+				UserID: operatorA,
+				Reason: mcom.SignOutReasonShiftEnded,
+			}, {
+				Site:   models.UniqueSite{Station: testStationC, SiteID: site},
+				UserID: operatorC,
+				Reason: mcom.SignOutReasonExpired,
+			}},
+		}, actual)
+
+		sessions, err := dm.ListOperatorSessions(ctx, mcom.ListOperatorSessionsRequest{
+			Station: testStationA,
+			Since:   time.Now().AddDate(0, 0, -2),
+		})
+		assert.NoError(err)
+		if assert.Len(sessions.Sessions, 1) {
+			assert.Equal(mcom.SignOutReasonShiftEnded, sessions.Sessions[0].EndReason)
+			assert.False(sessions.Sessions[0].EndedAt.IsZero())
+		}
+
+		// the site of station A is free to sign in.
+		assert.NoError(dm.SignInStation(commonsCtx.WithUserID(ctx, operatorA), mcom.SignInStationRequest{
+			Station:  testStationA,
+			Site:     site,
+			WorkDate: time.Now(),
+		}))
+	}
+	{ // nothing to sign out.
+		actual, err := dm.SignOutStaleOperators(ctx, mcom.SignOutStaleOperatorsRequest{
+			DepartmentID: testDepartmentB,
+			MaxDuration:  12 * time.Hour,
+		})
+		assert.NoError(err)
+		assert.Equal(mcom.SignOutStaleOperatorsReply{Operators: []mcom.SignedOutOperator{}}, actual)
+	}
+	{ // the sweeper signs out the operators as the system user.
+		assert.NoError(db.Model(&models.OperatorSession{}).
+			Where(`station = ? AND ended_at = 0`, testStationA).
+			Update("started_at", types.ToTimeNano(time.Now().Add(-2*time.Hour))).Error)
+
+		manager := dm.(*DataManager)
+		manager.startStaleOperatorSweeper(ctx, 10*time.Millisecond, mcom.SignOutStaleOperatorsRequest{
+			DepartmentID: testDepartmentA,
+		})
+		assert.Eventually(func() bool {
+			var count int64
+			if err := db.Model(&models.OperatorSession{}).
+				Where(`station = ? AND ended_at = 0`, testStationA).
+				Count(&count).Error; err != nil {
+				return false
+			}
+			return count == 0
+		}, time.Second, 10*time.Millisecond)
+		manager.stopSweeper()
+
+		var sessions []models.OperatorSession
+		assert.NoError(db.Where(`station = ?`, testStationA).Order(`started_at`).Find(&sessions).Error)
+		if assert.Len(sessions, 2) {
+			assert.Equal(SystemUserID, sessions[1].EndedBy)
+			assert.Equal(mcom.SignOutReasonShiftEnded, sessions[1].EndReason)
+		}
+		manager.stopSweeper = nil
+	}
+
+	assert.NoError(cm.Clear())
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/rs/xid"
+	"gorm.io/gorm"
+
+	"gitlab.kenda.com.tw/kenda/mcom/utils/resources"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
+)
+
+// HoldCase is a case holding the resources produced from a suspect lot.
+type HoldCase struct {
+	// ID is automatically generated before creating.
+	ID string `gorm:"type:text;primaryKey"`
+	// ResourceID and ProductType are of the suspect lot.
+	ResourceID  string `gorm:"type:text;not null;index:idx_hold_case_resource"`
+	ProductType string `gorm:"type:text;not null;index:idx_hold_case_resource"`
+	Reason      string `gorm:"type:text;not null"`
+	// Depth is the max depth of the lineage to hold, it is unlimited if zero.
+	Depth int32 `gorm:"not null"`
+
+	// CreatedAt the number of nanoseconds elapsed since January 1, 1970 UTC.
+	CreatedAt types.TimeNano `gorm:"autoCreateTime:nano;not null"`
+	CreatedBy string         `gorm:"type:text;not null"`
+	// ReleasedAt is zero if the case is not released.
+	ReleasedAt types.TimeNano `gorm:"default:0;not null"`
+	ReleasedBy string         `gorm:"type:text;default:'';not null"`
+}
+
+// BeforeCreate gorm hook.
+func (c *HoldCase) BeforeCreate(*gorm.DB) error {
+	c.ID = strings.ToUpper(xid.New().String())
+	return nil
+}
+
+// Model implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*HoldCase) Model() {}
+
+// TableName implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*HoldCase) TableName() string {
+	return "hold_case"
+}
+
+// IsReleased reports whether the case has been released.
+func (c HoldCase) IsReleased() bool {
+	return c.ReleasedAt != 0
+}
+
+// HoldCaseResource is a resource held by a hold case.
+type HoldCaseResource struct {
+	// CaseID is relative to HoldCase.ID.
+	CaseID      string `gorm:"type:text;primaryKey"`
+	ResourceID  string `gorm:"type:text;primaryKey"`
+	ProductType string `gorm:"type:text;primaryKey"`
+	// PreviousStatus is the status of the resource before it is held, which
+	// is restored while releasing the case.
+	PreviousStatus resources.MaterialStatus `gorm:"not null"`
+}
+
+// Model implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*HoldCaseResource) Model() {}
+
+// TableName implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*HoldCaseResource) TableName() string {
+	return "hold_case_resource"
+}
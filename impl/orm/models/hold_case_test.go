@@ -0,0 +1,22 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm/callbacks"
+)
+
+func TestHoldCase(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Implements(new(callbacks.BeforeCreateInterface), new(HoldCase))
+
+	var c HoldCase
+	assert.NoError(c.BeforeCreate(nil))
+	assert.NotEmpty(c.ID)
+	assert.False(c.IsReleased())
+
+	c.ReleasedAt = 1
+	assert.True(c.IsReleased())
+}
@@ -22,6 +22,13 @@ type Trigger interface {
 	MigrateTrigger(db *gorm.DB) error
 }
 
+// DataMigration definition.
+type DataMigration interface {
+	// MigrateData migrates the existing data to the current schema. It must be
+	// safe to be called repeatedly.
+	MigrateData(db *gorm.DB) error
+}
+
 // GetModelList returns a list of gorm models.
 func GetModelList() []Model {
 	return []Model{
@@ -29,6 +36,10 @@ func GetModelList() []Model {
 		&Account{},
 		&User{},
 		&Department{},
+		&ShiftCalendar{},
+		&UserQualification{},
+		&QualificationRequirement{},
+		&OperatorSession{},
 
 		&Site{},
 		&SiteContents{},
@@ -40,14 +51,23 @@ func GetModelList() []Model {
 		&FeedRecord{},
 
 		&Warehouse{},
+		&WarehouseLocation{},
 		&WarehouseStock{},
 		&MaterialResource{},
 		&ResourceTransportRecord{},
+		&HoldCase{},
+		&HoldCaseResource{},
+		&StockCount{},
+		&StockCountItem{},
 
 		&Station{},
 		&StationGroup{},
 		&StationConfiguration{},
+		&StationConfigurationVersion{},
+		&StationTemplate{},
 		&BindRecords{},
+		&SiteBindHistory{},
+		&StationStateHistory{},
 
 		&Recipe{},
 		&RecipeProcessDefinition{},
@@ -61,6 +81,10 @@ func GetModelList() []Model {
 		&PackRecord{},
 
 		&ToolResource{},
+		&ToolLifePolicy{},
+		&ToolType{},
+		&ToolResourceHistory{},
+		&ToolBindHistory{},
 
 		&LimitaryHour{},
 	}
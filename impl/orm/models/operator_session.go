@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
+)
+
+// OperatorSession is a period an operator signs in to an operator site.
+type OperatorSession struct {
+	// ID is a serial number, it is automatically generated when creating.
+	ID int64 `gorm:"type:bigserial;primaryKey"`
+
+	// UserID is relative to User.ID.
+	UserID string `gorm:"type:text;not null;index:idx_operator_session_user"`
+	// Station is relative to Site.Station.
+	Station string `gorm:"type:varchar(32);not null;index:idx_operator_session_station"`
+	// SiteName is relative to Site.Name.
+	SiteName string `gorm:"type:varchar(16);not null"`
+	// SiteIndex is relative to Site.Index.
+	SiteIndex int16     `gorm:"not null"`
+	Group     int8      `gorm:"not null"`
+	WorkDate  time.Time `gorm:"type:date;not null;index:idx_operator_session_work_date"`
+
+	// StartedAt the number of nanoseconds elapsed since January 1, 1970 UTC.
+	StartedAt types.TimeNano `gorm:"not null"`
+	// EndedAt the number of nanoseconds elapsed since January 1, 1970 UTC.
+	// It is zero if the operator has not signed out.
+	EndedAt types.TimeNano `gorm:"default:0;not null"`
+	EndedBy string         `gorm:"type:text;not null"`
+	// EndReason is empty if the operator signs out or is replaced by another
+	// operator, otherwise it is the reason the system signs out the operator.
+	EndReason string `gorm:"type:text;not null"`
+}
+
+// Model implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*OperatorSession) Model() {}
+
+// TableName implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*OperatorSession) TableName() string {
+	return "operator_session"
+}
+
+// Duration returns the duration of the session. The session is regarded as
+// ended at the specified time if the operator has not signed out.
+func (s OperatorSession) Duration(now time.Time) time.Duration {
+	end := now
+	if s.EndedAt != 0 {
+		end = s.EndedAt.Time()
+	}
+	if d := end.Sub(s.StartedAt.Time()); d > 0 {
+		return d
+	}
+	return 0
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
+)
+
+func TestOperatorSession_Duration(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2022, 1, 1, 8, 0, 0, 0, time.UTC)
+	now := start.Add(3 * time.Hour)
+	{ // ended.
+		assert.Equal(2*time.Hour, OperatorSession{
+			StartedAt: types.ToTimeNano(start),
+			EndedAt:   types.ToTimeNano(start.Add(2 * time.Hour)),
+		}.Duration(now))
+	}
+	{ // in progress.
+		assert.Equal(3*time.Hour, OperatorSession{
+			StartedAt: types.ToTimeNano(start),
+		}.Duration(now))
+	}
+	{ // started after now.
+		assert.Equal(time.Duration(0), OperatorSession{
+			StartedAt: types.ToTimeNano(now.Add(time.Hour)),
+		}.Duration(now))
+	}
+}
@@ -53,6 +53,34 @@ type PlanQuantity struct {
 	PlanQuantity decimal.Decimal `json:"plan_quantity,omitempty"`
 }
 
+// PlanQuantityPerBatch returns the average planned quantity of a batch.
+// It returns zero if there is no batch.
+func (d BatchQuantityDetails) PlanQuantityPerBatch() decimal.Decimal {
+	var (
+		total      decimal.Decimal
+		batchCount uint
+	)
+	switch d.BatchQuantityType {
+	case workorder.BatchSize_PER_BATCH_QUANTITIES:
+		for _, qty := range d.QuantityForBatches {
+			total = total.Add(qty)
+		}
+		batchCount = uint(len(d.QuantityForBatches))
+	case workorder.BatchSize_FIXED_QUANTITY:
+		if d.FixedQuantity != nil {
+			total, batchCount = d.FixedQuantity.PlanQuantity, d.FixedQuantity.BatchCount
+		}
+	case workorder.BatchSize_PLAN_QUANTITY:
+		if d.PlanQuantity != nil {
+			total, batchCount = d.PlanQuantity.PlanQuantity, d.PlanQuantity.BatchCount
+		}
+	}
+	if batchCount == 0 {
+		return decimal.Zero
+	}
+	return total.Div(decimal.NewFromInt(int64(batchCount)))
+}
+
 // Scan implements database/sql Scanner interface.
 func (info *WorkOrderInformation) Scan(src interface{}) error {
 	return ScanJSON(src, info)
@@ -183,6 +211,9 @@ type Batch struct {
 	// UpdatedAt the number of nanoseconds elapsed since January 1, 1970 UTC.
 	UpdatedAt types.TimeNano `gorm:"autoUpdateTime:nano;not null"`
 	UpdatedBy string         `gorm:"type:text;not null"`
+	// CreatedAt the number of nanoseconds elapsed since January 1, 1970 UTC.
+	// It is zero for the batches created before it is recorded.
+	CreatedAt types.TimeNano `gorm:"autoCreateTime:nano;default:0;not null"`
 }
 
 // TableName implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
@@ -6,6 +6,8 @@ import (
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm/callbacks"
+
+	"gitlab.kenda.com.tw/kenda/mcom/utils/workorder"
 )
 
 func TestWorkOrderInformation_implementation(t *testing.T) {
@@ -34,6 +36,27 @@ func TestWorkOrderInformation_implementation(t *testing.T) {
 	}
 }
 
+func TestBatchQuantityDetails_PlanQuantityPerBatch(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(decimal.NewFromInt(20).Equal(BatchQuantityDetails{
+		BatchQuantityType:  workorder.BatchSize_PER_BATCH_QUANTITIES,
+		QuantityForBatches: []decimal.Decimal{decimal.NewFromInt(10), decimal.NewFromInt(30)},
+	}.PlanQuantityPerBatch()))
+	assert.True(decimal.NewFromInt(25).Equal(BatchQuantityDetails{
+		BatchQuantityType: workorder.BatchSize_FIXED_QUANTITY,
+		FixedQuantity:     &FixedQuantity{BatchCount: 4, PlanQuantity: decimal.NewFromInt(100)},
+	}.PlanQuantityPerBatch()))
+	assert.True(decimal.NewFromInt(50).Equal(BatchQuantityDetails{
+		BatchQuantityType: workorder.BatchSize_PLAN_QUANTITY,
+		PlanQuantity:      &PlanQuantity{BatchCount: 2, PlanQuantity: decimal.NewFromInt(100)},
+	}.PlanQuantityPerBatch()))
+	// no batch.
+	assert.True(decimal.Zero.Equal(BatchQuantityDetails{
+		BatchQuantityType: workorder.BatchSize_PLAN_QUANTITY,
+	}.PlanQuantityPerBatch()))
+}
+
 func TestWorkOrder_BeforeCreate(t *testing.T) {
 	assert := assert.New(t)
 
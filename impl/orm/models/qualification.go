@@ -0,0 +1,83 @@
+package models
+
+import (
+	"sort"
+	"time"
+
+	"github.com/lib/pq"
+
+	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
+)
+
+// UserQualification is a skill or certification of a user.
+type UserQualification struct {
+	// UserID is relative to User.ID.
+	UserID        string `gorm:"type:text;primaryKey"`
+	Qualification string `gorm:"type:text;primaryKey"`
+	// ExpiresAt the number of nanoseconds elapsed since January 1, 1970 UTC.
+	// It is zero if the qualification never expires.
+	ExpiresAt types.TimeNano `gorm:"default:0;not null"`
+
+	// CreatedAt the number of nanoseconds elapsed since January 1, 1970 UTC.
+	CreatedAt types.TimeNano `gorm:"autoCreateTime:nano;not null"`
+	CreatedBy string         `gorm:"type:text;not null"`
+}
+
+// Model implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*UserQualification) Model() {}
+
+// TableName implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*UserQualification) TableName() string {
+	return "user_qualification"
+}
+
+// IsValid reports whether the qualification is not expired at the specified time.
+func (q UserQualification) IsValid(t time.Time) bool {
+	return q.ExpiresAt == 0 || t.Before(q.ExpiresAt.Time())
+}
+
+// UserQualifications definition.
+type UserQualifications []UserQualification
+
+// Missing returns the required qualifications which are not held or are
+// expired at the specified time, in ascending order.
+func (qs UserQualifications) Missing(required []string, t time.Time) []string {
+	valid := make(map[string]struct{}, len(qs))
+	for _, q := range qs {
+		if q.IsValid(t) {
+			valid[q.Qualification] = struct{}{}
+		}
+	}
+
+	missing := []string{}
+	for _, r := range required {
+		if _, ok := valid[r]; !ok {
+			missing = append(missing, r)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// QualificationRequirement is the required qualifications to operate a station
+// or the stations in a station group. One of StationID and GroupID is
+// specified.
+type QualificationRequirement struct {
+	// StationID is relative to Station.ID.
+	StationID string `gorm:"type:text;default:'';primaryKey"`
+	// GroupID is relative to StationGroup.ID.
+	GroupID        string         `gorm:"type:text;default:'';primaryKey"`
+	Qualifications pq.StringArray `gorm:"type:text[];default:'{}';not null"`
+
+	// UpdatedAt the number of nanoseconds elapsed since January 1, 1970 UTC.
+	UpdatedAt types.TimeNano `gorm:"autoUpdateTime:nano;not null"`
+	UpdatedBy string         `gorm:"type:text;not null"`
+}
+
+// Model implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*QualificationRequirement) Model() {}
+
+// TableName implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*QualificationRequirement) TableName() string {
+	return "qualification_requirement"
+}
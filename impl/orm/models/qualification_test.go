@@ -0,0 +1,26 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
+)
+
+func TestUserQualifications_Missing(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now()
+	qualifications := UserQualifications{
+		{Qualification: "A"},
+		{Qualification: "B", ExpiresAt: types.ToTimeNano(now.Add(time.Hour))},
+		{Qualification: "C", ExpiresAt: types.ToTimeNano(now.Add(-time.Hour))},
+	}
+	assert.Equal([]string{}, qualifications.Missing(nil, now))
+	assert.Equal([]string{}, qualifications.Missing([]string{"B", "A"}, now))
+	assert.Equal([]string{"C", "D"}, qualifications.Missing([]string{"D", "A", "C"}, now))
+	assert.Equal([]string{"B"}, qualifications.Missing([]string{"B"}, now.Add(2*time.Hour)))
+	assert.Equal([]string{"A"}, UserQualifications{}.Missing([]string{"A"}, now))
+}
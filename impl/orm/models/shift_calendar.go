@@ -0,0 +1,204 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+
+	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
+)
+
+// WorkDateLayout is the layout of the work dates in the shift calendar.
+const WorkDateLayout = "2006-01-02"
+
+const day = 24 * time.Hour
+
+// ShiftCalendar is the shift calendar of a department.
+//
+// A work date begins at the start of the first shift, e.g. the work date
+// 2022-01-02 is from 2022-01-02 07:30 to 2022-01-03 07:30 if the first shift
+// starts at 07:30.
+type ShiftCalendar struct {
+	// DepartmentID is relative to Department.ID.
+	DepartmentID string `gorm:"type:text;primaryKey"`
+	// TimeZone is the IANA time zone name of the plant, e.g. "Asia/Taipei".
+	// It is the local time zone if empty.
+	TimeZone string `gorm:"type:text;not null"`
+	// Shifts are in ascending order of the start time beginning with the
+	// first shift of a work date.
+	Shifts   ShiftDefinitions `gorm:"type:jsonb;default:'[]';not null"`
+	Rotation ShiftRotation    `gorm:"type:jsonb;default:'{}';not null"`
+	// Holidays are the work dates in WorkDateLayout.
+	Holidays pq.StringArray `gorm:"type:text[];default:'{}';not null"`
+
+	// UpdatedAt the number of nanoseconds elapsed since January 1, 1970 UTC.
+	UpdatedAt types.TimeNano `gorm:"autoUpdateTime:nano;not null"`
+	UpdatedBy string         `gorm:"type:text;not null"`
+}
+
+// Model implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*ShiftCalendar) Model() {}
+
+// TableName implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*ShiftCalendar) TableName() string {
+	return "shift_calendar"
+}
+
+// ShiftDefinition definition.
+type ShiftDefinition struct {
+	Name string `json:"name"`
+	// Start is the start time of the shift since the midnight.
+	Start time.Duration `json:"start"`
+}
+
+// ShiftDefinitions definition.
+type ShiftDefinitions []ShiftDefinition
+
+// Scan implements database/sql Scanner interface.
+func (s *ShiftDefinitions) Scan(src interface{}) error {
+	return ScanJSON(src, s)
+}
+
+// Value implements database/sql/driver Valuer interface.
+func (s ShiftDefinitions) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+// ShiftRotation is the rotation of the groups on the shifts.
+type ShiftRotation struct {
+	// Since is the work date in WorkDateLayout of the first day in Groups.
+	Since string `json:"since,omitempty"`
+	// Groups[i][j] is the group on the j-th shift of the i-th day of a cycle.
+	Groups [][]int32 `json:"groups,omitempty"`
+}
+
+// Scan implements database/sql Scanner interface.
+func (r *ShiftRotation) Scan(src interface{}) error {
+	return ScanJSON(src, r)
+}
+
+// Value implements database/sql/driver Valuer interface.
+func (r ShiftRotation) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+// ShiftAssignment is the shift of a time in the shift calendar.
+type ShiftAssignment struct {
+	// WorkDate is the midnight of the work date in UTC.
+	WorkDate time.Time
+	Shift    string
+	// Group is zero if there is no rotation.
+	Group   int32
+	Holiday bool
+}
+
+// Location returns the time zone of the calendar.
+func (c ShiftCalendar) Location() (*time.Location, error) {
+	if c.TimeZone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(c.TimeZone)
+}
+
+// locate returns the start of the work date of the specified time and the
+// index of the shift in Shifts.
+func (c ShiftCalendar) locate(t time.Time) (time.Time, int, error) {
+	loc, err := c.Location()
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	dayStart := c.Shifts[0].Start
+	y, m, d := t.In(loc).Add(-dayStart).Date()
+	workDateStart := time.Date(y, m, d, 0, 0, 0, 0, loc).Add(dayStart)
+
+	// elapsed time since the start of the work date.
+	elapsed := t.Sub(workDateStart)
+	index := 0
+	for i, shift := range c.Shifts {
+		if shiftOffset(dayStart, shift.Start) <= elapsed {
+			index = i
+		}
+	}
+	return workDateStart, index, nil
+}
+
+// Resolve returns the shift of the specified time. It returns an empty
+// assignment if there is no shift in the calendar.
+func (c ShiftCalendar) Resolve(t time.Time) (ShiftAssignment, error) {
+	if len(c.Shifts) == 0 {
+		return ShiftAssignment{}, nil
+	}
+
+	workDateStart, index, err := c.locate(t)
+	if err != nil {
+		return ShiftAssignment{}, err
+	}
+	y, m, d := workDateStart.Date()
+	workDate := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+	res := ShiftAssignment{
+		WorkDate: workDate,
+		Shift:    c.Shifts[index].Name,
+	}
+	if len(c.Rotation.Groups) != 0 {
+		since, err := time.Parse(WorkDateLayout, c.Rotation.Since)
+		if err != nil {
+			return ShiftAssignment{}, err
+		}
+		cycle := len(c.Rotation.Groups)
+		days := int(workDate.Sub(since) / day)
+		groups := c.Rotation.Groups[((days%cycle)+cycle)%cycle]
+		if index < len(groups) {
+			res.Group = groups[index]
+		}
+	}
+	date := workDate.Format(WorkDateLayout)
+	for _, holiday := range c.Holidays {
+		if holiday == date {
+			res.Holiday = true
+			break
+		}
+	}
+	return res, nil
+}
+
+// ShiftEnd returns the end time of the shift of the specified time, which is
+// the start time of the next shift. It returns a zero time if there is no
+// shift in the calendar.
+func (c ShiftCalendar) ShiftEnd(t time.Time) (time.Time, error) {
+	if len(c.Shifts) == 0 {
+		return time.Time{}, nil
+	}
+
+	workDateStart, index, err := c.locate(t)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if index+1 < len(c.Shifts) {
+		return workDateStart.Add(shiftOffset(c.Shifts[0].Start, c.Shifts[index+1].Start)), nil
+	}
+	return workDateStart.AddDate(0, 0, 1), nil
+}
+
+// shiftOffset returns the offset of the shift start from the start of the
+// work date.
+func shiftOffset(dayStart, start time.Duration) time.Duration {
+	return ((start-dayStart)%day + day) % day
+}
+
+// IsShiftsInOrder reports whether the shifts are in ascending order of the
+// start time beginning with the first shift of a work date, in a day.
+func IsShiftsInOrder(shifts []ShiftDefinition) bool {
+	for i, shift := range shifts {
+		if shift.Start < 0 || shift.Start >= day {
+			return false
+		}
+		if i > 0 && shiftOffset(shifts[0].Start, shift.Start) <= shiftOffset(shifts[0].Start, shifts[i-1].Start) {
+			return false
+		}
+	}
+	return true
+}
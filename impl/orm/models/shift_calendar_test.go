@@ -0,0 +1,142 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShiftCalendar_Resolve(t *testing.T) {
+	assert := assert.New(t)
+
+	loc, err := time.LoadLocation("Asia/Taipei")
+	if !assert.NoError(err) {
+		return
+	}
+	calendar := ShiftCalendar{
+		TimeZone: "Asia/Taipei",
+		Shifts: ShiftDefinitions{
+			{Name: "A", Start: 7*time.Hour + 30*time.Minute},
+			{Name: "B", Start: 15*time.Hour + 30*time.Minute},
+			{Name: "C", Start: 23*time.Hour + 30*time.Minute},
+		},
+		Rotation: ShiftRotation{
+			Since:  "2022-01-01",
+			Groups: [][]int32{{1, 2, 3}, {4, 1, 2}, {3, 4, 1}, {2, 3, 4}},
+		},
+		Holidays: []string{"2022-01-02"},
+	}
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		time     time.Time
+		expected ShiftAssignment
+	}{
+		{ // the start of the work date.
+			time:     time.Date(2022, 1, 1, 7, 30, 0, 0, loc),
+			expected: ShiftAssignment{WorkDate: date(2022, 1, 1), Shift: "A", Group: 1},
+		},
+		{ // before the start of the work date.
+			time:     time.Date(2022, 1, 1, 7, 29, 0, 0, loc),
+			expected: ShiftAssignment{WorkDate: date(2021, 12, 31), Shift: "C", Group: 4},
+		},
+		{ // the second shift.
+			time:     time.Date(2022, 1, 1, 16, 0, 0, 0, loc),
+			expected: ShiftAssignment{WorkDate: date(2022, 1, 1), Shift: "B", Group: 2},
+		},
+		{ // the night shift across the midnight on a holiday.
+			time:     time.Date(2022, 1, 3, 1, 0, 0, 0, loc),
+			expected: ShiftAssignment{WorkDate: date(2022, 1, 2), Shift: "C", Group: 2, Holiday: true},
+		},
+		{ // in UTC.
+			time:     time.Date(2022, 1, 4, 0, 0, 0, 0, time.UTC),
+			expected: ShiftAssignment{WorkDate: date(2022, 1, 4), Shift: "A", Group: 2},
+		},
+	}
+	for _, tt := range tests {
+		actual, err := calendar.Resolve(tt.time)
+		assert.NoError(err)
+		assert.Equal(tt.expected, actual, tt.time)
+	}
+
+	{ // no shift.
+		actual, err := ShiftCalendar{}.Resolve(time.Now())
+		assert.NoError(err)
+		assert.Equal(ShiftAssignment{}, actual)
+	}
+}
+
+func TestIsShiftsInOrder(t *testing.T) {
+	assert := assert.New(t)
+	assert.True(IsShiftsInOrder([]ShiftDefinition{
+		{Start: 7 * time.Hour},
+		{Start: 15 * time.Hour},
+		{Start: 23 * time.Hour},
+	}))
+	assert.True(IsShiftsInOrder([]ShiftDefinition{
+		{Start: 20 * time.Hour},
+		{Start: 4 * time.Hour},
+		{Start: 12 * time.Hour},
+	}))
+	assert.False(IsShiftsInOrder([]ShiftDefinition{
+		{Start: 7 * time.Hour},
+		{Start: 23 * time.Hour},
+		{Start: 15 * time.Hour},
+	}))
+	assert.False(IsShiftsInOrder([]ShiftDefinition{
+		{Start: 7 * time.Hour},
+		{Start: 7 * time.Hour},
+	}))
+	assert.False(IsShiftsInOrder([]ShiftDefinition{
+		{Start: 24 * time.Hour},
+	}))
+}
+
+func TestShiftCalendar_ShiftEnd(t *testing.T) {
+	assert := assert.New(t)
+
+	loc, err := time.LoadLocation("Asia/Taipei")
+	if !assert.NoError(err) {
+		return
+	}
+	calendar := ShiftCalendar{
+		TimeZone: "Asia/Taipei",
+		Shifts: ShiftDefinitions{
+			{Name: "A", Start: 7*time.Hour + 30*time.Minute},
+			{Name: "B", Start: 15*time.Hour + 30*time.Minute},
+			{Name: "C", Start: 23*time.Hour + 30*time.Minute},
+		},
+	}
+
+	tests := []struct {
+		time     time.Time
+		expected time.Time
+	}{
+		{
+			time:     time.Date(2022, 1, 1, 7, 30, 0, 0, loc),
+			expected: time.Date(2022, 1, 1, 15, 30, 0, 0, loc),
+		},
+		{
+			time:     time.Date(2022, 1, 1, 23, 0, 0, 0, loc),
+			expected: time.Date(2022, 1, 1, 23, 30, 0, 0, loc),
+		},
+		{ // the last shift ends at the start of the next work date.
+			time:     time.Date(2022, 1, 2, 3, 0, 0, 0, loc),
+			expected: time.Date(2022, 1, 2, 7, 30, 0, 0, loc),
+		},
+	}
+	for _, tt := range tests {
+		actual, err := calendar.ShiftEnd(tt.time)
+		assert.NoError(err)
+		assert.True(tt.expected.Equal(actual), "%v: %v", tt.time, actual)
+	}
+
+	{ // no shift.
+		actual, err := ShiftCalendar{}.ShiftEnd(time.Now())
+		assert.NoError(err)
+		assert.True(actual.IsZero())
+	}
+}
@@ -3,12 +3,17 @@ package models
 import (
 	"database/sql/driver"
 	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 
 	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/bindtype"
 	"gitlab.kenda.com.tw/kenda/mcom/utils/resources"
 	"gitlab.kenda.com.tw/kenda/mcom/utils/sites"
 	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
@@ -55,9 +60,222 @@ type SiteAttributes struct {
 	Type    sites.Type    `json:"type"`
 	SubType sites.SubType `json:"sub_type"`
 	// 限制 ProductID 。
-	// mcom 不會在掛載行為檢查這個項目。
+	// 除非 LimitationEnforced 為 true ，否則 mcom 不會在掛載行為檢查這個項目。
 	// 沒有限制的情況下其值將為 empty Slice 。
+	// 每個項目可以是 ProductID 、 path.Match 語法的 pattern 或 ProductGroup 的 ProductID 。
 	Limitation []string `json:"limitation"`
+	// LimitationEnforced indicates whether the materials to bind are checked
+	// with Limitation or not.
+	LimitationEnforced bool `json:"limitation_enforced,omitempty"`
+	// Capacity is the optional limits of the site.
+	Capacity SiteCapacity `json:"capacity"`
+	// FeedPolicy is the optional rules to feed the resources in the site.
+	FeedPolicy SiteFeedPolicy `json:"feed_policy"`
+}
+
+// IsLimitationPattern returns whether the limitation entry is a pattern or not.
+func IsLimitationPattern(entry string) bool {
+	return strings.ContainsAny(entry, `*?[\`)
+}
+
+// MatchLimitation returns whether the product id matches any of the limitation
+// entries or not. An entry is either a product id or a pattern in path.Match
+// syntax. Empty limitation matches all the products.
+func MatchLimitation(limitation []string, productID string) bool {
+	if len(limitation) == 0 {
+		return true
+	}
+	for _, entry := range limitation {
+		if entry == productID {
+			return true
+		}
+		if IsLimitationPattern(entry) {
+			if ok, _ := path.Match(entry, productID); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SiteCapacity definition.
+//
+// A zero value field means unlimited.
+type SiteCapacity struct {
+	// MaxResources is the max count of the resources in the site.
+	MaxResources int `json:"max_resources,omitempty"`
+	// MaxQuantity is the max total quantity of the resources in the site per unit.
+	// The units without a limit are unlimited.
+	MaxQuantity map[string]decimal.Decimal `json:"max_quantity,omitempty"`
+	// MaxQueueLength is the max length of a queue or colqueue site.
+	MaxQueueLength int `json:"max_queue_length,omitempty"`
+}
+
+// IsLimited returns whether the site has any limit or not.
+func (c SiteCapacity) IsLimited() bool {
+	return c.MaxResources != 0 || len(c.MaxQuantity) != 0 || c.MaxQueueLength != 0
+}
+
+// SiteRemainingCapacity is the remaining capacity of a site.
+//
+// A nil field means unlimited.
+type SiteRemainingCapacity struct {
+	Resources   *int
+	Quantity    map[string]decimal.Decimal
+	QueueLength *int
+}
+
+// Remaining returns the remaining capacity of the site with the specified
+// content.
+//
+// units is the unit of the resources in the content, the quantity of the
+// resources without unit will be counted to an empty unit.
+func (c SiteCapacity) Remaining(content SiteContent, units map[UniqueMaterialResource]string) SiteRemainingCapacity {
+	materials := content.Materials()
+
+	var res SiteRemainingCapacity
+	if c.MaxResources != 0 {
+		remaining := c.MaxResources - len(materials)
+		res.Resources = &remaining
+	}
+	if len(c.MaxQuantity) != 0 {
+		res.Quantity = make(map[string]decimal.Decimal, len(c.MaxQuantity))
+		for unit, max := range c.MaxQuantity {
+			res.Quantity[unit] = max
+		}
+		for _, material := range materials {
+			unit := units[UniqueMaterialResource{ResourceID: material.ResourceID, ProductType: material.ProductType}]
+			if max, ok := res.Quantity[unit]; ok && material.Quantity != nil {
+				res.Quantity[unit] = max.Sub(*material.Quantity)
+			}
+		}
+	}
+	if c.MaxQueueLength != 0 {
+		remaining := c.MaxQueueLength - content.QueueLength()
+		res.QueueLength = &remaining
+	}
+	return res
+}
+
+// Check returns Code_STATION_SITE_CAPACITY_EXCEEDED if the content of the
+// site exceeds the capacity.
+//
+// See Remaining for the details of units.
+func (c SiteCapacity) Check(content SiteContent, units map[UniqueMaterialResource]string) error {
+	remaining := c.Remaining(content, units)
+	if remaining.Resources != nil && *remaining.Resources < 0 {
+		return mcomErr.Error{
+			Code:    mcomErr.Code_STATION_SITE_CAPACITY_EXCEEDED,
+			Details: fmt.Sprintf("max resources: %d", c.MaxResources),
+		}
+	}
+	for unit, quantity := range remaining.Quantity {
+		if quantity.IsNegative() {
+			return mcomErr.Error{
+				Code:    mcomErr.Code_STATION_SITE_CAPACITY_EXCEEDED,
+				Details: fmt.Sprintf("max quantity: %s, unit: %s", c.MaxQuantity[unit], unit),
+			}
+		}
+	}
+	if remaining.QueueLength != nil && *remaining.QueueLength < 0 {
+		return mcomErr.Error{
+			Code:    mcomErr.Code_STATION_SITE_CAPACITY_EXCEEDED,
+			Details: fmt.Sprintf("max queue length: %d", c.MaxQueueLength),
+		}
+	}
+	return nil
+}
+
+// FeedOrder is the order to feed the resources in a container, collection,
+// queue or colqueue site. A slot site has only one resource to feed.
+type FeedOrder int8
+
+// FeedOrder enumeration.
+const (
+	// FeedOrderFIFO feeds the resources in the order they are bound. It is
+	// the default order.
+	FeedOrderFIFO FeedOrder = iota
+	// FeedOrderFEFO feeds the resource expiring first. The resources without
+	// expiry time are fed last in the order they are bound.
+	FeedOrderFEFO
+	// FeedOrderLIFO feeds the last bound resource first.
+	FeedOrderLIFO
+	// FeedOrderExplicit requires the resource to feed to be specified.
+	FeedOrderExplicit
+)
+
+// ExpiredResourceAction is how to handle the expired resources while feeding.
+type ExpiredResourceAction int8
+
+// ExpiredResourceAction enumeration.
+const (
+	// ExpiredResourceAllowed feeds the expired resources as the others. It is
+	// the default action.
+	ExpiredResourceAllowed ExpiredResourceAction = iota
+	// ExpiredResourceSkipped leaves the expired resources in the site and
+	// feeds the others.
+	ExpiredResourceSkipped
+	// ExpiredResourceRejected returns Code_RESOURCE_EXPIRED while feeding an
+	// expired resource.
+	ExpiredResourceRejected
+)
+
+// SiteFeedPolicy definition.
+//
+// The zero value feeds the resources in the order they are bound regardless
+// of their expiry time.
+type SiteFeedPolicy struct {
+	Order   FeedOrder             `json:"order,omitempty"`
+	Expired ExpiredResourceAction `json:"expired,omitempty"`
+}
+
+// IsValid reports whether the order and the expiry action are defined.
+func (p SiteFeedPolicy) IsValid() bool {
+	return p.Order >= FeedOrderFIFO && p.Order <= FeedOrderExplicit &&
+		p.Expired >= ExpiredResourceAllowed && p.Expired <= ExpiredResourceRejected
+}
+
+// Check returns Code_RESOURCE_EXPIRED if the material is expired at the
+// specified time and the expired resources are rejected.
+func (p SiteFeedPolicy) Check(material MaterialSite, now time.Time) error {
+	if p.Expired == ExpiredResourceRejected && material.IsExpired(now) {
+		return mcomErr.Error{
+			Code:    mcomErr.Code_RESOURCE_EXPIRED,
+			Details: "resource: " + material.ResourceID,
+		}
+	}
+	return nil
+}
+
+// sequence returns the indices of the materials in the order to feed. The nil
+// materials and the skipped expired materials are excluded.
+func (p SiteFeedPolicy) sequence(materials []*MaterialSite, now time.Time) []int {
+	res := []int{}
+	for i, material := range materials {
+		if material == nil {
+			continue
+		}
+		if p.Expired == ExpiredResourceSkipped && material.IsExpired(now) {
+			continue
+		}
+		res = append(res, i)
+	}
+
+	switch p.Order {
+	case FeedOrderFEFO:
+		sort.SliceStable(res, func(i, j int) bool {
+			a, b := materials[res[i]].ExpiryTime, materials[res[j]].ExpiryTime
+			if a == 0 || b == 0 {
+				return a != 0
+			}
+			return a < b
+		})
+	case FeedOrderLIFO:
+		for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+			res[i], res[j] = res[j], res[i]
+		}
+	}
+	return res
 }
 
 // Scan implements database/sql Scanner interface.
@@ -162,6 +380,201 @@ func (c SiteContent) Value() (driver.Value, error) {
 	return json.Marshal(c)
 }
 
+// Materials returns all the materials in the site.
+func (c SiteContent) Materials() []MaterialSite {
+	var res []MaterialSite
+	appendMaterials := func(resources []BoundResource) {
+		for _, resource := range resources {
+			if resource.Material != nil {
+				res = append(res, *resource.Material)
+			}
+		}
+	}
+
+	switch {
+	case c.Slot != nil:
+		if c.Slot.Material != nil {
+			res = append(res, *c.Slot.Material)
+		}
+	case c.Container != nil:
+		appendMaterials(*c.Container)
+	case c.Collection != nil:
+		appendMaterials(*c.Collection)
+	case c.Queue != nil:
+		for _, slot := range *c.Queue {
+			if slot.Material != nil {
+				res = append(res, *slot.Material)
+			}
+		}
+	case c.Colqueue != nil:
+		for _, collection := range *c.Colqueue {
+			appendMaterials(collection)
+		}
+	}
+	return res
+}
+
+// ReduceQuantity reduces the bound quantities of the specified resource in the
+// site content by up to the specified quantity, from the last bound one, and
+// returns the reduced quantity. The materials are kept in the site even if
+// their quantities are reduced to zero, and the materials without quantity are
+// ignored.
+func (c SiteContent) ReduceQuantity(resource UniqueMaterialResource, quantity decimal.Decimal) decimal.Decimal {
+	var materials []*MaterialSite
+	appendMaterials := func(resources []BoundResource) {
+		for _, resource := range resources {
+			materials = append(materials, resource.Material)
+		}
+	}
+	switch {
+	case c.Slot != nil:
+		materials = append(materials, c.Slot.Material)
+	case c.Container != nil:
+		appendMaterials(*c.Container)
+	case c.Collection != nil:
+		appendMaterials(*c.Collection)
+	case c.Queue != nil:
+		for _, slot := range *c.Queue {
+			materials = append(materials, slot.Material)
+		}
+	case c.Colqueue != nil:
+		for _, collection := range *c.Colqueue {
+			appendMaterials(collection)
+		}
+	}
+
+	reduced := decimal.Zero
+	for i := len(materials) - 1; i >= 0 && reduced.LessThan(quantity); i-- {
+		material := materials[i]
+		if material == nil || material.Quantity == nil ||
+			material.ResourceID != resource.ResourceID || material.ProductType != resource.ProductType {
+			continue
+		}
+		r := decimal.Min(*material.Quantity, quantity.Sub(reduced))
+		if !r.IsPositive() {
+			continue
+		}
+		material.Quantity = types.Decimal.NewFromDecimal(material.Quantity.Sub(r))
+		reduced = reduced.Add(r)
+	}
+	return reduced
+}
+
+// QueueLength returns the length of a queue or colqueue site.
+// It returns 0 for other types of sites.
+func (c SiteContent) QueueLength() int {
+	switch {
+	case c.Queue != nil:
+		return len(*c.Queue)
+	case c.Colqueue != nil:
+		return len(*c.Colqueue)
+	}
+	return 0
+}
+
+// Transfer moves the selected materials of the site content to the target
+// site content and returns the moved resources. The both site contents must
+// be the same type of site.
+//
+// The moved elements of a queue or a colqueue are pushed to the tail of the
+// target in order. A slot could only be transferred to an empty slot.
+func (c SiteContent) Transfer(target SiteContent, selected func(MaterialSite) bool) ([]BoundResource, error) {
+	isSelected := func(resource BoundResource) bool {
+		return resource.Material != nil && selected(*resource.Material)
+	}
+	split := func(resources []BoundResource) (moved, kept []BoundResource) {
+		moved, kept = []BoundResource{}, []BoundResource{}
+		for _, resource := range resources {
+			if isSelected(resource) {
+				moved = append(moved, resource)
+			} else {
+				kept = append(kept, resource)
+			}
+		}
+		return
+	}
+	typeMismatch := mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "site type mismatch"}
+
+	switch {
+	case c.Slot != nil:
+		if target.Slot == nil {
+			return nil, typeMismatch
+		}
+		if !isSelected(BoundResource(*c.Slot)) {
+			return []BoundResource{}, nil
+		}
+		if target.Slot.Material != nil {
+			return nil, mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "the target slot is not empty"}
+		}
+		moved := c.Slot.Clear()
+		target.Slot.Bind(moved)
+		return []BoundResource{moved}, nil
+	case c.Container != nil:
+		if target.Container == nil {
+			return nil, typeMismatch
+		}
+		moved, kept := split(*c.Container)
+		*c.Container = kept
+		target.Container.Add(moved)
+		return moved, nil
+	case c.Collection != nil:
+		if target.Collection == nil {
+			return nil, typeMismatch
+		}
+		moved, kept := split(*c.Collection)
+		*c.Collection = kept
+		target.Collection.Add(moved)
+		return moved, nil
+	case c.Queue != nil:
+		if target.Queue == nil {
+			return nil, typeMismatch
+		}
+		moved, kept := []BoundResource{}, Queue{}
+		for _, slot := range *c.Queue {
+			if isSelected(BoundResource(slot)) {
+				moved = append(moved, BoundResource(slot))
+				target.Queue.Push(BoundResource(slot))
+			} else {
+				kept = append(kept, slot)
+			}
+		}
+		*c.Queue = kept
+		return moved, nil
+	case c.Colqueue != nil:
+		if target.Colqueue == nil {
+			return nil, typeMismatch
+		}
+		moved, kept := []BoundResource{}, Colqueue{}
+		for _, collection := range *c.Colqueue {
+			m, k := split(collection)
+			if len(m) != 0 {
+				moved = append(moved, m...)
+				target.Colqueue.Push(m)
+			}
+			// the collection is removed if all of the resources are moved.
+			if len(k) != 0 || len(m) == 0 {
+				kept = append(kept, k)
+			}
+		}
+		*c.Colqueue = kept
+		return moved, nil
+	}
+	return nil, typeMismatch
+}
+
+// Copy returns a deep copy of the site content.
+func (c SiteContent) Copy() (SiteContent, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return SiteContent{}, err
+	}
+	var res SiteContent
+	if err := json.Unmarshal(b, &res); err != nil {
+		return SiteContent{}, err
+	}
+	return res, nil
+}
+
 // OperatorSite definition.
 type OperatorSite struct {
 	EmployeeID string    `json:"employee_id"`
@@ -203,6 +616,12 @@ type MaterialSite struct {
 	ExpiryTime types.TimeNano `json:"expiry_time"`
 }
 
+// IsExpired reports whether the material is expired at the specified time.
+// A material without expiry time never expires.
+func (m MaterialSite) IsExpired(t time.Time) bool {
+	return m.ExpiryTime != 0 && !t.Before(m.ExpiryTime.Time())
+}
+
 // Material definition.
 type Material struct {
 	ID    string `json:"id"`
@@ -242,37 +661,36 @@ func (container *Container) Bind(resources []BoundResource) []BoundResource {
 // Feed feeds the specified quantity from the site and returns fed materials.
 // If the material is NOT enough to feed, the returned value shortage is true.
 func (container *Container) Feed(quantity decimal.Decimal) (materials []FedMaterial, shortage bool) {
-	if len(*container) == 0 {
-		return []FedMaterial{}, true
+	materials, shortage, _ = container.FeedByPolicy(quantity, SiteFeedPolicy{}, time.Now())
+	return materials, shortage
+}
+
+// FeedByPolicy feeds the specified quantity from the site in the order of the
+// policy and returns fed materials. If the material is NOT enough to feed, the
+// returned value shortage is true.
+func (container *Container) FeedByPolicy(quantity decimal.Decimal, policy SiteFeedPolicy, now time.Time) (materials []FedMaterial, shortage bool, err error) {
+	sequence := policy.sequence(container.materials(), now)
+	if len(sequence) == 0 {
+		return []FedMaterial{}, true, nil
 	}
 
-	var counter int
-	for i, resource := range *container {
+	consumed := make(map[int]bool, len(sequence))
+	for _, i := range sequence {
+		resource := (*container)[i]
+		if err := policy.Check(*resource.Material, now); err != nil {
+			return nil, false, err
+		}
 		if resource.Material.Quantity.GreaterThan(quantity) {
 			(*container)[i].Material.Quantity = types.Decimal.NewFromDecimal(resource.Material.Quantity.Sub(quantity))
-			materials = append(materials, FedMaterial{
-				Material:    resource.Material.Material,
-				ResourceID:  resource.Material.ResourceID,
-				ProductType: resource.Material.ProductType,
-				Status:      resource.Material.Status,
-				ExpiryTime:  resource.Material.ExpiryTime.Time(),
-				Quantity:    quantity,
-			})
+			materials = append(materials, newFedMaterial(*resource.Material, quantity))
 			quantity = decimal.Zero
 			break
 		}
 		quantity = quantity.Sub(*resource.Material.Quantity)
-		materials = append(materials, FedMaterial{
-			Material:    resource.Material.Material,
-			ResourceID:  resource.Material.ResourceID,
-			ProductType: resource.Material.ProductType,
-			Status:      resource.Material.Status,
-			ExpiryTime:  resource.Material.ExpiryTime.Time(),
-			Quantity:    *resource.Material.Quantity,
-		})
-		counter++
+		materials = append(materials, newFedMaterial(*resource.Material, *resource.Material.Quantity))
+		consumed[i] = true
 	}
-	*container = (*container)[counter:]
+	container.remove(consumed)
 
 	if quantity.GreaterThan(decimal.Zero) {
 		// ! 投入數量多於剩餘量
@@ -280,25 +698,62 @@ func (container *Container) Feed(quantity decimal.Decimal) (materials []FedMater
 		shortage = true
 	}
 
-	return materials, shortage
+	return materials, shortage, nil
 }
 
 func (container *Container) FeedAll() []FedMaterial {
-	records := make([]FedMaterial, len(*container))
-	for i, res := range *container {
-		records[i] = FedMaterial{
-			Material:    res.Material.Material,
-			ResourceID:  res.Material.ResourceID,
-			ProductType: res.Material.ProductType,
-			Status:      res.Material.Status,
-			ExpiryTime:  res.Material.ExpiryTime.Time(),
-			Quantity:    *res.Material.Quantity,
-		}
-	}
-	container.Clear()
+	records, _ := container.FeedAllByPolicy(SiteFeedPolicy{}, time.Now())
 	return records
 }
 
+// FeedAllByPolicy feeds all the materials in the site in the order of the
+// policy. The skipped expired materials are left in the site.
+func (container *Container) FeedAllByPolicy(policy SiteFeedPolicy, now time.Time) ([]FedMaterial, error) {
+	sequence := policy.sequence(container.materials(), now)
+	records := make([]FedMaterial, len(sequence))
+	consumed := make(map[int]bool, len(sequence))
+	for i, index := range sequence {
+		material := *(*container)[index].Material
+		if err := policy.Check(material, now); err != nil {
+			return nil, err
+		}
+		records[i] = newFedMaterial(material, *material.Quantity)
+		consumed[index] = true
+	}
+	container.remove(consumed)
+	return records, nil
+}
+
+func (container Container) materials() []*MaterialSite {
+	res := make([]*MaterialSite, len(container))
+	for i, resource := range container {
+		res[i] = resource.Material
+	}
+	return res
+}
+
+// remove removes the resources at the specified indices.
+func (container *Container) remove(indices map[int]bool) {
+	res := Container{}
+	for i, resource := range *container {
+		if !indices[i] {
+			res = append(res, resource)
+		}
+	}
+	*container = res
+}
+
+func newFedMaterial(material MaterialSite, quantity decimal.Decimal) FedMaterial {
+	return FedMaterial{
+		Material:    material.Material,
+		ResourceID:  material.ResourceID,
+		ProductType: material.ProductType,
+		Status:      material.Status,
+		ExpiryTime:  material.ExpiryTime.Time(),
+		Quantity:    quantity,
+	}
+}
+
 type FedMaterial struct {
 	Material    Material
 	ResourceID  string
@@ -378,6 +833,31 @@ func (slot *Slot) FeedAll() []FedMaterial {
 	return materials
 }
 
+// FeedByPolicy feeds the specified quantity from the site as Feed does. The
+// skipped expired material is left in the site as if the site is empty.
+func (slot *Slot) FeedByPolicy(quantity decimal.Decimal, policy SiteFeedPolicy, now time.Time) (materials []FedMaterial, shortage bool, reduceQuantityFromWarehouse bool, err error) {
+	if len(policy.sequence([]*MaterialSite{slot.Material}, now)) == 0 {
+		return []FedMaterial{}, true, false, nil
+	}
+	if err := policy.Check(*slot.Material, now); err != nil {
+		return nil, false, false, err
+	}
+	materials, shortage, reduceQuantityFromWarehouse = slot.Feed(quantity)
+	return materials, shortage, reduceQuantityFromWarehouse, nil
+}
+
+// FeedAllByPolicy feeds all the material in the site as FeedAll does. The
+// skipped expired material is left in the site.
+func (slot *Slot) FeedAllByPolicy(policy SiteFeedPolicy, now time.Time) ([]FedMaterial, error) {
+	if len(policy.sequence([]*MaterialSite{slot.Material}, now)) == 0 {
+		return []FedMaterial{}, nil
+	}
+	if err := policy.Check(*slot.Material, now); err != nil {
+		return nil, err
+	}
+	return slot.FeedAll(), nil
+}
+
 // Collection is the site for collection.
 type Collection []BoundResource
 
@@ -413,6 +893,18 @@ func (collection *Collection) FeedAll() []FedMaterial {
 	return res
 }
 
+// FeedAllByPolicy feeds all the materials in the site in the order of the
+// policy. The skipped expired materials are left in the site.
+func (collection *Collection) FeedAllByPolicy(policy SiteFeedPolicy, now time.Time) ([]FedMaterial, error) {
+	container := Container(*collection)
+	records, err := container.FeedAllByPolicy(policy, now)
+	if err != nil {
+		return nil, err
+	}
+	*collection = Collection(container)
+	return records, nil
+}
+
 // Queue is the site for queue.
 type Queue []Slot
 
@@ -483,17 +975,20 @@ func (queue *Queue) maybeExtend(maxIndex uint16) {
 // in the site.
 // If reduceQuantityFromWarehouse is true, than the shortage field will be false.
 func (queue *Queue) Feed(quantity decimal.Decimal) (records []FedMaterial, shortage bool, reduceQuantityFromWarehouse bool, err error) {
-	for {
-		if len(*queue) == 0 {
-			return nil, false, false, mcomErr.Error{Code: mcomErr.Code_RESOURCE_MATERIAL_SHORTAGE}
-		}
-		if (*queue)[0].Material != nil {
-			break
-		}
-		queue.Pop()
+	return queue.FeedByPolicy(quantity, SiteFeedPolicy{}, time.Now())
+}
+
+// FeedByPolicy feeds materials at the first index in the order of the policy.
+// It returns Code_RESOURCE_MATERIAL_SHORTAGE if there is no material to feed
+// in the site.
+// If reduceQuantityFromWarehouse is true, than the shortage field will be false.
+func (queue *Queue) FeedByPolicy(quantity decimal.Decimal, policy SiteFeedPolicy, now time.Time) (records []FedMaterial, shortage bool, reduceQuantityFromWarehouse bool, err error) {
+	index, err := queue.next(policy, now)
+	if err != nil {
+		return nil, false, false, err
 	}
-	rec, shortage, reduceQuantityFromWarehouse := (*queue)[0].Feed(quantity)
-	if shortage {
+	rec, shortage, reduceQuantityFromWarehouse := (*queue)[index].Feed(quantity)
+	if shortage && index == 0 {
 		queue.Pop()
 	}
 	return rec, shortage, reduceQuantityFromWarehouse, nil
@@ -503,20 +998,48 @@ func (queue *Queue) Feed(quantity decimal.Decimal) (records []FedMaterial, short
 // It ONLY returns Code_RESOURCE_MATERIAL_SHORTAGE if there is no material
 // in the site.
 func (queue *Queue) FeedAll() ([]FedMaterial, error) {
+	return queue.FeedAllByPolicy(SiteFeedPolicy{}, time.Now())
+}
+
+// FeedAllByPolicy feeds all materials at the first index in the order of the
+// policy. It returns Code_RESOURCE_MATERIAL_SHORTAGE if there is no material
+// to feed in the site.
+func (queue *Queue) FeedAllByPolicy(policy SiteFeedPolicy, now time.Time) ([]FedMaterial, error) {
+	index, err := queue.next(policy, now)
+	if err != nil {
+		return nil, err
+	}
+
 	var slot Slot
-	for {
-		if len(*queue) == 0 {
-			return nil, mcomErr.Error{Code: mcomErr.Code_RESOURCE_MATERIAL_SHORTAGE}
-		}
+	if index == 0 {
 		slot = Slot(queue.Pop())
-		if slot.Material != nil {
-			break
-		}
+	} else {
+		slot = Slot((*queue)[index].Clear())
 	}
-
 	return slot.FeedAll(), nil
 }
 
+// next removes the empty slots at the head of the queue and returns the index
+// of the next slot to feed.
+func (queue *Queue) next(policy SiteFeedPolicy, now time.Time) (int, error) {
+	for len(*queue) != 0 && (*queue)[0].Material == nil {
+		queue.Pop()
+	}
+
+	materials := make([]*MaterialSite, len(*queue))
+	for i, slot := range *queue {
+		materials[i] = slot.Material
+	}
+	sequence := policy.sequence(materials, now)
+	if len(sequence) == 0 {
+		return 0, mcomErr.Error{Code: mcomErr.Code_RESOURCE_MATERIAL_SHORTAGE}
+	}
+	if err := policy.Check(*materials[sequence[0]], now); err != nil {
+		return 0, err
+	}
+	return sequence[0], nil
+}
+
 // Colqueue is the site for colqueue.
 type Colqueue []Collection
 
@@ -590,6 +1113,33 @@ func (colqueue *Colqueue) FeedAll() ([]FedMaterial, error) {
 	return collection.FeedAll(), nil
 }
 
+// FeedAllByPolicy feeds all the materials of the first collection which has
+// materials to feed in the order of the policy. The skipped expired materials
+// are left in the collection, and the collection is removed from the head of
+// the site once it is empty.
+// It returns Code_RESOURCE_MATERIAL_SHORTAGE if there is no material to feed
+// in the site.
+func (colqueue *Colqueue) FeedAllByPolicy(policy SiteFeedPolicy, now time.Time) ([]FedMaterial, error) {
+	for len(*colqueue) != 0 && len((*colqueue)[0]) == 0 {
+		colqueue.Pop()
+	}
+
+	for i := range *colqueue {
+		if len(policy.sequence(Container((*colqueue)[i]).materials(), now)) == 0 {
+			continue
+		}
+		records, err := (*colqueue)[i].FeedAllByPolicy(policy, now)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 && len((*colqueue)[0]) == 0 {
+			colqueue.Pop()
+		}
+		return records, nil
+	}
+	return nil, mcomErr.Error{Code: mcomErr.Code_RESOURCE_MATERIAL_SHORTAGE}
+}
+
 func (colqueue *Colqueue) maybeExtend(maxIndex uint16) {
 	if len(*colqueue)-1 < int(maxIndex) {
 		newColqueue := make(Colqueue, maxIndex+1)
@@ -671,3 +1221,46 @@ func (brs BindRecordsSet) Contains(umr UniqueMaterialResource) bool {
 func (BindRecordsSet) parseSetKey(umr UniqueMaterialResource) string {
 	return umr.ResourceID + " " + umr.ProductType
 }
+
+// SiteBindHistory is the event log of the binding actions in a site.
+type SiteBindHistory struct {
+	// ID is a serial number, it is automatically generated when creating.
+	ID int64 `gorm:"type:bigserial;primaryKey"`
+
+	// Station is relative to Site.Station.
+	Station string `gorm:"type:varchar(32);not null;index:idx_site_bind_history"`
+	// SiteName is relative to Site.Name.
+	SiteName string `gorm:"type:varchar(16);not null;index:idx_site_bind_history"`
+	// SiteIndex is relative to Site.Index.
+	SiteIndex int16 `gorm:"not null;index:idx_site_bind_history"`
+
+	BindType bindtype.BindType `gorm:"not null"`
+	// Resources are the resources in the bind request.
+	Resources BindHistoryResources `gorm:"type:jsonb;default:'[]';not null"`
+	// Before is the content of the site before binding.
+	Before SiteContent `gorm:"type:jsonb;default:'{}';not null"`
+	// After is the content of the site after binding.
+	After SiteContent `gorm:"type:jsonb;default:'{}';not null"`
+
+	// CreatedAt the number of nanoseconds elapsed since January 1, 1970 UTC.
+	CreatedAt types.TimeNano `gorm:"autoCreateTime:nano;not null;index:idx_site_bind_history"`
+	CreatedBy string         `gorm:"type:text;not null"`
+}
+
+// TableName implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (SiteBindHistory) TableName() string {
+	return "site_bind_history"
+}
+
+// BindHistoryResources definition.
+type BindHistoryResources []MaterialSite
+
+// Scan implements database/sql Scanner interface.
+func (r *BindHistoryResources) Scan(src interface{}) error {
+	return ScanJSON(src, r)
+}
+
+// Value implements database/sql/driver Valuer interface.
+func (r BindHistoryResources) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
@@ -1791,6 +1791,187 @@ func TestQueue_Feed(t *testing.T) {
 	}
 }
 
+func TestSiteFeedPolicy(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Unix(0, 100)
+	testBoundResources := testBoundResources{}
+	withExpiry := func(resource BoundResource, expiry types.TimeNano) BoundResource {
+		resource.Material.ExpiryTime = expiry
+		return resource
+	}
+	// A expires at 50, B never expires and C expires at 200.
+	newResources := func() []BoundResource {
+		return []BoundResource{
+			withExpiry(testBoundResources.resourceA(10), 50),
+			testBoundResources.resourceB(10),
+			withExpiry(testBoundResources.resourceC(10), 200),
+		}
+	}
+	resourceIDs := func(records []FedMaterial) []string {
+		res := make([]string, len(records))
+		for i, record := range records {
+			res[i] = record.ResourceID
+		}
+		return res
+	}
+
+	{ // invalid policy.
+		assert.True(SiteFeedPolicy{}.IsValid())
+		assert.False(SiteFeedPolicy{Order: FeedOrderExplicit + 1}.IsValid())
+		assert.False(SiteFeedPolicy{Expired: ExpiredResourceRejected + 1}.IsValid())
+	}
+	{ // container FEFO.
+		container := NewContainerSiteContent().Container
+		container.Add(newResources())
+
+		records, shortage, err := container.FeedByPolicy(decimal.NewFromInt(15), SiteFeedPolicy{Order: FeedOrderFEFO}, now)
+		assert.NoError(err)
+		assert.False(shortage)
+		assert.Equal([]string{"A", "C"}, resourceIDs(records))
+		assert.Equal(&Container{
+			testBoundResources.resourceB(10),
+			withExpiry(testBoundResources.resourceC(5), 200),
+		}, container)
+	}
+	{ // container LIFO.
+		container := NewContainerSiteContent().Container
+		container.Add(newResources())
+
+		records, shortage, err := container.FeedByPolicy(decimal.NewFromInt(35), SiteFeedPolicy{Order: FeedOrderLIFO}, now)
+		assert.NoError(err)
+		assert.True(shortage)
+		assert.Equal([]string{"C", "B", "A"}, resourceIDs(records))
+		assert.True(decimal.NewFromInt(15).Equal(records[2].Quantity))
+		assert.Equal(&Container{}, container)
+	}
+	{ // container skips the expired resources.
+		container := NewContainerSiteContent().Container
+		container.Add(newResources())
+
+		records, err := container.FeedAllByPolicy(SiteFeedPolicy{Expired: ExpiredResourceSkipped}, now)
+		assert.NoError(err)
+		assert.Equal([]string{"B", "C"}, resourceIDs(records))
+		assert.Equal(&Container{withExpiry(testBoundResources.resourceA(10), 50)}, container)
+	}
+	{ // container rejects the expired resources.
+		container := NewContainerSiteContent().Container
+		container.Add(newResources())
+
+		_, _, err := container.FeedByPolicy(decimal.NewFromInt(5), SiteFeedPolicy{Expired: ExpiredResourceRejected}, now)
+		assert.ErrorIs(err, mcomErr.Error{Code: mcomErr.Code_RESOURCE_EXPIRED, Details: "resource: A"})
+
+		_, err = container.FeedAllByPolicy(SiteFeedPolicy{Expired: ExpiredResourceRejected}, now)
+		assert.ErrorIs(err, mcomErr.Error{Code: mcomErr.Code_RESOURCE_EXPIRED, Details: "resource: A"})
+	}
+	{ // queue FEFO and skips the expired resources.
+		queue := NewQueueSiteContent().Queue
+		for _, resource := range newResources() {
+			queue.Push(resource)
+		}
+		policy := SiteFeedPolicy{Order: FeedOrderFEFO, Expired: ExpiredResourceSkipped}
+
+		records, err := queue.FeedAllByPolicy(policy, now)
+		assert.NoError(err)
+		assert.Equal([]string{"C"}, resourceIDs(records))
+		assert.Equal(&Queue{
+			Slot(withExpiry(testBoundResources.resourceA(10), 50)),
+			Slot(testBoundResources.resourceB(10)),
+			{},
+		}, queue)
+
+		records, _, _, err = queue.FeedByPolicy(decimal.NewFromInt(4), policy, now)
+		assert.NoError(err)
+		assert.Equal([]string{"B"}, resourceIDs(records))
+
+		queue.Remove(1)
+		_, err = queue.FeedAllByPolicy(policy, now)
+		assert.ErrorIs(err, mcomErr.Error{Code: mcomErr.Code_RESOURCE_MATERIAL_SHORTAGE})
+	}
+	{ // queue rejects the expired resources.
+		queue := NewQueueSiteContent().Queue
+		for _, resource := range newResources() {
+			queue.Push(resource)
+		}
+
+		_, _, _, err := queue.FeedByPolicy(decimal.NewFromInt(5), SiteFeedPolicy{Expired: ExpiredResourceRejected}, now)
+		assert.ErrorIs(err, mcomErr.Error{Code: mcomErr.Code_RESOURCE_EXPIRED, Details: "resource: A"})
+	}
+	{ // slot skips the expired resource.
+		slot := Slot(withExpiry(testBoundResources.resourceA(10), 50))
+		policy := SiteFeedPolicy{Expired: ExpiredResourceSkipped}
+
+		records, shortage, _, err := slot.FeedByPolicy(decimal.NewFromInt(5), policy, now)
+		assert.NoError(err)
+		assert.True(shortage)
+		assert.Empty(records)
+
+		records, err = slot.FeedAllByPolicy(policy, now)
+		assert.NoError(err)
+		assert.Empty(records)
+		assert.Equal(Slot(withExpiry(testBoundResources.resourceA(10), 50)), slot)
+	}
+	{ // slot rejects the expired resource.
+		slot := Slot(withExpiry(testBoundResources.resourceA(10), 50))
+		policy := SiteFeedPolicy{Expired: ExpiredResourceRejected}
+
+		_, _, _, err := slot.FeedByPolicy(decimal.NewFromInt(5), policy, now)
+		assert.ErrorIs(err, mcomErr.Error{Code: mcomErr.Code_RESOURCE_EXPIRED, Details: "resource: A"})
+		_, err = slot.FeedAllByPolicy(policy, now)
+		assert.ErrorIs(err, mcomErr.Error{Code: mcomErr.Code_RESOURCE_EXPIRED, Details: "resource: A"})
+
+		// not expired yet.
+		slot = Slot(withExpiry(testBoundResources.resourceC(10), 200))
+		records, shortage, _, err := slot.FeedByPolicy(decimal.NewFromInt(5), policy, now)
+		assert.NoError(err)
+		assert.False(shortage)
+		assert.Equal([]string{"C"}, resourceIDs(records))
+	}
+	{ // collection FEFO and skips the expired resources.
+		collection := Collection(newResources())
+
+		records, err := collection.FeedAllByPolicy(SiteFeedPolicy{Order: FeedOrderFEFO, Expired: ExpiredResourceSkipped}, now)
+		assert.NoError(err)
+		assert.Equal([]string{"C", "B"}, resourceIDs(records))
+		assert.Equal(Collection{withExpiry(testBoundResources.resourceA(10), 50)}, collection)
+	}
+	{ // collection rejects the expired resources.
+		collection := Collection(newResources())
+
+		_, err := collection.FeedAllByPolicy(SiteFeedPolicy{Expired: ExpiredResourceRejected}, now)
+		assert.ErrorIs(err, mcomErr.Error{Code: mcomErr.Code_RESOURCE_EXPIRED, Details: "resource: A"})
+		assert.Len(collection, 3)
+	}
+	{ // colqueue skips the expired resources.
+		colqueue := Colqueue{
+			{withExpiry(testBoundResources.resourceA(10), 50)},
+			{testBoundResources.resourceB(10), withExpiry(testBoundResources.resourceC(10), 200)},
+		}
+		policy := SiteFeedPolicy{Expired: ExpiredResourceSkipped}
+
+		records, err := colqueue.FeedAllByPolicy(policy, now)
+		assert.NoError(err)
+		assert.Equal([]string{"B", "C"}, resourceIDs(records))
+		assert.Equal(Colqueue{{withExpiry(testBoundResources.resourceA(10), 50)}, {}}, colqueue)
+
+		_, err = colqueue.FeedAllByPolicy(policy, now)
+		assert.ErrorIs(err, mcomErr.Error{Code: mcomErr.Code_RESOURCE_MATERIAL_SHORTAGE})
+	}
+	{ // colqueue rejects the expired resources.
+		colqueue := Colqueue{Collection(newResources())}
+
+		_, err := colqueue.FeedAllByPolicy(SiteFeedPolicy{Expired: ExpiredResourceRejected}, now)
+		assert.ErrorIs(err, mcomErr.Error{Code: mcomErr.Code_RESOURCE_EXPIRED, Details: "resource: A"})
+	}
+	{ // colqueue pops the fed collection.
+		colqueue := Colqueue{{}, {testBoundResources.resourceB(10)}, {testBoundResources.resourceC(10)}}
+
+		records, err := colqueue.FeedAllByPolicy(SiteFeedPolicy{}, now)
+		assert.NoError(err)
+		assert.Equal([]string{"B"}, resourceIDs(records))
+		assert.Equal(Colqueue{{testBoundResources.resourceC(10)}}, colqueue)
+	}
+}
+
 func Test_BindRecordsSet(t *testing.T) {
 	assert := assert.New(t)
 	var brs BindRecordsSet
@@ -1927,3 +2108,193 @@ func TestSiteContents_AfterFind(t *testing.T) {
 		assert.Equal(expected, contents)
 	}
 }
+
+func TestSiteCapacity(t *testing.T) {
+	assert := assert.New(t)
+
+	material := func(id string, quantity int64) BoundResource {
+		return BoundResource{Material: &MaterialSite{
+			Quantity:    types.Decimal.NewFromInt64(quantity),
+			ResourceID:  id,
+			ProductType: "T",
+		}}
+	}
+	units := map[UniqueMaterialResource]string{
+		{ResourceID: "A", ProductType: "T"}: "KG",
+		{ResourceID: "B", ProductType: "T"}: "KG",
+		{ResourceID: "C", ProductType: "T"}: "PCS",
+	}
+	content := SiteContent{Queue: &Queue{
+		{Material: material("A", 10).Material},
+		{Material: material("B", 20).Material},
+		{Material: material("C", 5).Material},
+	}}
+	assert.Len(content.Materials(), 3)
+	assert.Equal(3, content.QueueLength())
+
+	{ // unlimited.
+		capacity := SiteCapacity{}
+		assert.False(capacity.IsLimited())
+		assert.Equal(SiteRemainingCapacity{}, capacity.Remaining(content, units))
+		assert.NoError(capacity.Check(content, units))
+	}
+	{ // within the limits.
+		capacity := SiteCapacity{
+			MaxResources:   4,
+			MaxQuantity:    map[string]decimal.Decimal{"KG": decimal.NewFromInt(50)},
+			MaxQueueLength: 3,
+		}
+		assert.True(capacity.IsLimited())
+
+		remaining := capacity.Remaining(content, units)
+		if assert.NotNil(remaining.Resources) {
+			assert.Equal(1, *remaining.Resources)
+		}
+		if assert.NotNil(remaining.QueueLength) {
+			assert.Equal(0, *remaining.QueueLength)
+		}
+		assert.Len(remaining.Quantity, 1)
+		assert.True(decimal.NewFromInt(20).Equal(remaining.Quantity["KG"]))
+		assert.NoError(capacity.Check(content, units))
+	}
+	{ // max resources exceeded.
+		assert.ErrorIs(SiteCapacity{MaxResources: 2}.Check(content, units), mcomErr.Error{
+			Code:    mcomErr.Code_STATION_SITE_CAPACITY_EXCEEDED,
+			Details: "max resources: 2",
+		})
+	}
+	{ // max quantity exceeded.
+		assert.ErrorIs(SiteCapacity{
+			MaxQuantity: map[string]decimal.Decimal{"KG": decimal.NewFromInt(29)},
+		}.Check(content, units), mcomErr.Error{
+			Code:    mcomErr.Code_STATION_SITE_CAPACITY_EXCEEDED,
+			Details: "max quantity: 29, unit: KG",
+		})
+	}
+	{ // max queue length exceeded.
+		assert.ErrorIs(SiteCapacity{MaxQueueLength: 2}.Check(content, units), mcomErr.Error{
+			Code:    mcomErr.Code_STATION_SITE_CAPACITY_EXCEEDED,
+			Details: "max queue length: 2",
+		})
+	}
+	{ // other types of sites.
+		container := SiteContent{Container: &Container{material("A", 10), material("B", 20)}}
+		assert.Len(container.Materials(), 2)
+		assert.Equal(0, container.QueueLength())
+	}
+}
+
+func TestMatchLimitation(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(MatchLimitation(nil, "any"))
+	assert.True(MatchLimitation([]string{}, "any"))
+
+	limitation := []string{"APPLE", "PEN-*", "C?"}
+	assert.True(MatchLimitation(limitation, "APPLE"))
+	assert.True(MatchLimitation(limitation, "PEN-1"))
+	assert.True(MatchLimitation(limitation, "CC"))
+	assert.False(MatchLimitation(limitation, "APPLE PEN"))
+	assert.False(MatchLimitation(limitation, "CCC"))
+
+	assert.True(IsLimitationPattern("PEN-*"))
+	assert.False(IsLimitationPattern("APPLE"))
+}
+
+func TestSiteContent_Transfer(t *testing.T) {
+	assert := assert.New(t)
+
+	material := func(id string) BoundResource {
+		return BoundResource{Material: &MaterialSite{
+			Quantity:   types.Decimal.NewFromInt32(10),
+			ResourceID: id,
+		}}
+	}
+	all := func(MaterialSite) bool { return true }
+	only := func(id string) func(MaterialSite) bool {
+		return func(m MaterialSite) bool { return m.ResourceID == id }
+	}
+
+	{ // type mismatch.
+		_, err := SiteContent{Container: &Container{}}.Transfer(SiteContent{Slot: &Slot{}}, all)
+		assert.ErrorIs(err, mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "site type mismatch"})
+	}
+	{ // container.
+		from := SiteContent{Container: &Container{material("A"), material("B")}}
+		to := SiteContent{Container: &Container{material("C")}}
+		moved, err := from.Transfer(to, only("A"))
+		assert.NoError(err)
+		assert.Equal([]BoundResource{material("A")}, moved)
+		assert.Equal(Container{material("B")}, *from.Container)
+		assert.Equal(Container{material("C"), material("A")}, *to.Container)
+	}
+	{ // slot.
+		from := SiteContent{Slot: &Slot{Material: material("A").Material}}
+		to := SiteContent{Slot: &Slot{Material: material("B").Material}}
+		_, err := from.Transfer(to, all)
+		assert.ErrorIs(err, mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "the target slot is not empty"})
+
+		to = SiteContent{Slot: &Slot{}}
+		moved, err := from.Transfer(to, all)
+		assert.NoError(err)
+		assert.Equal([]BoundResource{material("A")}, moved)
+		assert.Nil(from.Slot.Material)
+		assert.Equal(material("A").Material, to.Slot.Material)
+	}
+	{ // queue.
+		from := SiteContent{Queue: &Queue{Slot(material("A")), Slot(material("B"))}}
+		to := SiteContent{Queue: &Queue{Slot(material("C"))}}
+		moved, err := from.Transfer(to, all)
+		assert.NoError(err)
+		assert.Len(moved, 2)
+		assert.Equal(Queue{}, *from.Queue)
+		assert.Equal(Queue{Slot(material("C")), Slot(material("A")), Slot(material("B"))}, *to.Queue)
+	}
+	{ // colqueue.
+		from := SiteContent{Colqueue: &Colqueue{{material("A"), material("B")}, {material("C")}}}
+		to := SiteContent{Colqueue: &Colqueue{}}
+		moved, err := from.Transfer(to, func(m MaterialSite) bool { return m.ResourceID != "B" })
+		assert.NoError(err)
+		assert.Len(moved, 2)
+		assert.Equal(Colqueue{{material("B")}}, *from.Colqueue)
+		assert.Equal(Colqueue{{material("A")}, {material("C")}}, *to.Colqueue)
+	}
+}
+
+func TestSiteContent_ReduceQuantity(t *testing.T) {
+	assert := assert.New(t)
+	testBoundResources := testBoundResources{}
+	resourceA := UniqueMaterialResource{ResourceID: "A", ProductType: "A"}
+	quantities := func(content SiteContent) []string {
+		var res []string
+		for _, material := range content.Materials() {
+			res = append(res, material.ResourceID+":"+material.Quantity.String())
+		}
+		return res
+	}
+
+	{ // container, from the last bound one.
+		content := SiteContent{Container: &Container{
+			testBoundResources.resourceA(10),
+			testBoundResources.resourceB(10),
+			testBoundResources.resourceA(4),
+		}}
+		assert.True(decimal.NewFromInt(6).Equal(content.ReduceQuantity(resourceA, decimal.NewFromInt(6))))
+		assert.Equal([]string{"A:8", "B:10", "A:0"}, quantities(content))
+	}
+	{ // slot, reduced up to the bound quantity.
+		slot := Slot(testBoundResources.resourceA(3))
+		content := SiteContent{Slot: &slot}
+		assert.True(decimal.NewFromInt(3).Equal(content.ReduceQuantity(resourceA, decimal.NewFromInt(5))))
+		assert.Equal([]string{"A:0"}, quantities(content))
+	}
+	{ // colqueue and the other resources.
+		content := SiteContent{Colqueue: &Colqueue{
+			{testBoundResources.resourceA(2)},
+			{testBoundResources.resourceB(10)},
+		}}
+		assert.True(decimal.NewFromInt(2).Equal(content.ReduceQuantity(resourceA, decimal.NewFromInt(5))))
+		assert.True(decimal.Zero.Equal(content.ReduceQuantity(UniqueMaterialResource{ResourceID: "C"}, decimal.NewFromInt(5))))
+		assert.Equal([]string{"A:0", "B:10"}, quantities(content))
+	}
+}
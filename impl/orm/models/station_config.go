@@ -3,8 +3,10 @@ package models
 import (
 	"database/sql/driver"
 	"encoding/json"
+	"strings"
 
 	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
 
 	"gitlab.kenda.com.tw/kenda/mcom/utils/stations"
 	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
@@ -14,8 +16,10 @@ type StationConfiguration struct {
 	StationID  string                         `gorm:"type:varchar(32);not null;primaryKey"`
 	Production StationConfigProductionSetting `gorm:"type:jsonb;not null"`
 	UI         StationConfigUISetting         `gorm:"type:jsonb;not null"`
-	UpdatedAt  types.TimeNano                 `gorm:"autoUpdateTime:nano;not null"`
-	UpdatedBy  string                         `gorm:"type:text;not null"`
+	// Version is relative to StationConfigurationVersion.Version.
+	Version   int32          `gorm:"default:0;not null"`
+	UpdatedAt types.TimeNano `gorm:"autoUpdateTime:nano;not null"`
+	UpdatedBy string         `gorm:"type:text;not null"`
 }
 
 // Model implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
@@ -26,6 +30,42 @@ func (*StationConfiguration) TableName() string {
 	return "station_configuration"
 }
 
+// StationConfigurationVersion is a version of the station configuration. A
+// new version is created whenever the station configuration is set.
+type StationConfigurationVersion struct {
+	StationID  string                         `gorm:"type:varchar(32);not null;primaryKey"`
+	Version    int32                          `gorm:"not null;primaryKey"`
+	Production StationConfigProductionSetting `gorm:"type:jsonb;not null"`
+	UI         StationConfigUISetting         `gorm:"type:jsonb;not null"`
+	// CreatedAt the number of nanoseconds elapsed since January 1, 1970 UTC.
+	CreatedAt types.TimeNano `gorm:"autoCreateTime:nano;not null"`
+	CreatedBy string         `gorm:"type:text;not null"`
+}
+
+// Model implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*StationConfigurationVersion) Model() {}
+
+// TableName implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*StationConfigurationVersion) TableName() string {
+	return "station_configuration_version"
+}
+
+// MigrateData implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+// DataMigration interface. The station configurations set before the versions
+// are recorded become the version 1.
+func (*StationConfigurationVersion) MigrateData(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(strings.Join([]string{
+			"INSERT INTO station_configuration_version (station_id, version, production, ui, created_at, created_by)",
+			"SELECT station_id, 1, production, ui, updated_at, updated_by FROM station_configuration WHERE version = 0",
+			"ON CONFLICT DO NOTHING;",
+		}, "\n")).Error; err != nil {
+			return err
+		}
+		return tx.Exec("UPDATE station_configuration SET version = 1 WHERE version = 0;").Error
+	})
+}
+
 type StationConfigUISetting struct {
 	SplitFeedAndCollect bool `json:"split_feed_and_collect"`
 
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+
+	"gitlab.kenda.com.tw/kenda/mcom/utils/sites"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
+)
+
+// StationTemplate is the structure and the configuration to create stations.
+type StationTemplate struct {
+	ID string `gorm:"type:text;primaryKey"`
+
+	Sites StationTemplateSites `gorm:"type:jsonb;default:'[]';not null"`
+
+	// HasConfiguration is false if there is no configuration to set for the
+	// created stations.
+	HasConfiguration bool                           `gorm:"default:false;not null"`
+	Production       StationConfigProductionSetting `gorm:"type:jsonb;not null"`
+	UI               StationConfigUISetting         `gorm:"type:jsonb;not null"`
+
+	// CreatedAt the number of nanoseconds elapsed since January 1, 1970 UTC.
+	CreatedAt types.TimeNano `gorm:"autoCreateTime:nano;not null"`
+	CreatedBy string         `gorm:"type:text;not null"`
+}
+
+// Model implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*StationTemplate) Model() {}
+
+// TableName implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*StationTemplate) TableName() string {
+	return "station_template"
+}
+
+// StationTemplateSite definition.
+type StationTemplateSite struct {
+	// Station is empty for the sites owned by the created station, otherwise
+	// it is the station ID of the shared site.
+	Station            string         `json:"station,omitempty"`
+	Name               string         `json:"name"`
+	Index              int16          `json:"index"`
+	Type               sites.Type     `json:"type"`
+	SubType            sites.SubType  `json:"sub_type"`
+	Limitation         []string       `json:"limitation,omitempty"`
+	LimitationEnforced bool           `json:"limitation_enforced,omitempty"`
+	Capacity           SiteCapacity   `json:"capacity"`
+	FeedPolicy         SiteFeedPolicy `json:"feed_policy"`
+}
+
+// StationTemplateSites definition.
+type StationTemplateSites []StationTemplateSite
+
+// Scan implements database/sql Scanner interface.
+func (s *StationTemplateSites) Scan(src interface{}) error {
+	return ScanJSON(src, s)
+}
+
+// Value implements database/sql/driver Valuer interface.
+func (s StationTemplateSites) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"database/sql/driver"
 	"encoding/json"
+	"sort"
 
 	"github.com/lib/pq"
 
@@ -53,6 +54,29 @@ func (Station) TableName() string {
 	return "station"
 }
 
+// StationStateHistory is the event log of the state transitions of a station.
+type StationStateHistory struct {
+	// ID is a serial number, it is automatically generated when creating.
+	ID int64 `gorm:"type:bigserial;primaryKey"`
+
+	// Station is relative to Station.ID.
+	Station string `gorm:"type:varchar(32);not null;index:idx_station_state_history"`
+
+	From stations.State `gorm:"not null"`
+	To   stations.State `gorm:"not null"`
+	// Reason is the reason code of the transition.
+	Reason string `gorm:"type:text;not null"`
+
+	// CreatedAt the number of nanoseconds elapsed since January 1, 1970 UTC.
+	CreatedAt types.TimeNano `gorm:"autoCreateTime:nano;not null;index:idx_station_state_history"`
+	CreatedBy string         `gorm:"type:text;not null"`
+}
+
+// TableName implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (StationStateHistory) TableName() string {
+	return "station_state_history"
+}
+
 // StationInformation definition.
 type StationInformation struct {
 	// Code is represented as what station is. It is used for production batch.
@@ -124,13 +148,148 @@ func (sites SitesID) Value() (driver.Value, error) {
 }
 
 // StationGroup definition.
+//
+// Station groups could be nested to model a hierarchy like plant → area →
+// line → station.
 type StationGroup struct {
 	ID string `gorm:"type:text;primaryKey"`
 	// Stations are relative to Station.ID.
 	Stations pq.StringArray `gorm:"type:varchar(32)[];default:'{}';not null"`
+	// Groups are the child groups, relative to StationGroup.ID.
+	Groups pq.StringArray `gorm:"type:text[];default:'{}';not null"`
 }
 
 // TableName implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
 func (StationGroup) TableName() string {
 	return "station_group"
 }
+
+// StationGroups is a station group hierarchy mapped by StationGroup.ID.
+type StationGroups map[string]StationGroup
+
+// NewStationGroups returns the hierarchy of the specified groups.
+func NewStationGroups(groups []StationGroup) StationGroups {
+	res := make(StationGroups, len(groups))
+	for _, group := range groups {
+		res[group.ID] = group
+	}
+	return res
+}
+
+// HasCycle reports whether the group could reach itself through its
+// descendant groups.
+func (groups StationGroups) HasCycle(id string) bool {
+	visited := make(map[string]struct{})
+	queue := append([]string{}, groups[id].Groups...)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == id {
+			return true
+		}
+		if _, ok := visited[current]; ok {
+			continue
+		}
+		visited[current] = struct{}{}
+		queue = append(queue, groups[current].Groups...)
+	}
+	return false
+}
+
+// Stations returns all the stations under the specified group and its
+// descendant groups in ascending order.
+func (groups StationGroups) Stations(id string) []string {
+	visited := make(map[string]struct{})
+	stations := make(map[string]struct{})
+	queue := []string{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if _, ok := visited[current]; ok {
+			continue
+		}
+		visited[current] = struct{}{}
+		for _, station := range groups[current].Stations {
+			stations[station] = struct{}{}
+		}
+		queue = append(queue, groups[current].Groups...)
+	}
+
+	res := make([]string, 0, len(stations))
+	for station := range stations {
+		res = append(res, station)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// Resolve resolves the specified station IDs or group IDs to station IDs in
+// ascending order. An ID is regarded as a station if there is no group with
+// the same ID.
+func (groups StationGroups) Resolve(ids []string) []string {
+	stations := make(map[string]struct{})
+	for _, id := range ids {
+		if _, ok := groups[id]; !ok {
+			stations[id] = struct{}{}
+			continue
+		}
+		for _, station := range groups.Stations(id) {
+			stations[station] = struct{}{}
+		}
+	}
+
+	res := make([]string, 0, len(stations))
+	for station := range stations {
+		res = append(res, station)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// StationGroupAncestor is an ancestor group of a station.
+type StationGroupAncestor struct {
+	ID string
+	// Depth is 1 for the groups containing the station directly, 2 for their
+	// parent groups and so on.
+	Depth int
+}
+
+// Ancestors returns the ancestor groups of the specified station in ascending
+// order of depth and ID. A group appears only once with its least depth.
+func (groups StationGroups) Ancestors(stationID string) []StationGroupAncestor {
+	parents := make(map[string][]string)
+	current := []string{}
+	for _, group := range groups {
+		for _, child := range group.Groups {
+			parents[child] = append(parents[child], group.ID)
+		}
+		for _, station := range group.Stations {
+			if station == stationID {
+				current = append(current, group.ID)
+				break
+			}
+		}
+	}
+
+	res := []StationGroupAncestor{}
+	visited := make(map[string]struct{})
+	for depth := 1; len(current) > 0; depth++ {
+		next := []string{}
+		for _, id := range current {
+			if _, ok := visited[id]; ok {
+				continue
+			}
+			visited[id] = struct{}{}
+			res = append(res, StationGroupAncestor{ID: id, Depth: depth})
+			next = append(next, parents[id]...)
+		}
+		current = next
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Depth == res[j].Depth {
+			return res[i].ID < res[j].ID
+		}
+		return res[i].Depth < res[j].Depth
+	})
+	return res
+}
@@ -145,3 +145,50 @@ func TestStation_RemoveSharedSite(t *testing.T) {
 		},
 	}, station)
 }
+
+func TestStationGroups(t *testing.T) {
+	assert := assert.New(t)
+
+	// plant → (areaA, areaB), areaA → lineA, areaB → lineA, lineB.
+	groups := NewStationGroups([]StationGroup{
+		{ID: "plant", Groups: []string{"areaA", "areaB"}},
+		{ID: "areaA", Stations: []string{"S0"}, Groups: []string{"lineA"}},
+		{ID: "areaB", Groups: []string{"lineA", "lineB"}},
+		{ID: "lineA", Stations: []string{"S2", "S1"}},
+		{ID: "lineB", Stations: []string{"S1", "S3"}},
+	})
+
+	{ // Stations.
+		assert.Equal([]string{"S0", "S1", "S2", "S3"}, groups.Stations("plant"))
+		assert.Equal([]string{"S1", "S2", "S3"}, groups.Stations("areaB"))
+		assert.Equal([]string{}, groups.Stations("not found"))
+	}
+	{ // Resolve.
+		assert.Equal([]string{"S0", "S1", "S2", "S9"}, groups.Resolve([]string{"areaA", "S9"}))
+	}
+	{ // Ancestors.
+		assert.Equal([]StationGroupAncestor{
+			{ID: "lineA", Depth: 1},
+			{ID: "lineB", Depth: 1},
+			{ID: "areaA", Depth: 2},
+			{ID: "areaB", Depth: 2},
+			{ID: "plant", Depth: 3},
+		}, groups.Ancestors("S1"))
+		assert.Equal([]StationGroupAncestor{
+			{ID: "areaA", Depth: 1},
+			{ID: "plant", Depth: 2},
+		}, groups.Ancestors("S0"))
+		assert.Equal([]StationGroupAncestor{}, groups.Ancestors("S9"))
+	}
+	{ // HasCycle.
+		assert.False(groups.HasCycle("plant"))
+
+		groups["lineB"] = StationGroup{ID: "lineB", Groups: []string{"plant"}}
+		assert.True(groups.HasCycle("lineB"))
+		assert.True(groups.HasCycle("plant"))
+		assert.False(groups.HasCycle("areaA"))
+
+		groups["lineA"] = StationGroup{ID: "lineA", Groups: []string{"lineA"}}
+		assert.True(groups.HasCycle("lineA"))
+	}
+}
@@ -0,0 +1,132 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/rs/xid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
+)
+
+// StockCountStatus is the status of a stock count.
+type StockCountStatus int8
+
+// StockCountStatus enumeration.
+const (
+	// StockCountCounting is accepting the counts.
+	StockCountCounting StockCountStatus = iota + 1
+	// StockCountPosted has been closed with the approved adjustments posted.
+	StockCountPosted
+	// StockCountCancelled has been closed without any adjustment.
+	StockCountCancelled
+)
+
+// StockCount is a stock-taking session of a warehouse or a location.
+type StockCount struct {
+	// ID is automatically generated before creating.
+	ID string `gorm:"type:text;primaryKey"`
+	// WarehouseID is relative to Warehouse.ID.
+	WarehouseID string `gorm:"type:char(1);not null"`
+	// Location is empty if the whole warehouse is counted. The descendants
+	// are counted as well if the location is in the WarehouseLocation.
+	Location string           `gorm:"type:varchar(32);not null"`
+	Status   StockCountStatus `gorm:"not null"`
+
+	// UpdatedAt the number of nanoseconds elapsed since January 1, 1970 UTC.
+	UpdatedAt types.TimeNano `gorm:"autoUpdateTime:nano;not null"`
+	UpdatedBy string         `gorm:"type:text;not null"`
+	CreatedAt types.TimeNano `gorm:"autoCreateTime:nano;not null"`
+	CreatedBy string         `gorm:"type:text;not null"`
+}
+
+// BeforeCreate gorm hook.
+func (c *StockCount) BeforeCreate(*gorm.DB) error {
+	c.ID = strings.ToUpper(xid.New().String())
+	return nil
+}
+
+// Model implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*StockCount) Model() {}
+
+// TableName implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*StockCount) TableName() string {
+	return "stock_count"
+}
+
+// IsClosed reports whether the stock count has been posted or cancelled.
+func (c StockCount) IsClosed() bool {
+	return c.Status != StockCountCounting
+}
+
+// StockCountVarianceType is the type of the difference between the counted
+// and the recorded resources.
+type StockCountVarianceType string
+
+// StockCountVarianceType enumeration.
+const (
+	// StockCountVarianceMissing is a resource in the snapshot but not counted.
+	StockCountVarianceMissing StockCountVarianceType = "MISSING"
+	// StockCountVarianceUnexpected is a resource counted but not in the
+	// snapshot.
+	StockCountVarianceUnexpected StockCountVarianceType = "UNEXPECTED"
+	// StockCountVarianceLocationDiff is a resource in the snapshot but counted
+	// in another location, the quantity may differ as well.
+	StockCountVarianceLocationDiff StockCountVarianceType = "LOCATION_DIFF"
+	// StockCountVarianceQuantityDiff is a resource counted in place with a
+	// different quantity.
+	StockCountVarianceQuantityDiff StockCountVarianceType = "QUANTITY_DIFF"
+)
+
+// StockCountItem is a resource in the snapshot or counted in a stock count.
+type StockCountItem struct {
+	// CountID is relative to StockCount.ID.
+	CountID     string `gorm:"type:text;primaryKey"`
+	ResourceID  string `gorm:"type:text;primaryKey"`
+	ProductType string `gorm:"type:text;primaryKey"`
+	ProductID   string `gorm:"type:text;not null"`
+
+	// InSnapshot is true if the resource was in the snapshot taken when the
+	// stock count was created.
+	InSnapshot bool `gorm:"not null"`
+	// WarehouseID, Location and Quantity are recorded in the snapshot, or
+	// when the resource is counted if it is not in the snapshot.
+	WarehouseID string          `gorm:"type:varchar(1);not null"`
+	Location    string          `gorm:"type:varchar(32);not null"`
+	Quantity    decimal.Decimal `gorm:"type:numeric(16, 6);not null"`
+
+	Counted         bool            `gorm:"not null"`
+	CountedLocation string          `gorm:"type:varchar(32);not null"`
+	CountedQuantity decimal.Decimal `gorm:"type:numeric(16, 6);not null"`
+	CountedAt       types.TimeNano  `gorm:"default:0;not null"`
+	CountedBy       string          `gorm:"type:text;default:'';not null"`
+
+	// Adjusted is true if the variance has been posted.
+	Adjusted bool `gorm:"default:false;not null"`
+}
+
+// Model implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*StockCountItem) Model() {}
+
+// TableName implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*StockCountItem) TableName() string {
+	return "stock_count_item"
+}
+
+// Variance returns the type of the variance, or false if the resource is
+// counted as recorded.
+func (item StockCountItem) Variance() (StockCountVarianceType, bool) {
+	switch {
+	case !item.Counted:
+		return StockCountVarianceMissing, item.InSnapshot
+	case !item.InSnapshot:
+		return StockCountVarianceUnexpected, true
+	case item.CountedLocation != item.Location:
+		return StockCountVarianceLocationDiff, true
+	case !item.CountedQuantity.Equal(item.Quantity):
+		return StockCountVarianceQuantityDiff, true
+	default:
+		return "", false
+	}
+}
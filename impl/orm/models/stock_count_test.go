@@ -0,0 +1,59 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm/callbacks"
+)
+
+func TestStockCount(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Implements(new(callbacks.BeforeCreateInterface), new(StockCount))
+
+	c := StockCount{Status: StockCountCounting}
+	assert.NoError(c.BeforeCreate(nil))
+	assert.NotEmpty(c.ID)
+	assert.False(c.IsClosed())
+
+	c.Status = StockCountPosted
+	assert.True(c.IsClosed())
+	c.Status = StockCountCancelled
+	assert.True(c.IsClosed())
+}
+
+func TestStockCountItem_Variance(t *testing.T) {
+	assert := assert.New(t)
+
+	snapshot := StockCountItem{
+		InSnapshot: true,
+		Location:   "01",
+		Quantity:   decimal.NewFromInt(10),
+	}
+	counted := func(item StockCountItem, location string, quantity int64) StockCountItem {
+		item.Counted = true
+		item.CountedLocation = location
+		item.CountedQuantity = decimal.NewFromInt(quantity)
+		return item
+	}
+
+	for _, c := range []struct {
+		item     StockCountItem
+		expected StockCountVarianceType
+		ok       bool
+	}{
+		{item: snapshot, expected: StockCountVarianceMissing, ok: true},
+		{item: StockCountItem{}, expected: StockCountVarianceMissing, ok: false},
+		{item: counted(StockCountItem{Location: "02"}, "01", 5), expected: StockCountVarianceUnexpected, ok: true},
+		{item: counted(snapshot, "02", 10), expected: StockCountVarianceLocationDiff, ok: true},
+		{item: counted(snapshot, "02", 8), expected: StockCountVarianceLocationDiff, ok: true},
+		{item: counted(snapshot, "01", 8), expected: StockCountVarianceQuantityDiff, ok: true},
+		{item: counted(snapshot, "01", 10), expected: "", ok: false},
+	} {
+		actual, ok := c.item.Variance()
+		assert.Equal(c.expected, actual)
+		assert.Equal(c.ok, ok)
+	}
+}
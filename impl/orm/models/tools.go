@@ -13,6 +13,18 @@ type ToolResource struct {
 	UpdatedBy   string         `gorm:"not null"`
 	CreatedAt   types.TimeNano `gorm:"autoCreateTime:nano;not null"`
 	CreatedBy   string         `gorm:"not null"`
+
+	// Cycles is the total usage cycles of the tool.
+	Cycles int64 `gorm:"default:0;not null"`
+	// CyclesSinceMaintenance is the usage cycles since the last maintenance.
+	CyclesSinceMaintenance int64 `gorm:"default:0;not null"`
+	// MaintainedAt the number of nanoseconds elapsed since January 1, 1970 UTC.
+	// It is zero if the tool has never been maintained.
+	MaintainedAt types.TimeNano `gorm:"default:0;not null"`
+
+	// RetiredAt the number of nanoseconds elapsed since January 1, 1970 UTC.
+	// It is zero if the tool is in service.
+	RetiredAt types.TimeNano `gorm:"default:0;not null"`
 }
 
 // Model implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
@@ -30,3 +42,159 @@ func (t *ToolResource) BindTo(site UniqueSite) {
 func (t *ToolResource) Unbind() {
 	t.BindingSite = UniqueSite{}
 }
+
+// IsRetired reports whether the tool has been retired.
+func (t ToolResource) IsRetired() bool {
+	return t.RetiredAt != 0
+}
+
+// ToolType is the master data of the tool IDs.
+type ToolType struct {
+	// ID is relative to ToolResource.ToolID.
+	ID          string `gorm:"type:text;primaryKey"`
+	Description string `gorm:"type:text;not null"`
+
+	// UpdatedAt the number of nanoseconds elapsed since January 1, 1970 UTC.
+	UpdatedAt types.TimeNano `gorm:"autoUpdateTime:nano;not null"`
+	UpdatedBy string         `gorm:"type:text;not null"`
+	// CreatedAt the number of nanoseconds elapsed since January 1, 1970 UTC.
+	CreatedAt types.TimeNano `gorm:"autoCreateTime:nano;not null"`
+	CreatedBy string         `gorm:"type:text;not null"`
+}
+
+// Model implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*ToolType) Model() {}
+
+// TableName implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*ToolType) TableName() string {
+	return "tool_type"
+}
+
+// ToolResourceAction is the action on a tool resource.
+type ToolResourceAction int16
+
+// ToolResourceAction enumeration.
+const (
+	ToolResourceActionCreate ToolResourceAction = iota + 1
+	ToolResourceActionUpdate
+	ToolResourceActionRetire
+)
+
+// ToolResourceHistory is the history of the registration of a tool resource.
+type ToolResourceHistory struct {
+	// ID is a serial number, it is automatically generated when creating.
+	ID int64 `gorm:"type:bigserial;primaryKey"`
+
+	// ResourceID is relative to ToolResource.ID.
+	ResourceID string             `gorm:"type:text;not null;index:idx_tool_resource_history"`
+	Action     ToolResourceAction `gorm:"not null"`
+	// ToolID is the tool ID after the action.
+	ToolID string `gorm:"type:text;not null"`
+	// Reason is the reason of the action, e.g. the reason of the retirement.
+	Reason string `gorm:"type:text;not null"`
+
+	// CreatedAt the number of nanoseconds elapsed since January 1, 1970 UTC.
+	CreatedAt types.TimeNano `gorm:"autoCreateTime:nano;not null;index:idx_tool_resource_history"`
+	CreatedBy string         `gorm:"type:text;not null"`
+}
+
+// Model implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*ToolResourceHistory) Model() {}
+
+// TableName implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*ToolResourceHistory) TableName() string {
+	return "tool_resource_history"
+}
+
+// ToolLifePolicy is the life limit and the maintenance interval of the tools
+// of a tool ID.
+type ToolLifePolicy struct {
+	// ToolID is relative to ToolResource.ToolID.
+	ToolID string `gorm:"type:text;primaryKey"`
+	// LifeLimit is the max usage cycles of a tool. It is unlimited if zero.
+	LifeLimit int64 `gorm:"default:0;not null"`
+	// MaintenanceInterval is the usage cycles between two maintenances. There
+	// is no maintenance if zero.
+	MaintenanceInterval int64 `gorm:"default:0;not null"`
+	// WarningCycles is the remaining cycles to warn before reaching the life
+	// limit or the maintenance interval.
+	WarningCycles int64 `gorm:"default:0;not null"`
+
+	// UpdatedAt the number of nanoseconds elapsed since January 1, 1970 UTC.
+	UpdatedAt types.TimeNano `gorm:"autoUpdateTime:nano;not null"`
+	UpdatedBy string         `gorm:"type:text;not null"`
+}
+
+// Model implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*ToolLifePolicy) Model() {}
+
+// TableName implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*ToolLifePolicy) TableName() string {
+	return "tool_life_policy"
+}
+
+// LifeExceeded reports whether the tool reaches the life limit.
+func (p ToolLifePolicy) LifeExceeded(t ToolResource) bool {
+	return p.LifeLimit > 0 && t.Cycles >= p.LifeLimit
+}
+
+// LifeWarning reports whether the tool is within the warning cycles of the
+// life limit or reaches the life limit.
+func (p ToolLifePolicy) LifeWarning(t ToolResource) bool {
+	return p.LifeLimit > 0 && t.Cycles >= p.LifeLimit-p.WarningCycles
+}
+
+// MaintenanceDue reports whether the tool reaches the maintenance interval.
+func (p ToolLifePolicy) MaintenanceDue(t ToolResource) bool {
+	return p.MaintenanceInterval > 0 && t.CyclesSinceMaintenance >= p.MaintenanceInterval
+}
+
+// MaintenanceWarning reports whether the tool is within the warning cycles of
+// the maintenance interval or reaches the maintenance interval.
+func (p ToolLifePolicy) MaintenanceWarning(t ToolResource) bool {
+	return p.MaintenanceInterval > 0 && t.CyclesSinceMaintenance >= p.MaintenanceInterval-p.WarningCycles
+}
+
+// ToolBindHistory is a period a tool is bound to a site.
+type ToolBindHistory struct {
+	// ID is a serial number, it is automatically generated when creating.
+	ID int64 `gorm:"type:bigserial;primaryKey"`
+
+	// ResourceID is relative to ToolResource.ID.
+	ResourceID string `gorm:"type:text;not null;index:idx_tool_bind_history_resource"`
+	// ToolID is relative to ToolResource.ToolID.
+	ToolID string `gorm:"type:text;not null;index:idx_tool_bind_history_tool"`
+	// Station is relative to Site.Station.
+	Station string `gorm:"type:varchar(32);not null;index:idx_tool_bind_history_site"`
+	// SiteName is relative to Site.Name.
+	SiteName string `gorm:"type:varchar(16);not null;index:idx_tool_bind_history_site"`
+	// SiteIndex is relative to Site.Index.
+	SiteIndex int16 `gorm:"not null;index:idx_tool_bind_history_site"`
+
+	// BoundAt the number of nanoseconds elapsed since January 1, 1970 UTC.
+	BoundAt types.TimeNano `gorm:"not null"`
+	BoundBy string         `gorm:"type:text;not null"`
+	// UnboundAt the number of nanoseconds elapsed since January 1, 1970 UTC.
+	// It is zero if the tool is still bound to the site.
+	UnboundAt types.TimeNano `gorm:"default:0;not null"`
+	UnboundBy string         `gorm:"type:text;not null"`
+}
+
+// Model implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*ToolBindHistory) Model() {}
+
+// TableName implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (*ToolBindHistory) TableName() string {
+	return "tool_bind_history"
+}
+
+// Site returns the site the tool is bound to.
+func (h ToolBindHistory) Site() UniqueSite {
+	return UniqueSite{
+		SiteID: SiteID{
+			Name:  h.SiteName,
+			Index: h.SiteIndex,
+		},
+		Station: h.Station,
+	}
+}
@@ -23,3 +23,48 @@ func Test_ToolResource(t *testing.T) {
 	tr.Unbind()
 	assert.Equal(tr, ToolResource{})
 }
+
+func TestToolLifePolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	policy := ToolLifePolicy{
+		LifeLimit:           100,
+		MaintenanceInterval: 20,
+		WarningCycles:       5,
+	}
+	{ // normal.
+		tr := ToolResource{Cycles: 50, CyclesSinceMaintenance: 10}
+		assert.False(policy.LifeWarning(tr))
+		assert.False(policy.LifeExceeded(tr))
+		assert.False(policy.MaintenanceWarning(tr))
+		assert.False(policy.MaintenanceDue(tr))
+	}
+	{ // warnings.
+		tr := ToolResource{Cycles: 95, CyclesSinceMaintenance: 15}
+		assert.True(policy.LifeWarning(tr))
+		assert.False(policy.LifeExceeded(tr))
+		assert.True(policy.MaintenanceWarning(tr))
+		assert.False(policy.MaintenanceDue(tr))
+	}
+	{ // exceeded.
+		tr := ToolResource{Cycles: 100, CyclesSinceMaintenance: 20}
+		assert.True(policy.LifeWarning(tr))
+		assert.True(policy.LifeExceeded(tr))
+		assert.True(policy.MaintenanceWarning(tr))
+		assert.True(policy.MaintenanceDue(tr))
+	}
+	{ // no limit.
+		tr := ToolResource{Cycles: 1000, CyclesSinceMaintenance: 1000}
+		assert.False(ToolLifePolicy{}.LifeWarning(tr))
+		assert.False(ToolLifePolicy{}.LifeExceeded(tr))
+		assert.False(ToolLifePolicy{}.MaintenanceWarning(tr))
+		assert.False(ToolLifePolicy{}.MaintenanceDue(tr))
+	}
+}
+
+func TestToolBindHistory_Site(t *testing.T) {
+	assert.Equal(t, UniqueSite{
+		SiteID:  SiteID{Name: "site", Index: 2},
+		Station: "station",
+	}, ToolBindHistory{Station: "station", SiteName: "site", SiteIndex: 2}.Site())
+}
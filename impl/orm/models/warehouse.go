@@ -29,11 +29,75 @@ func (Warehouse) TableName() string {
 	return "warehouse"
 }
 
+// WarehouseLocationType is the level of the location in the warehouse.
+type WarehouseLocationType int8
+
+// WarehouseLocationType enumeration.
+const (
+	WarehouseLocationZone WarehouseLocationType = iota + 1
+	WarehouseLocationRack
+	WarehouseLocationBin
+)
+
+// IsValid returns true if the type is defined.
+func (t WarehouseLocationType) IsValid() bool {
+	return t >= WarehouseLocationZone && t <= WarehouseLocationBin
+}
+
+// WarehouseLocation is a location in the warehouse such as a zone, a rack or
+// a bin. The locations are in a hierarchy by ParentID.
+type WarehouseLocation struct {
+	// WarehouseID is relative to Warehouse.ID.
+	WarehouseID string `gorm:"type:char(1);primaryKey"`
+	// ID is the location code, which is relative to WarehouseStock.Location.
+	ID string `gorm:"type:varchar(32);primaryKey"`
+	// ParentID is the location containing this one in the same warehouse, it
+	// is empty for the top-level locations.
+	ParentID string                `gorm:"type:varchar(32);not null"`
+	Type     WarehouseLocationType `gorm:"not null"`
+	// Capacity is the max total quantity of the stocks in the location and
+	// its descendants, it is unlimited if zero.
+	Capacity decimal.Decimal `gorm:"type:numeric(16, 6);not null"`
+	// ProductTypes are the product types allowed in the location and its
+	// descendants, all the product types are allowed if empty.
+	ProductTypes pq.StringArray `gorm:"type:text[];not null"`
+
+	UpdatedAt types.TimeNano `gorm:"autoUpdateTime:nano;not null"`
+	UpdatedBy string         `gorm:"type:text;not null"`
+	CreatedAt types.TimeNano `gorm:"autoCreateTime:nano;not null"`
+	CreatedBy string         `gorm:"type:text;not null"`
+}
+
+// TableName implements "gitlab.kenda.com.tw/kenda/mcom/impl/orm/models" Model interface.
+func (WarehouseLocation) TableName() string {
+	return "warehouse_location"
+}
+
+// IsProductTypeAllowed returns true if the product type is allowed in the
+// location.
+func (l WarehouseLocation) IsProductTypeAllowed(productType string) bool {
+	if len(l.ProductTypes) == 0 {
+		return true
+	}
+	for _, t := range l.ProductTypes {
+		if t == productType {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCapacityExceeded returns true if the quantity exceeds the capacity of
+// the location.
+func (l WarehouseLocation) IsCapacityExceeded(quantity decimal.Decimal) bool {
+	return l.Capacity.IsPositive() && quantity.GreaterThan(l.Capacity)
+}
+
 // WarehouseStock define stocks in the warehouse.
 type WarehouseStock struct {
 	// ID is relative to Warehouse.ID.
 	ID        string          `gorm:"type:char(1);not null;primaryKey"`
-	Location  string          `gorm:"type:varchar(2);not null;primaryKey"`
+	Location  string          `gorm:"type:varchar(32);not null;primaryKey"`
 	ProductID string          `gorm:"not null;primaryKey"`
 	Quantity  decimal.Decimal `gorm:"type:numeric(16, 6);not null"`
 }
@@ -105,7 +169,7 @@ type MaterialResource struct {
 
 	// WarehouseID is relative to Warehouse.ID.
 	WarehouseID       string `gorm:"type:varchar(1);not null"`
-	WarehouseLocation string `gorm:"type:varchar(2);not null"`
+	WarehouseLocation string `gorm:"type:varchar(32);not null"`
 
 	FeedRecordsID pq.StringArray `gorm:"type:text[];not null"`
 
@@ -202,17 +266,17 @@ type ResourceTransportRecord struct {
 	// OldWarehouseID are relative to Warehouse.ID.
 	OldWarehouseID string `gorm:"type:varchar(1);not null"`
 	// OldLocation are relative to Warehouse.Location.
-	OldLocation string `gorm:"type:varchar(2);not null"`
+	OldLocation string `gorm:"type:varchar(32);not null"`
 	// NewWarehouseID are relative to Warehouse.ID.
 	NewWarehouseID string `gorm:"type:varchar(1);not null"`
 	// NewLocation are relative to Warehouse.Location.
-	NewLocation string `gorm:"type:varchar(2);not null"`
+	NewLocation string `gorm:"type:varchar(32);not null"`
 	// ResourceID is relative to Resource.ID.
-	ResourceID string `gorm:"type:text;not null"`
+	ResourceID string `gorm:"type:text;not null;index"`
 	Note       string `gorm:"type:varchar(50);default:'';not null"`
 
 	// CreatedAt the number of nanoseconds elapsed since January 1, 1970 UTC.
-	CreatedAt types.TimeNano `gorm:"autoCreateTime:nano;not null"`
+	CreatedAt types.TimeNano `gorm:"autoCreateTime:nano;not null;index"`
 	CreatedBy string         `gorm:"type:text;not null"`
 }
 
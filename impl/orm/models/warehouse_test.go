@@ -0,0 +1,33 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarehouseLocation(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.False(WarehouseLocationType(0).IsValid())
+	assert.True(WarehouseLocationZone.IsValid())
+	assert.True(WarehouseLocationBin.IsValid())
+	assert.False((WarehouseLocationBin + 1).IsValid())
+
+	{ // unlimited.
+		var l WarehouseLocation
+		assert.True(l.IsProductTypeAllowed("RUBBER"))
+		assert.False(l.IsCapacityExceeded(decimal.NewFromInt(1000000)))
+	}
+	{ // limited.
+		l := WarehouseLocation{
+			Capacity:     decimal.NewFromInt(100),
+			ProductTypes: []string{"RUBBER", "STEEL"},
+		}
+		assert.True(l.IsProductTypeAllowed("STEEL"))
+		assert.False(l.IsProductTypeAllowed("CORD"))
+		assert.False(l.IsCapacityExceeded(decimal.NewFromInt(100)))
+		assert.True(l.IsCapacityExceeded(decimal.RequireFromString("100.000001")))
+	}
+}
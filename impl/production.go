@@ -401,12 +401,22 @@ func (dm *DataManager) ListWorkOrdersByDuration(ctx context.Context, req mcom.Li
 		return mcom.ListWorkOrdersByDurationReply{}, err
 	}
 
+	session := dm.newSession(ctx)
+
 	// #region parse date
-	since, err := parseDate(req.Since)
+	departmentID := req.DepartmentID
+	if req.ResolveWorkDate && departmentID == "" {
+		station, err := session.getStation(req.Station)
+		if err != nil {
+			return mcom.ListWorkOrdersByDurationReply{}, err
+		}
+		departmentID = station.AdminDepartmentID
+	}
+	since, err := session.parseWorkDate(departmentID, req.Since, req.ResolveWorkDate)
 	if err != nil {
 		return mcom.ListWorkOrdersByDurationReply{}, err
 	}
-	until, err := parseDate(req.Until)
+	until, err := session.parseWorkDate(departmentID, req.Until, req.ResolveWorkDate)
 	if err != nil {
 		return mcom.ListWorkOrdersByDurationReply{}, err
 	}
@@ -418,7 +428,6 @@ func (dm *DataManager) ListWorkOrdersByDuration(ctx context.Context, req mcom.Li
 		DepartmentID: req.DepartmentID,
 	}
 
-	session := dm.newSession(ctx)
 	workOrders := []models.WorkOrder{}
 
 	sql := session.db.
@@ -214,12 +214,12 @@ func (dm *DataManager) ListProductPlans(ctx context.Context, req mcom.ListProduc
 		return mcom.ListProductPlansReply{}, err
 	}
 
-	planDate, err := parseDate(req.Date)
+	session := dm.newSession(ctx)
+	planDate, err := session.parseWorkDate(req.DepartmentOID, req.Date, req.ResolveWorkDate)
 	if err != nil {
 		return mcom.ListProductPlansReply{}, err
 	}
 
-	session := dm.newSession(ctx)
 	plans, err := session.getProductPlans(req.DepartmentOID, planDate, req.ProductType)
 	if err != nil {
 		return mcom.ListProductPlansReply{}, err
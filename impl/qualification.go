@@ -0,0 +1,282 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
+)
+
+// SetUserQualification implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) SetUserQualification(ctx context.Context, req mcom.SetUserQualificationRequest) error {
+	if err := req.CheckInsufficiency(); err != nil {
+		return err
+	}
+
+	session := dm.newSession(ctx)
+	if err := session.db.Where(`id = ?`, req.UserID).Take(&models.User{}).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return mcomErr.Error{
+				Code:    mcomErr.Code_USER_NOT_FOUND,
+				Details: "user: " + req.UserID,
+			}
+		}
+		return err
+	}
+
+	qualification := models.UserQualification{
+		UserID:        req.UserID,
+		Qualification: req.Qualification,
+		CreatedBy:     commonsCtx.UserID(ctx),
+	}
+	if !req.ExpiresAt.IsZero() {
+		qualification.ExpiresAt = types.ToTimeNano(req.ExpiresAt)
+	}
+	return session.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "qualification"}},
+			DoUpdates: clause.AssignmentColumns([]string{"expires_at", "created_at", "created_by"}),
+		}).
+		Create(&qualification).Error
+}
+
+// DeleteUserQualification implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) DeleteUserQualification(ctx context.Context, req mcom.DeleteUserQualificationRequest) error {
+	if err := req.CheckInsufficiency(); err != nil {
+		return err
+	}
+
+	session := dm.newSession(ctx)
+	return session.db.
+		Where(`user_id = ? AND qualification = ?`, req.UserID, req.Qualification).
+		Delete(&models.UserQualification{}).Error
+}
+
+// ListUserQualifications implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) ListUserQualifications(ctx context.Context, req mcom.ListUserQualificationsRequest) (mcom.ListUserQualificationsReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.ListUserQualificationsReply{}, err
+	}
+
+	session := dm.newSession(ctx)
+	var qualifications []models.UserQualification
+	if err := session.db.
+		Where(`user_id = ?`, req.UserID).
+		Order("qualification").
+		Find(&qualifications).Error; err != nil {
+		return mcom.ListUserQualificationsReply{}, err
+	}
+
+	now := time.Now()
+	res := make([]mcom.UserQualification, len(qualifications))
+	for i, qualification := range qualifications {
+		res[i] = mcom.UserQualification{
+			Qualification: qualification.Qualification,
+			Expired:       !qualification.IsValid(now),
+			CreatedAt:     qualification.CreatedAt,
+			CreatedBy:     qualification.CreatedBy,
+		}
+		if qualification.ExpiresAt != 0 {
+			res[i].ExpiresAt = qualification.ExpiresAt.Time()
+		}
+	}
+	return mcom.ListUserQualificationsReply{Qualifications: res}, nil
+}
+
+// SetQualificationRequirement implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) SetQualificationRequirement(ctx context.Context, req mcom.SetQualificationRequirementRequest) error {
+	if err := req.CheckInsufficiency(); err != nil {
+		return err
+	}
+
+	session := dm.newSession(ctx)
+	if req.StationID != "" {
+		if _, err := session.getStation(req.StationID); err != nil {
+			return err
+		}
+	} else {
+		groups, err := session.listStationGroups()
+		if err != nil {
+			return err
+		}
+		if _, ok := groups[req.GroupID]; !ok {
+			return mcomErr.Error{
+				Code:    mcomErr.Code_STATION_GROUP_ID_NOT_FOUND,
+				Details: "station group: " + req.GroupID,
+			}
+		}
+	}
+
+	if len(req.Qualifications) == 0 {
+		return session.db.
+			Where(`station_id = ? AND group_id = ?`, req.StationID, req.GroupID).
+			Delete(&models.QualificationRequirement{}).Error
+	}
+
+	return session.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "station_id"}, {Name: "group_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"qualifications", "updated_at", "updated_by"}),
+		}).
+		Create(&models.QualificationRequirement{
+			StationID:      req.StationID,
+			GroupID:        req.GroupID,
+			Qualifications: uniqueStrings(req.Qualifications),
+			UpdatedBy:      commonsCtx.UserID(ctx),
+		}).Error
+}
+
+// uniqueStrings returns the distinct values in ascending order.
+func uniqueStrings(values []string) []string {
+	set := make(map[string]struct{}, len(values))
+	res := []string{}
+	for _, v := range values {
+		if _, ok := set[v]; !ok {
+			set[v] = struct{}{}
+			res = append(res, v)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// listRequiredQualifications returns the qualifications required by the
+// station and its ancestor groups in ascending order.
+func (session *session) listRequiredQualifications(stationID string) ([]string, error) {
+	groups, err := session.listStationGroups()
+	if err != nil {
+		return nil, err
+	}
+	ancestors := groups.Ancestors(stationID)
+	groupIDs := make([]string, len(ancestors))
+	for i, ancestor := range ancestors {
+		groupIDs[i] = ancestor.ID
+	}
+
+	var requirements []models.QualificationRequirement
+	if err := session.db.
+		Where(`station_id = ? OR group_id IN ?`, stationID, groupIDs).
+		Find(&requirements).Error; err != nil {
+		return nil, err
+	}
+
+	qualifications := []string{}
+	for _, requirement := range requirements {
+		qualifications = append(qualifications, requirement.Qualifications...)
+	}
+	return uniqueStrings(qualifications), nil
+}
+
+// ListStationRequiredQualifications implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) ListStationRequiredQualifications(ctx context.Context, req mcom.ListStationRequiredQualificationsRequest) (mcom.ListStationRequiredQualificationsReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.ListStationRequiredQualificationsReply{}, err
+	}
+
+	session := dm.newSession(ctx)
+	if _, err := session.getStation(req.StationID); err != nil {
+		return mcom.ListStationRequiredQualificationsReply{}, err
+	}
+	qualifications, err := session.listRequiredQualifications(req.StationID)
+	if err != nil {
+		return mcom.ListStationRequiredQualificationsReply{}, err
+	}
+	return mcom.ListStationRequiredQualificationsReply{Qualifications: qualifications}, nil
+}
+
+// checkUserQualifications checks if the user holds all the qualifications
+// required to operate the station.
+func (session *session) checkUserQualifications(userID, stationID string) error {
+	required, err := session.listRequiredQualifications(stationID)
+	if err != nil {
+		return err
+	}
+	if len(required) == 0 {
+		return nil
+	}
+
+	var qualifications models.UserQualifications
+	if err := session.db.Where(`user_id = ?`, userID).Find(&qualifications).Error; err != nil {
+		return err
+	}
+	if missing := qualifications.Missing(required, time.Now()); len(missing) != 0 {
+		return mcomErr.Error{
+			Code:    mcomErr.Code_USER_STATION_SIGN_ON_FORBIDDEN,
+			Details: "missing qualifications: " + strings.Join(missing, ", "),
+		}
+	}
+	return nil
+}
+
+// ListQualifiedUsers implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) ListQualifiedUsers(ctx context.Context, req mcom.ListQualifiedUsersRequest) (mcom.ListQualifiedUsersReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.ListQualifiedUsersReply{}, err
+	}
+
+	session := dm.newSession(ctx)
+	if _, err := session.getStation(req.StationID); err != nil {
+		return mcom.ListQualifiedUsersReply{}, err
+	}
+	required, err := session.listRequiredQualifications(req.StationID)
+	if err != nil {
+		return mcom.ListQualifiedUsersReply{}, err
+	}
+
+	now := time.Now()
+	var users []models.User
+	db := session.db.Where(`leave_date IS NULL OR leave_date > ?`, now)
+	if req.DepartmentID != "" {
+		db = db.Where(`department_id = ?`, req.DepartmentID)
+	}
+	if err := db.Find(&users).Error; err != nil {
+		return mcom.ListQualifiedUsersReply{}, err
+	}
+
+	var qualifications []models.UserQualification
+	if len(required) != 0 {
+		if err := session.db.Where(`qualification IN ?`, required).Find(&qualifications).Error; err != nil {
+			return mcom.ListQualifiedUsersReply{}, err
+		}
+	}
+	userQualifications := make(map[string]models.UserQualifications)
+	for _, qualification := range qualifications {
+		userQualifications[qualification.UserID] = append(userQualifications[qualification.UserID], qualification)
+	}
+
+	var signedIn []string
+	if err := session.db.
+		Model(&models.SiteContents{}).
+		Where(`content -> 'slot' -> 'operator' ->> 'employee_id' <> ''`).
+		Pluck(`content -> 'slot' -> 'operator' ->> 'employee_id'`, &signedIn).Error; err != nil {
+		return mcom.ListQualifiedUsersReply{}, err
+	}
+	isSignedIn := make(map[string]struct{}, len(signedIn))
+	for _, user := range signedIn {
+		isSignedIn[user] = struct{}{}
+	}
+
+	res := []string{}
+	for _, user := range users {
+		if _, ok := isSignedIn[user.ID]; ok {
+			continue
+		}
+		if len(userQualifications[user.ID].Missing(required, now)) != 0 {
+			continue
+		}
+		res = append(res, user.ID)
+	}
+	sort.Strings(res)
+	return mcom.ListQualifiedUsersReply{Users: res}, nil
+}
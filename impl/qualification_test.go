@@ -0,0 +1,169 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/sites"
+)
+
+func TestDataManager_Qualification(t *testing.T) {
+	assert := assert.New(t)
+	ctx, dm, db := initializeDB(t)
+	defer dm.Close()
+	ctx = commonsCtx.WithUserID(ctx, testUser)
+	cm := newClearMaster(db,
+		&models.UserQualification{},
+		&models.QualificationRequirement{},
+		&models.User{},
+		&models.StationGroup{},
+		&models.Station{},
+		&models.Site{},
+		&models.SiteContents{},
+	)
+	assert.NoError(cm.Clear())
+
+	const (
+		operatorA = "OPERATOR_A"
+		operatorB = "OPERATOR_B"
+
+		curing = "CURING"
+		safety = "SAFETY"
+	)
+
+	assert.NoError(dm.CreateUsers(ctx, mcom.CreateUsersRequest{
+		Users: []mcom.User{
+			{ID: operatorA, DepartmentID: testDepartmentA},
+			{ID: operatorB, DepartmentID: testDepartmentA},
+		},
+	}))
+	assert.NoError(dm.CreateStation(ctx, mcom.CreateStationRequest{
+		ID:            testStationA,
+		DepartmentOID: testDepartmentA,
+		Sites: []mcom.SiteInformation{{
+			Name:    testSiteA,
+			Index:   0,
+			Type:    sites.Type_SLOT,
+			SubType: sites.SubType_OPERATOR,
+		}},
+	}))
+	assert.NoError(dm.CreateStationGroup(ctx, mcom.StationGroupRequest{
+		ID:       testStationGroupID,
+		Stations: []string{testStationA},
+	}))
+
+	{ // SetUserQualification: user not found.
+		assert.ErrorIs(dm.SetUserQualification(ctx, mcom.SetUserQualificationRequest{
+			UserID:        "NOBODY",
+			Qualification: curing,
+		}), mcomErr.Error{
+			Code:    mcomErr.Code_USER_NOT_FOUND,
+			Details: "user: NOBODY",
+		})
+	}
+	{ // SetQualificationRequirement: station group not found.
+		assert.ErrorIs(dm.SetQualificationRequirement(ctx, mcom.SetQualificationRequirementRequest{
+			GroupID:        "NOT_FOUND",
+			Qualifications: []string{safety},
+		}), mcomErr.Error{
+			Code:    mcomErr.Code_STATION_GROUP_ID_NOT_FOUND,
+			Details: "station group: NOT_FOUND",
+		})
+	}
+	{ // SetQualificationRequirement: good case.
+		assert.NoError(dm.SetQualificationRequirement(ctx, mcom.SetQualificationRequirementRequest{
+			StationID:      testStationA,
+			Qualifications: []string{curing},
+		}))
+		assert.NoError(dm.SetQualificationRequirement(ctx, mcom.SetQualificationRequirementRequest{
+			GroupID:        testStationGroupID,
+			Qualifications: []string{safety, curing},
+		}))
+
+		actual, err := dm.ListStationRequiredQualifications(ctx, mcom.ListStationRequiredQualificationsRequest{
+			StationID: testStationA,
+		})
+		assert.NoError(err)
+		assert.Equal(mcom.ListStationRequiredQualificationsReply{
+			Qualifications: []string{curing, safety},
+		}, actual)
+	}
+	{ // SetUserQualification: good case.
+		assert.NoError(dm.SetUserQualification(ctx, mcom.SetUserQualificationRequest{
+			UserID:        operatorA,
+			Qualification: curing,
+		}))
+		assert.NoError(dm.SetUserQualification(ctx, mcom.SetUserQualificationRequest{
+			UserID:        operatorA,
+			Qualification: safety,
+			ExpiresAt:     time.Now().Add(-time.Hour),
+		}))
+		assert.NoError(dm.SetUserQualification(ctx, mcom.SetUserQualificationRequest{
+			UserID:        operatorB,
+			Qualification: curing,
+		}))
+		assert.NoError(dm.SetUserQualification(ctx, mcom.SetUserQualificationRequest{
+			UserID:        operatorB,
+			Qualification: safety,
+		}))
+
+		actual, err := dm.ListUserQualifications(ctx, mcom.ListUserQualificationsRequest{UserID: operatorA})
+		assert.NoError(err)
+		if assert.Len(actual.Qualifications, 2) {
+			assert.Equal(curing, actual.Qualifications[0].Qualification)
+			assert.False(actual.Qualifications[0].Expired)
+			assert.True(actual.Qualifications[0].ExpiresAt.IsZero())
+			assert.Equal(safety, actual.Qualifications[1].Qualification)
+			assert.True(actual.Qualifications[1].Expired)
+		}
+	}
+	{ // ListQualifiedUsers: good case.
+		actual, err := dm.ListQualifiedUsers(ctx, mcom.ListQualifiedUsersRequest{StationID: testStationA})
+		assert.NoError(err)
+		assert.Equal(mcom.ListQualifiedUsersReply{Users: []string{operatorB}}, actual)
+	}
+	{ // SignInStation: expired qualification.
+		err := dm.SignInStation(commonsCtx.WithUserID(ctx, operatorA), mcom.SignInStationRequest{
+			Station:  testStationA,
+			Site:     models.SiteID{Name: testSiteA},
+			WorkDate: time.Now(),
+		})
+		assert.ErrorIs(err, mcomErr.Error{
+			Code:    mcomErr.Code_USER_STATION_SIGN_ON_FORBIDDEN,
+			Details: "missing qualifications: " + safety,
+		})
+	}
+	{ // SignInStation: good case.
+		assert.NoError(dm.SignInStation(commonsCtx.WithUserID(ctx, operatorB), mcom.SignInStationRequest{
+			Station:  testStationA,
+			Site:     models.SiteID{Name: testSiteA},
+			WorkDate: time.Now(),
+		}))
+
+		actual, err := dm.ListQualifiedUsers(ctx, mcom.ListQualifiedUsersRequest{StationID: testStationA})
+		assert.NoError(err)
+		assert.Equal(mcom.ListQualifiedUsersReply{Users: []string{}}, actual)
+	}
+	{ // DeleteUserQualification and remove the requirement.
+		assert.NoError(dm.DeleteUserQualification(ctx, mcom.DeleteUserQualificationRequest{
+			UserID:        operatorA,
+			Qualification: safety,
+		}))
+		assert.NoError(dm.SetQualificationRequirement(ctx, mcom.SetQualificationRequirementRequest{
+			GroupID: testStationGroupID,
+		}))
+
+		actual, err := dm.ListQualifiedUsers(ctx, mcom.ListQualifiedUsersRequest{StationID: testStationA})
+		assert.NoError(err)
+		assert.Equal(mcom.ListQualifiedUsersReply{Users: []string{operatorA}}, actual)
+	}
+
+	assert.NoError(cm.Clear())
+}
@@ -0,0 +1,205 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/sites"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/stations"
+)
+
+// CheckProductionReadiness implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) CheckProductionReadiness(ctx context.Context, req mcom.CheckProductionReadinessRequest) (mcom.CheckProductionReadinessReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.CheckProductionReadinessReply{}, err
+	}
+
+	tx := dm.beginTx(ctx)
+	defer tx.Rollback() // nolint: errcheck
+
+	blockers, err := tx.checkProductionReadiness(req.WorkOrder, req.Station)
+	if err != nil {
+		return mcom.CheckProductionReadinessReply{}, err
+	}
+	return mcom.CheckProductionReadinessReply{
+		Ready:    len(blockers) == 0,
+		Blockers: blockers,
+	}, nil
+}
+
+// checkProductionReadiness returns the blockers of the production of the work
+// order at the station. The station is locked in share mode, so its state can
+// not be changed until the transaction ends.
+func (tx *txDataManager) checkProductionReadiness(workOrderID, stationID string) (mcom.ProductionBlockers, error) {
+	var workOrder models.WorkOrder
+	if err := tx.db.Where(`id = ?`, workOrderID).Take(&workOrder).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, mcomErr.Error{Code: mcomErr.Code_WORKORDER_NOT_FOUND}
+		}
+		return nil, err
+	}
+	var station models.Station
+	if err := tx.db.
+		Clauses(clause.Locking{Strength: "SHARE"}).
+		Where(models.Station{ID: stationID}).
+		Take(&station).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, mcomErr.Error{
+				Code:    mcomErr.Code_STATION_NOT_FOUND,
+				Details: fmt.Sprintf("station not found, id: %s", stationID),
+			}
+		}
+		return nil, err
+	}
+
+	blockers := mcom.ProductionBlockers{}
+	if station.State != stations.State_IDLE && station.State != stations.State_RUNNING {
+		blockers = append(blockers, mcom.ProductionBlocker{
+			Type:    mcom.ProductionBlockerStationState,
+			Details: "state: " + station.State.String(),
+		})
+	}
+
+	stationSites, err := tx.listStationSites(station.Sites)
+	if err != nil {
+		return nil, err
+	}
+	if !hasOperatorSignedIn(stationSites) {
+		blockers = append(blockers, mcom.ProductionBlocker{
+			Type: mcom.ProductionBlockerOperatorNotSignedIn,
+		})
+	}
+
+	config, found, err := tx.getStationProcessConfig(workOrder.ProcessOID, stationID)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return append(blockers, mcom.ProductionBlocker{
+			Type:    mcom.ProductionBlockerStationNotInProcess,
+			Details: fmt.Sprintf("process: %s, type: %s", workOrder.ProcessName, workOrder.ProcessType),
+		}), nil
+	}
+
+	blockers = append(blockers, checkRecipeTools(config.Tools, stationSites)...)
+	for _, step := range config.Steps {
+		blockers = append(blockers, checkRecipeMaterials(step.Materials, stationID, stationSites)...)
+	}
+	return blockers, nil
+}
+
+// getStationProcessConfig returns the config of the process for the station.
+// It returns false if the station is not one of the stations of the process.
+func (tx *txDataManager) getStationProcessConfig(processOID, stationID string) (models.RecipeProcessConfig, bool, error) {
+	var process models.RecipeProcessDefinition
+	if err := tx.db.Where(`oid = ?`, processOID).Take(&process).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.RecipeProcessConfig{}, false, mcomErr.Error{Code: mcomErr.Code_PROCESS_NOT_FOUND}
+		}
+		return models.RecipeProcessConfig{}, false, err
+	}
+
+	// the groups are only resolved, so they are not locked.
+	var stationGroups []models.StationGroup
+	if err := tx.db.Find(&stationGroups).Error; err != nil {
+		return models.RecipeProcessConfig{}, false, err
+	}
+	groups := models.NewStationGroups(stationGroups)
+	for _, config := range process.Configs {
+		for _, station := range groups.Resolve(config.Stations) {
+			if station == stationID {
+				return config, true, nil
+			}
+		}
+	}
+	return models.RecipeProcessConfig{}, false, nil
+}
+
+func hasOperatorSignedIn(stationSites []mcom.ListStationSite) bool {
+	for _, site := range stationSites {
+		if site.Information.SubType != sites.SubType_OPERATOR || site.Content.Slot == nil {
+			continue
+		}
+		if site.Content.Slot.Operator.Current().EmployeeID != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// checkRecipeTools returns the blockers of the required tools which are not
+// bound to the station.
+func checkRecipeTools(tools []models.RecipeTool, stationSites []mcom.ListStationSite) []mcom.ProductionBlocker {
+	mounted := make(map[string]struct{})
+	for _, site := range stationSites {
+		if site.Information.SubType != sites.SubType_TOOL || site.Content.Slot == nil || site.Content.Slot.Tool == nil {
+			continue
+		}
+		mounted[site.Content.Slot.Tool.ToolID] = struct{}{}
+	}
+
+	var blockers []mcom.ProductionBlocker
+	for _, tool := range tools {
+		if !tool.Required {
+			continue
+		}
+		if _, ok := mounted[tool.ID]; !ok {
+			blockers = append(blockers, mcom.ProductionBlocker{
+				Type:     mcom.ProductionBlockerToolNotMounted,
+				Expected: tool.ID,
+				Details:  "tool: " + tool.ID,
+			})
+		}
+	}
+	return blockers
+}
+
+// checkRecipeMaterials returns the blockers of the materials which are not
+// bound to the specified sites, or to any site of the station if the site of
+// the material is not specified.
+func checkRecipeMaterials(materials []models.RecipeMaterial, stationID string, stationSites []mcom.ListStationSite) []mcom.ProductionBlocker {
+	isBound := func(material models.RecipeMaterial) bool {
+		for _, site := range stationSites {
+			if material.Site != "" && site.Information.SiteID.Name != material.Site {
+				continue
+			}
+			for _, bound := range site.Content.Materials() {
+				if bound.Material.ID == material.ID && (material.Grade == "" || bound.Material.Grade == material.Grade) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	var blockers []mcom.ProductionBlocker
+	for _, material := range materials {
+		if isBound(material) {
+			continue
+		}
+		blocker := mcom.ProductionBlocker{
+			Type:     mcom.ProductionBlockerMaterialNotBound,
+			Expected: material.ID,
+			Details:  "material: " + material.ID,
+		}
+		if material.Grade != "" {
+			blocker.Details += ", grade: " + material.Grade
+		}
+		if material.Site != "" {
+			blocker.Site = models.UniqueSite{
+				SiteID:  models.SiteID{Name: material.Site},
+				Station: stationID,
+			}
+			blocker.Details += ", site: " + material.Site
+		}
+		blockers = append(blockers, blocker)
+	}
+	return blockers
+}
@@ -0,0 +1,201 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/bindtype"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/sites"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/stations"
+)
+
+func TestDataManager_CheckProductionReadiness(t *testing.T) {
+	assert := assert.New(t)
+	ctx, dm, db := initializeDB(t)
+	defer dm.Close()
+	ctx = commonsCtx.WithUserID(ctx, testUser)
+	cm := newClearMaster(db,
+		&models.WorkOrder{},
+		&models.RecipeProcessDefinition{},
+		&models.StationGroup{},
+		&models.Station{},
+		&models.Site{},
+		&models.SiteContents{},
+		&models.ToolResource{},
+		&models.ToolBindHistory{},
+		&models.OperatorSession{},
+		&models.StationStateHistory{},
+	)
+	assert.NoError(cm.Clear())
+
+	const (
+		operatorSite = "OPERATOR"
+		toolSite     = "TOOL"
+		materialSite = "MATERIAL"
+		mold         = "MOLD"
+		moldA        = "MOLD_A"
+		rubber       = "RUBBER"
+	)
+	assert.NoError(dm.CreateStation(ctx, mcom.CreateStationRequest{
+		ID:            testStationA,
+		DepartmentOID: testDepartmentA,
+		State:         stations.State_IDLE,
+		Sites: []mcom.SiteInformation{{
+			Name:    operatorSite,
+			Type:    sites.Type_SLOT,
+			SubType: sites.SubType_OPERATOR,
+		}, {
+			Name:    toolSite,
+			Type:    sites.Type_SLOT,
+			SubType: sites.SubType_TOOL,
+		}, {
+			Name:    materialSite,
+			Type:    sites.Type_SLOT,
+			SubType: sites.SubType_MATERIAL,
+		}},
+	}))
+	assert.NoError(dm.CreateStationGroup(ctx, mcom.StationGroupRequest{
+		ID:       testStationGroupID,
+		Stations: []string{testStationA},
+	}))
+	assert.NoError(db.Create(&models.RecipeProcessDefinition{
+		OID:  testProcessOID,
+		Name: testProcessName,
+		Type: testProcessType,
+		Configs: []models.RecipeProcessConfig{{
+			Stations: []string{testStationGroupID},
+			Tools: []models.RecipeTool{
+				{ID: mold, Required: true},
+				{ID: "OPTIONAL"},
+			},
+			Steps: []models.RecipeProcessStep{{
+				Materials: []models.RecipeMaterial{{ID: rubber, Site: materialSite}},
+			}},
+		}},
+	}).Error)
+	workOrder := models.WorkOrder{
+		ProcessOID:   testProcessOID,
+		ProcessName:  testProcessName,
+		ProcessType:  testProcessType,
+		DepartmentID: testDepartmentA,
+		ReservedDate: time.Now(),
+	}
+	assert.NoError(db.Create(&workOrder).Error)
+	assert.NoError(db.Create(&models.ToolResource{ID: moldA, ToolID: mold}).Error)
+
+	{ // insufficient request.
+		_, err := dm.CheckProductionReadiness(ctx, mcom.CheckProductionReadinessRequest{Station: testStationA})
+		assert.ErrorIs(err, mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "Key: 'CheckProductionReadinessRequest.WorkOrder' Error:Field validation for 'WorkOrder' failed on the 'required' tag",
+		})
+	}
+	{ // work order not found.
+		_, err := dm.CheckProductionReadiness(ctx, mcom.CheckProductionReadinessRequest{WorkOrder: "NOT_FOUND", Station: testStationA})
+		assert.ErrorIs(err, mcomErr.Error{Code: mcomErr.Code_WORKORDER_NOT_FOUND})
+	}
+	{ // station not in the process.
+		assert.NoError(dm.CreateStation(ctx, mcom.CreateStationRequest{
+			ID:            testStationB,
+			DepartmentOID: testDepartmentA,
+			State:         stations.State_IDLE,
+		}))
+		actual, err := dm.CheckProductionReadiness(ctx, mcom.CheckProductionReadinessRequest{WorkOrder: workOrder.ID, Station: testStationB})
+		assert.NoError(err)
+		assert.Equal(mcom.CheckProductionReadinessReply{
+			Blockers: mcom.ProductionBlockers{{
+				Type: mcom.ProductionBlockerOperatorNotSignedIn,
+			}, {
+				Type:    mcom.ProductionBlockerStationNotInProcess,
+				Details: "process: " + testProcessName + ", type: " + testProcessType,
+			}},
+		}, actual)
+	}
+
+	materialBlocker := mcom.ProductionBlocker{
+		Type: mcom.ProductionBlockerMaterialNotBound,
+		Site: models.UniqueSite{
+			SiteID:  models.SiteID{Name: materialSite},
+			Station: testStationA,
+		},
+		Expected: rubber,
+		Details:  "material: " + rubber + ", site: " + materialSite,
+	}
+	{ // all blockers.
+		assert.NoError(dm.ChangeStationState(ctx, mcom.ChangeStationStateRequest{
+			ID:     testStationA,
+			State:  stations.State_MALFUNCTION,
+			Reason: "broken",
+		}))
+		actual, err := dm.CheckProductionReadiness(ctx, mcom.CheckProductionReadinessRequest{WorkOrder: workOrder.ID, Station: testStationA})
+		assert.NoError(err)
+		assert.Equal(mcom.CheckProductionReadinessReply{
+			Blockers: mcom.ProductionBlockers{{
+				Type:    mcom.ProductionBlockerStationState,
+				Details: "state: MALFUNCTION",
+			}, {
+				Type: mcom.ProductionBlockerOperatorNotSignedIn,
+			}, {
+				Type:     mcom.ProductionBlockerToolNotMounted,
+				Expected: mold,
+				Details:  "tool: " + mold,
+			}, materialBlocker},
+		}, actual)
+	}
+	{ // Feed with the readiness check.
+		_, err := dm.Feed(ctx, mcom.FeedRequest{
+			Batch: mcom.BatchID{WorkOrder: workOrder.ID, Number: 1},
+			FeedContent: []mcom.FeedPerSite{mcom.FeedPerSiteType3{
+				Quantity:   decimal.NewFromInt(1),
+				ResourceID: "R",
+			}},
+		}, mcom.WithProductionReadinessCheck(testStationA))
+		assert.ErrorIs(err, mcomErr.Error{
+			Code: mcomErr.Code_PRODUCTION_NOT_READY,
+			Details: "STATION_STATE: state: MALFUNCTION; OPERATOR_NOT_SIGNED_IN; TOOL_NOT_MOUNTED: tool: " + mold +
+				"; MATERIAL_NOT_BOUND: material: " + rubber + ", site: " + materialSite,
+		})
+	}
+	{ // the material is still missing.
+		assert.NoError(dm.ChangeStationState(ctx, mcom.ChangeStationStateRequest{
+			ID:     testStationA,
+			State:  stations.State_REPAIRING,
+			Reason: "repair",
+		}))
+		assert.NoError(dm.ChangeStationState(ctx, mcom.ChangeStationStateRequest{
+			ID:    testStationA,
+			State: stations.State_IDLE,
+		}))
+		assert.NoError(dm.SignInStation(ctx, mcom.SignInStationRequest{
+			Station:  testStationA,
+			Site:     models.SiteID{Name: operatorSite},
+			WorkDate: time.Now(),
+		}))
+		assert.NoError(dm.ToolResourceBindV2(ctx, mcom.ToolResourceBindRequestV2{
+			Details: []mcom.ToolBindRequestDetailV2{{
+				Type: bindtype.BindType_RESOURCE_BINDING_SLOT_BIND,
+				Site: models.UniqueSite{
+					SiteID:  models.SiteID{Name: toolSite},
+					Station: testStationA,
+				},
+				Resource: mcom.ToolResource{ResourceID: moldA, ToolID: mold},
+			}},
+		}))
+
+		actual, err := dm.CheckProductionReadiness(ctx, mcom.CheckProductionReadinessRequest{WorkOrder: workOrder.ID, Station: testStationA})
+		assert.NoError(err)
+		assert.Equal(mcom.CheckProductionReadinessReply{
+			Blockers: mcom.ProductionBlockers{materialBlocker},
+		}, actual)
+	}
+
+	assert.NoError(cm.Clear())
+}
@@ -597,19 +597,27 @@ func (dm *DataManager) GetRecipe(ctx context.Context, req mcom.GetRecipeRequest)
 	return session.parseSingleRecipe(result, req.NeedProcesses())
 }
 
+func (session *session) getProcessDefinition(recipeID, name, typ string) (models.RecipeProcessDefinition, error) {
+	var process models.RecipeProcessDefinition
+	if err := session.db.
+		Where(`recipe_id = ? AND name = ? AND type = ?`, recipeID, name, typ).
+		Take(&process).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.RecipeProcessDefinition{}, mcomErr.Error{Code: mcomErr.Code_PROCESS_NOT_FOUND}
+		}
+		return models.RecipeProcessDefinition{}, err
+	}
+	return process, nil
+}
+
 func (dm *DataManager) GetProcessDefinition(ctx context.Context, req mcom.GetProcessDefinitionRequest) (mcom.GetProcessDefinitionReply, error) {
 	if err := req.CheckInsufficiency(); err != nil {
 		return mcom.GetProcessDefinitionReply{}, err
 	}
 
 	session := dm.newSession(ctx)
-	var process models.RecipeProcessDefinition
-	if err := session.db.
-		Where(`recipe_id = ? AND name = ? AND type = ?`, req.RecipeID, req.ProcessName, req.ProcessType).
-		Take(&process).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return mcom.GetProcessDefinitionReply{}, mcomErr.Error{Code: mcomErr.Code_PROCESS_NOT_FOUND}
-		}
+	process, err := session.getProcessDefinition(req.RecipeID, req.ProcessName, req.ProcessType)
+	if err != nil {
 		return mcom.GetProcessDefinitionReply{}, err
 	}
 
@@ -625,3 +633,27 @@ func (dm *DataManager) GetProcessDefinition(ctx context.Context, req mcom.GetPro
 		}),
 	}}, nil
 }
+
+// ListProcessStations implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) ListProcessStations(ctx context.Context, req mcom.ListProcessStationsRequest) (mcom.ListProcessStationsReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.ListProcessStationsReply{}, err
+	}
+
+	session := dm.newSession(ctx)
+	process, err := session.getProcessDefinition(req.RecipeID, req.ProcessName, req.ProcessType)
+	if err != nil {
+		return mcom.ListProcessStationsReply{}, err
+	}
+
+	groups, err := session.listStationGroups()
+	if err != nil {
+		return mcom.ListProcessStationsReply{}, err
+	}
+
+	ids := []string{}
+	for _, config := range process.Configs {
+		ids = append(ids, config.Stations...)
+	}
+	return mcom.ListProcessStationsReply{Stations: groups.Resolve(ids)}, nil
+}
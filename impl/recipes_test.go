@@ -711,3 +711,52 @@ func Test_GetProcessDefinition(t *testing.T) {
 	}
 	assert.NoError(cm.Clear())
 }
+
+func Test_ListProcessStations(t *testing.T) {
+	ctx, dm, db := initializeDB(t)
+	defer dm.Close()
+	assert := assert.New(t)
+	cm := newClearMaster(db, &models.Recipe{}, &models.RecipeProcessDefinition{}, &models.StationGroup{})
+	assert.NoError(cm.Clear())
+	{ // record not found.
+		_, err := dm.ListProcessStations(ctx, mcom.ListProcessStationsRequest{
+			RecipeID:    "not found",
+			ProcessName: "not found",
+			ProcessType: "not found",
+		})
+		assert.ErrorIs(err, mcomErr.Error{Code: mcomErr.Code_PROCESS_NOT_FOUND})
+	}
+	{ // good case: station B is a group of a line.
+		assert.NoError(dm.CreateRecipes(ctx, mcom.CreateRecipesRequest{
+			Recipes: []mcom.Recipe{{
+				ID: testRecipeID,
+				Product: mcom.Product{
+					ID:   testProductID,
+					Type: testProductType,
+				},
+				Version:            mcom.RecipeVersion{Major: "1", Minor: "2", Stage: "UNSPECIFIED", ReleasedAt: types.ToTimeNano(time.Now())},
+				Processes:          commonRecipeProcessesInfo,
+				ProcessDefinitions: commonProcessDefinition,
+			}},
+		}))
+		assert.NoError(dm.CreateStationGroup(ctx, mcom.StationGroupRequest{
+			ID:       "LINE",
+			Stations: []string{"B1", "B2"},
+		}))
+		assert.NoError(dm.CreateStationGroup(ctx, mcom.StationGroupRequest{
+			ID:     "B",
+			Groups: []string{"LINE"},
+		}))
+
+		actual, err := dm.ListProcessStations(ctx, mcom.ListProcessStationsRequest{
+			RecipeID:    testRecipeID,
+			ProcessName: testProcessName,
+			ProcessType: testProcessType,
+		})
+		assert.NoError(err)
+		assert.Equal(mcom.ListProcessStationsReply{
+			Stations: []string{"A", "B1", "B2", "C"},
+		}, actual)
+	}
+	assert.NoError(cm.Clear())
+}
@@ -8,6 +8,7 @@ import (
 
 	"gorm.io/gorm"
 
+	pbWorkOrder "gitlab.kenda.com.tw/kenda/commons/v2/proto/golang/mes/v2/workorder"
 	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
 
 	"gitlab.kenda.com.tw/kenda/mcom"
@@ -219,11 +220,34 @@ func (dm *DataManager) CreateCollectRecord(ctx context.Context, req mcom.CreateC
 		return err
 	}
 
-	session := dm.newSession(ctx)
-	return session.createCollectRecord(commonsCtx.UserID(ctx), req, req.ResourceOID)
+	tx := dm.beginTx(ctx)
+	defer tx.Rollback() // nolint: errcheck
+	if err := tx.createCollectRecord(commonsCtx.UserID(ctx), req, req.ResourceOID); err != nil {
+		return err
+	}
+
+	// each collected batch is a usage cycle of the tools on the station, unless
+	// the work order has batches which have been counted by CreateBatch.
+	var batches int64
+	if err := tx.db.
+		Model(&models.Batch{}).
+		Where(`work_order = ? AND status <> ?`, req.WorkOrder, pbWorkOrder.BatchStatus_BATCH_CANCELLED).
+		Count(&batches).Error; err != nil {
+		return err
+	}
+	if batches == 0 {
+		cycles := int64(1)
+		if req.BatchCount > 0 {
+			cycles = int64(req.BatchCount)
+		}
+		if err := tx.increaseToolCycles(req.Station, cycles); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
 }
 
-func (session *session) createCollectRecord(operatorID string, req mcom.CreateCollectRecordRequest, resourceOID string) error {
+func (tx *txDataManager) createCollectRecord(operatorID string, req mcom.CreateCollectRecordRequest, resourceOID string) error {
 	detail := models.CollectRecordDetail{
 		OperatorID: operatorID,
 		Quantity:   req.Quantity,
@@ -232,7 +256,7 @@ func (session *session) createCollectRecord(operatorID string, req mcom.CreateCo
 		detail.BatchCount = req.BatchCount
 	}
 
-	if err := session.db.Create(&models.CollectRecord{
+	if err := tx.db.Create(&models.CollectRecord{
 		WorkOrder:   req.WorkOrder,
 		Sequence:    req.Sequence,
 		LotNumber:   req.LotNumber,
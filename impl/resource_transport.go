@@ -0,0 +1,128 @@
+package impl
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
+)
+
+// ListResourceTransportRecords implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) ListResourceTransportRecords(ctx context.Context, req mcom.ListResourceTransportRecordsRequest) (mcom.ListResourceTransportRecordsReply, error) {
+	session := dm.newSession(ctx)
+	condition := func(db *gorm.DB) *gorm.DB {
+		res := db.Model(&models.ResourceTransportRecord{})
+		if req.ResourceID != "" {
+			res = res.Where(`resource_id = ?`, req.ResourceID)
+		}
+		if req.Location != "" {
+			res = res.Where(`((old_warehouse_id = ? AND old_location = ?) OR (new_warehouse_id = ? AND new_location = ?))`,
+				req.WarehouseID, req.Location, req.WarehouseID, req.Location)
+		} else if req.WarehouseID != "" {
+			res = res.Where(`(old_warehouse_id = ? OR new_warehouse_id = ?)`, req.WarehouseID, req.WarehouseID)
+		}
+		if req.CreatedBy != "" {
+			res = res.Where(`created_by = ?`, req.CreatedBy)
+		}
+		if !req.Since.IsZero() {
+			res = res.Where(`created_at >= ?`, types.ToTimeNano(req.Since))
+		}
+		if !req.Until.IsZero() {
+			res = res.Where(`created_at <= ?`, types.ToTimeNano(req.Until))
+		}
+		if !req.NeedOrder() {
+			res = res.Order(`id`)
+		}
+		return res
+	}
+
+	dataCount, records, err := listHandler[models.ResourceTransportRecord](&session, req, condition)
+	if err != nil {
+		return mcom.ListResourceTransportRecordsReply{}, err
+	}
+
+	replies := make([]mcom.ResourceTransportRecord, len(records))
+	for i, record := range records {
+		replies[i] = mcom.ResourceTransportRecord{
+			ID:         record.ID,
+			ResourceID: record.ResourceID,
+			From: mcom.Warehouse{
+				ID:       record.OldWarehouseID,
+				Location: record.OldLocation,
+			},
+			To: mcom.Warehouse{
+				ID:       record.NewWarehouseID,
+				Location: record.NewLocation,
+			},
+			Note:      record.Note,
+			CreatedAt: record.CreatedAt.Time(),
+			CreatedBy: record.CreatedBy,
+		}
+	}
+	return mcom.ListResourceTransportRecordsReply{
+		Records: replies,
+		PaginationReply: mcom.PaginationReply{
+			AmountOfData: dataCount,
+		},
+	}, nil
+}
+
+// GetResourceLocationAt implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) GetResourceLocationAt(ctx context.Context, req mcom.GetResourceLocationAtRequest) (mcom.GetResourceLocationAtReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.GetResourceLocationAtReply{}, err
+	}
+
+	session := dm.newSession(ctx)
+	resources, err := session.getMaterialResources(req.ResourceID)
+	if err != nil {
+		return mcom.GetResourceLocationAtReply{}, err
+	}
+	at := types.ToTimeNano(req.Time)
+	created := false
+	for _, resource := range resources {
+		if resource.CreatedAt <= at {
+			created = true
+			break
+		}
+	}
+	if !created {
+		return mcom.GetResourceLocationAtReply{}, mcomErr.Error{
+			Code:    mcomErr.Code_RESOURCE_NOT_FOUND,
+			Details: "resource: " + req.ResourceID,
+		}
+	}
+
+	// the last transport before the time.
+	var record models.ResourceTransportRecord
+	err = session.db.
+		Where(`resource_id = ? AND created_at <= ?`, req.ResourceID, at).
+		Order(`created_at DESC, id DESC`).
+		Take(&record).Error
+	if err == nil {
+		return mcom.GetResourceLocationAtReply{ID: record.NewWarehouseID, Location: record.NewLocation}, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return mcom.GetResourceLocationAtReply{}, err
+	}
+
+	// the first transport after the time.
+	err = session.db.
+		Where(`resource_id = ? AND created_at > ?`, req.ResourceID, at).
+		Order(`created_at, id`).
+		Take(&record).Error
+	if err == nil {
+		return mcom.GetResourceLocationAtReply{ID: record.OldWarehouseID, Location: record.OldLocation}, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return mcom.GetResourceLocationAtReply{}, err
+	}
+
+	// never transported, it is where the resource was stocked in when created.
+	return mcom.GetResourceLocationAtReply{ID: resources[0].WarehouseID, Location: resources[0].WarehouseLocation}, nil
+}
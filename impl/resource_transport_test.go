@@ -0,0 +1,146 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
+)
+
+func TestDataManager_ResourceTransportRecords(t *testing.T) {
+	assert := assert.New(t)
+	ctx, dm, db := initializeDB(t)
+	defer dm.Close()
+	cm := newClearMaster(db, &models.MaterialResource{}, &models.ResourceTransportRecord{})
+	assert.NoError(cm.Clear())
+	defer func() { assert.NoError(cm.Clear()) }()
+
+	base := time.Date(2026, 1, 1, 8, 0, 0, 0, time.Local)
+	// R1 was created in A/00 at base, moved to A/01 at base+1h and B/00 at base+2h.
+	// R2 was created at base without stocking in and moved to A/01 at base+3h.
+	assert.NoError(db.Create([]models.MaterialResource{{
+		ID:                "R1",
+		ProductID:         "P",
+		ProductType:       "T",
+		Quantity:          decimal.NewFromInt(10),
+		WarehouseID:       "A",
+		WarehouseLocation: "00",
+		FeedRecordsID:     []string{},
+		CreatedAt:         types.ToTimeNano(base),
+	}, {
+		ID:            "R2",
+		ProductID:     "P",
+		ProductType:   "T",
+		Quantity:      decimal.NewFromInt(10),
+		FeedRecordsID: []string{},
+		CreatedAt:     types.ToTimeNano(base),
+	}}).Error)
+	records := []models.ResourceTransportRecord{{
+		OldWarehouseID: "A",
+		OldLocation:    "00",
+		NewWarehouseID: "A",
+		NewLocation:    "01",
+		ResourceID:     "R1",
+		CreatedAt:      types.ToTimeNano(base.Add(time.Hour)),
+		CreatedBy:      "U1",
+	}, {
+		OldWarehouseID: "A",
+		OldLocation:    "01",
+		NewWarehouseID: "B",
+		NewLocation:    "00",
+		ResourceID:     "R1",
+		CreatedAt:      types.ToTimeNano(base.Add(2 * time.Hour)),
+		CreatedBy:      "U2",
+	}, {
+		NewWarehouseID: "A",
+		NewLocation:    "01",
+		ResourceID:     "R2",
+		CreatedAt:      types.ToTimeNano(base.Add(3 * time.Hour)),
+		CreatedBy:      "U1",
+	}}
+	assert.NoError(db.Create(&records).Error)
+	toReply := func(rs ...models.ResourceTransportRecord) []mcom.ResourceTransportRecord {
+		res := make([]mcom.ResourceTransportRecord, len(rs))
+		for i, r := range rs {
+			res[i] = mcom.ResourceTransportRecord{
+				ID:         r.ID,
+				ResourceID: r.ResourceID,
+				From:       mcom.Warehouse{ID: r.OldWarehouseID, Location: r.OldLocation},
+				To:         mcom.Warehouse{ID: r.NewWarehouseID, Location: r.NewLocation},
+				CreatedAt:  r.CreatedAt.Time(),
+				CreatedBy:  r.CreatedBy,
+			}
+		}
+		return res
+	}
+
+	{ // all.
+		rep, err := dm.ListResourceTransportRecords(ctx, mcom.ListResourceTransportRecordsRequest{})
+		assert.NoError(err)
+		assert.Equal(mcom.ListResourceTransportRecordsReply{Records: toReply(records...)}, rep)
+	}
+	{ // by resource.
+		rep, err := dm.ListResourceTransportRecords(ctx, mcom.ListResourceTransportRecordsRequest{ResourceID: "R2"})
+		assert.NoError(err)
+		assert.Equal(toReply(records[2]), rep.Records)
+	}
+	{ // by warehouse and location, either the old or the new one.
+		rep, err := dm.ListResourceTransportRecords(ctx, mcom.ListResourceTransportRecordsRequest{WarehouseID: "A", Location: "01"})
+		assert.NoError(err)
+		assert.Equal(toReply(records...), rep.Records)
+
+		rep, err = dm.ListResourceTransportRecords(ctx, mcom.ListResourceTransportRecordsRequest{WarehouseID: "B"})
+		assert.NoError(err)
+		assert.Equal(toReply(records[1]), rep.Records)
+	}
+	{ // by user and time range.
+		rep, err := dm.ListResourceTransportRecords(ctx, mcom.ListResourceTransportRecordsRequest{
+			CreatedBy: "U1",
+			Since:     base.Add(30 * time.Minute),
+			Until:     base.Add(90 * time.Minute),
+		})
+		assert.NoError(err)
+		assert.Equal(toReply(records[0]), rep.Records)
+	}
+	{ // pagination and order.
+		rep, err := dm.ListResourceTransportRecords(ctx, mcom.ListResourceTransportRecordsRequest{}.
+			WithPagination(mcom.PaginationRequest{PageCount: 1, ObjectsPerPage: 2}).
+			WithOrder(mcom.Order{Name: "created_at", Descending: true}))
+		assert.NoError(err)
+		assert.Equal(mcom.ListResourceTransportRecordsReply{
+			Records:         toReply(records[2], records[1]),
+			PaginationReply: mcom.PaginationReply{AmountOfData: 3},
+		}, rep)
+	}
+
+	{ // resource not found.
+		_, err := dm.GetResourceLocationAt(ctx, mcom.GetResourceLocationAtRequest{ResourceID: "R9", Time: base})
+		assert.ErrorIs(err, mcomErr.Error{Code: mcomErr.Code_RESOURCE_NOT_FOUND, Details: "resource: R9"})
+	}
+	{ // created after the time.
+		_, err := dm.GetResourceLocationAt(ctx, mcom.GetResourceLocationAtRequest{ResourceID: "R1", Time: base.Add(-time.Hour)})
+		assert.ErrorIs(err, mcomErr.Error{Code: mcomErr.Code_RESOURCE_NOT_FOUND, Details: "resource: R1"})
+	}
+	for _, c := range []struct {
+		resourceID string
+		at         time.Duration
+		expected   mcom.GetResourceLocationAtReply
+	}{
+		{resourceID: "R1", at: 0, expected: mcom.GetResourceLocationAtReply{ID: "A", Location: "00"}},
+		{resourceID: "R1", at: time.Hour, expected: mcom.GetResourceLocationAtReply{ID: "A", Location: "01"}},
+		{resourceID: "R1", at: 90 * time.Minute, expected: mcom.GetResourceLocationAtReply{ID: "A", Location: "01"}},
+		{resourceID: "R1", at: 5 * time.Hour, expected: mcom.GetResourceLocationAtReply{ID: "B", Location: "00"}},
+		{resourceID: "R2", at: time.Hour, expected: mcom.GetResourceLocationAtReply{}},
+		{resourceID: "R2", at: 5 * time.Hour, expected: mcom.GetResourceLocationAtReply{ID: "A", Location: "01"}},
+	} {
+		rep, err := dm.GetResourceLocationAt(ctx, mcom.GetResourceLocationAtRequest{ResourceID: c.resourceID, Time: base.Add(c.at)})
+		assert.NoError(err)
+		assert.Equal(c.expected, rep, "%s at %v", c.resourceID, c.at)
+	}
+}
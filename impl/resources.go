@@ -161,6 +161,14 @@ func (session *session) createMaterialResourcesAndStockIn(
 
 	// stock in.
 	if needStockIn(warehouse) {
+		// the resources are new to the warehouse.
+		stocked := make([]models.MaterialResource, len(resources))
+		for i, resource := range resources {
+			stocked[i] = models.MaterialResource{ProductType: resource.ProductType, Quantity: resource.Quantity}
+		}
+		if err := tx.checkWarehouseLocation(warehouse, stocked); err != nil {
+			return nil, err
+		}
 		if err := tx.stockIn(resources, warehouse); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,179 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+)
+
+// SetShiftCalendar implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) SetShiftCalendar(ctx context.Context, req mcom.SetShiftCalendarRequest) error {
+	if err := req.CheckInsufficiency(); err != nil {
+		return err
+	}
+
+	shifts := make(models.ShiftDefinitions, len(req.Shifts))
+	for i, shift := range req.Shifts {
+		shifts[i] = models.ShiftDefinition{
+			Name:  shift.Name,
+			Start: shift.Start,
+		}
+	}
+	holidays := make([]string, len(req.Holidays))
+	for i, holiday := range req.Holidays {
+		holidays[i] = holiday.Format(models.WorkDateLayout)
+	}
+	calendar := models.ShiftCalendar{
+		DepartmentID: req.DepartmentID,
+		TimeZone:     req.TimeZone,
+		Shifts:       shifts,
+		Holidays:     pq.StringArray(holidays),
+		UpdatedBy:    commonsCtx.UserID(ctx),
+	}
+	if len(req.Rotation.Groups) != 0 {
+		calendar.Rotation = models.ShiftRotation{
+			Since:  req.Rotation.Since.Format(models.WorkDateLayout),
+			Groups: req.Rotation.Groups,
+		}
+	}
+
+	session := dm.newSession(ctx)
+	return session.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "department_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"time_zone", "shifts", "rotation", "holidays", "updated_at", "updated_by"}),
+		}).
+		Create(&calendar).Error
+}
+
+func (session *session) getShiftCalendar(departmentID string) (models.ShiftCalendar, error) {
+	var calendar models.ShiftCalendar
+	if err := session.db.Where(`department_id = ?`, departmentID).Take(&calendar).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ShiftCalendar{}, mcomErr.Error{
+				Code:    mcomErr.Code_SHIFT_CALENDAR_NOT_FOUND,
+				Details: "department: " + departmentID,
+			}
+		}
+		return models.ShiftCalendar{}, err
+	}
+	return calendar, nil
+}
+
+// GetShiftCalendar implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) GetShiftCalendar(ctx context.Context, req mcom.GetShiftCalendarRequest) (mcom.GetShiftCalendarReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.GetShiftCalendarReply{}, err
+	}
+
+	session := dm.newSession(ctx)
+	calendar, err := session.getShiftCalendar(req.DepartmentID)
+	if err != nil {
+		return mcom.GetShiftCalendarReply{}, err
+	}
+
+	res := mcom.GetShiftCalendarReply{
+		DepartmentID: calendar.DepartmentID,
+		TimeZone:     calendar.TimeZone,
+		Shifts:       make([]mcom.Shift, len(calendar.Shifts)),
+		Holidays:     make([]time.Time, len(calendar.Holidays)),
+		UpdatedAt:    calendar.UpdatedAt,
+		UpdatedBy:    calendar.UpdatedBy,
+	}
+	for i, shift := range calendar.Shifts {
+		res.Shifts[i] = mcom.Shift{
+			Name:  shift.Name,
+			Start: shift.Start,
+		}
+	}
+	for i, holiday := range calendar.Holidays {
+		if res.Holidays[i], err = time.Parse(models.WorkDateLayout, holiday); err != nil {
+			return mcom.GetShiftCalendarReply{}, err
+		}
+	}
+	if len(calendar.Rotation.Groups) != 0 {
+		since, err := time.Parse(models.WorkDateLayout, calendar.Rotation.Since)
+		if err != nil {
+			return mcom.GetShiftCalendarReply{}, err
+		}
+		res.Rotation = mcom.ShiftRotation{
+			Since:  since,
+			Groups: calendar.Rotation.Groups,
+		}
+	}
+	return res, nil
+}
+
+// resolveShift returns the shift of the specified time in the shift calendar
+// of the department.
+func (session *session) resolveShift(departmentID string, t time.Time) (models.ShiftAssignment, error) {
+	calendar, err := session.getShiftCalendar(departmentID)
+	if err != nil {
+		return models.ShiftAssignment{}, err
+	}
+	return calendar.Resolve(t)
+}
+
+// ResolveWorkDate implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) ResolveWorkDate(ctx context.Context, req mcom.ResolveWorkDateRequest) (mcom.ResolveWorkDateReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.ResolveWorkDateReply{}, err
+	}
+
+	session := dm.newSession(ctx)
+	station, err := session.getStation(req.Station)
+	if err != nil {
+		return mcom.ResolveWorkDateReply{}, err
+	}
+
+	shift, err := session.resolveShift(station.AdminDepartmentID, req.Time)
+	if err != nil {
+		return mcom.ResolveWorkDateReply{}, err
+	}
+	return mcom.ResolveWorkDateReply{
+		WorkDate: shift.WorkDate,
+		Shift:    shift.Shift,
+		Group:    shift.Group,
+		Holiday:  shift.Holiday,
+	}, nil
+}
+
+// verifyWorkDateByShiftCalendar verifies if the work date is the work date of
+// now in the shift calendar of the department.
+func (session *session) verifyWorkDateByShiftCalendar(departmentID string, workDate time.Time) error {
+	shift, err := session.resolveShift(departmentID, time.Now())
+	if err != nil {
+		return err
+	}
+	if shift.WorkDate.Format(models.WorkDateLayout) != workDate.Format(models.WorkDateLayout) {
+		return mcomErr.Error{
+			Code:    mcomErr.Code_BAD_WORK_DATE,
+			Details: "expected work date: " + shift.WorkDate.Format(models.WorkDateLayout),
+		}
+	}
+	return nil
+}
+
+// parseWorkDate returns the work date of the specified time in the shift
+// calendar of the department if resolve is true, otherwise the date of the
+// time.
+func (session *session) parseWorkDate(departmentID string, t time.Time, resolve bool) (time.Time, error) {
+	if !resolve {
+		return parseDate(t)
+	}
+	shift, err := session.resolveShift(departmentID, t)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return shift.WorkDate, nil
+}
@@ -0,0 +1,112 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+)
+
+func TestDataManager_ShiftCalendar(t *testing.T) {
+	assert := assert.New(t)
+	ctx, dm, db := initializeDB(t)
+	defer dm.Close()
+	ctx = commonsCtx.WithUserID(ctx, testUser)
+	cm := newClearMaster(db, &models.ShiftCalendar{}, &models.Station{}, &models.Site{}, &models.SiteContents{})
+	assert.NoError(cm.Clear())
+
+	req := mcom.SetShiftCalendarRequest{
+		DepartmentID: testDepartmentA,
+		TimeZone:     "Asia/Taipei",
+		Shifts: []mcom.Shift{
+			{Name: "A", Start: 7*time.Hour + 30*time.Minute},
+			{Name: "B", Start: 15*time.Hour + 30*time.Minute},
+			{Name: "C", Start: 23*time.Hour + 30*time.Minute},
+		},
+		Rotation: mcom.ShiftRotation{
+			Since:  time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+			Groups: [][]int32{{1, 2, 3}, {4, 1, 2}, {3, 4, 1}, {2, 3, 4}},
+		},
+		Holidays: []time.Time{time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)},
+	}
+
+	{ // GetShiftCalendar: not found.
+		_, err := dm.GetShiftCalendar(ctx, mcom.GetShiftCalendarRequest{DepartmentID: testDepartmentA})
+		assert.ErrorIs(err, mcomErr.Error{
+			Code:    mcomErr.Code_SHIFT_CALENDAR_NOT_FOUND,
+			Details: "department: " + testDepartmentA,
+		})
+	}
+	{ // SetShiftCalendar: good case.
+		assert.NoError(dm.SetShiftCalendar(ctx, req))
+
+		actual, err := dm.GetShiftCalendar(ctx, mcom.GetShiftCalendarRequest{DepartmentID: testDepartmentA})
+		assert.NoError(err)
+		assert.Equal(mcom.GetShiftCalendarReply{
+			DepartmentID: testDepartmentA,
+			TimeZone:     req.TimeZone,
+			Shifts:       req.Shifts,
+			Rotation:     req.Rotation,
+			Holidays:     req.Holidays,
+			UpdatedAt:    actual.UpdatedAt,
+			UpdatedBy:    testUser,
+		}, actual)
+	}
+	{ // SetShiftCalendar: replace.
+		req.Holidays = []time.Time{}
+		assert.NoError(dm.SetShiftCalendar(ctx, req))
+
+		actual, err := dm.GetShiftCalendar(ctx, mcom.GetShiftCalendarRequest{DepartmentID: testDepartmentA})
+		assert.NoError(err)
+		assert.Empty(actual.Holidays)
+	}
+	{ // ResolveWorkDate: station not found.
+		_, err := dm.ResolveWorkDate(ctx, mcom.ResolveWorkDateRequest{
+			Station: testStationA,
+			Time:    time.Now(),
+		})
+		assert.ErrorIs(err, mcomErr.Error{
+			Code:    mcomErr.Code_STATION_NOT_FOUND,
+			Details: "station not found, id: " + testStationA,
+		})
+	}
+
+	assert.NoError(dm.CreateStation(ctx, mcom.CreateStationRequest{
+		ID:            testStationA,
+		DepartmentOID: testDepartmentA,
+	}))
+
+	{ // ResolveWorkDate: good case.
+		actual, err := dm.ResolveWorkDate(ctx, mcom.ResolveWorkDateRequest{
+			Station: testStationA,
+			// 2022-01-03 01:00 in Asia/Taipei.
+			Time: time.Date(2022, 1, 2, 17, 0, 0, 0, time.UTC),
+		})
+		assert.NoError(err)
+		assert.Equal(mcom.ResolveWorkDateReply{
+			WorkDate: time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC),
+			Shift:    "C",
+			Group:    2,
+		}, actual)
+	}
+	{ // ListProductPlans: resolve work date.
+		_, err := dm.ListProductPlans(ctx, mcom.ListProductPlansRequest{
+			DepartmentOID:   testDepartmentB,
+			Date:            time.Now(),
+			ProductType:     "A",
+			ResolveWorkDate: true,
+		})
+		assert.ErrorIs(err, mcomErr.Error{
+			Code:    mcomErr.Code_SHIFT_CALENDAR_NOT_FOUND,
+			Details: "department: " + testDepartmentB,
+		})
+	}
+
+	assert.NoError(cm.Clear())
+}
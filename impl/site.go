@@ -21,6 +21,7 @@ import (
 	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
 	"gitlab.kenda.com.tw/kenda/mcom/utils/bindtype"
 	"gitlab.kenda.com.tw/kenda/mcom/utils/sites"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
 )
 
 func (dm *DataManager) BindRecordsCheck(ctx context.Context, req mcom.BindRecordsCheckRequest) error {
@@ -56,6 +57,195 @@ func (dm *DataManager) BindRecordsCheck(ctx context.Context, req mcom.BindRecord
 	}
 }
 
+// ListSiteBindHistory implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) ListSiteBindHistory(ctx context.Context, req mcom.ListSiteBindHistoryRequest) (mcom.ListSiteBindHistoryReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.ListSiteBindHistoryReply{}, err
+	}
+
+	session := dm.newSession(ctx)
+	site := models.UniqueSite{SiteID: req.Site}
+	if req.Station != "" {
+		var err error
+		if site, err = session.getStationSite(req.Station, req.Site); err != nil {
+			return mcom.ListSiteBindHistoryReply{}, err
+		}
+	} else if _, err := session.getSiteAttributes(site); err != nil {
+		return mcom.ListSiteBindHistoryReply{}, err
+	}
+
+	query := session.db.
+		Where(`station = ? AND site_name = ? AND site_index = ? AND created_at >= ?`,
+			site.Station, site.SiteID.Name, site.SiteID.Index, types.ToTimeNano(req.Since))
+	if !req.Until.IsZero() {
+		query = query.Where(`created_at <= ?`, types.ToTimeNano(req.Until))
+	}
+
+	var histories []models.SiteBindHistory
+	if err := query.Order(`created_at, id`).Find(&histories).Error; err != nil {
+		return mcom.ListSiteBindHistoryReply{}, err
+	}
+
+	res := make([]mcom.SiteBindHistory, len(histories))
+	for i, history := range histories {
+		res[i] = mcom.SiteBindHistory{
+			Site:      site,
+			BindType:  history.BindType,
+			Resources: history.Resources,
+			Before:    history.Before,
+			After:     history.After,
+			CreatedAt: history.CreatedAt.Time(),
+			CreatedBy: history.CreatedBy,
+		}
+	}
+	return mcom.ListSiteBindHistoryReply{Histories: res}, nil
+}
+
+// getStationSite returns the specified site of the station. The station of
+// the returned site is the owner of the site, which is different from the
+// specified station if the site is shared.
+func (session *session) getStationSite(stationID string, siteID models.SiteID) (models.UniqueSite, error) {
+	station, err := session.getStation(stationID)
+	if err != nil {
+		return models.UniqueSite{}, err
+	}
+	for _, site := range station.Sites {
+		if site.SiteID == siteID {
+			return site, nil
+		}
+	}
+	return models.UniqueSite{}, mcomErr.Error{Code: mcomErr.Code_STATION_SITE_NOT_FOUND}
+}
+
+// TransferSiteContents implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) TransferSiteContents(ctx context.Context, req mcom.TransferSiteContentsRequest) error {
+	if err := req.CheckInsufficiency(); err != nil {
+		return err
+	}
+	updatedBy := commonsCtx.UserID(ctx)
+	session := dm.newSession(ctx)
+	tx, cancel := session.beginTx().withTimeout()
+	defer cancel()
+	defer tx.Rollback() // nolint: errcheck
+
+	stationSites, err := tx.getToBindSites(req)
+	if err != nil {
+		return err
+	}
+	from, to := stationSites[0].Information, stationSites[1].Information
+	if from.SubType != sites.SubType_MATERIAL {
+		return mcomErr.Error{
+			Code:    mcomErr.Code_BAD_REQUEST,
+			Details: fmt.Sprintf("invalid site sub type, station: %s name: %s index: %d", from.Station, from.SiteID.Name, from.SiteID.Index),
+		}
+	}
+	if to.SubType != from.SubType {
+		return mcomErr.Error{
+			Code:    mcomErr.Code_STATION_SITE_SUB_TYPE_MISMATCH,
+			Details: fmt.Sprintf("station: %s name: %s index: %d", to.Station, to.SiteID.Name, to.SiteID.Index),
+		}
+	}
+	if to.Type != from.Type {
+		return mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "site type mismatch"}
+	}
+
+	contentsPool, err := newSiteContentsPoolV2(stationSites)
+	if err != nil {
+		return err
+	}
+	fromContent, err := contentsPool.get(req.From)
+	if err != nil {
+		return err
+	}
+	toContent, err := contentsPool.get(req.To)
+	if err != nil {
+		return err
+	}
+	fromBefore, err := fromContent.Copy()
+	if err != nil {
+		return err
+	}
+	toBefore, err := toContent.Copy()
+	if err != nil {
+		return err
+	}
+
+	selected := make(map[string]bool, len(req.ResourceIDs))
+	for _, id := range req.ResourceIDs {
+		selected[id] = false
+	}
+	moved, err := fromContent.Transfer(*toContent, func(material models.MaterialSite) bool {
+		if len(selected) == 0 {
+			return true
+		}
+		if _, ok := selected[material.ResourceID]; ok {
+			selected[material.ResourceID] = true
+			return true
+		}
+		return false
+	})
+	if err != nil {
+		return err
+	}
+	notFound := []string{}
+	for _, id := range req.ResourceIDs {
+		if !selected[id] {
+			notFound = append(notFound, id)
+		}
+	}
+	if len(notFound) != 0 {
+		return mcomErr.Error{
+			Code:    mcomErr.Code_RESOURCE_NOT_FOUND,
+			Details: "resources not in the site: " + strings.Join(notFound, ";"),
+		}
+	}
+
+	toBindResources := make([]mcom.BindMaterialResource, len(moved))
+	for i, resource := range moved {
+		toBindResources[i] = mcom.BindMaterialResource{
+			Material:    resource.Material.Material,
+			Quantity:    resource.Material.Quantity,
+			ResourceID:  resource.Material.ResourceID,
+			ProductType: resource.Material.ProductType,
+			Status:      resource.Material.Status,
+			ExpiryTime:  resource.Material.ExpiryTime,
+		}
+	}
+	if err := tx.checkSitesLimitation(map[models.UniqueSite][]mcom.BindMaterialResource{req.To: toBindResources}); err != nil {
+		return err
+	}
+	if to.Capacity.IsLimited() {
+		if err := tx.checkSiteCapacity(to, *toContent); err != nil {
+			return err
+		}
+	}
+
+	fromHistory, err := newSiteBindHistory(from.UniqueSite, bindtype.BindType_RESOURCE_BINDING_TRANSFER_OUT, moved, fromBefore, *fromContent, updatedBy)
+	if err != nil {
+		return err
+	}
+	toHistory, err := newSiteBindHistory(to.UniqueSite, bindtype.BindType_RESOURCE_BINDING_TRANSFER_IN, moved, toBefore, *toContent, updatedBy)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.updateSiteContents(contentsPool.listContentsToUpdate(), updatedBy); err != nil {
+		return err
+	}
+	if err := tx.createSiteBindHistories([]models.SiteBindHistory{fromHistory, toHistory}); err != nil {
+		return err
+	}
+	if err := tx.addBindRecordV2(mcom.MaterialResourceBindRequestV2{
+		Details: []mcom.MaterialBindRequestDetailV2{{
+			Site:      req.To,
+			Resources: toBindResources,
+		}},
+	}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
 // Deprecated: use V2 instead
 func (tx *txDataManager) addBindRecord(req mcom.MaterialResourceBindRequest) error {
 	for _, detail := range req.Details {
@@ -180,6 +370,14 @@ func (dm *DataManager) MaterialResourceBindV2(ctx context.Context, req mcom.Mate
 		return err
 	}
 
+	toCheckLimitation := make(map[models.UniqueSite][]mcom.BindMaterialResource)
+	for _, detail := range req.Details {
+		toCheckLimitation[detail.Site] = append(toCheckLimitation[detail.Site], detail.Resources...)
+	}
+	if err := tx.checkSitesLimitation(toCheckLimitation); err != nil {
+		return err
+	}
+
 	// parse resources
 	resources := []mcom.BindMaterialResource{}
 	for _, detail := range req.Details {
@@ -191,11 +389,16 @@ func (dm *DataManager) MaterialResourceBindV2(ctx context.Context, req mcom.Mate
 		return err
 	}
 
-	for _, detail := range req.Details {
+	histories := make([]models.SiteBindHistory, len(req.Details))
+	for i, detail := range req.Details {
 		site, err := contentsPool.get(detail.Site)
 		if err != nil {
 			return err
 		}
+		before, err := site.Copy()
+		if err != nil {
+			return err
+		}
 		resources := parseBoundResources(detail.Resources)
 		var takenResources []models.BoundResource
 
@@ -275,6 +478,14 @@ func (dm *DataManager) MaterialResourceBindV2(ctx context.Context, req mcom.Mate
 			return fmt.Errorf("bind type unspecified")
 		}
 		resourcesManager.add(takenResources)
+
+		if histories[i], err = newSiteBindHistory(detail.Site, detail.Type, resources, before, *site, updatedBy); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.checkSitesCapacity(req, stationSites, contentsPool); err != nil {
+		return err
 	}
 
 	stocksVariation, err := session.listStocksVariation(commonsCtx.Logger(ctx), resourcesManager)
@@ -295,6 +506,9 @@ func (dm *DataManager) MaterialResourceBindV2(ctx context.Context, req mcom.Mate
 	if err := tx.updateSiteContents(contentsToUpdate, updatedBy); err != nil {
 		return err
 	}
+	if err := tx.createSiteBindHistories(histories); err != nil {
+		return err
+	}
 
 	return tx.Commit()
 }
@@ -361,6 +575,8 @@ func (tx *txDataManager) getToBindSites(req mcom.ResourceBindRequest) ([]mcom.Li
 				Type:       siteMap[d.GetUniqueSite()].Attributes.Type,
 				SubType:    siteMap[d.GetUniqueSite()].Attributes.SubType,
 				Limitation: siteMap[d.GetUniqueSite()].Attributes.Limitation,
+				Capacity:   siteMap[d.GetUniqueSite()].Attributes.Capacity,
+				FeedPolicy: siteMap[d.GetUniqueSite()].Attributes.FeedPolicy,
 			},
 			Content: contentMap[d.GetUniqueSite()],
 		}
@@ -587,7 +803,22 @@ func (dm *DataManager) MaterialResourceBind(ctx context.Context, req mcom.Materi
 		return err
 	}
 
+	siteOwners := make(map[models.SiteID]string)
+	for _, site := range stationSites {
+		siteOwners[site.Information.SiteID] = site.Information.Station
+	}
+
+	toCheckLimitation := make(map[models.UniqueSite][]mcom.BindMaterialResource)
 	for _, detail := range req.Details {
+		site := models.UniqueSite{SiteID: detail.Site, Station: siteOwners[detail.Site]}
+		toCheckLimitation[site] = append(toCheckLimitation[site], detail.Resources...)
+	}
+	if err := tx.checkSitesLimitation(toCheckLimitation); err != nil {
+		return err
+	}
+
+	histories := make([]models.SiteBindHistory, len(req.Details))
+	for i, detail := range req.Details {
 		site, err := contentsPool.get(models.UniqueSite{SiteID: models.SiteID{
 			Name:  detail.Site.Name,
 			Index: detail.Site.Index,
@@ -595,6 +826,10 @@ func (dm *DataManager) MaterialResourceBind(ctx context.Context, req mcom.Materi
 		if err != nil {
 			return err
 		}
+		before, err := site.Copy()
+		if err != nil {
+			return err
+		}
 		resources := parseBoundResources(detail.Resources)
 		var takenResources []models.BoundResource
 
@@ -674,6 +909,13 @@ func (dm *DataManager) MaterialResourceBind(ctx context.Context, req mcom.Materi
 			return fmt.Errorf("bind type unspecified")
 		}
 		resourcesManager.add(takenResources)
+
+		if histories[i], err = newSiteBindHistory(models.UniqueSite{
+			SiteID:  detail.Site,
+			Station: siteOwners[detail.Site],
+		}, detail.Type, resources, before, *site, updatedBy); err != nil {
+			return err
+		}
 	}
 
 	stocksVariation, err := session.listStocksVariation(commonsCtx.Logger(ctx), resourcesManager)
@@ -694,6 +936,9 @@ func (dm *DataManager) MaterialResourceBind(ctx context.Context, req mcom.Materi
 	if err := tx.updateSiteContents(contentsToUpdate, updatedBy); err != nil {
 		return err
 	}
+	if err := tx.createSiteBindHistories(histories); err != nil {
+		return err
+	}
 
 	return tx.Commit()
 }
@@ -765,6 +1010,227 @@ func checkBadRequest(req mcom.MaterialResourceBindRequestV2, siteTypeMapping map
 	return nil
 }
 
+// checkSitesLimitation checks the resources to bind with the limitation of the
+// sites which enforce their limitation. Besides the product ids and patterns,
+// the children of the product groups and the substitutions of the allowed
+// products are also allowed.
+func (tx *txDataManager) checkSitesLimitation(resources map[models.UniqueSite][]mcom.BindMaterialResource) error {
+	condition := [][3]string{}
+	for site, res := range resources {
+		if len(res) != 0 {
+			condition = append(condition, [3]string{site.Station, site.SiteID.Name, strconv.Itoa(int(site.SiteID.Index))})
+		}
+	}
+	if len(condition) == 0 {
+		return nil
+	}
+
+	var targetSites []models.Site
+	if err := tx.db.Where(`(station, name, index) IN ?`, condition).Find(&targetSites).Error; err != nil {
+		return err
+	}
+
+	limitations := make(map[models.UniqueSite][]string)
+	for _, site := range targetSites {
+		if site.Attributes.LimitationEnforced && len(site.Attributes.Limitation) != 0 {
+			limitations[models.UniqueSite{
+				SiteID:  models.SiteID{Name: site.Name, Index: site.Index},
+				Station: site.Station,
+			}] = site.Attributes.Limitation
+		}
+	}
+	if len(limitations) == 0 {
+		return nil
+	}
+
+	for site, limitation := range limitations {
+		allowed, err := expandLimitation(tx.db, limitation)
+		if err != nil {
+			return err
+		}
+		for _, resource := range resources[site] {
+			if !models.MatchLimitation(allowed, resource.Material.ID) {
+				return mcomErr.Error{
+					Code: mcomErr.Code_PRODUCT_ID_MISMATCH,
+					Details: fmt.Sprintf("resource: %s, product id: %s is not allowed in station: %s name: %s index: %d",
+						resource.ResourceID, resource.Material.ID, site.Station, site.SiteID.Name, site.SiteID.Index),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// expandLimitation appends the children of the product groups and the
+// substitutions of the products in the limitation.
+func expandLimitation(db *gorm.DB, limitation []string) ([]string, error) {
+	productIDs := []string{}
+	for _, entry := range limitation {
+		if !models.IsLimitationPattern(entry) {
+			productIDs = append(productIDs, entry)
+		}
+	}
+	if len(productIDs) == 0 {
+		return limitation, nil
+	}
+
+	res := append([]string{}, limitation...)
+
+	var groups []models.ProductGroup
+	if err := db.Where(`product_id IN ?`, productIDs).Find(&groups).Error; err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		productIDs = append(productIDs, group.Children...)
+	}
+	res = append(res, productIDs...)
+
+	var mappings []models.SubstitutionMapping
+	if err := db.Where(`id IN ?`, productIDs).Find(&mappings).Error; err != nil {
+		return nil, err
+	}
+	for _, mapping := range mappings {
+		for _, substitution := range mapping.Substitutions {
+			res = append(res, substitution.ID)
+		}
+	}
+	return res, nil
+}
+
+// checkSitesCapacity checks the capacity of the sites which resources are
+// bound to. Sites without new resources are not checked so that the resources
+// in the overloaded sites could still be removed.
+func (tx *txDataManager) checkSitesCapacity(req mcom.MaterialResourceBindRequestV2, stationSites []mcom.ListStationSite, contentsPool siteContentsPool) error {
+	toCheck := make(map[models.UniqueSite]struct{})
+	for _, detail := range req.Details {
+		if len(detail.Resources) != 0 {
+			toCheck[detail.Site] = struct{}{}
+		}
+	}
+
+	for _, site := range stationSites {
+		capacity := site.Information.Capacity
+		if _, ok := toCheck[site.Information.UniqueSite]; !ok || !capacity.IsLimited() {
+			continue
+		}
+
+		content, err := contentsPool.get(site.Information.UniqueSite)
+		if err != nil {
+			return err
+		}
+		if err := tx.checkSiteCapacity(site.Information, *content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkSiteCapacity checks the content of the site with its capacity.
+func (tx *txDataManager) checkSiteCapacity(site mcom.ListStationSitesInformation, content models.SiteContent) error {
+	var units map[models.UniqueMaterialResource]string
+	if len(site.Capacity.MaxQuantity) != 0 {
+		var err error
+		if units, err = tx.listResourceUnits(content); err != nil {
+			return err
+		}
+	}
+
+	if err := site.Capacity.Check(content, units); err != nil {
+		if e, ok := mcomErr.As(err); ok {
+			e.Details = fmt.Sprintf("station: %s name: %s index: %d, %s",
+				site.Station, site.SiteID.Name, site.SiteID.Index, e.Details)
+			return e
+		}
+		return err
+	}
+	return nil
+}
+
+// setRemainingCapacity sets the remaining capacity of the limited sites.
+func (tx *txDataManager) setRemainingCapacity(stationSites []mcom.ListStationSite) error {
+	var contents []models.SiteContent
+	for _, site := range stationSites {
+		if len(site.Information.Capacity.MaxQuantity) != 0 {
+			contents = append(contents, site.Content)
+		}
+	}
+	units, err := tx.listResourceUnits(contents...)
+	if err != nil {
+		return err
+	}
+
+	for i, site := range stationSites {
+		if site.Information.Capacity.IsLimited() {
+			stationSites[i].Information.RemainingCapacity = site.Information.Capacity.Remaining(site.Content, units)
+		}
+	}
+	return nil
+}
+
+// listResourceUnits returns the units of the materials in the specified site contents.
+func (tx *txDataManager) listResourceUnits(contents ...models.SiteContent) (map[models.UniqueMaterialResource]string, error) {
+	res := make(map[models.UniqueMaterialResource]string)
+	condition := [][]string{}
+	for _, content := range contents {
+		for _, material := range content.Materials() {
+			condition = append(condition, []string{material.ResourceID, material.ProductType})
+		}
+	}
+	if len(condition) == 0 {
+		return res, nil
+	}
+
+	var resources []models.MaterialResource
+	if err := tx.db.Model(&models.MaterialResource{}).
+		Where(`(id, product_type) IN ?`, condition).
+		Find(&resources).Error; err != nil {
+		return nil, err
+	}
+	for _, resource := range resources {
+		res[models.UniqueMaterialResource{
+			ResourceID:  resource.ID,
+			ProductType: resource.ProductType,
+		}] = resource.Info.Unit
+	}
+	return res, nil
+}
+
+func newSiteBindHistory(
+	site models.UniqueSite,
+	bindType bindtype.BindType,
+	resources []models.BoundResource,
+	before models.SiteContent,
+	after models.SiteContent,
+	createdBy string) (models.SiteBindHistory, error) {
+	// the site content will be mutated by the following bind actions.
+	after, err := after.Copy()
+	if err != nil {
+		return models.SiteBindHistory{}, err
+	}
+
+	boundResources := make(models.BindHistoryResources, len(resources))
+	for i, res := range resources {
+		boundResources[i] = *res.Material
+	}
+	return models.SiteBindHistory{
+		Station:   site.Station,
+		SiteName:  site.SiteID.Name,
+		SiteIndex: site.SiteID.Index,
+		BindType:  bindType,
+		Resources: boundResources,
+		Before:    before,
+		After:     after,
+		CreatedBy: createdBy,
+	}, nil
+}
+
+func (tx *txDataManager) createSiteBindHistories(histories []models.SiteBindHistory) error {
+	if len(histories) == 0 {
+		return nil
+	}
+	return tx.db.Create(&histories).Error
+}
+
 func parseBoundResources(resources []mcom.BindMaterialResource) []models.BoundResource {
 	res := make([]models.BoundResource, len(resources))
 	for i := range resources {
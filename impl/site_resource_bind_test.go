@@ -1346,6 +1346,84 @@ func (aso bindOperationAssertion) assertSiteContents(expectedSiteContents models
 	assert.Equal(expectedSiteContents.Content, actual.Content)
 }
 
+func TestDataManager_SiteCapacity(t *testing.T) {
+	ctx, dm, db := initializeDB(t)
+	defer dm.Close()
+	assert := assert.New(t)
+
+	assert.NoError(clearMaterialResourceBindTestDB(db))
+	defer func() { assert.NoError(clearMaterialResourceBindTestDB(db)) }()
+
+	_, err := dm.CreateMaterialResources(ctx, mcom.CreateMaterialResourcesRequest{
+		Materials: []mcom.CreateMaterialResourcesRequestDetail{
+			{Type: "A", ID: "A", Quantity: decimal.NewFromInt(100), Unit: "KG", ResourceID: "A"},
+			{Type: "B", ID: "B", Quantity: decimal.NewFromInt(100), Unit: "KG", ResourceID: "B"},
+		},
+	})
+	assert.NoError(err)
+
+	assert.NoError(dm.CreateStation(ctx, mcom.CreateStationRequest{
+		ID:            testStationA,
+		DepartmentOID: testDepartmentA,
+		Sites: []mcom.SiteInformation{{
+			Name:    "capacity",
+			Type:    sites.Type_CONTAINER,
+			SubType: sites.SubType_MATERIAL,
+			Capacity: models.SiteCapacity{
+				MaxResources: 2,
+				MaxQuantity:  map[string]decimal.Decimal{"KG": decimal.NewFromInt(50)},
+			},
+		}},
+		State: stations.State_IDLE,
+	}))
+
+	site := models.UniqueSite{SiteID: models.SiteID{Name: "capacity"}, Station: testStationA}
+	bind := func(bindType bindtype.BindType, quantities ...int32) error {
+		resources := make([]mcom.BindMaterialResource, len(quantities))
+		for i, quantity := range quantities {
+			id := []string{"A", "B"}[i%2]
+			resources[i] = mcom.BindMaterialResource{
+				Material:    models.Material{ID: id},
+				Quantity:    types.Decimal.NewFromInt32(quantity),
+				ResourceID:  id,
+				ProductType: id,
+			}
+		}
+		return dm.MaterialResourceBindV2(ctx, mcom.MaterialResourceBindRequestV2{
+			Details: []mcom.MaterialBindRequestDetailV2{{Type: bindType, Site: site, Resources: resources}},
+		})
+	}
+
+	{ // max quantity exceeded.
+		assert.ErrorIs(bind(bindtype.BindType_RESOURCE_BINDING_CONTAINER_BIND, 30, 30), mcomErr.Error{
+			Code:    mcomErr.Code_STATION_SITE_CAPACITY_EXCEEDED,
+			Details: "station: " + testStationA + " name: capacity index: 0, max quantity: 50, unit: KG",
+		})
+	}
+	{ // max resources exceeded.
+		assert.ErrorIs(bind(bindtype.BindType_RESOURCE_BINDING_CONTAINER_BIND, 10, 10, 10), mcomErr.Error{
+			Code:    mcomErr.Code_STATION_SITE_CAPACITY_EXCEEDED,
+			Details: "station: " + testStationA + " name: capacity index: 0, max resources: 2",
+		})
+	}
+	{ // good case.
+		assert.NoError(bind(bindtype.BindType_RESOURCE_BINDING_CONTAINER_BIND, 10, 20))
+
+		rep, err := dm.GetStation(ctx, mcom.GetStationRequest{ID: testStationA})
+		if assert.NoError(err) && assert.Len(rep.Sites, 1) {
+			remaining := rep.Sites[0].Information.RemainingCapacity
+			if assert.NotNil(remaining.Resources) {
+				assert.Equal(0, *remaining.Resources)
+			}
+			assert.Nil(remaining.QueueLength)
+			assert.True(decimal.NewFromInt(20).Equal(remaining.Quantity["KG"]))
+		}
+	}
+	{ // clearing the site is not limited.
+		assert.NoError(bind(bindtype.BindType_RESOURCE_BINDING_CONTAINER_CLEAR))
+	}
+}
+
 func clearMaterialResourceBindTestDB(db *gorm.DB) error {
 	return newClearMaster(db,
 		&models.MaterialResource{},
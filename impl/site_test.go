@@ -10,6 +10,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
 
+	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
+
 	"gitlab.kenda.com.tw/kenda/mcom"
 	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
 	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
@@ -522,3 +524,319 @@ func Test_SiteMaterialExpiryTime(t *testing.T) {
 
 	assert.NoError(clearMaterialResourceBindTestDB(db))
 }
+
+func TestDataManager_ListSiteBindHistory(t *testing.T) {
+	assert := assert.New(t)
+	ctx := commonsCtx.WithUserID(context.Background(), testUser)
+	dm, db, err := newTestDataManager()
+	if !assert.NoError(err) {
+		return
+	}
+	if !assert.NotNil(dm) {
+		return
+	}
+	defer dm.Close()
+
+	cm := newClearMaster(db, &models.Station{}, &models.Site{}, &models.SiteContents{}, &models.MaterialResource{}, &models.BindRecords{}, &models.SiteBindHistory{})
+	assert.NoError(cm.Clear())
+
+	{ // station not found.
+		_, err := dm.ListSiteBindHistory(ctx, mcom.ListSiteBindHistoryRequest{
+			Station: testStationA,
+			Site:    models.SiteID{Name: testSiteContainer},
+			Since:   time.Now(),
+		})
+		assert.ErrorIs(err, mcomErr.Error{
+			Code:    mcomErr.Code_STATION_NOT_FOUND,
+			Details: "station not found, id: " + testStationA,
+		})
+	}
+
+	assert.NoError(dm.CreateStation(ctx, mcom.CreateStationRequest{
+		ID:            testStationA,
+		DepartmentOID: testDepartmentA,
+		Sites: []mcom.SiteInformation{{
+			Name:    testSiteContainer,
+			Type:    sites.Type_CONTAINER,
+			SubType: sites.SubType_MATERIAL,
+		}},
+		State: stations.State_IDLE,
+	}))
+
+	{ // site not found.
+		_, err := dm.ListSiteBindHistory(ctx, mcom.ListSiteBindHistoryRequest{
+			Station: testStationA,
+			Site:    models.SiteID{Name: testSiteSlot},
+			Since:   time.Now(),
+		})
+		assert.ErrorIs(err, mcomErr.Error{Code: mcomErr.Code_STATION_SITE_NOT_FOUND})
+	}
+
+	site := models.UniqueSite{SiteID: models.SiteID{Name: testSiteContainer}, Station: testStationA}
+	resource := models.MaterialSite{
+		Material:    models.Material{ID: "A", Grade: "A"},
+		Quantity:    types.Decimal.NewFromInt32(10),
+		ResourceID:  "A",
+		ProductType: "A",
+	}
+	since := time.Now()
+	assert.NoError(dm.MaterialResourceBindV2(ctx, mcom.MaterialResourceBindRequestV2{
+		Details: []mcom.MaterialBindRequestDetailV2{{
+			Type: bindtype.BindType_RESOURCE_BINDING_CONTAINER_BIND,
+			Site: site,
+			Resources: []mcom.BindMaterialResource{{
+				Material:    resource.Material,
+				Quantity:    resource.Quantity,
+				ResourceID:  resource.ResourceID,
+				ProductType: resource.ProductType,
+			}},
+		}},
+	}))
+	bound := time.Now()
+	assert.NoError(dm.MaterialResourceBindV2(ctx, mcom.MaterialResourceBindRequestV2{
+		Details: []mcom.MaterialBindRequestDetailV2{{
+			Type: bindtype.BindType_RESOURCE_BINDING_CONTAINER_CLEAR,
+			Site: site,
+		}},
+	}))
+
+	{ // good case.
+		rep, err := dm.ListSiteBindHistory(ctx, mcom.ListSiteBindHistoryRequest{
+			Station: testStationA,
+			Site:    site.SiteID,
+			Since:   since,
+		})
+		if assert.NoError(err) && assert.Len(rep.Histories, 2) {
+			assert.Equal(site, rep.Histories[0].Site)
+			assert.Equal(bindtype.BindType_RESOURCE_BINDING_CONTAINER_BIND, rep.Histories[0].BindType)
+			assert.Equal([]models.MaterialSite{resource}, rep.Histories[0].Resources)
+			assert.Equal(models.SiteContent{Container: &models.Container{}}, rep.Histories[0].Before)
+			assert.Equal(models.SiteContent{Container: &models.Container{{Material: &resource}}}, rep.Histories[0].After)
+			assert.Equal(testUser, rep.Histories[0].CreatedBy)
+
+			assert.Equal(bindtype.BindType_RESOURCE_BINDING_CONTAINER_CLEAR, rep.Histories[1].BindType)
+			assert.Empty(rep.Histories[1].Resources)
+			assert.Equal(rep.Histories[0].After, rep.Histories[1].Before)
+			assert.Equal(models.SiteContent{Container: &models.Container{}}, rep.Histories[1].After)
+		}
+	}
+	{ // time range.
+		rep, err := dm.ListSiteBindHistory(ctx, mcom.ListSiteBindHistoryRequest{
+			Station: testStationA,
+			Site:    site.SiteID,
+			Since:   since,
+			Until:   bound,
+		})
+		if assert.NoError(err) && assert.Len(rep.Histories, 1) {
+			assert.Equal(bindtype.BindType_RESOURCE_BINDING_CONTAINER_BIND, rep.Histories[0].BindType)
+		}
+	}
+	{ // shared site.
+		shared := models.UniqueSite{SiteID: models.SiteID{Name: testSiteSlot}}
+		_, err := dm.ListSiteBindHistory(ctx, mcom.ListSiteBindHistoryRequest{
+			Site:  shared.SiteID,
+			Since: since,
+		})
+		assert.ErrorIs(err, mcomErr.Error{
+			Code:    mcomErr.Code_STATION_SITE_NOT_FOUND,
+			Details: "site not found, station: , name: " + testSiteSlot + ", index: 0",
+		})
+
+		assert.NoError(db.Create(&models.Site{
+			Name:              testSiteSlot,
+			AdminDepartmentID: testDepartmentA,
+			Attributes: models.SiteAttributes{
+				Type:    sites.Type_SLOT,
+				SubType: sites.SubType_MATERIAL,
+			},
+		}).Error)
+		assert.NoError(db.Create(&models.SiteBindHistory{
+			SiteName:  testSiteSlot,
+			BindType:  bindtype.BindType_RESOURCE_BINDING_SLOT_CLEAR,
+			CreatedBy: testUser,
+		}).Error)
+
+		rep, err := dm.ListSiteBindHistory(ctx, mcom.ListSiteBindHistoryRequest{
+			Site:  shared.SiteID,
+			Since: since,
+		})
+		if assert.NoError(err) && assert.Len(rep.Histories, 1) {
+			assert.Equal(shared, rep.Histories[0].Site)
+			assert.Equal(bindtype.BindType_RESOURCE_BINDING_SLOT_CLEAR, rep.Histories[0].BindType)
+		}
+	}
+
+	assert.NoError(cm.Clear())
+}
+
+func TestDataManager_TransferSiteContents(t *testing.T) {
+	assert := assert.New(t)
+	ctx := commonsCtx.WithUserID(context.Background(), testUser)
+	dm, db, err := newTestDataManager()
+	if !assert.NoError(err) {
+		return
+	}
+	if !assert.NotNil(dm) {
+		return
+	}
+	defer dm.Close()
+
+	cm := newClearMaster(db, &models.Station{}, &models.Site{}, &models.SiteContents{}, &models.MaterialResource{},
+		&models.WarehouseStock{}, &models.BindRecords{}, &models.SiteBindHistory{})
+	assert.NoError(cm.Clear())
+
+	const (
+		testStationB  = "STATION-B"
+		testSiteQueue = "QUEUE"
+	)
+	for _, station := range []string{testStationA, testStationB} {
+		assert.NoError(dm.CreateStation(ctx, mcom.CreateStationRequest{
+			ID:            station,
+			DepartmentOID: testDepartmentA,
+			Sites: []mcom.SiteInformation{{
+				Name:    testSiteContainer,
+				Type:    sites.Type_CONTAINER,
+				SubType: sites.SubType_MATERIAL,
+			}, {
+				Name:    testSiteQueue,
+				Type:    sites.Type_QUEUE,
+				SubType: sites.SubType_MATERIAL,
+			}, {
+				Name:    testSiteSlot,
+				Type:    sites.Type_SLOT,
+				SubType: sites.SubType_OPERATOR,
+			}},
+			State: stations.State_IDLE,
+		}))
+	}
+
+	_, err = dm.CreateMaterialResources(ctx, mcom.CreateMaterialResourcesRequest{
+		Materials: []mcom.CreateMaterialResourcesRequestDetail{
+			{Type: "A", ID: "A", Quantity: decimal.NewFromInt(100), ResourceID: "A"},
+			{Type: "B", ID: "B", Quantity: decimal.NewFromInt(100), ResourceID: "B"},
+		},
+	})
+	assert.NoError(err)
+
+	siteOf := func(station, name string) models.UniqueSite {
+		return models.UniqueSite{SiteID: models.SiteID{Name: name}, Station: station}
+	}
+	from, to := siteOf(testStationA, testSiteContainer), siteOf(testStationB, testSiteContainer)
+	resource := func(id string) mcom.BindMaterialResource {
+		return mcom.BindMaterialResource{
+			Material:    models.Material{ID: id},
+			Quantity:    types.Decimal.NewFromInt32(10),
+			ResourceID:  id,
+			ProductType: id,
+		}
+	}
+	assert.NoError(dm.MaterialResourceBindV2(ctx, mcom.MaterialResourceBindRequestV2{
+		Details: []mcom.MaterialBindRequestDetailV2{{
+			Type:      bindtype.BindType_RESOURCE_BINDING_CONTAINER_BIND,
+			Site:      from,
+			Resources: []mcom.BindMaterialResource{resource("A"), resource("B")},
+		}},
+	}))
+	listResourceIDs := func(site models.UniqueSite) []string {
+		rep, err := dm.ListSiteMaterials(ctx, mcom.ListSiteMaterialsRequest{Station: site.Station, Site: site.SiteID})
+		assert.NoError(err)
+		res := make([]string, len(rep))
+		for i, m := range rep {
+			res[i] = m.ResourceID
+		}
+		return res
+	}
+
+	{ // site not found.
+		assert.ErrorIs(dm.TransferSiteContents(ctx, mcom.TransferSiteContentsRequest{
+			From: from,
+			To:   siteOf(testStationB, "not found"),
+		}), mcomErr.Error{Code: mcomErr.Code_STATION_SITE_NOT_FOUND})
+	}
+	{ // sub type mismatch.
+		assert.ErrorIs(dm.TransferSiteContents(ctx, mcom.TransferSiteContentsRequest{
+			From: from,
+			To:   siteOf(testStationB, testSiteSlot),
+		}), mcomErr.Error{
+			Code:    mcomErr.Code_STATION_SITE_SUB_TYPE_MISMATCH,
+			Details: "station: " + testStationB + " name: " + testSiteSlot + " index: 0",
+		})
+	}
+	{ // type mismatch.
+		assert.ErrorIs(dm.TransferSiteContents(ctx, mcom.TransferSiteContentsRequest{
+			From: from,
+			To:   siteOf(testStationB, testSiteQueue),
+		}), mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "site type mismatch"})
+	}
+	{ // resource not in the site.
+		assert.ErrorIs(dm.TransferSiteContents(ctx, mcom.TransferSiteContentsRequest{
+			From:        from,
+			To:          to,
+			ResourceIDs: []string{"A", "C"},
+		}), mcomErr.Error{Code: mcomErr.Code_RESOURCE_NOT_FOUND, Details: "resources not in the site: C"})
+		assert.ElementsMatch([]string{"A", "B"}, listResourceIDs(from))
+	}
+
+	since := time.Now()
+	{ // transfer the selected resources.
+		assert.NoError(dm.TransferSiteContents(ctx, mcom.TransferSiteContentsRequest{
+			From:        from,
+			To:          to,
+			ResourceIDs: []string{"A"},
+		}))
+		assert.Equal([]string{"B"}, listResourceIDs(from))
+		assert.Equal([]string{"A"}, listResourceIDs(to))
+
+		// the moved resources are recorded in the bind records of the destination.
+		assert.NoError(dm.BindRecordsCheck(ctx, mcom.BindRecordsCheckRequest{
+			Site:      to,
+			Resources: []models.UniqueMaterialResource{{ResourceID: "A", ProductType: "A"}},
+		}))
+		assert.ErrorIs(dm.BindRecordsCheck(ctx, mcom.BindRecordsCheckRequest{
+			Site:      to,
+			Resources: []models.UniqueMaterialResource{{ResourceID: "B", ProductType: "B"}},
+		}), mcomErr.Error{
+			Code:    mcomErr.Code_STATION_SITE_BIND_RECORD_NOT_FOUND,
+			Details: "not found resources: resource id:B, product type:B",
+		})
+
+		rep, err := dm.ListSiteBindHistory(ctx, mcom.ListSiteBindHistoryRequest{
+			Station: to.Station,
+			Site:    to.SiteID,
+			Since:   since,
+		})
+		if assert.NoError(err) && assert.Len(rep.Histories, 1) {
+			assert.Equal(bindtype.BindType_RESOURCE_BINDING_TRANSFER_IN, rep.Histories[0].BindType)
+			if assert.Len(rep.Histories[0].Resources, 1) {
+				assert.True(decimal.NewFromInt(10).Equal(*rep.Histories[0].Resources[0].Quantity))
+			}
+		}
+	}
+	{ // transfer all the resources.
+		assert.NoError(dm.TransferSiteContents(ctx, mcom.TransferSiteContentsRequest{
+			From: from,
+			To:   to,
+		}))
+		assert.Empty(listResourceIDs(from))
+		assert.Equal([]string{"A", "B"}, listResourceIDs(to))
+		assert.NoError(dm.BindRecordsCheck(ctx, mcom.BindRecordsCheckRequest{
+			Site: to,
+			Resources: []models.UniqueMaterialResource{
+				{ResourceID: "A", ProductType: "A"},
+				{ResourceID: "B", ProductType: "B"},
+			},
+		}))
+
+		rep, err := dm.ListSiteBindHistory(ctx, mcom.ListSiteBindHistoryRequest{
+			Station: from.Station,
+			Site:    from.SiteID,
+			Since:   since,
+		})
+		if assert.NoError(err) && assert.Len(rep.Histories, 2) {
+			assert.Equal(bindtype.BindType_RESOURCE_BINDING_TRANSFER_OUT, rep.Histories[1].BindType)
+			assert.Equal(models.SiteContent{Container: &models.Container{}}, rep.Histories[1].After)
+		}
+	}
+
+	assert.NoError(cm.Clear())
+}
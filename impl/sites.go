@@ -7,6 +7,9 @@ import (
 
 	"github.com/ahmetb/go-linq"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
 
 	"gitlab.kenda.com.tw/kenda/mcom"
 	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
@@ -71,15 +74,91 @@ func (dm *DataManager) GetSite(ctx context.Context, req mcom.GetSiteRequest) (mc
 		return mcom.GetSiteReply{}, err
 	}
 
+	// the LimitHandler allows the same products as the binding does.
+	attributes := site.Attributes
+	allowed, err := expandLimitation(session.db, attributes.Limitation)
+	if err != nil {
+		return mcom.GetSiteReply{}, err
+	}
+	attributes.Limitation = allowed
+
 	return mcom.GetSiteReply{
 		Name:               req.SiteName,
 		Index:              req.SiteIndex,
 		AdminDepartmentOID: site.AdminDepartmentID,
-		Attributes:         mcom.NewSiteAttributes(site.Attributes),
+		Attributes:         mcom.NewSiteAttributes(attributes),
 		Content:            siteContents.Content,
 	}, nil
 }
 
+// UpdateSiteLimitation implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) UpdateSiteLimitation(ctx context.Context, req mcom.UpdateSiteLimitationRequest) error {
+	if err := req.CheckInsufficiency(); err != nil {
+		return err
+	}
+
+	tx := dm.beginTx(ctx)
+	defer tx.Rollback() // nolint: errcheck
+
+	var site models.Site
+	if err := tx.db.Where(`name = ? AND index = ? AND station = ?`, req.SiteName, req.SiteIndex, req.StationID).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Take(&site).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return mcomErr.Error{Code: mcomErr.Code_STATION_SITE_NOT_FOUND}
+		}
+		return err
+	}
+
+	limitation := req.Limitation
+	if limitation == nil {
+		limitation = []string{}
+	}
+	site.Attributes.Limitation = limitation
+	site.Attributes.LimitationEnforced = req.Enforced
+
+	if err := tx.db.Model(&models.Site{}).
+		Where(`name = ? AND index = ? AND station = ?`, req.SiteName, req.SiteIndex, req.StationID).
+		Updates(models.Site{
+			Attributes: site.Attributes,
+			UpdatedBy:  commonsCtx.UserID(ctx),
+		}).Error; err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UpdateSiteFeedPolicy implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) UpdateSiteFeedPolicy(ctx context.Context, req mcom.UpdateSiteFeedPolicyRequest) error {
+	if err := req.CheckInsufficiency(); err != nil {
+		return err
+	}
+
+	tx := dm.beginTx(ctx)
+	defer tx.Rollback() // nolint: errcheck
+
+	var site models.Site
+	if err := tx.db.Where(`name = ? AND index = ? AND station = ?`, req.SiteName, req.SiteIndex, req.StationID).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Take(&site).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return mcomErr.Error{Code: mcomErr.Code_STATION_SITE_NOT_FOUND}
+		}
+		return err
+	}
+	site.Attributes.FeedPolicy = req.FeedPolicy
+
+	if err := tx.db.Model(&models.Site{}).
+		Where(`name = ? AND index = ? AND station = ?`, req.SiteName, req.SiteIndex, req.StationID).
+		Updates(models.Site{
+			Attributes: site.Attributes,
+			UpdatedBy:  commonsCtx.UserID(ctx),
+		}).Error; err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
 func (session *session) checkStationSiteRelation(stationName, siteName string, siteIndex int16) error {
 	// station contains specified site.
 	var station models.Station
@@ -3,12 +3,15 @@ package impl
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 
 	"gitlab.kenda.com.tw/kenda/mcom"
 	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
 	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/bindtype"
 	"gitlab.kenda.com.tw/kenda/mcom/utils/sites"
 	"gitlab.kenda.com.tw/kenda/mcom/utils/stations"
 	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
@@ -294,6 +297,385 @@ func TestDataManager_GetSite(t *testing.T) {
 	}
 }
 
+func TestDataManager_UpdateSiteLimitation(t *testing.T) {
+	ctx, dm, db := initializeDB(t)
+	defer dm.Close()
+	assert := assert.New(t)
+
+	cm := newClearMaster(db,
+		&models.MaterialResource{},
+		&models.SiteContents{},
+		&models.Site{},
+		&models.Station{},
+		&models.WarehouseStock{},
+		&models.BindRecords{},
+		&models.SiteBindHistory{},
+		&models.ProductGroup{},
+		&models.SubstitutionMapping{})
+	assert.NoError(cm.Clear())
+	defer func() { assert.NoError(cm.Clear()) }()
+
+	const (
+		station = "testLimitation"
+		site    = "testLimitation"
+	)
+	assert.NoError(dm.CreateStation(ctx, mcom.CreateStationRequest{
+		ID:            station,
+		DepartmentOID: testDepartmentA,
+		Sites: []mcom.SiteInformation{{
+			Name:    site,
+			Type:    sites.Type_SLOT,
+			SubType: sites.SubType_MATERIAL,
+		}},
+		State: stations.State_IDLE,
+	}))
+	assert.NoError(db.Create(&models.ProductGroup{
+		ProductID:    "GROUP",
+		ProductType:  "T",
+		DepartmentID: testDepartmentA,
+		Children:     []string{"CHILD"},
+	}).Error)
+	assert.NoError(db.Create(&models.SubstitutionMapping{
+		ID:            "APPLE",
+		Substitutions: []models.Substitution{{ID: "PINEAPPLE"}},
+		UpdatedBy:     testUser,
+	}).Error)
+
+	productIDs := []string{"APPLE", "PEN-1", "CHILD", "PINEAPPLE", "BANANA"}
+	materials := make([]mcom.CreateMaterialResourcesRequestDetail, len(productIDs))
+	for i, id := range productIDs {
+		materials[i] = mcom.CreateMaterialResourcesRequestDetail{
+			Type:       "T",
+			ID:         id,
+			Quantity:   decimal.NewFromInt(10),
+			ResourceID: id,
+		}
+	}
+	_, err := dm.CreateMaterialResources(ctx, mcom.CreateMaterialResourcesRequest{Materials: materials})
+	assert.NoError(err)
+
+	bind := func(productID string) error {
+		return dm.MaterialResourceBindV2(ctx, mcom.MaterialResourceBindRequestV2{
+			Details: []mcom.MaterialBindRequestDetailV2{{
+				Type: bindtype.BindType_RESOURCE_BINDING_SLOT_BIND,
+				Site: models.UniqueSite{SiteID: models.SiteID{Name: site}, Station: station},
+				Resources: []mcom.BindMaterialResource{{
+					Material:    models.Material{ID: productID},
+					Quantity:    types.Decimal.NewFromInt16(1),
+					ResourceID:  productID,
+					ProductType: "T",
+				}},
+			}},
+		})
+	}
+
+	{ // insufficient request.
+		assert.ErrorIs(dm.UpdateSiteLimitation(ctx, mcom.UpdateSiteLimitationRequest{StationID: station}), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "Key: 'UpdateSiteLimitationRequest.SiteName' Error:Field validation for 'SiteName' failed on the 'required' tag",
+		})
+	}
+	{ // site not found.
+		assert.ErrorIs(dm.UpdateSiteLimitation(ctx, mcom.UpdateSiteLimitationRequest{
+			StationID: station,
+			SiteName:  "not found",
+		}), mcomErr.Error{Code: mcomErr.Code_STATION_SITE_NOT_FOUND})
+	}
+	{ // the limitation is not checked if it is not enforced.
+		assert.NoError(dm.UpdateSiteLimitation(ctx, mcom.UpdateSiteLimitationRequest{
+			StationID:  station,
+			SiteName:   site,
+			Limitation: []string{"APPLE"},
+		}))
+		assert.NoError(bind("BANANA"))
+	}
+	{ // enforced.
+		assert.NoError(dm.UpdateSiteLimitation(ctx, mcom.UpdateSiteLimitationRequest{
+			StationID:  station,
+			SiteName:   site,
+			Limitation: []string{"APPLE", "PEN-*", "GROUP"},
+			Enforced:   true,
+		}))
+
+		rep, err := dm.GetSite(ctx, mcom.GetSiteRequest{StationID: station, SiteName: site})
+		if assert.NoError(err) {
+			assert.True(rep.Attributes.LimitationEnforced)
+			// product id, pattern, product group and substitution.
+			for _, id := range []string{"APPLE", "PEN-2", "CHILD", "PINEAPPLE"} {
+				assert.NoError(rep.Attributes.LimitHandler(id), id)
+			}
+			assert.ErrorIs(rep.Attributes.LimitHandler("BANANA"), mcomErr.Error{
+				Code:    mcomErr.Code_PRODUCT_ID_MISMATCH,
+				Details: "product id: BANANA",
+			})
+		}
+
+		// product id, pattern, product group and substitution.
+		for _, id := range []string{"APPLE", "PEN-1", "CHILD", "PINEAPPLE"} {
+			assert.NoError(bind(id), id)
+		}
+		assert.ErrorIs(bind("BANANA"), mcomErr.Error{
+			Code:    mcomErr.Code_PRODUCT_ID_MISMATCH,
+			Details: "resource: BANANA, product id: BANANA is not allowed in station: testLimitation name: testLimitation index: 0",
+		})
+	}
+	{ // clear the limitation.
+		assert.NoError(dm.UpdateSiteLimitation(ctx, mcom.UpdateSiteLimitationRequest{
+			StationID: station,
+			SiteName:  site,
+			Enforced:  true,
+		}))
+		assert.NoError(bind("BANANA"))
+	}
+	{ // shared site.
+		const sharedSite = "shared"
+		assert.NoError(db.Create(&models.Site{
+			Name:              sharedSite,
+			AdminDepartmentID: testDepartmentA,
+			Attributes: models.SiteAttributes{
+				Type:    sites.Type_SLOT,
+				SubType: sites.SubType_MATERIAL,
+			},
+		}).Error)
+
+		assert.NoError(dm.UpdateSiteLimitation(ctx, mcom.UpdateSiteLimitationRequest{
+			SiteName:   sharedSite,
+			Limitation: []string{"APPLE"},
+			Enforced:   true,
+		}))
+
+		var actual models.Site
+		assert.NoError(db.Where(`name = ? AND station = ''`, sharedSite).Take(&actual).Error)
+		assert.Equal([]string{"APPLE"}, actual.Attributes.Limitation)
+		assert.True(actual.Attributes.LimitationEnforced)
+	}
+}
+
+func TestDataManager_UpdateSiteFeedPolicy(t *testing.T) {
+	ctx, dm, db := initializeDB(t)
+	defer dm.Close()
+	assert := assert.New(t)
+
+	cm := newClearMaster(db,
+		&models.SiteContents{},
+		&models.Site{},
+		&models.Station{},
+		&models.FeedRecord{},
+		&models.MaterialResource{})
+	assert.NoError(cm.Clear())
+	defer func() { assert.NoError(cm.Clear()) }()
+
+	const (
+		station  = "testFeedPolicy"
+		site     = "container"
+		slotSite = "slot"
+	)
+	assert.NoError(dm.CreateStation(ctx, mcom.CreateStationRequest{
+		ID:            station,
+		DepartmentOID: testDepartmentA,
+		Sites: []mcom.SiteInformation{{
+			Name:    site,
+			Type:    sites.Type_CONTAINER,
+			SubType: sites.SubType_MATERIAL,
+		}, {
+			Name:    slotSite,
+			Type:    sites.Type_SLOT,
+			SubType: sites.SubType_MATERIAL,
+		}},
+		State: stations.State_IDLE,
+	}))
+
+	// A has expired, B expires later than C.
+	now := time.Now()
+	newResource := func(id string, expiry time.Time) models.BoundResource {
+		return models.BoundResource{Material: &models.MaterialSite{
+			Material:    models.Material{ID: id},
+			Quantity:    types.Decimal.NewFromInt32(10),
+			ResourceID:  id,
+			ProductType: id,
+			ExpiryTime:  types.ToTimeNano(expiry),
+		}}
+	}
+	resetContent := func() {
+		assert.NoError(db.Model(&models.SiteContents{}).
+			Where(`station = ? AND name = ? AND index = ?`, station, site, 0).
+			Updates(models.SiteContents{Content: models.SiteContent{Container: &models.Container{
+				newResource("A", now.Add(-time.Hour)),
+				newResource("B", now.Add(2*time.Hour)),
+				newResource("C", now.Add(time.Hour)),
+			}}}).Error)
+	}
+	feed := func(feedAll bool) error {
+		_, err := dm.Feed(ctx, mcom.FeedRequest{
+			FeedContent: []mcom.FeedPerSite{mcom.FeedPerSiteType1{
+				Site: models.UniqueSite{
+					SiteID:  models.SiteID{Name: site},
+					Station: station,
+				},
+				FeedAll:  feedAll,
+				Quantity: decimal.NewFromInt(15),
+			}},
+		})
+		return err
+	}
+	remaining := func() []string {
+		rep, err := dm.GetSite(ctx, mcom.GetSiteRequest{StationID: station, SiteName: site})
+		assert.NoError(err)
+		var res []string
+		for _, resource := range *rep.Content.Container {
+			res = append(res, resource.Material.ResourceID)
+		}
+		return res
+	}
+
+	{ // insufficient request.
+		assert.ErrorIs(dm.UpdateSiteFeedPolicy(ctx, mcom.UpdateSiteFeedPolicyRequest{SiteName: site}), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "Key: 'UpdateSiteFeedPolicyRequest.StationID' Error:Field validation for 'StationID' failed on the 'required' tag",
+		})
+	}
+	{ // site not found.
+		assert.ErrorIs(dm.UpdateSiteFeedPolicy(ctx, mcom.UpdateSiteFeedPolicyRequest{
+			StationID: station,
+			SiteName:  "not found",
+		}), mcomErr.Error{Code: mcomErr.Code_STATION_SITE_NOT_FOUND})
+	}
+	{ // FEFO and skip the expired resources.
+		policy := models.SiteFeedPolicy{Order: models.FeedOrderFEFO, Expired: models.ExpiredResourceSkipped}
+		assert.NoError(dm.UpdateSiteFeedPolicy(ctx, mcom.UpdateSiteFeedPolicyRequest{
+			StationID:  station,
+			SiteName:   site,
+			FeedPolicy: policy,
+		}))
+		rep, err := dm.GetSite(ctx, mcom.GetSiteRequest{StationID: station, SiteName: site})
+		if assert.NoError(err) {
+			assert.Equal(policy, rep.Attributes.FeedPolicy)
+		}
+
+		resetContent()
+		assert.NoError(feed(false))
+		assert.Equal([]string{"A", "B"}, remaining())
+	}
+	{ // reject the expired resources.
+		assert.NoError(dm.UpdateSiteFeedPolicy(ctx, mcom.UpdateSiteFeedPolicyRequest{
+			StationID:  station,
+			SiteName:   site,
+			FeedPolicy: models.SiteFeedPolicy{Expired: models.ExpiredResourceRejected},
+		}))
+
+		resetContent()
+		assert.ErrorIs(feed(true), mcomErr.Error{Code: mcomErr.Code_RESOURCE_EXPIRED, Details: "resource: A"})
+		assert.Equal([]string{"A", "B", "C"}, remaining())
+	}
+	{ // the resources must be specified.
+		assert.NoError(dm.UpdateSiteFeedPolicy(ctx, mcom.UpdateSiteFeedPolicyRequest{
+			StationID:  station,
+			SiteName:   site,
+			FeedPolicy: models.SiteFeedPolicy{Order: models.FeedOrderExplicit},
+		}))
+
+		assert.ErrorIs(feed(false), mcomErr.Error{
+			Code:    mcomErr.Code_BAD_REQUEST,
+			Details: "the resource to feed must be specified for the site: " + site,
+		})
+		assert.NoError(feed(true))
+		assert.Empty(remaining())
+	}
+
+	// the expired resource A in the slot site.
+	resetSlot := func() {
+		slot := models.Slot(newResource("A", now.Add(-time.Hour)))
+		assert.NoError(db.Model(&models.SiteContents{}).
+			Where(`station = ? AND name = ? AND index = ?`, station, slotSite, 0).
+			Updates(models.SiteContents{Content: models.SiteContent{Slot: &slot}}).Error)
+	}
+	feedSlot := func(feedAll bool) error {
+		_, err := dm.Feed(ctx, mcom.FeedRequest{
+			FeedContent: []mcom.FeedPerSite{mcom.FeedPerSiteType1{
+				Site: models.UniqueSite{
+					SiteID:  models.SiteID{Name: slotSite},
+					Station: station,
+				},
+				FeedAll:  feedAll,
+				Quantity: decimal.NewFromInt(5),
+			}},
+		})
+		return err
+	}
+	slotResource := func() string {
+		rep, err := dm.GetSite(ctx, mcom.GetSiteRequest{StationID: station, SiteName: slotSite})
+		assert.NoError(err)
+		if rep.Content.Slot == nil || rep.Content.Slot.Material == nil {
+			return ""
+		}
+		return rep.Content.Slot.Material.ResourceID
+	}
+	{ // the slot site skips the expired resource.
+		assert.NoError(dm.UpdateSiteFeedPolicy(ctx, mcom.UpdateSiteFeedPolicyRequest{
+			StationID:  station,
+			SiteName:   slotSite,
+			FeedPolicy: models.SiteFeedPolicy{Expired: models.ExpiredResourceSkipped},
+		}))
+
+		resetSlot()
+		assert.NoError(feedSlot(false))
+		assert.NoError(feedSlot(true))
+		assert.Equal("A", slotResource())
+	}
+	{ // the slot site rejects the expired resource.
+		assert.NoError(dm.UpdateSiteFeedPolicy(ctx, mcom.UpdateSiteFeedPolicyRequest{
+			StationID:  station,
+			SiteName:   slotSite,
+			FeedPolicy: models.SiteFeedPolicy{Expired: models.ExpiredResourceRejected},
+		}))
+
+		resetSlot()
+		assert.ErrorIs(feedSlot(false), mcomErr.Error{Code: mcomErr.Code_RESOURCE_EXPIRED, Details: "resource: A"})
+		assert.ErrorIs(feedSlot(true), mcomErr.Error{Code: mcomErr.Code_RESOURCE_EXPIRED, Details: "resource: A"})
+		assert.Equal("A", slotResource())
+	}
+	{ // the slot site allows the expired resource by default.
+		assert.NoError(dm.UpdateSiteFeedPolicy(ctx, mcom.UpdateSiteFeedPolicyRequest{
+			StationID: station,
+			SiteName:  slotSite,
+		}))
+
+		resetSlot()
+		assert.NoError(feedSlot(true))
+		assert.Equal("", slotResource())
+	}
+	{ // the shared site rejects the expired resource.
+		const sharedSite = "shared"
+		assert.NoError(db.Create(&models.Site{
+			Name:              sharedSite,
+			AdminDepartmentID: testDepartmentA,
+			Attributes: models.SiteAttributes{
+				Type:       sites.Type_SLOT,
+				SubType:    sites.SubType_MATERIAL,
+				FeedPolicy: models.SiteFeedPolicy{Expired: models.ExpiredResourceRejected},
+			},
+		}).Error)
+		assert.NoError(db.Create(&models.MaterialResource{
+			ID:            "A",
+			ProductID:     "A",
+			ProductType:   "A",
+			Quantity:      decimal.NewFromInt(10),
+			ExpiryTime:    types.ToTimeNano(now.Add(-time.Hour)),
+			FeedRecordsID: []string{},
+		}).Error)
+
+		_, err := dm.Feed(ctx, mcom.FeedRequest{
+			FeedContent: []mcom.FeedPerSite{mcom.FeedPerSiteType2{
+				Site:        models.UniqueSite{SiteID: models.SiteID{Name: sharedSite}},
+				Quantity:    decimal.NewFromInt(5),
+				ResourceID:  "A",
+				ProductType: "A",
+			}},
+		})
+		assert.ErrorIs(err, mcomErr.Error{Code: mcomErr.Code_RESOURCE_EXPIRED, Details: "resource: A"})
+	}
+}
+
 func TestSiteContents_AfterFind(t *testing.T) {
 	assert := assert.New(t)
 	_, _, db := initializeDB(t)
@@ -3,6 +3,7 @@ package impl
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -10,6 +11,7 @@ import (
 	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
 
 	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
 	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
 )
 
@@ -18,30 +20,59 @@ func (dm *DataManager) SetStationConfiguration(ctx context.Context, req mcom.Set
 		return err
 	}
 
-	session := dm.newSession(ctx)
-	return session.setStationConfiguration(req, commonsCtx.UserID(ctx))
+	tx := dm.beginTx(ctx)
+	defer tx.Rollback() // nolint: errcheck
+
+	if err := tx.setStationConfiguration(req, commonsCtx.UserID(ctx)); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-func (session *session) setStationConfiguration(req mcom.SetStationConfigurationRequest, updater string) error {
-	assignments := clause.Assignments(map[string]interface{}{
-		"production": models.StationConfigProductionSetting{
-			ProductTypes: req.Feed.ProductTypes,
-		},
-		"ui": models.StationConfigUISetting{
-			SplitFeedAndCollect:     req.SplitFeedAndCollect,
-			NeedMaterialResource:    req.Feed.NeedMaterialResource,
-			FeedQuantitySource:      req.Feed.QuantitySource,
-			FeedingOperatorSites:    req.Feed.OperatorSites,
-			NeedCarrierResource:     req.Collect.NeedCarrierResource,
-			NeedCollectResource:     req.Collect.NeedCollectResource,
-			CollectQuantitySource:   req.Collect.QuantitySource,
-			DefaultCollectQuantity:  req.Collect.DefaultQuantity,
-			CollectingOperatorSites: req.Collect.OperatorSites,
-		},
-		"updated_by": updater,
-	})
+// setStationConfiguration sets the station configuration as a new version.
+func (tx *txDataManager) setStationConfiguration(req mcom.SetStationConfigurationRequest, updater string) error {
+	// lock the station configuration to serialize the versions of the station,
+	// the configuration is created if it does not exist yet.
+	if err := tx.db.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.StationConfiguration{
+			StationID: req.StationID,
+			UpdatedBy: updater,
+		}).Error; err != nil {
+		return err
+	}
+	var locked models.StationConfiguration
+	if err := tx.db.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(`station_id = ?`, req.StationID).
+		Take(&locked).Error; err != nil {
+		return err
+	}
+
+	var latest int32
+	if err := tx.db.Model(&models.StationConfigurationVersion{}).
+		Where(`station_id = ?`, req.StationID).
+		Select(`COALESCE(MAX(version), 0)`).
+		Scan(&latest).Error; err != nil {
+		return err
+	}
+
+	config := newStationConfiguration(req, updater)
+	config.Version = latest + 1
+	if err := tx.db.Create(&models.StationConfigurationVersion{
+		StationID:  config.StationID,
+		Version:    config.Version,
+		Production: config.Production,
+		UI:         config.UI,
+		CreatedBy:  updater,
+	}).Error; err != nil {
+		return err
+	}
+	return upsertStationConfiguration(tx.db, config)
+}
 
-	config := models.StationConfiguration{
+func newStationConfiguration(req mcom.SetStationConfigurationRequest, updater string) models.StationConfiguration {
+	return models.StationConfiguration{
 		StationID: req.StationID,
 		Production: models.StationConfigProductionSetting{
 			ProductTypes: req.Feed.ProductTypes,
@@ -59,8 +90,17 @@ func (session *session) setStationConfiguration(req mcom.SetStationConfiguration
 		},
 		UpdatedBy: updater,
 	}
+}
 
-	return session.db.Model(&models.StationConfiguration{}).
+func upsertStationConfiguration(db *gorm.DB, config models.StationConfiguration) error {
+	assignments := clause.Assignments(map[string]interface{}{
+		"production": config.Production,
+		"ui":         config.UI,
+		"version":    config.Version,
+		"updated_by": config.UpdatedBy,
+	})
+
+	return db.Model(&models.StationConfiguration{}).
 		Clauses(clause.OnConflict{
 			Columns:   []clause.Column{{Name: "station_id"}},
 			DoUpdates: assignments,
@@ -84,6 +124,10 @@ func (session session) getStationConfiguration(req mcom.GetStationConfigurationR
 		}
 		return mcom.GetStationConfigurationReply{}, err
 	}
+	return parseStationConfiguration(configs), nil
+}
+
+func parseStationConfiguration(configs models.StationConfiguration) mcom.GetStationConfigurationReply {
 	return mcom.GetStationConfigurationReply{
 		Feed: mcom.StationFeedConfigs{
 			ProductTypes:         configs.Production.ProductTypes,
@@ -99,7 +143,110 @@ func (session session) getStationConfiguration(req mcom.GetStationConfigurationR
 			OperatorSites:       configs.UI.CollectingOperatorSites,
 		},
 		SplitFeedAndCollect: configs.UI.SplitFeedAndCollect,
+		Version:             configs.Version,
 		UpdatedAt:           configs.UpdatedAt,
 		UpdatedBy:           configs.UpdatedBy,
+	}
+}
+
+func newStationConfigurationFromVersion(version models.StationConfigurationVersion) mcom.StationConfiguration {
+	config := parseStationConfiguration(models.StationConfiguration{
+		Production: version.Production,
+		UI:         version.UI,
+	})
+	return mcom.StationConfiguration{
+		SplitFeedAndCollect: config.SplitFeedAndCollect,
+		Feed:                config.Feed,
+		Collect:             config.Collect,
+	}
+}
+
+// ListStationConfigurationVersions implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) ListStationConfigurationVersions(ctx context.Context, req mcom.ListStationConfigurationVersionsRequest) (mcom.ListStationConfigurationVersionsReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.ListStationConfigurationVersionsReply{}, err
+	}
+
+	session := dm.newSession(ctx)
+	var versions []models.StationConfigurationVersion
+	if err := session.db.
+		Where(`station_id = ?`, req.StationID).
+		Order(`version DESC`).
+		Find(&versions).Error; err != nil {
+		return mcom.ListStationConfigurationVersionsReply{}, err
+	}
+
+	res := make([]mcom.StationConfigurationVersion, len(versions))
+	for i, version := range versions {
+		res[i] = mcom.StationConfigurationVersion{
+			Version:       version.Version,
+			Configuration: newStationConfigurationFromVersion(version),
+			CreatedAt:     version.CreatedAt,
+			CreatedBy:     version.CreatedBy,
+		}
+	}
+	return mcom.ListStationConfigurationVersionsReply{Versions: res}, nil
+}
+
+func getStationConfigurationVersion(db *gorm.DB, stationID string, version int32) (models.StationConfigurationVersion, error) {
+	var res models.StationConfigurationVersion
+	if err := db.
+		Where(`station_id = ? AND version = ?`, stationID, version).
+		Take(&res).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.StationConfigurationVersion{}, mcomErr.Error{
+				Code:    mcomErr.Code_STATION_CONFIGURATION_VERSION_NOT_FOUND,
+				Details: fmt.Sprintf("station: %s, version: %d", stationID, version),
+			}
+		}
+		return models.StationConfigurationVersion{}, err
+	}
+	return res, nil
+}
+
+// DiffStationConfigurationVersions implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) DiffStationConfigurationVersions(ctx context.Context, req mcom.DiffStationConfigurationVersionsRequest) (mcom.DiffStationConfigurationVersionsReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.DiffStationConfigurationVersionsReply{}, err
+	}
+
+	session := dm.newSession(ctx)
+	from, err := getStationConfigurationVersion(session.db, req.StationID, req.FromVersion)
+	if err != nil {
+		return mcom.DiffStationConfigurationVersionsReply{}, err
+	}
+	to, err := getStationConfigurationVersion(session.db, req.StationID, req.ToVersion)
+	if err != nil {
+		return mcom.DiffStationConfigurationVersionsReply{}, err
+	}
+
+	return mcom.DiffStationConfigurationVersionsReply{
+		Differences: newStationConfigurationFromVersion(from).Diff(newStationConfigurationFromVersion(to)),
 	}, nil
 }
+
+// RollbackStationConfiguration implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) RollbackStationConfiguration(ctx context.Context, req mcom.RollbackStationConfigurationRequest) error {
+	if err := req.CheckInsufficiency(); err != nil {
+		return err
+	}
+
+	tx := dm.beginTx(ctx)
+	defer tx.Rollback() // nolint: errcheck
+
+	version, err := getStationConfigurationVersion(tx.db, req.StationID, req.Version)
+	if err != nil {
+		return err
+	}
+	config := newStationConfigurationFromVersion(version)
+
+	if err := tx.setStationConfiguration(mcom.SetStationConfigurationRequest{
+		StationID:           req.StationID,
+		SplitFeedAndCollect: config.SplitFeedAndCollect,
+		Feed:                config.Feed,
+		Collect:             config.Collect,
+	}, commonsCtx.UserID(ctx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -1,12 +1,16 @@
 package impl
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 
+	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
+
 	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
 	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
 	"gitlab.kenda.com.tw/kenda/mcom/utils/stations"
 )
@@ -15,7 +19,7 @@ func TestDataManager_Set_Get_StationConfiguration(t *testing.T) {
 	assert := assert.New(t)
 	ctx, dm, db := initializeDB(t)
 	defer dm.Close()
-	cm := newClearMaster(db, &models.StationConfiguration{})
+	cm := newClearMaster(db, &models.StationConfiguration{}, &models.StationConfigurationVersion{})
 	assert.NoError(cm.Clear())
 	{ // create a config.
 		assert.NoError(dm.SetStationConfiguration(ctx, mcom.SetStationConfigurationRequest{
@@ -47,6 +51,7 @@ func TestDataManager_Set_Get_StationConfiguration(t *testing.T) {
 				QuantitySource:      stations.CollectQuantitySource_FROM_STATION_CONFIGS,
 				DefaultQuantity:     decimal.NewFromInt(15),
 			},
+			Version:   1,
 			UpdatedAt: actual.UpdatedAt,
 		}
 		assert.Equal(expected, actual)
@@ -95,6 +100,7 @@ func TestDataManager_Set_Get_StationConfiguration(t *testing.T) {
 				DefaultQuantity:     decimal.NewFromInt(50),
 			},
 
+			Version:   2,
 			UpdatedAt: actual.UpdatedAt,
 		}
 		assert.Equal(expected, actual)
@@ -106,3 +112,137 @@ func TestDataManager_Set_Get_StationConfiguration(t *testing.T) {
 	}
 	assert.NoError(cm.Clear())
 }
+
+func TestDataManager_StationConfigurationVersions(t *testing.T) {
+	assert := assert.New(t)
+	ctx, dm, db := initializeDB(t)
+	defer dm.Close()
+	ctx = commonsCtx.WithUserID(ctx, testUser)
+	cm := newClearMaster(db, &models.StationConfiguration{}, &models.StationConfigurationVersion{})
+	assert.NoError(cm.Clear())
+
+	first := mcom.SetStationConfigurationRequest{
+		StationID: "station",
+		Feed: mcom.StationFeedConfigs{
+			ProductTypes:   []string{"A"},
+			QuantitySource: stations.FeedQuantitySource_FROM_RECIPE,
+		},
+		Collect: mcom.StationCollectConfigs{
+			QuantitySource:  stations.CollectQuantitySource_FROM_STATION_CONFIGS,
+			DefaultQuantity: decimal.NewFromInt(15),
+		},
+	}
+	second := first
+	second.Collect.QuantitySource = stations.CollectQuantitySource_FROM_STATION_PARAMS
+	assert.NoError(dm.SetStationConfiguration(ctx, first))
+	assert.NoError(dm.SetStationConfiguration(ctx, second))
+
+	{ // ListStationConfigurationVersions.
+		rep, err := dm.ListStationConfigurationVersions(ctx, mcom.ListStationConfigurationVersionsRequest{StationID: "station"})
+		assert.NoError(err)
+		if assert.Len(rep.Versions, 2) {
+			assert.Equal(int32(2), rep.Versions[0].Version)
+			assert.Equal(int32(1), rep.Versions[1].Version)
+			assert.Equal(testUser, rep.Versions[0].CreatedBy)
+			assert.Equal(stations.CollectQuantitySource_FROM_STATION_CONFIGS, rep.Versions[1].Configuration.Collect.QuantitySource)
+		}
+	}
+	{ // DiffStationConfigurationVersions: version not found.
+		_, err := dm.DiffStationConfigurationVersions(ctx, mcom.DiffStationConfigurationVersionsRequest{
+			StationID:   "station",
+			FromVersion: 1,
+			ToVersion:   3,
+		})
+		assert.ErrorIs(err, mcomErr.Error{
+			Code:    mcomErr.Code_STATION_CONFIGURATION_VERSION_NOT_FOUND,
+			Details: "station: station, version: 3",
+		})
+	}
+	{ // DiffStationConfigurationVersions: good case.
+		rep, err := dm.DiffStationConfigurationVersions(ctx, mcom.DiffStationConfigurationVersionsRequest{
+			StationID:   "station",
+			FromVersion: 1,
+			ToVersion:   2,
+		})
+		assert.NoError(err)
+		assert.Equal(mcom.DiffStationConfigurationVersionsReply{
+			Differences: []mcom.StationConfigurationDifference{{
+				Field: "Collect.QuantitySource",
+				From:  stations.CollectQuantitySource_FROM_STATION_CONFIGS,
+				To:    stations.CollectQuantitySource_FROM_STATION_PARAMS,
+			}},
+		}, rep)
+	}
+	{ // RollbackStationConfiguration: version not found.
+		assert.ErrorIs(dm.RollbackStationConfiguration(ctx, mcom.RollbackStationConfigurationRequest{
+			StationID: "station",
+			Version:   3,
+		}), mcomErr.Error{
+			Code:    mcomErr.Code_STATION_CONFIGURATION_VERSION_NOT_FOUND,
+			Details: "station: station, version: 3",
+		})
+	}
+	{ // RollbackStationConfiguration: good case.
+		assert.NoError(dm.RollbackStationConfiguration(ctx, mcom.RollbackStationConfigurationRequest{
+			StationID: "station",
+			Version:   1,
+		}))
+
+		rep, err := dm.GetStationConfiguration(ctx, mcom.GetStationConfigurationRequest{StationID: "station"})
+		assert.NoError(err)
+		assert.Equal(int32(3), rep.Version)
+		assert.Equal(stations.CollectQuantitySource_FROM_STATION_CONFIGS, rep.Collect.QuantitySource)
+	}
+	{ // SetStationConfiguration: concurrent versions.
+		const count = 5
+		var wg sync.WaitGroup
+		errs := make([]error, count)
+		for i := 0; i < count; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = dm.SetStationConfiguration(ctx, mcom.SetStationConfigurationRequest{StationID: "concurrent"})
+			}(i)
+		}
+		wg.Wait()
+		for _, err := range errs {
+			assert.NoError(err)
+		}
+
+		rep, err := dm.ListStationConfigurationVersions(ctx, mcom.ListStationConfigurationVersionsRequest{StationID: "concurrent"})
+		assert.NoError(err)
+		if assert.Len(rep.Versions, count) {
+			assert.Equal(int32(count), rep.Versions[0].Version)
+		}
+	}
+	{ // the configurations set before the versions are recorded.
+		assert.NoError(db.Create(&models.StationConfiguration{
+			StationID: "unversioned",
+			UI: models.StationConfigUISetting{
+				SplitFeedAndCollect: true,
+			},
+			UpdatedBy: testUser,
+		}).Error)
+		assert.NoError(maybeMigrateData(db, &models.StationConfiguration{}, &models.StationConfigurationVersion{}))
+		// migrating repeatedly does not change anything.
+		assert.NoError(maybeMigrateData(db, &models.StationConfigurationVersion{}))
+
+		rep, err := dm.GetStationConfiguration(ctx, mcom.GetStationConfigurationRequest{StationID: "unversioned"})
+		assert.NoError(err)
+		assert.Equal(int32(1), rep.Version)
+
+		versions, err := dm.ListStationConfigurationVersions(ctx, mcom.ListStationConfigurationVersionsRequest{StationID: "unversioned"})
+		assert.NoError(err)
+		if assert.Len(versions.Versions, 1) {
+			assert.Equal(int32(1), versions.Versions[0].Version)
+			assert.Equal(testUser, versions.Versions[0].CreatedBy)
+			assert.True(versions.Versions[0].Configuration.SplitFeedAndCollect)
+		}
+
+		assert.NoError(dm.SetStationConfiguration(ctx, mcom.SetStationConfigurationRequest{StationID: "unversioned"}))
+		rep, err = dm.GetStationConfiguration(ctx, mcom.GetStationConfigurationRequest{StationID: "unversioned"})
+		assert.NoError(err)
+		assert.Equal(int32(2), rep.Version)
+	}
+	assert.NoError(cm.Clear())
+}
@@ -0,0 +1,152 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/stations"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
+)
+
+// DefaultStationStateTransitions is the default allowed transitions of the
+// station states. The key is the current state and the value is the states
+// which the station could be changed to.
+var DefaultStationStateTransitions = map[stations.State][]stations.State{
+	stations.State_SHUTDOWN: {
+		stations.State_IDLE,
+		stations.State_MAINTENANCE,
+		stations.State_DISPOSAL,
+	},
+	stations.State_IDLE: {
+		stations.State_SHUTDOWN,
+		stations.State_RUNNING,
+		stations.State_MAINTENANCE,
+		stations.State_MALFUNCTION,
+	},
+	stations.State_RUNNING: {
+		stations.State_SHUTDOWN,
+		stations.State_IDLE,
+		stations.State_MALFUNCTION,
+	},
+	stations.State_MAINTENANCE: {
+		stations.State_SHUTDOWN,
+		stations.State_IDLE,
+		stations.State_REPAIRING,
+	},
+	stations.State_REPAIRING: {
+		stations.State_SHUTDOWN,
+		stations.State_IDLE,
+		stations.State_MALFUNCTION,
+	},
+	stations.State_MALFUNCTION: {
+		stations.State_SHUTDOWN,
+		stations.State_REPAIRING,
+	},
+}
+
+func (dm *DataManager) isStationStateTransitionAllowed(from, to stations.State) bool {
+	for _, state := range dm.stationStateTransitions[from] {
+		if state == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ChangeStationState implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) ChangeStationState(ctx context.Context, req mcom.ChangeStationStateRequest) error {
+	if err := req.CheckInsufficiency(); err != nil {
+		return err
+	}
+
+	tx := dm.beginTx(ctx)
+	defer tx.Rollback() // nolint: errcheck
+
+	var station models.Station
+	if err := tx.db.Where(`id = ?`, req.ID).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Take(&station).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return mcomErr.Error{
+				Code:    mcomErr.Code_STATION_NOT_FOUND,
+				Details: "station not found, id: " + req.ID,
+			}
+		}
+		return err
+	}
+
+	if !dm.isStationStateTransitionAllowed(station.State, req.State) {
+		return mcomErr.Error{
+			Code:    mcomErr.Code_STATION_STATE_TRANSITION_NOT_ALLOWED,
+			Details: fmt.Sprintf("from %s to %s", station.State, req.State),
+		}
+	}
+
+	updatedBy := commonsCtx.UserID(ctx)
+	if err := tx.db.Model(&models.Station{}).
+		Where(`id = ?`, req.ID).
+		Updates(models.Station{
+			State:     req.State,
+			UpdatedBy: updatedBy,
+		}).Error; err != nil {
+		return err
+	}
+	if err := tx.createStationStateHistory(req.ID, station.State, req.State, req.Reason, updatedBy); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (tx *txDataManager) createStationStateHistory(station string, from, to stations.State, reason, createdBy string) error {
+	return tx.db.Create(&models.StationStateHistory{
+		Station:   station,
+		From:      from,
+		To:        to,
+		Reason:    reason,
+		CreatedBy: createdBy,
+	}).Error
+}
+
+// ListStationStateHistory implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) ListStationStateHistory(ctx context.Context, req mcom.ListStationStateHistoryRequest) (mcom.ListStationStateHistoryReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.ListStationStateHistoryReply{}, err
+	}
+
+	session := dm.newSession(ctx)
+	if _, err := session.getStation(req.Station); err != nil {
+		return mcom.ListStationStateHistoryReply{}, err
+	}
+
+	query := session.db.
+		Where(`station = ? AND created_at >= ?`, req.Station, types.ToTimeNano(req.Since))
+	if !req.Until.IsZero() {
+		query = query.Where(`created_at <= ?`, types.ToTimeNano(req.Until))
+	}
+
+	var histories []models.StationStateHistory
+	if err := query.Order(`created_at, id`).Find(&histories).Error; err != nil {
+		return mcom.ListStationStateHistoryReply{}, err
+	}
+
+	res := make([]mcom.StationStateHistory, len(histories))
+	for i, history := range histories {
+		res[i] = mcom.StationStateHistory{
+			From:      history.From,
+			To:        history.To,
+			Reason:    history.Reason,
+			CreatedAt: history.CreatedAt.Time(),
+			CreatedBy: history.CreatedBy,
+		}
+	}
+	return mcom.ListStationStateHistoryReply{Histories: res}, nil
+}
@@ -0,0 +1,156 @@
+package impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/stations"
+)
+
+func TestDataManager_ChangeStationState(t *testing.T) {
+	assert := assert.New(t)
+	ctx := commonsCtx.WithUserID(context.Background(), testUser)
+
+	dm, db, err := newTestDataManager()
+	if !assert.NoError(err) {
+		return
+	}
+	if !assert.NotNil(dm) {
+		return
+	}
+	defer dm.Close()
+	assert.NoError(clearStationsData(db))
+
+	{ // insufficient request: missing reason.
+		assert.ErrorIs(dm.ChangeStationState(ctx, mcom.ChangeStationStateRequest{
+			ID:    testStationA,
+			State: stations.State_MALFUNCTION,
+		}), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "missing reason for state: MALFUNCTION",
+		})
+	}
+	{ // station not found.
+		assert.ErrorIs(dm.ChangeStationState(ctx, mcom.ChangeStationStateRequest{
+			ID:    testStationA,
+			State: stations.State_IDLE,
+		}), mcomErr.Error{
+			Code:    mcomErr.Code_STATION_NOT_FOUND,
+			Details: "station not found, id: " + testStationA,
+		})
+	}
+
+	created := time.Now()
+	assert.NoError(dm.CreateStation(ctx, mcom.CreateStationRequest{
+		ID:            testStationA,
+		DepartmentOID: testDepartmentA,
+	}))
+	since := time.Now()
+
+	{ // the initial state is recorded.
+		rep, err := dm.ListStationStateHistory(ctx, mcom.ListStationStateHistoryRequest{
+			Station: testStationA,
+			Since:   created,
+			Until:   since,
+		})
+		if assert.NoError(err) && assert.Len(rep.Histories, 1) {
+			assert.Equal(stations.State_UNSPECIFIED, rep.Histories[0].From)
+			assert.Equal(stations.State_SHUTDOWN, rep.Histories[0].To)
+		}
+	}
+
+	{ // transition not allowed.
+		assert.ErrorIs(dm.ChangeStationState(ctx, mcom.ChangeStationStateRequest{
+			ID:     testStationA,
+			State:  stations.State_REPAIRING,
+			Reason: "R01",
+		}), mcomErr.Error{
+			Code:    mcomErr.Code_STATION_STATE_TRANSITION_NOT_ALLOWED,
+			Details: "from SHUTDOWN to REPAIRING",
+		})
+	}
+	{ // good case.
+		assert.NoError(dm.ChangeStationState(ctx, mcom.ChangeStationStateRequest{
+			ID:    testStationA,
+			State: stations.State_IDLE,
+		}))
+		assert.NoError(dm.ChangeStationState(ctx, mcom.ChangeStationStateRequest{
+			ID:     testStationA,
+			State:  stations.State_MALFUNCTION,
+			Reason: "M01",
+		}))
+
+		rep, err := dm.GetStation(ctx, mcom.GetStationRequest{ID: testStationA})
+		if assert.NoError(err) {
+			assert.Equal(stations.State_MALFUNCTION, rep.State)
+		}
+	}
+	{ // UpdateStation: missing reason.
+		assert.ErrorIs(dm.UpdateStation(ctx, mcom.UpdateStationRequest{
+			ID:    testStationA,
+			State: stations.State_REPAIRING,
+		}), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "missing reason for state: REPAIRING",
+		})
+	}
+	{ // UpdateStation: transition not allowed.
+		assert.ErrorIs(dm.UpdateStation(ctx, mcom.UpdateStationRequest{
+			ID:    testStationA,
+			State: stations.State_RUNNING,
+		}), mcomErr.Error{
+			Code:    mcomErr.Code_STATION_STATE_TRANSITION_NOT_ALLOWED,
+			Details: "from MALFUNCTION to RUNNING",
+		})
+	}
+	{ // the state changed by UpdateStation is also recorded.
+		assert.NoError(dm.UpdateStation(ctx, mcom.UpdateStationRequest{
+			ID:          testStationA,
+			State:       stations.State_REPAIRING,
+			StateReason: "R01",
+		}))
+		assert.NoError(dm.UpdateStation(ctx, mcom.UpdateStationRequest{
+			ID:    testStationA,
+			State: stations.State_SHUTDOWN,
+		}))
+	}
+	{ // list state history.
+		rep, err := dm.ListStationStateHistory(ctx, mcom.ListStationStateHistoryRequest{
+			Station: testStationA,
+			Since:   since,
+		})
+		if assert.NoError(err) && assert.Len(rep.Histories, 4) {
+			expected := []mcom.StationStateHistory{
+				{From: stations.State_SHUTDOWN, To: stations.State_IDLE},
+				{From: stations.State_IDLE, To: stations.State_MALFUNCTION, Reason: "M01"},
+				{From: stations.State_MALFUNCTION, To: stations.State_REPAIRING, Reason: "R01"},
+				{From: stations.State_REPAIRING, To: stations.State_SHUTDOWN},
+			}
+			for i := range rep.Histories {
+				assert.Equal(expected[i].From, rep.Histories[i].From)
+				assert.Equal(expected[i].To, rep.Histories[i].To)
+				assert.Equal(expected[i].Reason, rep.Histories[i].Reason)
+				assert.Equal(testUser, rep.Histories[i].CreatedBy)
+			}
+		}
+	}
+	{ // time range.
+		rep, err := dm.ListStationStateHistory(ctx, mcom.ListStationStateHistoryRequest{
+			Station: testStationA,
+			Since:   since,
+			Until:   since,
+		})
+		if assert.NoError(err) {
+			assert.Empty(rep.Histories)
+		}
+	}
+
+	assert.NoError(clearStationsData(db))
+}
@@ -0,0 +1,353 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+)
+
+// CreateStationTemplate implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) CreateStationTemplate(ctx context.Context, req mcom.CreateStationTemplateRequest) error {
+	if err := req.CheckInsufficiency(); err != nil {
+		return err
+	}
+
+	templateSites := make(models.StationTemplateSites, len(req.Sites))
+	for i, site := range req.Sites {
+		templateSites[i] = models.StationTemplateSite{
+			Station:            site.Station,
+			Name:               site.Name,
+			Index:              int16(site.Index),
+			Type:               site.Type,
+			SubType:            site.SubType,
+			Limitation:         site.Limitation,
+			LimitationEnforced: site.LimitationEnforced,
+			Capacity:           site.Capacity,
+			FeedPolicy:         site.FeedPolicy,
+		}
+	}
+
+	template := models.StationTemplate{
+		ID:        req.ID,
+		Sites:     templateSites,
+		CreatedBy: commonsCtx.UserID(ctx),
+	}
+	if req.Configuration != nil {
+		config := newStationConfiguration(mcom.SetStationConfigurationRequest{
+			SplitFeedAndCollect: req.Configuration.SplitFeedAndCollect,
+			Feed:                req.Configuration.Feed,
+			Collect:             req.Configuration.Collect,
+		}, "")
+		template.HasConfiguration = true
+		template.Production = config.Production
+		template.UI = config.UI
+	}
+
+	session := dm.newSession(ctx)
+	if err := session.db.Create(&template).Error; err != nil {
+		if IsPqError(err, UniqueViolation) {
+			return mcomErr.Error{
+				Code:    mcomErr.Code_STATION_TEMPLATE_ALREADY_EXISTS,
+				Details: "station template: " + req.ID,
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+func (session *session) getStationTemplate(id string) (models.StationTemplate, error) {
+	var template models.StationTemplate
+	if err := session.db.Where(`id = ?`, id).Take(&template).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.StationTemplate{}, mcomErr.Error{
+				Code:    mcomErr.Code_STATION_TEMPLATE_NOT_FOUND,
+				Details: "station template: " + id,
+			}
+		}
+		return models.StationTemplate{}, err
+	}
+	return template, nil
+}
+
+// GetStationTemplate implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) GetStationTemplate(ctx context.Context, req mcom.GetStationTemplateRequest) (mcom.GetStationTemplateReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.GetStationTemplateReply{}, err
+	}
+
+	session := dm.newSession(ctx)
+	template, err := session.getStationTemplate(req.ID)
+	if err != nil {
+		return mcom.GetStationTemplateReply{}, err
+	}
+
+	res := mcom.GetStationTemplateReply{
+		ID:        template.ID,
+		Sites:     make([]mcom.SiteInformation, len(template.Sites)),
+		CreatedAt: template.CreatedAt,
+		CreatedBy: template.CreatedBy,
+	}
+	for i, site := range template.Sites {
+		res.Sites[i] = newSiteInformationFromTemplate(site)
+	}
+	if template.HasConfiguration {
+		config := parseStationConfiguration(models.StationConfiguration{
+			Production: template.Production,
+			UI:         template.UI,
+		})
+		res.Configuration = &mcom.StationConfiguration{
+			SplitFeedAndCollect: config.SplitFeedAndCollect,
+			Feed:                config.Feed,
+			Collect:             config.Collect,
+		}
+	}
+	return res, nil
+}
+
+// DeleteStationTemplate implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) DeleteStationTemplate(ctx context.Context, req mcom.DeleteStationTemplateRequest) error {
+	if err := req.CheckInsufficiency(); err != nil {
+		return err
+	}
+
+	session := dm.newSession(ctx)
+	return session.db.Where(`id = ?`, req.ID).Delete(&models.StationTemplate{}).Error
+}
+
+func newSiteInformationFromTemplate(site models.StationTemplateSite) mcom.SiteInformation {
+	return mcom.SiteInformation{
+		Station:            site.Station,
+		Name:               site.Name,
+		Index:              int(site.Index),
+		Type:               site.Type,
+		SubType:            site.SubType,
+		Limitation:         site.Limitation,
+		LimitationEnforced: site.LimitationEnforced,
+		Capacity:           site.Capacity,
+		FeedPolicy:         site.FeedPolicy,
+	}
+}
+
+// replaceOperatorSitesStation replaces the station of the operator sites
+// which are in the from station with the to station.
+func replaceOperatorSitesStation(sites []models.UniqueSite, from, to string) []models.UniqueSite {
+	if sites == nil {
+		return nil
+	}
+	res := make([]models.UniqueSite, len(sites))
+	for i, site := range sites {
+		if site.Station == from {
+			site.Station = to
+		}
+		res[i] = site
+	}
+	return res
+}
+
+// CreateStationFromTemplate implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) CreateStationFromTemplate(ctx context.Context, req mcom.CreateStationFromTemplateRequest) (mcom.CreateStationFromTemplateReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.CreateStationFromTemplateReply{}, err
+	}
+
+	session := dm.newSession(ctx)
+	template, err := session.getStationTemplate(req.TemplateID)
+	if err != nil {
+		return mcom.CreateStationFromTemplateReply{}, err
+	}
+
+	plan := mcom.StationCreationPlan{
+		Station: mcom.CreateStationRequest{
+			ID:            req.ID,
+			DepartmentOID: req.DepartmentOID,
+			Sites:         make([]mcom.SiteInformation, len(template.Sites)),
+			State:         req.State,
+			Information:   req.Information,
+		},
+	}
+	for i, site := range template.Sites {
+		plan.Station.Sites[i] = newSiteInformationFromTemplate(site)
+	}
+	plan.Station.Correct()
+
+	if template.HasConfiguration {
+		config := parseStationConfiguration(models.StationConfiguration{
+			Production: template.Production,
+			UI:         template.UI,
+		})
+		config.Feed.OperatorSites = replaceOperatorSitesStation(config.Feed.OperatorSites, "", req.ID)
+		config.Collect.OperatorSites = replaceOperatorSitesStation(config.Collect.OperatorSites, "", req.ID)
+		plan.Configuration = &mcom.SetStationConfigurationRequest{
+			StationID:           req.ID,
+			SplitFeedAndCollect: config.SplitFeedAndCollect,
+			Feed:                config.Feed,
+			Collect:             config.Collect,
+		}
+	}
+
+	if !req.DryRun {
+		tx := dm.beginTx(ctx)
+		defer tx.Rollback() // nolint: errcheck
+
+		if err := tx.createStationByPlan(plan, commonsCtx.UserID(ctx)); err != nil {
+			return mcom.CreateStationFromTemplateReply{}, err
+		}
+		if err := tx.Commit(); err != nil {
+			return mcom.CreateStationFromTemplateReply{}, err
+		}
+	}
+	return mcom.CreateStationFromTemplateReply(plan), nil
+}
+
+// CloneStation implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) CloneStation(ctx context.Context, req mcom.CloneStationRequest) (mcom.CloneStationReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.CloneStationReply{}, err
+	}
+
+	tx := dm.beginTx(ctx)
+	defer tx.Rollback() // nolint: errcheck
+
+	// the source station is locked so that it is not changed while cloning.
+	var source models.Station
+	if err := tx.db.
+		Clauses(clause.Locking{Strength: "SHARE"}).
+		Where(models.Station{ID: req.SourceID}).
+		Take(&source).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return mcom.CloneStationReply{}, mcomErr.Error{
+				Code:    mcomErr.Code_STATION_NOT_FOUND,
+				Details: fmt.Sprintf("station not found, id: %s", req.SourceID),
+			}
+		}
+		return mcom.CloneStationReply{}, err
+	}
+
+	siteAttributes, err := tx.getSitesAttributes(source.Sites)
+	if err != nil {
+		return mcom.CloneStationReply{}, err
+	}
+
+	departmentOID := req.DepartmentOID
+	if departmentOID == "" {
+		departmentOID = source.AdminDepartmentID
+	}
+	plan := mcom.StationCreationPlan{
+		Station: mcom.CreateStationRequest{
+			ID:            req.NewID,
+			DepartmentOID: departmentOID,
+			Sites:         make([]mcom.SiteInformation, len(source.Sites)),
+			Information: mcom.StationInformation{
+				Code:        source.Information.Code,
+				Description: source.Information.Description,
+			},
+		},
+	}
+	for i, site := range source.Sites {
+		station := site.Station
+		if station == req.SourceID {
+			station = req.NewID
+		}
+		attributes := siteAttributes[site]
+		plan.Station.Sites[i] = mcom.SiteInformation{
+			Station:            station,
+			Name:               site.SiteID.Name,
+			Index:              int(site.SiteID.Index),
+			Type:               attributes.Type,
+			SubType:            attributes.SubType,
+			Limitation:         attributes.Limitation,
+			LimitationEnforced: attributes.LimitationEnforced,
+			Capacity:           attributes.Capacity,
+			FeedPolicy:         attributes.FeedPolicy,
+		}
+	}
+
+	configs, found, err := tx.getStationConfiguration(req.SourceID)
+	if err != nil {
+		return mcom.CloneStationReply{}, err
+	}
+	if found {
+		config := parseStationConfiguration(configs)
+		plan.Configuration = &mcom.SetStationConfigurationRequest{
+			StationID:           req.NewID,
+			SplitFeedAndCollect: config.SplitFeedAndCollect,
+			Feed:                config.Feed,
+			Collect:             config.Collect,
+		}
+		plan.Configuration.Feed.OperatorSites = replaceOperatorSitesStation(config.Feed.OperatorSites, req.SourceID, req.NewID)
+		plan.Configuration.Collect.OperatorSites = replaceOperatorSitesStation(config.Collect.OperatorSites, req.SourceID, req.NewID)
+	}
+
+	if req.DryRun {
+		return mcom.CloneStationReply(plan), nil
+	}
+	if err := tx.createStationByPlan(plan, commonsCtx.UserID(ctx)); err != nil {
+		return mcom.CloneStationReply{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return mcom.CloneStationReply{}, err
+	}
+	return mcom.CloneStationReply(plan), nil
+}
+
+// getStationConfiguration returns the configuration of the station. It
+// returns false if the station has no configuration.
+func (tx *txDataManager) getStationConfiguration(stationID string) (models.StationConfiguration, bool, error) {
+	var configs models.StationConfiguration
+	if err := tx.db.Where(models.StationConfiguration{StationID: stationID}).Take(&configs).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.StationConfiguration{}, false, nil
+		}
+		return models.StationConfiguration{}, false, err
+	}
+	return configs, true, nil
+}
+
+// getSitesAttributes returns the attributes of the specified sites.
+func (tx *txDataManager) getSitesAttributes(sites []models.UniqueSite) (map[models.UniqueSite]models.SiteAttributes, error) {
+	res := make(map[models.UniqueSite]models.SiteAttributes, len(sites))
+	if len(sites) == 0 {
+		return res, nil
+	}
+
+	condition := make([][3]interface{}, len(sites))
+	for i, site := range sites {
+		condition[i] = [3]interface{}{site.SiteID.Name, site.SiteID.Index, site.Station}
+	}
+	var results []models.Site
+	if err := tx.db.Where(`(name, index, station) IN ?`, condition).Find(&results).Error; err != nil {
+		return nil, err
+	}
+	for _, site := range results {
+		res[models.UniqueSite{
+			SiteID: models.SiteID{
+				Name:  site.Name,
+				Index: site.Index,
+			},
+			Station: site.Station,
+		}] = site.Attributes
+	}
+	return res, nil
+}
+
+func (tx *txDataManager) createStationByPlan(plan mcom.StationCreationPlan, userID string) error {
+	if err := tx.createStation(plan.Station, userID); err != nil {
+		return err
+	}
+	if plan.Configuration != nil {
+		if err := tx.setStationConfiguration(*plan.Configuration, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
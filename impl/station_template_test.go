@@ -0,0 +1,224 @@
+package impl
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/sites"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/stations"
+)
+
+const testStationTemplate = "CURING_PRESS"
+
+func TestDataManager_StationTemplate(t *testing.T) {
+	assert := assert.New(t)
+	ctx, dm, db := initializeDB(t)
+	defer dm.Close()
+	ctx = commonsCtx.WithUserID(ctx, testUser)
+	cm := newClearMaster(db, &models.StationTemplate{}, &models.Station{}, &models.Site{}, &models.SiteContents{}, &models.StationConfiguration{}, &models.StationConfigurationVersion{})
+	assert.NoError(cm.Clear())
+
+	templateSites := []mcom.SiteInformation{{
+		Name:               testSiteA,
+		Index:              0,
+		Type:               sites.Type_SLOT,
+		SubType:            sites.SubType_MATERIAL,
+		Limitation:         []string{"P1"},
+		LimitationEnforced: true,
+	}, {
+		Name:     testSiteB,
+		Index:    1,
+		Type:     sites.Type_QUEUE,
+		SubType:  sites.SubType_MATERIAL,
+		Capacity: models.SiteCapacity{MaxQueueLength: 3},
+	}}
+	configuration := mcom.StationConfiguration{
+		Feed: mcom.StationFeedConfigs{
+			ProductTypes:   []string{"A"},
+			QuantitySource: stations.FeedQuantitySource_FROM_RECIPE,
+			OperatorSites: []models.UniqueSite{{
+				SiteID: models.SiteID{Name: testSiteA},
+			}},
+		},
+		Collect: mcom.StationCollectConfigs{
+			QuantitySource:  stations.CollectQuantitySource_FROM_STATION_CONFIGS,
+			DefaultQuantity: decimal.NewFromInt(15),
+		},
+	}
+
+	{ // GetStationTemplate: not found.
+		_, err := dm.GetStationTemplate(ctx, mcom.GetStationTemplateRequest{ID: testStationTemplate})
+		assert.ErrorIs(err, mcomErr.Error{
+			Code:    mcomErr.Code_STATION_TEMPLATE_NOT_FOUND,
+			Details: "station template: " + testStationTemplate,
+		})
+	}
+	{ // CreateStationTemplate: good case.
+		assert.NoError(dm.CreateStationTemplate(ctx, mcom.CreateStationTemplateRequest{
+			ID:            testStationTemplate,
+			Sites:         templateSites,
+			Configuration: &configuration,
+		}))
+
+		actual, err := dm.GetStationTemplate(ctx, mcom.GetStationTemplateRequest{ID: testStationTemplate})
+		assert.NoError(err)
+		assert.Equal(mcom.GetStationTemplateReply{
+			ID:            testStationTemplate,
+			Sites:         templateSites,
+			Configuration: &configuration,
+			CreatedAt:     actual.CreatedAt,
+			CreatedBy:     testUser,
+		}, actual)
+	}
+	{ // CreateStationTemplate: already exists.
+		assert.ErrorIs(dm.CreateStationTemplate(ctx, mcom.CreateStationTemplateRequest{
+			ID: testStationTemplate,
+		}), mcomErr.Error{
+			Code:    mcomErr.Code_STATION_TEMPLATE_ALREADY_EXISTS,
+			Details: "station template: " + testStationTemplate,
+		})
+	}
+
+	expectedSites := []mcom.SiteInformation{{
+		Station:            testStationA,
+		Name:               testSiteA,
+		Index:              0,
+		Type:               sites.Type_SLOT,
+		SubType:            sites.SubType_MATERIAL,
+		Limitation:         []string{"P1"},
+		LimitationEnforced: true,
+	}, {
+		Station:  testStationA,
+		Name:     testSiteB,
+		Index:    1,
+		Type:     sites.Type_QUEUE,
+		SubType:  sites.SubType_MATERIAL,
+		Capacity: models.SiteCapacity{MaxQueueLength: 3},
+	}}
+	expectedConfiguration := func(station string) *mcom.SetStationConfigurationRequest {
+		return &mcom.SetStationConfigurationRequest{
+			StationID: station,
+			Feed: mcom.StationFeedConfigs{
+				ProductTypes:   []string{"A"},
+				QuantitySource: stations.FeedQuantitySource_FROM_RECIPE,
+				OperatorSites: []models.UniqueSite{{
+					SiteID:  models.SiteID{Name: testSiteA},
+					Station: station,
+				}},
+			},
+			Collect: mcom.StationCollectConfigs{
+				QuantitySource:  stations.CollectQuantitySource_FROM_STATION_CONFIGS,
+				DefaultQuantity: decimal.NewFromInt(15),
+			},
+		}
+	}
+
+	{ // CreateStationFromTemplate: dry run.
+		actual, err := dm.CreateStationFromTemplate(ctx, mcom.CreateStationFromTemplateRequest{
+			TemplateID:    testStationTemplate,
+			ID:            testStationA,
+			DepartmentOID: testDepartmentA,
+			DryRun:        true,
+		})
+		assert.NoError(err)
+		assert.Equal(mcom.CreateStationFromTemplateReply{
+			Station: mcom.CreateStationRequest{
+				ID:            testStationA,
+				DepartmentOID: testDepartmentA,
+				Sites:         expectedSites,
+			},
+			Configuration: expectedConfiguration(testStationA),
+		}, actual)
+
+		_, err = dm.GetStation(ctx, mcom.GetStationRequest{ID: testStationA})
+		assert.ErrorIs(err, mcomErr.Error{
+			Code:    mcomErr.Code_STATION_NOT_FOUND,
+			Details: "station not found, id: " + testStationA,
+		})
+	}
+	{ // CreateStationFromTemplate: good case.
+		_, err := dm.CreateStationFromTemplate(ctx, mcom.CreateStationFromTemplateRequest{
+			TemplateID:    testStationTemplate,
+			ID:            testStationA,
+			DepartmentOID: testDepartmentA,
+		})
+		assert.NoError(err)
+
+		station, err := dm.GetStation(ctx, mcom.GetStationRequest{ID: testStationA})
+		if assert.NoError(err) {
+			assert.Len(station.Sites, 2)
+		}
+		config, err := dm.GetStationConfiguration(ctx, mcom.GetStationConfigurationRequest{StationID: testStationA})
+		assert.NoError(err)
+		assert.Equal(expectedConfiguration(testStationA).Feed, config.Feed)
+	}
+	{ // CreateStationFromTemplate: station already exists.
+		_, err := dm.CreateStationFromTemplate(ctx, mcom.CreateStationFromTemplateRequest{
+			TemplateID:    testStationTemplate,
+			ID:            testStationA,
+			DepartmentOID: testDepartmentA,
+		})
+		assert.ErrorIs(err, mcomErr.Error{
+			Code:    mcomErr.Code_STATION_SITE_ALREADY_EXISTS,
+			Details: "name: " + testSiteA + ", index: 0",
+		})
+	}
+	{ // CloneStation: dry run.
+		actual, err := dm.CloneStation(ctx, mcom.CloneStationRequest{
+			SourceID: testStationA,
+			NewID:    testStationB,
+			DryRun:   true,
+		})
+		assert.NoError(err)
+		for i := range expectedSites {
+			expectedSites[i].Station = testStationB
+		}
+		assert.Equal(mcom.CloneStationReply{
+			Station: mcom.CreateStationRequest{
+				ID:            testStationB,
+				DepartmentOID: testDepartmentA,
+				Sites:         expectedSites,
+			},
+			Configuration: expectedConfiguration(testStationB),
+		}, actual)
+	}
+	{ // CloneStation: good case.
+		_, err := dm.CloneStation(ctx, mcom.CloneStationRequest{
+			SourceID: testStationA,
+			NewID:    testStationB,
+		})
+		assert.NoError(err)
+
+		station, err := dm.GetStation(ctx, mcom.GetStationRequest{ID: testStationB})
+		if assert.NoError(err) {
+			assert.Len(station.Sites, 2)
+		}
+	}
+	{ // CloneStation: source not found.
+		_, err := dm.CloneStation(ctx, mcom.CloneStationRequest{
+			SourceID: testStationC,
+			NewID:    "NEW",
+		})
+		assert.ErrorIs(err, mcomErr.Error{
+			Code:    mcomErr.Code_STATION_NOT_FOUND,
+			Details: "station not found, id: " + testStationC,
+		})
+	}
+	{ // DeleteStationTemplate: good case.
+		assert.NoError(dm.DeleteStationTemplate(ctx, mcom.DeleteStationTemplateRequest{ID: testStationTemplate}))
+		_, err := dm.GetStationTemplate(ctx, mcom.GetStationTemplateRequest{ID: testStationTemplate})
+		assert.ErrorIs(err, mcomErr.Error{
+			Code:    mcomErr.Code_STATION_TEMPLATE_NOT_FOUND,
+			Details: "station template: " + testStationTemplate,
+		})
+	}
+
+	assert.NoError(cm.Clear())
+}
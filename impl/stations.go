@@ -197,13 +197,19 @@ func (tx *txDataManager) listStationSites(sites []models.UniqueSite, opts ...par
 					},
 					Station: targetSites[i].Station,
 				},
-				Type:    targetSites[i].Attributes.Type,
-				SubType: targetSites[i].Attributes.SubType,
+				Type:       targetSites[i].Attributes.Type,
+				SubType:    targetSites[i].Attributes.SubType,
+				Capacity:   targetSites[i].Attributes.Capacity,
+				FeedPolicy: targetSites[i].Attributes.FeedPolicy,
 			},
 			Content: parseContent(targetSites[i].Attributes.Type, targetSites[i].Attributes.SubType, mapContents[models.SiteID{Name: targetSites[i].Name, Index: targetSites[i].Index}]),
 		}
 	}
 
+	if err := tx.setRemainingCapacity(result); err != nil {
+		return nil, err
+	}
+
 	// sort.
 	sort.Slice(result, func(i, j int) bool {
 		if result[i].Information.SiteID.Name == result[j].Information.SiteID.Name {
@@ -401,9 +407,12 @@ func (tx *txDataManager) createSites(createdBy, departmentOID, station string, u
 				Index:             int16(v.Index),
 				AdminDepartmentID: departmentOID,
 				Attributes: models.SiteAttributes{
-					Type:       v.Type,
-					SubType:    v.SubType,
-					Limitation: v.Limitation,
+					Type:               v.Type,
+					SubType:            v.SubType,
+					Limitation:         v.Limitation,
+					LimitationEnforced: v.LimitationEnforced,
+					Capacity:           v.Capacity,
+					FeedPolicy:         v.FeedPolicy,
 				},
 				Station:   station,
 				UpdatedBy: createdBy,
@@ -458,9 +467,16 @@ func (dm *DataManager) CreateStation(ctx context.Context, req mcom.CreateStation
 	tx := dm.beginTx(ctx)
 	defer tx.Rollback() // nolint: errcheck
 
+	if err := tx.createStation(req, commonsCtx.UserID(ctx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (tx *txDataManager) createStation(req mcom.CreateStationRequest, createdBy string) error {
 	toCreateSites, toAssociateSites := splitOwnSitesAndForeignSites(toWillCreateSites(req.Sites), req.ID)
 
-	createdSites, err := tx.createSites(commonsCtx.UserID(ctx), req.DepartmentOID, req.ID, toCreateSites)
+	createdSites, err := tx.createSites(createdBy, req.DepartmentOID, req.ID, toCreateSites)
 	if err != nil {
 		return err
 	}
@@ -485,16 +501,18 @@ func (dm *DataManager) CreateStation(ctx context.Context, req mcom.CreateStation
 				Code:        req.Information.Code,
 				Description: req.Information.Description,
 			},
-			UpdatedBy: commonsCtx.UserID(ctx),
-			CreatedBy: commonsCtx.UserID(ctx),
+			UpdatedBy: createdBy,
+			CreatedBy: createdBy,
 		}).Error; err != nil {
 		if IsPqError(err, UniqueViolation) {
 			return mcomErr.Error{
 				Code: mcomErr.Code_STATION_ALREADY_EXISTS,
 			}
 		}
+		return err
 	}
-	return tx.Commit()
+	// the initial state is the baseline of the state histories.
+	return tx.createStationStateHistory(req.ID, stations.State_UNSPECIFIED, state, "", createdBy)
 }
 
 type splitSiteType interface {
@@ -718,6 +736,29 @@ func (dm *DataManager) UpdateStation(ctx context.Context, req mcom.UpdateStation
 	tx := dm.beginTx(ctx)
 	defer tx.Rollback() // nolint: errcheck
 
+	// the state transition is checked against the locked station as
+	// ChangeStationState does.
+	var locked models.Station
+	if err := tx.db.
+		Select(`state`).
+		Where(`id = ?`, req.ID).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Take(&locked).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return mcomErr.Error{Code: mcomErr.Code_STATION_NOT_FOUND}
+		}
+		return err
+	}
+	previousState := locked.State
+	if req.State == stations.State_UNSPECIFIED {
+		composer.station.State = previousState
+	} else if req.State != previousState && !dm.isStationStateTransitionAllowed(previousState, req.State) {
+		return mcomErr.Error{
+			Code:    mcomErr.Code_STATION_STATE_TRANSITION_NOT_ALLOWED,
+			Details: fmt.Sprintf("from %s to %s", previousState, req.State),
+		}
+	}
+
 	toCreateSites, toAssociateSites := splitOwnSitesAndForeignSites(composer.listWillCreateSites(), req.ID)
 
 	if _, err := tx.createSites(commonsCtx.UserID(ctx), station.AdminDepartmentID, req.ID, toCreateSites); err != nil {
@@ -743,6 +784,11 @@ func (dm *DataManager) UpdateStation(ctx context.Context, req mcom.UpdateStation
 	if err := tx.updateStation(req.ID, station); err != nil {
 		return err
 	}
+	if station.State != previousState {
+		if err := tx.createStationStateHistory(req.ID, previousState, station.State, req.StateReason, updatedBy); err != nil {
+			return err
+		}
+	}
 
 	return tx.Commit()
 }
@@ -872,11 +918,61 @@ func (session *session) isStationSitesEmpty(sites []willDeleteSite) error {
 	return nil
 }
 
-func (session *session) createStationGroup(id string, stations []string) error {
-	if err := session.db.Create(&models.StationGroup{
-		ID:       id,
-		Stations: pq.StringArray(stations),
-	}).Error; err != nil {
+func (tx *txDataManager) listStationGroups() (models.StationGroups, error) {
+	var groups []models.StationGroup
+	if err := tx.db.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Find(&groups).Error; err != nil {
+		return nil, err
+	}
+	return models.NewStationGroups(groups), nil
+}
+
+func (session *session) listStationGroups() (models.StationGroups, error) {
+	var groups []models.StationGroup
+	if err := session.db.Find(&groups).Error; err != nil {
+		return nil, err
+	}
+	return models.NewStationGroups(groups), nil
+}
+
+// checkStationGroup checks the child groups of the specified group exist and
+// there is no cycle in the hierarchy.
+func checkStationGroup(groups models.StationGroups, group models.StationGroup) error {
+	for _, child := range group.Groups {
+		if _, ok := groups[child]; !ok && child != group.ID {
+			return mcomErr.Error{
+				Code:    mcomErr.Code_STATION_GROUP_ID_NOT_FOUND,
+				Details: "child group not found: " + child,
+			}
+		}
+	}
+
+	groups[group.ID] = group
+	if groups.HasCycle(group.ID) {
+		return mcomErr.Error{
+			Code:    mcomErr.Code_STATION_GROUP_CYCLE_DETECTED,
+			Details: "station group: " + group.ID,
+		}
+	}
+	return nil
+}
+
+func (tx *txDataManager) createStationGroup(group models.StationGroup) error {
+	groups, err := tx.listStationGroups()
+	if err != nil {
+		return err
+	}
+	if _, ok := groups[group.ID]; ok {
+		return mcomErr.Error{
+			Code: mcomErr.Code_STATION_GROUP_ALREADY_EXISTS,
+		}
+	}
+	if err := checkStationGroup(groups, group); err != nil {
+		return err
+	}
+
+	if err := tx.db.Create(&group).Error; err != nil {
 		if IsPqError(err, UniqueViolation) {
 			return mcomErr.Error{
 				Code: mcomErr.Code_STATION_GROUP_ALREADY_EXISTS,
@@ -887,21 +983,38 @@ func (session *session) createStationGroup(id string, stations []string) error {
 	return nil
 }
 
-func (session *session) updateStationGroup(id string, stations []string) error {
-	result := session.db.Model(&models.StationGroup{
-		ID: id,
-	}).Updates(&models.StationGroup{
-		Stations: pq.StringArray(stations),
-	})
-	if result.Error != nil {
-		return result.Error
+func (tx *txDataManager) updateStationGroup(group models.StationGroup) error {
+	groups, err := tx.listStationGroups()
+	if err != nil {
+		return err
 	}
-	if result.RowsAffected == 0 {
+	if _, ok := groups[group.ID]; !ok {
 		return mcomErr.Error{
 			Code: mcomErr.Code_STATION_GROUP_ID_NOT_FOUND,
 		}
 	}
-	return nil
+	if err := checkStationGroup(groups, group); err != nil {
+		return err
+	}
+
+	return tx.db.Model(&models.StationGroup{
+		ID: group.ID,
+	}).Select("stations", "groups").Updates(&group).Error
+}
+
+func newStationGroup(req mcom.StationGroupRequest) models.StationGroup {
+	stations, groups := req.Stations, req.Groups
+	if stations == nil {
+		stations = []string{}
+	}
+	if groups == nil {
+		groups = []string{}
+	}
+	return models.StationGroup{
+		ID:       req.ID,
+		Stations: pq.StringArray(stations),
+		Groups:   pq.StringArray(groups),
+	}
 }
 
 // CreateStationGroup implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
@@ -910,8 +1023,13 @@ func (dm *DataManager) CreateStationGroup(ctx context.Context, req mcom.StationG
 		return err
 	}
 
-	session := dm.newSession(ctx)
-	return session.createStationGroup(req.ID, req.Stations)
+	tx := dm.beginTx(ctx)
+	defer tx.Rollback() // nolint: errcheck
+
+	if err := tx.createStationGroup(newStationGroup(req)); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 // UpdateStationGroup implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
@@ -920,8 +1038,13 @@ func (dm *DataManager) UpdateStationGroup(ctx context.Context, req mcom.StationG
 		return err
 	}
 
-	session := dm.newSession(ctx)
-	return session.updateStationGroup(req.ID, req.Stations)
+	tx := dm.beginTx(ctx)
+	defer tx.Rollback() // nolint: errcheck
+
+	if err := tx.updateStationGroup(newStationGroup(req)); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 // DeleteStationGroup implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
@@ -930,13 +1053,63 @@ func (dm *DataManager) DeleteStationGroup(ctx context.Context, req mcom.DeleteSt
 		return err
 	}
 
-	session := dm.newSession(ctx)
-	if err := session.db.Delete(&models.StationGroup{
+	tx := dm.beginTx(ctx)
+	defer tx.Rollback() // nolint: errcheck
+
+	if err := tx.db.Delete(&models.StationGroup{
 		ID: req.GroupID,
 	}).Error; err != nil {
 		return err
 	}
-	return nil
+	// remove the group from its parent groups.
+	if err := tx.db.Model(&models.StationGroup{}).
+		Where(`? = ANY(groups)`, req.GroupID).
+		Update("groups", gorm.Expr(`array_remove(groups, ?)`, req.GroupID)).Error; err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ListGroupStations implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) ListGroupStations(ctx context.Context, req mcom.ListGroupStationsRequest) (mcom.ListGroupStationsReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.ListGroupStationsReply{}, err
+	}
+
+	session := dm.newSession(ctx)
+	groups, err := session.listStationGroups()
+	if err != nil {
+		return mcom.ListGroupStationsReply{}, err
+	}
+	if _, ok := groups[req.GroupID]; !ok {
+		return mcom.ListGroupStationsReply{}, mcomErr.Error{
+			Code: mcomErr.Code_STATION_GROUP_ID_NOT_FOUND,
+		}
+	}
+	return mcom.ListGroupStationsReply{Stations: groups.Stations(req.GroupID)}, nil
+}
+
+// ListStationAncestors implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) ListStationAncestors(ctx context.Context, req mcom.ListStationAncestorsRequest) (mcom.ListStationAncestorsReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.ListStationAncestorsReply{}, err
+	}
+
+	session := dm.newSession(ctx)
+	groups, err := session.listStationGroups()
+	if err != nil {
+		return mcom.ListStationAncestorsReply{}, err
+	}
+
+	ancestors := groups.Ancestors(req.StationID)
+	res := make([]mcom.StationGroupAncestor, len(ancestors))
+	for i, ancestor := range ancestors {
+		res[i] = mcom.StationGroupAncestor{
+			GroupID: ancestor.ID,
+			Depth:   ancestor.Depth,
+		}
+	}
+	return mcom.ListStationAncestorsReply{Ancestors: res}, nil
 }
 
 func (dm *DataManager) ListStationIDs(ctx context.Context, req mcom.ListStationIDsRequest) (mcom.ListStationIDsReply, error) {
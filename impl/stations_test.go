@@ -107,8 +107,106 @@ func TestDataManager_StationGroup(t *testing.T) {
 	}
 }
 
+func TestDataManager_StationGroupHierarchy(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	dm, db, err := newTestDataManager()
+	if !assert.NoError(err) {
+		return
+	}
+	if !assert.NotNil(dm) {
+		return
+	}
+	defer dm.Close()
+	assert.NoError(newClearMaster(db, &models.StationGroup{}).Clear())
+
+	const (
+		plant = "PLANT"
+		area  = "AREA"
+		line  = "LINE"
+	)
+
+	{ // CreateStationGroup: child group not found.
+		assert.ErrorIs(dm.CreateStationGroup(ctx, mcom.StationGroupRequest{
+			ID:     plant,
+			Groups: []string{area},
+		}), mcomErr.Error{
+			Code:    mcomErr.Code_STATION_GROUP_ID_NOT_FOUND,
+			Details: "child group not found: " + area,
+		})
+	}
+	{ // CreateStationGroup: good case.
+		assert.NoError(dm.CreateStationGroup(ctx, mcom.StationGroupRequest{
+			ID:       line,
+			Stations: []string{testStationA, testStationB},
+		}))
+		assert.NoError(dm.CreateStationGroup(ctx, mcom.StationGroupRequest{
+			ID:       area,
+			Stations: []string{testStationC},
+			Groups:   []string{line},
+		}))
+		assert.NoError(dm.CreateStationGroup(ctx, mcom.StationGroupRequest{
+			ID:     plant,
+			Groups: []string{area},
+		}))
+	}
+	{ // UpdateStationGroup: cycle detected.
+		assert.ErrorIs(dm.UpdateStationGroup(ctx, mcom.StationGroupRequest{
+			ID:       line,
+			Stations: []string{testStationA},
+			Groups:   []string{plant},
+		}), mcomErr.Error{
+			Code:    mcomErr.Code_STATION_GROUP_CYCLE_DETECTED,
+			Details: "station group: " + line,
+		})
+		assert.ErrorIs(dm.UpdateStationGroup(ctx, mcom.StationGroupRequest{
+			ID:     line,
+			Groups: []string{line},
+		}), mcomErr.Error{
+			Code:    mcomErr.Code_STATION_GROUP_CYCLE_DETECTED,
+			Details: "station group: " + line,
+		})
+	}
+	{ // ListGroupStations: not found.
+		_, err := dm.ListGroupStations(ctx, mcom.ListGroupStationsRequest{GroupID: "NOT_FOUND"})
+		assert.ErrorIs(err, mcomErr.Error{Code: mcomErr.Code_STATION_GROUP_ID_NOT_FOUND})
+	}
+	{ // ListGroupStations: good case.
+		rep, err := dm.ListGroupStations(ctx, mcom.ListGroupStationsRequest{GroupID: plant})
+		assert.NoError(err)
+		assert.Equal(mcom.ListGroupStationsReply{
+			Stations: []string{testStationA, testStationB, testStationC},
+		}, rep)
+	}
+	{ // ListStationAncestors: good case.
+		rep, err := dm.ListStationAncestors(ctx, mcom.ListStationAncestorsRequest{StationID: testStationA})
+		assert.NoError(err)
+		assert.Equal(mcom.ListStationAncestorsReply{
+			Ancestors: []mcom.StationGroupAncestor{
+				{GroupID: line, Depth: 1},
+				{GroupID: area, Depth: 2},
+				{GroupID: plant, Depth: 3},
+			},
+		}, rep)
+	}
+	{ // DeleteStationGroup: removed from the parent groups.
+		assert.NoError(dm.DeleteStationGroup(ctx, mcom.DeleteStationGroupRequest{GroupID: line}))
+
+		rep, err := dm.ListGroupStations(ctx, mcom.ListGroupStationsRequest{GroupID: plant})
+		assert.NoError(err)
+		assert.Equal(mcom.ListGroupStationsReply{Stations: []string{testStationC}}, rep)
+
+		var group models.StationGroup
+		assert.NoError(db.Where(`id = ?`, area).Take(&group).Error)
+		assert.Empty(group.Groups)
+	}
+
+	assert.NoError(newClearMaster(db, &models.StationGroup{}).Clear())
+}
+
 func clearStationsData(db *gorm.DB) error {
-	return newClearMaster(db, &models.Station{}, &models.Site{}, &models.SiteContents{}).Clear()
+	return newClearMaster(db, &models.Station{}, &models.Site{}, &models.SiteContents{}, &models.StationStateHistory{}).Clear()
 }
 
 // test sites order in GetStation / ListStations.
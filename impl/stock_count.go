@@ -0,0 +1,514 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
+)
+
+// CreateStockCount implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) CreateStockCount(ctx context.Context, req mcom.CreateStockCountRequest) (mcom.CreateStockCountReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.CreateStockCountReply{}, err
+	}
+
+	tx := dm.beginTx(ctx)
+	defer tx.Rollback() // nolint: errcheck
+
+	if err := checkWarehouseExists(tx.db, req.WarehouseID); err != nil {
+		return mcom.CreateStockCountReply{}, err
+	}
+	count := models.StockCount{
+		WarehouseID: req.WarehouseID,
+		Location:    req.Location,
+		Status:      models.StockCountCounting,
+	}
+	scope, err := newStockCountScope(tx.db, count)
+	if err != nil {
+		return mcom.CreateStockCountReply{}, err
+	}
+	if req.Location != "" {
+		if err := scope.checkLocation(req.Location); err != nil {
+			return mcom.CreateStockCountReply{}, err
+		}
+	}
+
+	var resources []models.MaterialResource
+	if err := tx.db.
+		Where(`warehouse_id = ? AND warehouse_location <> '' AND quantity > 0`, req.WarehouseID).
+		Order(`id, product_type`).
+		Find(&resources).Error; err != nil {
+		return mcom.CreateStockCountReply{}, err
+	}
+
+	userID := commonsCtx.UserID(ctx)
+	count.UpdatedBy = userID
+	count.CreatedBy = userID
+	if err := tx.db.Create(&count).Error; err != nil {
+		return mcom.CreateStockCountReply{}, err
+	}
+
+	snapshot := []models.UniqueMaterialResource{}
+	var items []models.StockCountItem
+	for _, resource := range resources {
+		if !scope.contains(resource.WarehouseLocation) {
+			continue
+		}
+		snapshot = append(snapshot, resourceKey(resource))
+		items = append(items, models.StockCountItem{
+			CountID:         count.ID,
+			ResourceID:      resource.ID,
+			ProductType:     resource.ProductType,
+			ProductID:       resource.ProductID,
+			InSnapshot:      true,
+			WarehouseID:     resource.WarehouseID,
+			Location:        resource.WarehouseLocation,
+			Quantity:        resource.Quantity,
+			CountedQuantity: decimal.Zero,
+		})
+	}
+	if len(items) != 0 {
+		if err := tx.db.Create(&items).Error; err != nil {
+			return mcom.CreateStockCountReply{}, err
+		}
+	}
+
+	return mcom.CreateStockCountReply{
+		CountID:   count.ID,
+		Resources: snapshot,
+	}, tx.Commit()
+}
+
+// SubmitStockCount implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) SubmitStockCount(ctx context.Context, req mcom.SubmitStockCountRequest) error {
+	if err := req.CheckInsufficiency(); err != nil {
+		return err
+	}
+
+	tx := dm.beginTx(ctx)
+	defer tx.Rollback() // nolint: errcheck
+
+	// the PDAs may submit at the same time, but not while posting.
+	count, err := getStockCount(tx.db.Clauses(clause.Locking{Strength: "SHARE"}), req.CountID)
+	if err != nil {
+		return err
+	}
+	if count.IsClosed() {
+		return mcomErr.Error{Code: mcomErr.Code_STOCK_COUNT_CLOSED, Details: "stock count: " + count.ID}
+	}
+	scope, err := newStockCountScope(tx.db, count)
+	if err != nil {
+		return err
+	}
+
+	resourceIDs := make([]string, len(req.Entries))
+	for i, entry := range req.Entries {
+		resourceIDs[i] = entry.ResourceID
+	}
+	var items []models.StockCountItem
+	if err := tx.db.
+		Where(`count_id = ? AND resource_id IN ?`, count.ID, uniqueStrings(resourceIDs)).
+		Find(&items).Error; err != nil {
+		return err
+	}
+	itemsByResourceID := make(map[string][]models.StockCountItem)
+	for _, item := range items {
+		itemsByResourceID[item.ResourceID] = append(itemsByResourceID[item.ResourceID], item)
+	}
+
+	userID := commonsCtx.UserID(ctx)
+	countedAt := types.ToTimeNano(time.Now())
+	for _, entry := range req.Entries {
+		location := entry.Location
+		if location == "" {
+			location = count.Location
+		}
+		if location == "" {
+			return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: "missing location, resource: " + entry.ResourceID}
+		}
+		if err := scope.checkLocation(location); err != nil {
+			return err
+		}
+		if !scope.contains(location) {
+			return mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "the location is out of the stock count: " + location}
+		}
+
+		item, err := tx.getStockCountItem(count.ID, entry, itemsByResourceID[entry.ResourceID])
+		if err != nil {
+			return err
+		}
+		item.Counted = true
+		item.CountedLocation = location
+		item.CountedQuantity = entry.Quantity
+		item.CountedAt = countedAt
+		item.CountedBy = userID
+		// recounts replace the previous counts.
+		if err := tx.db.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "count_id"}, {Name: "resource_id"}, {Name: "product_type"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"counted", "counted_location", "counted_quantity", "counted_at", "counted_by",
+			}),
+		}).Create(&item).Error; err != nil {
+			return err
+		}
+		itemsByResourceID[item.ResourceID] = replaceStockCountItem(itemsByResourceID[item.ResourceID], item)
+	}
+	return tx.Commit()
+}
+
+// getStockCountItem returns the item of the counted resource, which is a new
+// item not in the snapshot if it is not found in items.
+func (tx *txDataManager) getStockCountItem(countID string, entry mcom.StockCountEntry, items []models.StockCountItem) (models.StockCountItem, error) {
+	for _, item := range items {
+		if item.ProductType == entry.ProductType || (entry.ProductType == "" && len(items) == 1) {
+			return item, nil
+		}
+	}
+
+	query := tx.db.Where(`id = ?`, entry.ResourceID)
+	if entry.ProductType != "" {
+		query = query.Where(`product_type = ?`, entry.ProductType)
+	}
+	var resources []models.MaterialResource
+	if err := query.Find(&resources).Error; err != nil {
+		return models.StockCountItem{}, err
+	}
+	switch len(resources) {
+	case 0:
+		return models.StockCountItem{}, mcomErr.Error{Code: mcomErr.Code_RESOURCE_NOT_FOUND, Details: "resource: " + entry.ResourceID}
+	case 1:
+	default:
+		return models.StockCountItem{}, mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "missing product type, resource: " + entry.ResourceID,
+		}
+	}
+	for _, item := range items {
+		if item.ProductType == resources[0].ProductType {
+			return item, nil
+		}
+	}
+	return models.StockCountItem{
+		CountID:     countID,
+		ResourceID:  resources[0].ID,
+		ProductType: resources[0].ProductType,
+		ProductID:   resources[0].ProductID,
+		InSnapshot:  false,
+		WarehouseID: resources[0].WarehouseID,
+		Location:    resources[0].WarehouseLocation,
+		Quantity:    resources[0].Quantity,
+	}, nil
+}
+
+// replaceStockCountItem replaces the item of the same resource in items, or
+// appends it if not found.
+func replaceStockCountItem(items []models.StockCountItem, item models.StockCountItem) []models.StockCountItem {
+	for i := range items {
+		if items[i].ProductType == item.ProductType {
+			items[i] = item
+			return items
+		}
+	}
+	return append(items, item)
+}
+
+// ListStockCountVariances implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) ListStockCountVariances(ctx context.Context, req mcom.ListStockCountVariancesRequest) (mcom.ListStockCountVariancesReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.ListStockCountVariancesReply{}, err
+	}
+
+	session := dm.newSession(ctx)
+	count, err := getStockCount(session.db, req.CountID)
+	if err != nil {
+		return mcom.ListStockCountVariancesReply{}, err
+	}
+	var items []models.StockCountItem
+	if err := session.db.
+		Where(`count_id = ?`, count.ID).
+		Order(`resource_id, product_type`).
+		Find(&items).Error; err != nil {
+		return mcom.ListStockCountVariancesReply{}, err
+	}
+
+	reply := mcom.ListStockCountVariancesReply{
+		WarehouseID: count.WarehouseID,
+		Location:    count.Location,
+		Status:      count.Status,
+		Variances:   []mcom.StockCountVariance{},
+	}
+	for _, item := range items {
+		if item.Counted {
+			reply.Counted++
+		}
+		varianceType, ok := item.Variance()
+		if !ok {
+			continue
+		}
+		variance := mcom.StockCountVariance{
+			Type: varianceType,
+			UniqueMaterialResource: models.UniqueMaterialResource{
+				ResourceID:  item.ResourceID,
+				ProductType: item.ProductType,
+			},
+			ProductID:        item.ProductID,
+			Recorded:         mcom.Warehouse{ID: item.WarehouseID, Location: item.Location},
+			RecordedQuantity: item.Quantity,
+			Adjusted:         item.Adjusted,
+		}
+		if item.Counted {
+			variance.Counted = mcom.Warehouse{ID: count.WarehouseID, Location: item.CountedLocation}
+			variance.CountedQuantity = item.CountedQuantity
+			variance.CountedAt = item.CountedAt.Time()
+			variance.CountedBy = item.CountedBy
+		}
+		reply.Variances = append(reply.Variances, variance)
+	}
+	return reply, nil
+}
+
+// PostStockCount implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) PostStockCount(ctx context.Context, req mcom.PostStockCountRequest) error {
+	if err := req.CheckInsufficiency(); err != nil {
+		return err
+	}
+
+	tx := dm.beginTx(ctx)
+	defer tx.Rollback() // nolint: errcheck
+
+	count, err := getStockCount(tx.db.Clauses(clause.Locking{Strength: "UPDATE"}), req.CountID)
+	if err != nil {
+		return err
+	}
+	if count.IsClosed() {
+		return mcomErr.Error{Code: mcomErr.Code_STOCK_COUNT_CLOSED, Details: "stock count: " + count.ID}
+	}
+
+	var items []models.StockCountItem
+	if err := tx.db.
+		Where(`count_id = ?`, count.ID).
+		Order(`resource_id, product_type`).
+		Find(&items).Error; err != nil {
+		return err
+	}
+	approved := make(map[models.UniqueMaterialResource]bool, len(req.Resources))
+	for _, resource := range req.Resources {
+		approved[resource] = true
+	}
+	var (
+		targets   []models.StockCountItem
+		condition [][]string
+	)
+	for _, item := range items {
+		key := models.UniqueMaterialResource{ResourceID: item.ResourceID, ProductType: item.ProductType}
+		if _, ok := item.Variance(); !ok || (!req.All && !approved[key]) {
+			continue
+		}
+		targets = append(targets, item)
+		condition = append(condition, []string{item.ResourceID, item.ProductType})
+	}
+
+	userID := commonsCtx.UserID(ctx)
+	if len(targets) != 0 {
+		if err := tx.postStockCountAdjustments(count, targets, condition, userID); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.db.Model(&models.StockCount{}).
+		Where(`id = ?`, count.ID).
+		Updates(models.StockCount{
+			Status:    models.StockCountPosted,
+			UpdatedBy: userID,
+		}).Error; err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// postStockCountAdjustments adjusts the resources and the stocks to the
+// counts. The resources counted in another location are transported there,
+// and the differences between the counted and the recorded quantities are
+// applied to the current quantities, since the resources may have been used
+// during the count. All the adjusted resources are recorded in the transport
+// records.
+//
+// The locations are not checked against the warehouse locations since the
+// counts are what is in the warehouse.
+func (tx *txDataManager) postStockCountAdjustments(count models.StockCount, items []models.StockCountItem, condition [][]string, userID string) error {
+	var resources []models.MaterialResource
+	if err := tx.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(`(id, product_type) IN ?`, condition).
+		Find(&resources).Error; err != nil {
+		return err
+	}
+	current := make(map[models.UniqueMaterialResource]models.MaterialResource, len(resources))
+	for _, resource := range resources {
+		current[resourceKey(resource)] = resource
+	}
+
+	// the resources to transport and to record by the new warehouses.
+	var warehouses []mcom.Warehouse
+	transported := make(map[mcom.Warehouse][]models.MaterialResource)
+	recorded := make(map[mcom.Warehouse][]models.MaterialResource)
+	resourcesVariation := make(map[updatedResource]decimal.Decimal)
+	stocksVariation := make(map[updatedWarehouseStock]decimal.Decimal)
+	for _, item := range items {
+		resource, ok := current[models.UniqueMaterialResource{ResourceID: item.ResourceID, ProductType: item.ProductType}]
+		if !ok {
+			return mcomErr.Error{Code: mcomErr.Code_RESOURCE_NOT_FOUND, Details: "resource: " + item.ResourceID}
+		}
+
+		varianceType, _ := item.Variance()
+		to := mcom.Warehouse{ID: count.WarehouseID, Location: item.CountedLocation}
+		variation := item.CountedQuantity.Sub(item.Quantity)
+		if varianceType == models.StockCountVarianceMissing {
+			to = mcom.Warehouse{ID: resource.WarehouseID, Location: resource.WarehouseLocation}
+			variation = item.Quantity.Neg()
+		}
+		if resource.Quantity.Add(variation).IsNegative() {
+			variation = resource.Quantity.Neg()
+		}
+
+		if _, ok := recorded[to]; !ok {
+			warehouses = append(warehouses, to)
+		}
+		recorded[to] = append(recorded[to], resource)
+		if resource.WarehouseID != to.ID || resource.WarehouseLocation != to.Location {
+			transported[to] = append(transported[to], resource)
+		}
+		if !variation.IsZero() {
+			resourcesVariation[updatedResource{id: resource.ID, productType: resource.ProductType}] = variation
+			if needStockIn(to) {
+				key := updatedWarehouseStock{ID: to.ID, Location: to.Location, ProductID: resource.ProductID}
+				stocksVariation[key] = stocksVariation[key].Add(variation)
+			}
+		}
+	}
+
+	for _, warehouse := range warehouses {
+		if resources, ok := transported[warehouse]; ok {
+			if err := tx.updateWarehouseStocks(warehouse, resources); err != nil {
+				return err
+			}
+		}
+	}
+	if len(resourcesVariation) != 0 {
+		if err := tx.updateResource(resourcesVariation, userID); err != nil {
+			return err
+		}
+		if err := tx.updateStock(stocksVariation, userID); err != nil {
+			return err
+		}
+	}
+	for _, warehouse := range warehouses {
+		if err := tx.createResourceTransportRecords(warehouse, "stock count: "+count.ID, userID, recorded[warehouse]); err != nil {
+			return err
+		}
+	}
+
+	return tx.db.Model(&models.StockCountItem{}).
+		Where(`count_id = ? AND (resource_id, product_type) IN ?`, count.ID, condition).
+		Update(`adjusted`, true).Error
+}
+
+// CancelStockCount implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) CancelStockCount(ctx context.Context, req mcom.CancelStockCountRequest) error {
+	if err := req.CheckInsufficiency(); err != nil {
+		return err
+	}
+
+	tx := dm.beginTx(ctx)
+	defer tx.Rollback() // nolint: errcheck
+
+	count, err := getStockCount(tx.db.Clauses(clause.Locking{Strength: "UPDATE"}), req.CountID)
+	if err != nil {
+		return err
+	}
+	if count.IsClosed() {
+		return mcomErr.Error{Code: mcomErr.Code_STOCK_COUNT_CLOSED, Details: "stock count: " + count.ID}
+	}
+	if err := tx.db.Model(&models.StockCount{}).
+		Where(`id = ?`, count.ID).
+		Updates(models.StockCount{
+			Status:    models.StockCountCancelled,
+			UpdatedBy: commonsCtx.UserID(ctx),
+		}).Error; err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func getStockCount(db *gorm.DB, countID string) (models.StockCount, error) {
+	var count models.StockCount
+	if err := db.Where(`id = ?`, countID).Take(&count).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.StockCount{}, mcomErr.Error{Code: mcomErr.Code_STOCK_COUNT_NOT_FOUND, Details: "stock count: " + countID}
+		}
+		return models.StockCount{}, err
+	}
+	return count, nil
+}
+
+// stockCountScope is the locations counted in a stock count.
+type stockCountScope struct {
+	warehouseID string
+	// location is empty if the whole warehouse is counted.
+	location string
+	// locations are the warehouse locations, which are empty if the
+	// warehouse has the legacy free-form locations.
+	locations warehouseLocations
+}
+
+func newStockCountScope(db *gorm.DB, count models.StockCount) (stockCountScope, error) {
+	locations, err := listWarehouseLocations(db, count.WarehouseID)
+	if err != nil {
+		return stockCountScope{}, err
+	}
+	return stockCountScope{
+		warehouseID: count.WarehouseID,
+		location:    count.Location,
+		locations:   locations,
+	}, nil
+}
+
+// checkLocation checks whether the location is one of the warehouse
+// locations.
+func (scope stockCountScope) checkLocation(location string) error {
+	if len(scope.locations) == 0 {
+		return nil
+	}
+	if _, ok := scope.locations.lineage(location); !ok {
+		return mcomErr.Error{
+			Code:    mcomErr.Code_WAREHOUSE_LOCATION_NOT_FOUND,
+			Details: "warehouse: " + scope.warehouseID + ", location: " + location,
+		}
+	}
+	return nil
+}
+
+// contains returns true if the location is counted, including the
+// descendants of the counted location.
+func (scope stockCountScope) contains(location string) bool {
+	switch {
+	case location == "":
+		return false
+	case scope.location == "":
+		return true
+	case len(scope.locations) != 0:
+		return scope.locations.contains(scope.location, location)
+	default:
+		return location == scope.location
+	}
+}
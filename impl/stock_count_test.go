@@ -0,0 +1,201 @@
+package impl
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+)
+
+func TestDataManager_StockCount(t *testing.T) {
+	assert := assert.New(t)
+	ctx, dm, db := initializeDB(t)
+	defer dm.Close()
+	ctx = commonsCtx.WithUserID(ctx, testUser)
+	cm := newClearMaster(db,
+		&models.Warehouse{},
+		&models.WarehouseLocation{},
+		&models.WarehouseStock{},
+		&models.MaterialResource{},
+		&models.ResourceTransportRecord{},
+		&models.StockCount{},
+		&models.StockCountItem{},
+	)
+	assert.NoError(cm.Clear())
+	defer func() { assert.NoError(cm.Clear()) }()
+
+	assert.NoError(db.Create(&models.Warehouse{ID: "W", DepartmentID: testDepartmentA}).Error)
+	_, err := dm.CreateMaterialResources(ctx, mcom.CreateMaterialResourcesRequest{
+		Materials: []mcom.CreateMaterialResourcesRequestDetail{
+			{Type: "T", ID: "P", Quantity: decimal.NewFromInt(10), ResourceID: "R1"},
+			{Type: "T", ID: "P", Quantity: decimal.NewFromInt(20), ResourceID: "R2"},
+			{Type: "T", ID: "P", Quantity: decimal.NewFromInt(5), ResourceID: "R3"},
+		},
+	}, mcom.WithStockIn(mcom.Warehouse{ID: "W", Location: "01"}))
+	assert.NoError(err)
+	_, err = dm.CreateMaterialResources(ctx, mcom.CreateMaterialResourcesRequest{
+		Materials: []mcom.CreateMaterialResourcesRequestDetail{
+			{Type: "T", ID: "P", Quantity: decimal.NewFromInt(8), ResourceID: "R4"},
+		},
+	}, mcom.WithStockIn(mcom.Warehouse{ID: "W", Location: "02"}))
+	assert.NoError(err)
+
+	{ // warehouse not found.
+		_, err := dm.CreateStockCount(ctx, mcom.CreateStockCountRequest{WarehouseID: "N"})
+		assert.ErrorIs(err, mcomErr.Error{Code: mcomErr.Code_WAREHOUSE_NOT_FOUND, Details: "warehouse: N"})
+	}
+	{ // stock count not found.
+		_, err := dm.ListStockCountVariances(ctx, mcom.ListStockCountVariancesRequest{CountID: "C"})
+		assert.ErrorIs(err, mcomErr.Error{Code: mcomErr.Code_STOCK_COUNT_NOT_FOUND, Details: "stock count: C"})
+	}
+
+	rep, err := dm.CreateStockCount(ctx, mcom.CreateStockCountRequest{WarehouseID: "W", Location: "01"})
+	assert.NoError(err)
+	assert.Equal([]models.UniqueMaterialResource{
+		{ResourceID: "R1", ProductType: "T"},
+		{ResourceID: "R2", ProductType: "T"},
+		{ResourceID: "R3", ProductType: "T"},
+	}, rep.Resources)
+	countID := rep.CountID
+
+	{ // out of the stock count.
+		assert.ErrorIs(dm.SubmitStockCount(ctx, mcom.SubmitStockCountRequest{
+			CountID: countID,
+			Entries: []mcom.StockCountEntry{{ResourceID: "R4", Quantity: decimal.NewFromInt(8), Location: "02"}},
+		}), mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "the location is out of the stock count: 02"})
+	}
+	{ // resource not found.
+		assert.ErrorIs(dm.SubmitStockCount(ctx, mcom.SubmitStockCountRequest{
+			CountID: countID,
+			Entries: []mcom.StockCountEntry{{ResourceID: "R9", Quantity: decimal.NewFromInt(1)}},
+		}), mcomErr.Error{Code: mcomErr.Code_RESOURCE_NOT_FOUND, Details: "resource: R9"})
+	}
+	{ // good case.
+		assert.NoError(dm.SubmitStockCount(ctx, mcom.SubmitStockCountRequest{
+			CountID: countID,
+			Entries: []mcom.StockCountEntry{
+				{ResourceID: "R1", Quantity: decimal.NewFromInt(8)},
+				{ResourceID: "R2", ProductType: "T", Quantity: decimal.NewFromInt(20)},
+				{ResourceID: "R4", Quantity: decimal.NewFromInt(8)},
+			},
+		}))
+		// recounts replace the previous counts.
+		assert.NoError(dm.SubmitStockCount(ctx, mcom.SubmitStockCountRequest{
+			CountID: countID,
+			Entries: []mcom.StockCountEntry{{ResourceID: "R1", Quantity: decimal.NewFromInt(7)}},
+		}))
+	}
+	{ // variances.
+		rep, err := dm.ListStockCountVariances(ctx, mcom.ListStockCountVariancesRequest{CountID: countID})
+		assert.NoError(err)
+		for i, v := range rep.Variances {
+			if v.Type != models.StockCountVarianceMissing {
+				assert.False(v.CountedAt.IsZero())
+				rep.Variances[i].CountedAt = v.CountedAt.Truncate(0)
+			}
+		}
+		assert.Equal(mcom.ListStockCountVariancesReply{
+			WarehouseID: "W",
+			Location:    "01",
+			Status:      models.StockCountCounting,
+			Counted:     3,
+			Variances: []mcom.StockCountVariance{{
+				Type:                   models.StockCountVarianceQuantityDiff,
+				UniqueMaterialResource: models.UniqueMaterialResource{ResourceID: "R1", ProductType: "T"},
+				ProductID:              "P",
+				Recorded:               mcom.Warehouse{ID: "W", Location: "01"},
+				RecordedQuantity:       decimal.RequireFromString("10.000000"),
+				Counted:                mcom.Warehouse{ID: "W", Location: "01"},
+				CountedQuantity:        decimal.RequireFromString("7.000000"),
+				CountedAt:              rep.Variances[0].CountedAt,
+				CountedBy:              testUser,
+			}, {
+				Type:                   models.StockCountVarianceMissing,
+				UniqueMaterialResource: models.UniqueMaterialResource{ResourceID: "R3", ProductType: "T"},
+				ProductID:              "P",
+				Recorded:               mcom.Warehouse{ID: "W", Location: "01"},
+				RecordedQuantity:       decimal.RequireFromString("5.000000"),
+			}, {
+				Type:                   models.StockCountVarianceUnexpected,
+				UniqueMaterialResource: models.UniqueMaterialResource{ResourceID: "R4", ProductType: "T"},
+				ProductID:              "P",
+				Recorded:               mcom.Warehouse{ID: "W", Location: "02"},
+				RecordedQuantity:       decimal.RequireFromString("8.000000"),
+				Counted:                mcom.Warehouse{ID: "W", Location: "01"},
+				CountedQuantity:        decimal.RequireFromString("8.000000"),
+				CountedAt:              rep.Variances[2].CountedAt,
+				CountedBy:              testUser,
+			}},
+		}, rep)
+	}
+	{ // post all the variances.
+		assert.NoError(dm.PostStockCount(ctx, mcom.PostStockCountRequest{CountID: countID, All: true}))
+
+		for id, expected := range map[string]mcom.Warehouse{
+			"R1": {ID: "W", Location: "01"},
+			"R3": {ID: "W", Location: "01"},
+			"R4": {ID: "W", Location: "01"},
+		} {
+			var resource models.MaterialResource
+			assert.NoError(db.Where(`id = ?`, id).Take(&resource).Error)
+			assert.Equal(expected, mcom.Warehouse{ID: resource.WarehouseID, Location: resource.WarehouseLocation}, id)
+		}
+		var resources []models.MaterialResource
+		assert.NoError(db.Where(`id IN ?`, []string{"R1", "R3", "R4"}).Order(`id`).Find(&resources).Error)
+		assert.Len(resources, 3)
+		for i, expected := range []int64{7, 0, 8} {
+			assert.True(resources[i].Quantity.Equal(decimal.NewFromInt(expected)), resources[i].ID)
+		}
+
+		var stocks []models.WarehouseStock
+		assert.NoError(db.Where(`id = ?`, "W").Order(`location`).Find(&stocks).Error)
+		assert.Len(stocks, 2)
+		assert.True(stocks[0].Quantity.Equal(decimal.NewFromInt(35)))
+		assert.True(stocks[1].Quantity.IsZero())
+
+		records, err := dm.ListResourceTransportRecords(ctx, mcom.ListResourceTransportRecordsRequest{CreatedBy: testUser})
+		assert.NoError(err)
+		var adjusted []string
+		for _, record := range records.Records {
+			if record.Note == "stock count: "+countID {
+				adjusted = append(adjusted, record.ResourceID)
+			}
+		}
+		assert.ElementsMatch([]string{"R1", "R3", "R4"}, adjusted)
+
+		rep, err := dm.ListStockCountVariances(ctx, mcom.ListStockCountVariancesRequest{CountID: countID})
+		assert.NoError(err)
+		assert.Equal(models.StockCountPosted, rep.Status)
+		for _, v := range rep.Variances {
+			assert.True(v.Adjusted, v.ResourceID)
+		}
+	}
+	{ // closed.
+		closed := mcomErr.Error{Code: mcomErr.Code_STOCK_COUNT_CLOSED, Details: "stock count: " + countID}
+		assert.ErrorIs(dm.SubmitStockCount(ctx, mcom.SubmitStockCountRequest{
+			CountID: countID,
+			Entries: []mcom.StockCountEntry{{ResourceID: "R2", Quantity: decimal.NewFromInt(1)}},
+		}), closed)
+		assert.ErrorIs(dm.PostStockCount(ctx, mcom.PostStockCountRequest{CountID: countID, All: true}), closed)
+		assert.ErrorIs(dm.CancelStockCount(ctx, mcom.CancelStockCountRequest{CountID: countID}), closed)
+	}
+	{ // cancel.
+		rep, err := dm.CreateStockCount(ctx, mcom.CreateStockCountRequest{WarehouseID: "W"})
+		assert.NoError(err)
+		assert.ErrorIs(dm.SubmitStockCount(ctx, mcom.SubmitStockCountRequest{
+			CountID: rep.CountID,
+			Entries: []mcom.StockCountEntry{{ResourceID: "R2", Quantity: decimal.NewFromInt(1)}},
+		}), mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: "missing location, resource: R2"})
+		assert.NoError(dm.CancelStockCount(ctx, mcom.CancelStockCountRequest{CountID: rep.CountID}))
+
+		variances, err := dm.ListStockCountVariances(ctx, mcom.ListStockCountVariancesRequest{CountID: rep.CountID})
+		assert.NoError(err)
+		assert.Equal(models.StockCountCancelled, variances.Status)
+	}
+}
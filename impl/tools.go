@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
 
@@ -15,6 +18,7 @@ import (
 	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
 	"gitlab.kenda.com.tw/kenda/mcom/utils/bindtype"
 	"gitlab.kenda.com.tw/kenda/mcom/utils/sites"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
 )
 
 // GetToolResource implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
@@ -36,6 +40,7 @@ func (dm *DataManager) GetToolResource(ctx context.Context, req mcom.GetToolReso
 		BindingSite: res.BindingSite,
 		CreatedBy:   res.CreatedBy,
 		CreatedAt:   res.CreatedAt,
+		RetiredAt:   res.RetiredAt,
 	}, nil
 }
 
@@ -188,6 +193,10 @@ func (dm *DataManager) ToolResourceBind(ctx context.Context, req mcom.ToolResour
 	// #endregion get unbind resources
 
 	// #region update to db
+	if err := tx.checkToolsBindable(toolResourceIDs(toUpdate.bindResources)); err != nil {
+		return err
+	}
+
 	if err := tx.updateSiteContents(toUpdate.siteContents, updatedBy); err != nil {
 		return err
 	}
@@ -199,6 +208,10 @@ func (dm *DataManager) ToolResourceBind(ctx context.Context, req mcom.ToolResour
 	if err := tx.maybeUpdateToolResources(unbindResources); err != nil {
 		return err
 	}
+
+	if err := tx.recordToolBinds(toolResourceIDs(toUpdate.bindResources), toUpdate.unbindResources, updatedBy); err != nil {
+		return err
+	}
 	// #endregion update to db
 	return tx.Commit()
 }
@@ -319,6 +332,10 @@ func (dm *DataManager) ToolResourceBindV2(ctx context.Context, req mcom.ToolReso
 	// #endregion get unbind resources
 
 	// #region update to db
+	if err := tx.checkToolsBindable(toolResourceIDs(toUpdate.bindResources)); err != nil {
+		return err
+	}
+
 	if err := tx.updateSiteContents(toUpdate.siteContents, updatedBy); err != nil {
 		return err
 	}
@@ -330,10 +347,22 @@ func (dm *DataManager) ToolResourceBindV2(ctx context.Context, req mcom.ToolReso
 	if err := tx.maybeUpdateToolResources(unbindResources); err != nil {
 		return err
 	}
+
+	if err := tx.recordToolBinds(toolResourceIDs(toUpdate.bindResources), toUpdate.unbindResources, updatedBy); err != nil {
+		return err
+	}
 	// #endregion update to db
 	return tx.Commit()
 }
 
+func toolResourceIDs(resources []models.ToolResource) []string {
+	ids := make([]string, len(resources))
+	for i, resource := range resources {
+		ids[i] = resource.ID
+	}
+	return ids
+}
+
 func (tx *txDataManager) maybeUpdateToolResources(resources []models.ToolResource) error {
 	for _, resource := range resources {
 		if err := tx.db.Model(&models.ToolResource{}).
@@ -368,6 +397,7 @@ func (dm *DataManager) ListToolResources(ctx context.Context, req mcom.ListToolR
 			BindingSite: resource.BindingSite,
 			CreatedBy:   resource.CreatedBy,
 			CreatedAt:   resource.CreatedAt,
+			RetiredAt:   resource.RetiredAt,
 		}
 	}
 
@@ -375,3 +405,657 @@ func (dm *DataManager) ListToolResources(ctx context.Context, req mcom.ListToolR
 		Resources: res,
 	}, nil
 }
+
+// SetToolLifePolicy implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) SetToolLifePolicy(ctx context.Context, req mcom.SetToolLifePolicyRequest) error {
+	if err := req.CheckInsufficiency(); err != nil {
+		return err
+	}
+
+	session := dm.newSession(ctx)
+	return session.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "tool_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"life_limit", "maintenance_interval", "warning_cycles", "updated_at", "updated_by"}),
+		}).
+		Create(&models.ToolLifePolicy{
+			ToolID:              req.ToolID,
+			LifeLimit:           req.LifeLimit,
+			MaintenanceInterval: req.MaintenanceInterval,
+			WarningCycles:       req.WarningCycles,
+			UpdatedBy:           commonsCtx.UserID(ctx),
+		}).Error
+}
+
+// RecordToolMaintenance implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) RecordToolMaintenance(ctx context.Context, req mcom.RecordToolMaintenanceRequest) error {
+	if err := req.CheckInsufficiency(); err != nil {
+		return err
+	}
+
+	session := dm.newSession(ctx)
+	result := session.db.
+		Model(&models.ToolResource{}).
+		Where(`id = ?`, req.ResourceID).
+		Updates(map[string]interface{}{
+			"cycles_since_maintenance": 0,
+			"maintained_at":            types.ToTimeNano(time.Now()),
+			"updated_by":               commonsCtx.UserID(ctx),
+		})
+	if err := result.Error; err != nil {
+		return err
+	}
+	if result.RowsAffected == 0 {
+		return mcomErr.Error{Code: mcomErr.Code_RESOURCE_NOT_FOUND}
+	}
+	return nil
+}
+
+// increaseToolCycles increases the usage cycles of the tools bound to the
+// station. It returns TOOL_LIFE_EXCEEDED if any of the tools has reached its
+// life limit before the increment.
+func (tx *txDataManager) increaseToolCycles(station string, cycles int64) error {
+	var resources []models.ToolResource
+	if err := tx.db.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(`binding_site ->> 'station' = ?`, station).
+		Find(&resources).Error; err != nil {
+		return err
+	}
+	if len(resources) == 0 {
+		return nil
+	}
+
+	policies, err := listToolLifePolicies(tx.db, resources)
+	if err != nil {
+		return err
+	}
+	resourceIDs := make([]string, len(resources))
+	for i, resource := range resources {
+		if policies[resource.ToolID].LifeExceeded(resource) {
+			return mcomErr.Error{
+				Code:    mcomErr.Code_TOOL_LIFE_EXCEEDED,
+				Details: fmt.Sprintf("resource: %s, cycles: %d", resource.ID, resource.Cycles),
+			}
+		}
+		resourceIDs[i] = resource.ID
+	}
+
+	return tx.db.
+		Model(&models.ToolResource{}).
+		Where(`id IN ?`, resourceIDs).
+		Updates(map[string]interface{}{
+			"cycles":                   gorm.Expr("cycles + ?", cycles),
+			"cycles_since_maintenance": gorm.Expr("cycles_since_maintenance + ?", cycles),
+		}).Error
+}
+
+// listToolLifePolicies returns the life policies of the tool IDs of the
+// resources.
+func listToolLifePolicies(db *gorm.DB, resources []models.ToolResource) (map[string]models.ToolLifePolicy, error) {
+	toolIDs := make([]string, len(resources))
+	for i, resource := range resources {
+		toolIDs[i] = resource.ToolID
+	}
+
+	var policies []models.ToolLifePolicy
+	if err := db.Where(`tool_id IN ?`, uniqueStrings(toolIDs)).Find(&policies).Error; err != nil {
+		return nil, err
+	}
+	res := make(map[string]models.ToolLifePolicy, len(policies))
+	for _, policy := range policies {
+		res[policy.ToolID] = policy
+	}
+	return res, nil
+}
+
+// checkToolsBindable checks the tool resources are in service and have not
+// reached their life limits.
+func (tx *txDataManager) checkToolsBindable(resourceIDs []string) error {
+	if len(resourceIDs) == 0 {
+		return nil
+	}
+
+	var resources []models.ToolResource
+	if err := tx.db.Where(`id IN ?`, resourceIDs).Find(&resources).Error; err != nil {
+		return err
+	}
+	policies, err := listToolLifePolicies(tx.db, resources)
+	if err != nil {
+		return err
+	}
+	for _, resource := range resources {
+		if resource.IsRetired() {
+			return mcomErr.Error{Code: mcomErr.Code_TOOL_RETIRED, Details: "resource: " + resource.ID}
+		}
+		if policies[resource.ToolID].LifeExceeded(resource) {
+			return mcomErr.Error{
+				Code:    mcomErr.Code_TOOL_LIFE_EXCEEDED,
+				Details: fmt.Sprintf("resource: %s, cycles: %d", resource.ID, resource.Cycles),
+			}
+		}
+	}
+	return nil
+}
+
+func parseToolLife(resource models.ToolResource, policy models.ToolLifePolicy) mcom.ToolLife {
+	res := mcom.ToolLife{
+		ResourceID:             resource.ID,
+		ToolID:                 resource.ToolID,
+		BindingSite:            resource.BindingSite,
+		Cycles:                 resource.Cycles,
+		CyclesSinceMaintenance: resource.CyclesSinceMaintenance,
+		LifeLimit:              policy.LifeLimit,
+		MaintenanceInterval:    policy.MaintenanceInterval,
+		LifeWarning:            policy.LifeWarning(resource),
+		LifeExceeded:           policy.LifeExceeded(resource),
+		MaintenanceWarning:     policy.MaintenanceWarning(resource),
+		MaintenanceDue:         policy.MaintenanceDue(resource),
+	}
+	if resource.MaintainedAt != 0 {
+		res.MaintainedAt = resource.MaintainedAt.Time()
+	}
+	return res
+}
+
+// GetToolLife implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) GetToolLife(ctx context.Context, req mcom.GetToolLifeRequest) (mcom.GetToolLifeReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.GetToolLifeReply{}, err
+	}
+
+	session := dm.newSession(ctx)
+	var resource models.ToolResource
+	if err := session.db.Where(`id = ?`, req.ResourceID).Take(&resource).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return mcom.GetToolLifeReply{}, mcomErr.Error{Code: mcomErr.Code_RESOURCE_NOT_FOUND}
+		}
+		return mcom.GetToolLifeReply{}, err
+	}
+	policies, err := listToolLifePolicies(session.db, []models.ToolResource{resource})
+	if err != nil {
+		return mcom.GetToolLifeReply{}, err
+	}
+	return mcom.GetToolLifeReply(parseToolLife(resource, policies[resource.ToolID])), nil
+}
+
+// ListToolsDueForMaintenance implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) ListToolsDueForMaintenance(ctx context.Context, req mcom.ListToolsDueForMaintenanceRequest) (mcom.ListToolsDueForMaintenanceReply, error) {
+	session := dm.newSession(ctx)
+	query := session.db.
+		Where(`tool_id IN (?)`, session.db.
+			Model(&models.ToolLifePolicy{}).
+			Select(`tool_id`).
+			Where(`life_limit > 0 OR maintenance_interval > 0`)).
+		Where(`retired_at = 0`)
+	if req.ToolID != "" {
+		query = query.Where(`tool_id = ?`, req.ToolID)
+	}
+
+	var resources []models.ToolResource
+	if err := query.Order(`id`).Find(&resources).Error; err != nil {
+		return mcom.ListToolsDueForMaintenanceReply{}, err
+	}
+	policies, err := listToolLifePolicies(session.db, resources)
+	if err != nil {
+		return mcom.ListToolsDueForMaintenanceReply{}, err
+	}
+
+	res := []mcom.ToolLife{}
+	for _, resource := range resources {
+		life := parseToolLife(resource, policies[resource.ToolID])
+		if life.LifeExceeded || life.MaintenanceDue ||
+			(req.IncludeWarnings && (life.LifeWarning || life.MaintenanceWarning)) {
+			res = append(res, life)
+		}
+	}
+	return mcom.ListToolsDueForMaintenanceReply{Tools: res}, nil
+}
+
+// CreateToolTypes implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) CreateToolTypes(ctx context.Context, req mcom.CreateToolTypesRequest) error {
+	if err := req.CheckInsufficiency(); err != nil {
+		return err
+	}
+
+	createdBy := commonsCtx.UserID(ctx)
+	toolTypes := make([]models.ToolType, len(req.Types))
+	for i, toolType := range req.Types {
+		toolTypes[i] = models.ToolType{
+			ID:          toolType.ID,
+			Description: toolType.Description,
+			UpdatedBy:   createdBy,
+			CreatedBy:   createdBy,
+		}
+	}
+
+	session := dm.newSession(ctx)
+	if err := session.db.Create(&toolTypes).Error; err != nil {
+		if IsPqError(err, UniqueViolation) {
+			return mcomErr.Error{Code: mcomErr.Code_TOOL_TYPE_ALREADY_EXISTS}
+		}
+		return err
+	}
+	return nil
+}
+
+// UpdateToolType implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) UpdateToolType(ctx context.Context, req mcom.UpdateToolTypeRequest) error {
+	if err := req.CheckInsufficiency(); err != nil {
+		return err
+	}
+
+	session := dm.newSession(ctx)
+	result := session.db.
+		Model(&models.ToolType{}).
+		Where(`id = ?`, req.ID).
+		Updates(map[string]interface{}{
+			"description": req.Description,
+			"updated_by":  commonsCtx.UserID(ctx),
+		})
+	if err := result.Error; err != nil {
+		return err
+	}
+	if result.RowsAffected == 0 {
+		return mcomErr.Error{Code: mcomErr.Code_TOOL_TYPE_NOT_FOUND, Details: "tool type: " + req.ID}
+	}
+	return nil
+}
+
+// ListToolTypes implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) ListToolTypes(ctx context.Context) (mcom.ListToolTypesReply, error) {
+	session := dm.newSession(ctx)
+	var toolTypes []models.ToolType
+	if err := session.db.Order(`id`).Find(&toolTypes).Error; err != nil {
+		return mcom.ListToolTypesReply{}, err
+	}
+
+	res := make([]mcom.ToolType, len(toolTypes))
+	for i, toolType := range toolTypes {
+		res[i] = mcom.ToolType{
+			ID:          toolType.ID,
+			Description: toolType.Description,
+		}
+	}
+	return mcom.ListToolTypesReply{Types: res}, nil
+}
+
+// checkToolTypes checks the tool IDs are in the tool type master data.
+func (tx *txDataManager) checkToolTypes(toolIDs []string) error {
+	toolIDs = uniqueStrings(toolIDs)
+
+	var existed []string
+	if err := tx.db.Model(&models.ToolType{}).Where(`id IN ?`, toolIDs).Pluck("id", &existed).Error; err != nil {
+		return err
+	}
+	if len(existed) == len(toolIDs) {
+		return nil
+	}
+
+	existedSet := make(map[string]struct{}, len(existed))
+	for _, id := range existed {
+		existedSet[id] = struct{}{}
+	}
+	for _, id := range toolIDs {
+		if _, ok := existedSet[id]; !ok {
+			return mcomErr.Error{Code: mcomErr.Code_TOOL_TYPE_NOT_FOUND, Details: "tool type: " + id}
+		}
+	}
+	return nil
+}
+
+// getToolResourceForUpdate returns the tool resource in service and locks it.
+func (tx *txDataManager) getToolResourceForUpdate(resourceID string) (models.ToolResource, error) {
+	var resource models.ToolResource
+	if err := tx.db.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(`id = ?`, resourceID).
+		Take(&resource).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ToolResource{}, mcomErr.Error{Code: mcomErr.Code_RESOURCE_NOT_FOUND}
+		}
+		return models.ToolResource{}, err
+	}
+	if resource.IsRetired() {
+		return models.ToolResource{}, mcomErr.Error{Code: mcomErr.Code_TOOL_RETIRED, Details: "resource: " + resourceID}
+	}
+	return resource, nil
+}
+
+// CreateToolResources implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) CreateToolResources(ctx context.Context, req mcom.CreateToolResourcesRequest) error {
+	if err := req.CheckInsufficiency(); err != nil {
+		return err
+	}
+
+	createdBy := commonsCtx.UserID(ctx)
+	toolIDs := make([]string, len(req.Resources))
+	resources := make([]models.ToolResource, len(req.Resources))
+	histories := make([]models.ToolResourceHistory, len(req.Resources))
+	for i, resource := range req.Resources {
+		toolIDs[i] = resource.ToolID
+		resources[i] = models.ToolResource{
+			ID:        resource.ResourceID,
+			ToolID:    resource.ToolID,
+			UpdatedBy: createdBy,
+			CreatedBy: createdBy,
+		}
+		histories[i] = models.ToolResourceHistory{
+			ResourceID: resource.ResourceID,
+			Action:     models.ToolResourceActionCreate,
+			ToolID:     resource.ToolID,
+			CreatedBy:  createdBy,
+		}
+	}
+
+	tx := dm.beginTx(ctx)
+	defer tx.Rollback() // nolint: errcheck
+
+	if err := tx.checkToolTypes(toolIDs); err != nil {
+		return err
+	}
+	if err := tx.db.Create(&resources).Error; err != nil {
+		if IsPqError(err, UniqueViolation) {
+			return mcomErr.Error{Code: mcomErr.Code_RESOURCE_EXISTED}
+		}
+		return err
+	}
+	if err := tx.db.Create(&histories).Error; err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UpdateToolResource implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) UpdateToolResource(ctx context.Context, req mcom.UpdateToolResourceRequest) error {
+	if err := req.CheckInsufficiency(); err != nil {
+		return err
+	}
+
+	updatedBy := commonsCtx.UserID(ctx)
+
+	tx := dm.beginTx(ctx)
+	defer tx.Rollback() // nolint: errcheck
+
+	if _, err := tx.getToolResourceForUpdate(req.ResourceID); err != nil {
+		return err
+	}
+	if err := tx.checkToolTypes([]string{req.ToolID}); err != nil {
+		return err
+	}
+	if err := tx.db.
+		Model(&models.ToolResource{}).
+		Where(`id = ?`, req.ResourceID).
+		Updates(map[string]interface{}{
+			"tool_id":    req.ToolID,
+			"updated_by": updatedBy,
+		}).Error; err != nil {
+		return err
+	}
+	if err := tx.db.Create(&models.ToolResourceHistory{
+		ResourceID: req.ResourceID,
+		Action:     models.ToolResourceActionUpdate,
+		ToolID:     req.ToolID,
+		CreatedBy:  updatedBy,
+	}).Error; err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RetireToolResource implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) RetireToolResource(ctx context.Context, req mcom.RetireToolResourceRequest) error {
+	if err := req.CheckInsufficiency(); err != nil {
+		return err
+	}
+
+	updatedBy := commonsCtx.UserID(ctx)
+
+	tx := dm.beginTx(ctx)
+	defer tx.Rollback() // nolint: errcheck
+
+	resource, err := tx.getToolResourceForUpdate(req.ResourceID)
+	if err != nil {
+		return err
+	}
+	if resource.BindingSite != (models.UniqueSite{}) {
+		return mcomErr.Error{
+			Code:    mcomErr.Code_RESOURCE_UNAVAILABLE,
+			Details: fmt.Sprintf("resource %s is bound to station: %s, site: %s, index: %d", resource.ID, resource.BindingSite.Station, resource.BindingSite.SiteID.Name, resource.BindingSite.SiteID.Index),
+		}
+	}
+	if err := tx.db.
+		Model(&models.ToolResource{}).
+		Where(`id = ?`, req.ResourceID).
+		Updates(map[string]interface{}{
+			"retired_at": types.ToTimeNano(time.Now()),
+			"updated_by": updatedBy,
+		}).Error; err != nil {
+		return err
+	}
+	if err := tx.db.Create(&models.ToolResourceHistory{
+		ResourceID: req.ResourceID,
+		Action:     models.ToolResourceActionRetire,
+		ToolID:     resource.ToolID,
+		Reason:     req.Reason,
+		CreatedBy:  updatedBy,
+	}).Error; err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ListToolResourceHistory implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) ListToolResourceHistory(ctx context.Context, req mcom.ListToolResourceHistoryRequest) (mcom.ListToolResourceHistoryReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.ListToolResourceHistoryReply{}, err
+	}
+
+	session := dm.newSession(ctx)
+	var histories []models.ToolResourceHistory
+	if err := session.db.
+		Where(`resource_id = ?`, req.ResourceID).
+		Order(`created_at, id`).
+		Find(&histories).Error; err != nil {
+		return mcom.ListToolResourceHistoryReply{}, err
+	}
+
+	res := make([]mcom.ToolResourceHistory, len(histories))
+	for i, history := range histories {
+		res[i] = mcom.ToolResourceHistory{
+			Action:    history.Action,
+			ToolID:    history.ToolID,
+			Reason:    history.Reason,
+			CreatedAt: history.CreatedAt.Time(),
+			CreatedBy: history.CreatedBy,
+		}
+	}
+	return mcom.ListToolResourceHistoryReply{Histories: res}, nil
+}
+
+// recordToolBinds ends the bind histories of the unbound and the bound tools,
+// and starts the bind histories of the bound tools. The tool IDs and the
+// binding sites are those stored in the tool resources, so it must be called
+// after the tool resources are updated.
+func (tx *txDataManager) recordToolBinds(bound []string, unbound []string, by string) error {
+	now := types.ToTimeNano(time.Now())
+
+	ended := append(append([]string{}, bound...), unbound...)
+	if len(ended) != 0 {
+		if err := tx.db.
+			Model(&models.ToolBindHistory{}).
+			Where(`resource_id IN ? AND unbound_at = 0`, ended).
+			Updates(map[string]interface{}{
+				"unbound_at": now,
+				"unbound_by": by,
+			}).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(bound) == 0 {
+		return nil
+	}
+	var resources []models.ToolResource
+	if err := tx.db.Where(`id IN ?`, bound).Find(&resources).Error; err != nil {
+		return err
+	}
+	if len(resources) == 0 {
+		return nil
+	}
+	histories := make([]models.ToolBindHistory, len(resources))
+	for i, resource := range resources {
+		histories[i] = models.ToolBindHistory{
+			ResourceID: resource.ID,
+			ToolID:     resource.ToolID,
+			Station:    resource.BindingSite.Station,
+			SiteName:   resource.BindingSite.SiteID.Name,
+			SiteIndex:  resource.BindingSite.SiteID.Index,
+			BoundAt:    now,
+			BoundBy:    by,
+		}
+	}
+	return tx.db.Create(&histories).Error
+}
+
+// inBindHistory reports whether the production at the station at the time
+// is during the bind history.
+func inBindHistory(history models.ToolBindHistory, station string, at types.TimeNano) bool {
+	return history.Station == station &&
+		at >= history.BoundAt &&
+		(history.UnboundAt == 0 || at < history.UnboundAt)
+}
+
+// listToolBindProductions lists the collect records and the batches at the
+// stations of the sites during the bind histories. The productions of the
+// shared sites are empty.
+func listToolBindProductions(db *gorm.DB, histories []models.ToolBindHistory) ([][]mcom.ToolBindProduction, error) {
+	res := make([][]mcom.ToolBindProduction, len(histories))
+	for i := range res {
+		res[i] = []mcom.ToolBindProduction{}
+	}
+
+	// a condition of the time windows of all the histories.
+	var windows []string
+	var args []interface{}
+	for _, history := range histories {
+		if history.Station == "" {
+			continue
+		}
+		if history.UnboundAt == 0 {
+			windows = append(windows, `(station = ? AND created_at >= ?)`)
+			args = append(args, history.Station, history.BoundAt)
+		} else {
+			windows = append(windows, `(station = ? AND created_at >= ? AND created_at < ?)`)
+			args = append(args, history.Station, history.BoundAt, history.UnboundAt)
+		}
+	}
+	if len(windows) == 0 {
+		return res, nil
+	}
+	condition := `(` + strings.Join(windows, ` OR `) + `)`
+
+	var records []models.CollectRecord
+	if err := db.Where(condition, args...).Order(`created_at`).Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	var batches []struct {
+		models.Batch
+		Station string
+	}
+	if err := db.
+		Table(`(?) AS b`, db.
+			Model(&models.Batch{}).
+			Select(`batch.*, work_order.station`).
+			Joins(`JOIN work_order ON work_order.id = batch.work_order`)).
+		Where(condition, args...).
+		Order(`created_at`).
+		Find(&batches).Error; err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		for i, history := range histories {
+			if inBindHistory(history, record.Station, record.CreatedAt) {
+				res[i] = append(res[i], mcom.ToolBindProduction{
+					WorkOrder:   record.WorkOrder,
+					Sequence:    record.Sequence,
+					LotNumber:   record.LotNumber,
+					Quantity:    record.Detail.Quantity,
+					CollectedAt: record.CreatedAt.Time(),
+				})
+			}
+		}
+	}
+	for _, batch := range batches {
+		for i, history := range histories {
+			if inBindHistory(history, batch.Station, batch.CreatedAt) {
+				res[i] = append(res[i], mcom.ToolBindProduction{
+					WorkOrder:   batch.WorkOrder,
+					Sequence:    batch.Number,
+					Batch:       true,
+					CollectedAt: batch.CreatedAt.Time(),
+				})
+			}
+		}
+	}
+	for i := range res {
+		productions := res[i]
+		sort.SliceStable(productions, func(j, k int) bool {
+			return productions[j].CollectedAt.Before(productions[k].CollectedAt)
+		})
+	}
+	return res, nil
+}
+
+// ListToolBindHistory implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) ListToolBindHistory(ctx context.Context, req mcom.ListToolBindHistoryRequest) (mcom.ListToolBindHistoryReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.ListToolBindHistoryReply{}, err
+	}
+
+	session := dm.newSession(ctx)
+	query := session.db
+	if req.ResourceID != "" {
+		query = query.Where(`resource_id = ?`, req.ResourceID)
+	}
+	if req.ToolID != "" {
+		query = query.Where(`tool_id = ?`, req.ToolID)
+	}
+	if req.Site != nil {
+		query = query.Where(`station = ? AND site_name = ? AND site_index = ?`, req.Site.Station, req.Site.SiteID.Name, req.Site.SiteID.Index)
+	}
+	if !req.Since.IsZero() {
+		query = query.Where(`(unbound_at = 0 OR unbound_at > ?)`, types.ToTimeNano(req.Since))
+	}
+	if !req.Until.IsZero() {
+		query = query.Where(`bound_at < ?`, types.ToTimeNano(req.Until))
+	}
+
+	var histories []models.ToolBindHistory
+	if err := query.Order(`bound_at, id`).Find(&histories).Error; err != nil {
+		return mcom.ListToolBindHistoryReply{}, err
+	}
+
+	productions, err := listToolBindProductions(session.db, histories)
+	if err != nil {
+		return mcom.ListToolBindHistoryReply{}, err
+	}
+	res := make([]mcom.ToolBindHistory, len(histories))
+	for i, history := range histories {
+		res[i] = mcom.ToolBindHistory{
+			ResourceID:  history.ResourceID,
+			ToolID:      history.ToolID,
+			Site:        history.Site(),
+			BoundAt:     history.BoundAt.Time(),
+			BoundBy:     history.BoundBy,
+			UnboundBy:   history.UnboundBy,
+			Productions: productions[i],
+		}
+		if history.UnboundAt != 0 {
+			res[i].UnboundAt = history.UnboundAt.Time()
+		}
+	}
+	return mcom.ListToolBindHistoryReply{Histories: res}, nil
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"bou.ke/monkey"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 
 	"gitlab.kenda.com.tw/kenda/mcom"
@@ -1086,3 +1087,423 @@ func TestDataManager_ListToolResources(t *testing.T) {
 	patch.Unpatch()
 	assert.NoError(cm.Clear())
 }
+
+func TestDataManager_ToolLife(t *testing.T) {
+	assert := assert.New(t)
+	ctx, dm, db := initializeDB(t)
+	defer dm.Close()
+	cm := newClearMaster(db, &models.Station{}, &models.Site{}, &models.SiteContents{}, &models.ToolResource{}, &models.ToolLifePolicy{}, &models.CollectRecord{}, &models.WorkOrder{}, &models.Batch{})
+	assert.NoError(cm.Clear())
+
+	const (
+		mold  = "MOLD"
+		moldA = "MOLD_A"
+		moldB = "MOLD_B"
+	)
+	site := models.UniqueSite{
+		SiteID:  models.SiteID{Name: "slot"},
+		Station: testStationA,
+	}
+	assert.NoError(dm.CreateStation(ctx, mcom.CreateStationRequest{
+		ID:            testStationA,
+		DepartmentOID: testDepartmentA,
+		Sites: []mcom.SiteInformation{{
+			Name:    site.SiteID.Name,
+			Type:    sites.Type_SLOT,
+			SubType: sites.SubType_TOOL,
+		}},
+	}))
+	assert.NoError(db.Create(&[]models.ToolResource{
+		{ID: moldA, ToolID: mold},
+		{ID: moldB, ToolID: mold},
+	}).Error)
+	assert.NoError(dm.SetToolLifePolicy(ctx, mcom.SetToolLifePolicyRequest{
+		ToolID:              mold,
+		LifeLimit:           10,
+		MaintenanceInterval: 4,
+		WarningCycles:       1,
+	}))
+
+	bind := func(resourceID string) error {
+		return dm.ToolResourceBindV2(ctx, mcom.ToolResourceBindRequestV2{
+			Details: []mcom.ToolBindRequestDetailV2{{
+				Type: bindtype.BindType_RESOURCE_BINDING_SLOT_BIND,
+				Site: site,
+				Resource: mcom.ToolResource{
+					ResourceID: resourceID,
+					ToolID:     mold,
+				},
+			}},
+		})
+	}
+	collect := func(sequence int16, batchCount int16) error {
+		return dm.CreateCollectRecord(ctx, mcom.CreateCollectRecordRequest{
+			WorkOrder:   "WO",
+			Sequence:    sequence,
+			LotNumber:   "LOT",
+			Station:     testStationA,
+			ResourceOID: "OID",
+			Quantity:    decimal.NewFromInt(1),
+			BatchCount:  batchCount,
+		})
+	}
+
+	assert.NoError(bind(moldA))
+	assert.NoError(collect(1, 0))
+	assert.NoError(collect(2, 2))
+
+	{ // GetToolLife: maintenance warning.
+		actual, err := dm.GetToolLife(ctx, mcom.GetToolLifeRequest{ResourceID: moldA})
+		assert.NoError(err)
+		assert.Equal(mcom.GetToolLifeReply{
+			ResourceID:             moldA,
+			ToolID:                 mold,
+			BindingSite:            site,
+			Cycles:                 3,
+			CyclesSinceMaintenance: 3,
+			LifeLimit:              10,
+			MaintenanceInterval:    4,
+			MaintenanceWarning:     true,
+		}, actual)
+	}
+	{ // ListToolsDueForMaintenance.
+		actual, err := dm.ListToolsDueForMaintenance(ctx, mcom.ListToolsDueForMaintenanceRequest{})
+		assert.NoError(err)
+		assert.Empty(actual.Tools)
+
+		actual, err = dm.ListToolsDueForMaintenance(ctx, mcom.ListToolsDueForMaintenanceRequest{IncludeWarnings: true})
+		assert.NoError(err)
+		if assert.Len(actual.Tools, 1) {
+			assert.Equal(moldA, actual.Tools[0].ResourceID)
+		}
+	}
+	{ // RecordToolMaintenance.
+		assert.NoError(dm.RecordToolMaintenance(ctx, mcom.RecordToolMaintenanceRequest{ResourceID: moldA}))
+
+		actual, err := dm.GetToolLife(ctx, mcom.GetToolLifeRequest{ResourceID: moldA})
+		assert.NoError(err)
+		assert.Equal(int64(3), actual.Cycles)
+		assert.Equal(int64(0), actual.CyclesSinceMaintenance)
+		assert.False(actual.MaintainedAt.IsZero())
+
+		assert.ErrorIs(dm.RecordToolMaintenance(ctx, mcom.RecordToolMaintenanceRequest{ResourceID: "NOT_FOUND"}), mcomErr.Error{
+			Code: mcomErr.Code_RESOURCE_NOT_FOUND,
+		})
+	}
+	{ // bind a tool reaching its life limit.
+		assert.NoError(collect(3, 7))
+		assert.NoError(bind(moldB))
+
+		actual, err := dm.ListToolsDueForMaintenance(ctx, mcom.ListToolsDueForMaintenanceRequest{ToolID: mold})
+		assert.NoError(err)
+		if assert.Len(actual.Tools, 1) {
+			assert.Equal(moldA, actual.Tools[0].ResourceID)
+			assert.True(actual.Tools[0].LifeExceeded)
+			assert.True(actual.Tools[0].MaintenanceDue)
+		}
+
+		assert.ErrorIs(bind(moldA), mcomErr.Error{
+			Code:    mcomErr.Code_TOOL_LIFE_EXCEEDED,
+			Details: "resource: " + moldA + ", cycles: 10",
+		})
+	}
+	{ // batches count toward the tool life once.
+		workOrder := models.WorkOrder{
+			ProcessOID:   testProcessOID,
+			ProcessName:  testProcessName,
+			ProcessType:  testProcessType,
+			DepartmentID: testDepartmentA,
+			Station:      testStationA,
+			ReservedDate: time.Now(),
+		}
+		assert.NoError(db.Create(&workOrder).Error)
+		assert.NoError(dm.CreateBatch(ctx, mcom.CreateBatchRequest{WorkOrder: workOrder.ID, Number: 1}))
+		// the collect of the batch is not counted again.
+		assert.NoError(dm.CreateCollectRecord(ctx, mcom.CreateCollectRecordRequest{
+			WorkOrder:   workOrder.ID,
+			Sequence:    1,
+			LotNumber:   "LOT",
+			Station:     testStationA,
+			ResourceOID: "OID",
+			Quantity:    decimal.NewFromInt(1),
+			BatchCount:  1,
+		}))
+
+		actual, err := dm.GetToolLife(ctx, mcom.GetToolLifeRequest{ResourceID: moldB})
+		assert.NoError(err)
+		assert.Equal(int64(1), actual.Cycles)
+		assert.Equal(int64(1), actual.CyclesSinceMaintenance)
+	}
+	{ // collects and batches with a bound tool reaching its life limit.
+		assert.NoError(db.Model(&models.ToolResource{}).Where(`id = ?`, moldB).Update("cycles", 10).Error)
+
+		expected := mcomErr.Error{
+			Code:    mcomErr.Code_TOOL_LIFE_EXCEEDED,
+			Details: "resource: " + moldB + ", cycles: 10",
+		}
+		assert.ErrorIs(collect(4, 0), expected)
+
+		var workOrder models.WorkOrder
+		assert.NoError(db.Where(`station = ?`, testStationA).Take(&workOrder).Error)
+		assert.ErrorIs(dm.CreateBatch(ctx, mcom.CreateBatchRequest{WorkOrder: workOrder.ID, Number: 2}), expected)
+
+		actual, err := dm.GetToolLife(ctx, mcom.GetToolLifeRequest{ResourceID: moldB})
+		assert.NoError(err)
+		assert.Equal(int64(10), actual.Cycles)
+		assert.Equal(int64(1), actual.CyclesSinceMaintenance)
+	}
+
+	assert.NoError(cm.Clear())
+}
+
+func TestDataManager_ToolRegistry(t *testing.T) {
+	assert := assert.New(t)
+	ctx, dm, db := initializeDB(t)
+	defer dm.Close()
+	cm := newClearMaster(db, &models.Station{}, &models.Site{}, &models.SiteContents{}, &models.ToolResource{}, &models.ToolType{}, &models.ToolResourceHistory{})
+	assert.NoError(cm.Clear())
+
+	const (
+		mold      = "MOLD"
+		otherMold = "OTHER_MOLD"
+		moldA     = "MOLD_A"
+	)
+	site := models.UniqueSite{
+		SiteID:  models.SiteID{Name: "slot"},
+		Station: testStationA,
+	}
+	assert.NoError(dm.CreateStation(ctx, mcom.CreateStationRequest{
+		ID:            testStationA,
+		DepartmentOID: testDepartmentA,
+		Sites: []mcom.SiteInformation{{
+			Name:    site.SiteID.Name,
+			Type:    sites.Type_SLOT,
+			SubType: sites.SubType_TOOL,
+		}},
+	}))
+	bind := func(bindType bindtype.BindType, resource mcom.ToolResource) error {
+		return dm.ToolResourceBindV2(ctx, mcom.ToolResourceBindRequestV2{
+			Details: []mcom.ToolBindRequestDetailV2{{
+				Type:     bindType,
+				Site:     site,
+				Resource: resource,
+			}},
+		})
+	}
+
+	{ // CreateToolTypes: good case.
+		assert.NoError(dm.CreateToolTypes(ctx, mcom.CreateToolTypesRequest{
+			Types: []mcom.ToolType{{ID: mold, Description: "mold"}, {ID: otherMold}},
+		}))
+		assert.NoError(dm.UpdateToolType(ctx, mcom.UpdateToolTypeRequest{ID: otherMold, Description: "other mold"}))
+
+		actual, err := dm.ListToolTypes(ctx)
+		assert.NoError(err)
+		assert.Equal(mcom.ListToolTypesReply{
+			Types: []mcom.ToolType{{ID: mold, Description: "mold"}, {ID: otherMold, Description: "other mold"}},
+		}, actual)
+	}
+	{ // CreateToolTypes: already exists.
+		assert.ErrorIs(dm.CreateToolTypes(ctx, mcom.CreateToolTypesRequest{
+			Types: []mcom.ToolType{{ID: mold}},
+		}), mcomErr.Error{Code: mcomErr.Code_TOOL_TYPE_ALREADY_EXISTS})
+	}
+	{ // UpdateToolType: not found.
+		assert.ErrorIs(dm.UpdateToolType(ctx, mcom.UpdateToolTypeRequest{ID: "NOT_FOUND"}), mcomErr.Error{
+			Code:    mcomErr.Code_TOOL_TYPE_NOT_FOUND,
+			Details: "tool type: NOT_FOUND",
+		})
+	}
+	{ // CreateToolResources: tool type not found.
+		assert.ErrorIs(dm.CreateToolResources(ctx, mcom.CreateToolResourcesRequest{
+			Resources: []mcom.CreateToolResource{{ResourceID: moldA, ToolID: "NOT_FOUND"}},
+		}), mcomErr.Error{
+			Code:    mcomErr.Code_TOOL_TYPE_NOT_FOUND,
+			Details: "tool type: NOT_FOUND",
+		})
+	}
+	{ // CreateToolResources: good case.
+		assert.NoError(dm.CreateToolResources(ctx, mcom.CreateToolResourcesRequest{
+			Resources: []mcom.CreateToolResource{{ResourceID: moldA, ToolID: mold}},
+		}))
+
+		actual, err := dm.GetToolResource(ctx, mcom.GetToolResourceRequest{ResourceID: moldA})
+		assert.NoError(err)
+		assert.Equal(mold, actual.ToolID)
+		assert.Zero(actual.RetiredAt)
+	}
+	{ // CreateToolResources: already exists.
+		assert.ErrorIs(dm.CreateToolResources(ctx, mcom.CreateToolResourcesRequest{
+			Resources: []mcom.CreateToolResource{{ResourceID: moldA, ToolID: mold}},
+		}), mcomErr.Error{Code: mcomErr.Code_RESOURCE_EXISTED})
+	}
+	{ // UpdateToolResource: good case.
+		assert.NoError(dm.UpdateToolResource(ctx, mcom.UpdateToolResourceRequest{ResourceID: moldA, ToolID: otherMold}))
+
+		actual, err := dm.GetToolResource(ctx, mcom.GetToolResourceRequest{ResourceID: moldA})
+		assert.NoError(err)
+		assert.Equal(otherMold, actual.ToolID)
+	}
+	{ // UpdateToolResource: not found.
+		assert.ErrorIs(dm.UpdateToolResource(ctx, mcom.UpdateToolResourceRequest{ResourceID: "NOT_FOUND", ToolID: mold}), mcomErr.Error{
+			Code: mcomErr.Code_RESOURCE_NOT_FOUND,
+		})
+	}
+	{ // RetireToolResource: bound to a site.
+		assert.NoError(bind(bindtype.BindType_RESOURCE_BINDING_SLOT_BIND, mcom.ToolResource{ResourceID: moldA, ToolID: otherMold}))
+		assert.ErrorIs(dm.RetireToolResource(ctx, mcom.RetireToolResourceRequest{ResourceID: moldA}), mcomErr.Error{
+			Code:    mcomErr.Code_RESOURCE_UNAVAILABLE,
+			Details: "resource " + moldA + " is bound to station: " + testStationA + ", site: slot, index: 0",
+		})
+		assert.NoError(bind(bindtype.BindType_RESOURCE_BINDING_SLOT_CLEAR, mcom.ToolResource{}))
+	}
+	{ // RetireToolResource: good case.
+		assert.NoError(dm.RetireToolResource(ctx, mcom.RetireToolResourceRequest{ResourceID: moldA, Reason: "broken"}))
+
+		actual, err := dm.GetToolResource(ctx, mcom.GetToolResourceRequest{ResourceID: moldA})
+		assert.NoError(err)
+		assert.NotZero(actual.RetiredAt)
+	}
+	{ // retired tool.
+		retired := mcomErr.Error{
+			Code:    mcomErr.Code_TOOL_RETIRED,
+			Details: "resource: " + moldA,
+		}
+		assert.ErrorIs(dm.RetireToolResource(ctx, mcom.RetireToolResourceRequest{ResourceID: moldA}), retired)
+		assert.ErrorIs(dm.UpdateToolResource(ctx, mcom.UpdateToolResourceRequest{ResourceID: moldA, ToolID: mold}), retired)
+		assert.ErrorIs(bind(bindtype.BindType_RESOURCE_BINDING_SLOT_BIND, mcom.ToolResource{ResourceID: moldA, ToolID: otherMold}), retired)
+	}
+	{ // ListToolResourceHistory.
+		actual, err := dm.ListToolResourceHistory(ctx, mcom.ListToolResourceHistoryRequest{ResourceID: moldA})
+		assert.NoError(err)
+		if assert.Len(actual.Histories, 3) {
+			assert.Equal(models.ToolResourceActionCreate, actual.Histories[0].Action)
+			assert.Equal(mold, actual.Histories[0].ToolID)
+			assert.Equal(models.ToolResourceActionUpdate, actual.Histories[1].Action)
+			assert.Equal(otherMold, actual.Histories[1].ToolID)
+			assert.Equal(models.ToolResourceActionRetire, actual.Histories[2].Action)
+			assert.Equal("broken", actual.Histories[2].Reason)
+		}
+	}
+
+	assert.NoError(cm.Clear())
+}
+
+func TestDataManager_ListToolBindHistory(t *testing.T) {
+	assert := assert.New(t)
+	ctx, dm, db := initializeDB(t)
+	defer dm.Close()
+	cm := newClearMaster(db, &models.Station{}, &models.Site{}, &models.SiteContents{}, &models.ToolResource{}, &models.ToolBindHistory{}, &models.CollectRecord{}, &models.WorkOrder{}, &models.Batch{})
+	assert.NoError(cm.Clear())
+
+	const (
+		mold  = "MOLD"
+		moldA = "MOLD_A"
+		moldB = "MOLD_B"
+	)
+	site := models.UniqueSite{
+		SiteID:  models.SiteID{Name: "slot"},
+		Station: testStationA,
+	}
+	assert.NoError(dm.CreateStation(ctx, mcom.CreateStationRequest{
+		ID:            testStationA,
+		DepartmentOID: testDepartmentA,
+		Sites: []mcom.SiteInformation{{
+			Name:    site.SiteID.Name,
+			Type:    sites.Type_SLOT,
+			SubType: sites.SubType_TOOL,
+		}},
+	}))
+	assert.NoError(db.Create(&[]models.ToolResource{
+		{ID: moldA, ToolID: mold},
+		{ID: moldB, ToolID: mold},
+	}).Error)
+
+	bind := func(resourceID, toolID string) error {
+		return dm.ToolResourceBindV2(ctx, mcom.ToolResourceBindRequestV2{
+			Details: []mcom.ToolBindRequestDetailV2{{
+				Type: bindtype.BindType_RESOURCE_BINDING_SLOT_BIND,
+				Site: site,
+				Resource: mcom.ToolResource{
+					ResourceID: resourceID,
+					ToolID:     toolID,
+				},
+			}},
+		})
+	}
+	collect := func(sequence int16) error {
+		return dm.CreateCollectRecord(ctx, mcom.CreateCollectRecordRequest{
+			WorkOrder:   "WO",
+			Sequence:    sequence,
+			LotNumber:   "LOT",
+			Station:     testStationA,
+			ResourceOID: "OID",
+			Quantity:    decimal.NewFromInt(1),
+		})
+	}
+
+	workOrder := models.WorkOrder{
+		ProcessOID:   testProcessOID,
+		ProcessName:  testProcessName,
+		ProcessType:  testProcessType,
+		DepartmentID: testDepartmentA,
+		Station:      testStationA,
+		ReservedDate: time.Now(),
+	}
+	assert.NoError(db.Create(&workOrder).Error)
+
+	assert.NoError(bind(moldA, mold))
+	assert.NoError(collect(1))
+	// replaces MOLD_A, the history keeps the registered tool ID.
+	assert.NoError(bind(moldB, "OTHER_MOLD"))
+	assert.NoError(collect(2))
+	assert.NoError(dm.CreateBatch(ctx, mcom.CreateBatchRequest{WorkOrder: workOrder.ID, Number: 1}))
+	assert.NoError(dm.ToolResourceBindV2(ctx, mcom.ToolResourceBindRequestV2{
+		Details: []mcom.ToolBindRequestDetailV2{{
+			Type: bindtype.BindType_RESOURCE_BINDING_SLOT_CLEAR,
+			Site: site,
+		}},
+	}))
+	assert.NoError(collect(3))
+
+	{ // by resource.
+		actual, err := dm.ListToolBindHistory(ctx, mcom.ListToolBindHistoryRequest{ResourceID: moldA})
+		assert.NoError(err)
+		if assert.Len(actual.Histories, 1) {
+			history := actual.Histories[0]
+			assert.Equal(site, history.Site)
+			assert.False(history.UnboundAt.IsZero())
+			if assert.Len(history.Productions, 1) {
+				assert.Equal("WO", history.Productions[0].WorkOrder)
+				assert.Equal(int16(1), history.Productions[0].Sequence)
+			}
+		}
+	}
+	{ // by site.
+		actual, err := dm.ListToolBindHistory(ctx, mcom.ListToolBindHistoryRequest{Site: &site})
+		assert.NoError(err)
+		if assert.Len(actual.Histories, 2) {
+			assert.Equal(moldA, actual.Histories[0].ResourceID)
+			assert.Equal(moldB, actual.Histories[1].ResourceID)
+			assert.Equal(mold, actual.Histories[1].ToolID)
+			assert.False(actual.Histories[1].UnboundAt.IsZero())
+			if assert.Len(actual.Histories[1].Productions, 2) {
+				assert.Equal(int16(2), actual.Histories[1].Productions[0].Sequence)
+				assert.False(actual.Histories[1].Productions[0].Batch)
+				assert.Equal(workOrder.ID, actual.Histories[1].Productions[1].WorkOrder)
+				assert.Equal(int16(1), actual.Histories[1].Productions[1].Sequence)
+				assert.True(actual.Histories[1].Productions[1].Batch)
+			}
+		}
+	}
+	{ // by tool ID in a time range.
+		actual, err := dm.ListToolBindHistory(ctx, mcom.ListToolBindHistoryRequest{
+			ToolID: mold,
+			Since:  time.Now(),
+		})
+		assert.NoError(err)
+		assert.Empty(actual.Histories)
+	}
+
+	assert.NoError(cm.Clear())
+}
@@ -511,6 +511,14 @@ func (dm *DataManager) SignInStation(ctx context.Context, req mcom.SignInStation
 	if err != nil {
 		return err
 	}
+	if o.VerifyWorkDateByShiftCalendar {
+		if err := session.verifyWorkDateByShiftCalendar(station.AdminDepartmentID, req.WorkDate); err != nil {
+			return err
+		}
+	}
+	if err := session.checkUserQualifications(userID, req.Station); err != nil {
+		return err
+	}
 	if !station.Sites.Contains(req.Site) {
 		if !o.CreateSiteIfNotExists {
 			return mcomErr.Error{
@@ -563,22 +571,35 @@ func (dm *DataManager) SignInStation(ctx context.Context, req mcom.SignInStation
 		}
 	}
 
-	return session.db.
+	operator := models.OperatorSite{
+		EmployeeID: userID,
+		Group:      int8(req.Group),
+		WorkDate:   req.WorkDate,
+	}
+
+	tx := dm.beginTx(ctx)
+	defer tx.Rollback() // nolint: errcheck
+	if err := tx.db.
 		Model(&models.SiteContents{}).
 		Where(" name = ? AND index = ? AND station = ?", req.Site.Name, req.Site.Index, req.Station).
 		Select("content", "updated_by").
 		Updates(&models.SiteContents{
 			Content: models.SiteContent{
 				Slot: &models.Slot{
-					Operator: &models.OperatorSite{
-						EmployeeID: userID,
-						Group:      int8(req.Group),
-						WorkDate:   req.WorkDate,
-					},
+					Operator: &operator,
 				},
 			},
 			UpdatedBy: userID,
-		}).Error
+		}).Error; err != nil {
+		return err
+	}
+	if err := tx.startOperatorSession(models.UniqueSite{
+		SiteID:  req.Site,
+		Station: req.Station,
+	}, operator, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 func (dm *DataManager) SignOutStations(ctx context.Context, req mcom.SignOutStationsRequest) error {
@@ -626,7 +647,7 @@ func (tx *txDataManager) signOutStations(userID string, req mcom.SignOutStations
 	}
 
 	if len(condition) > 0 {
-		return tx.db.Model(&models.SiteContents{}).
+		if err := tx.db.Model(&models.SiteContents{}).
 			Where(`(station,name,index) IN ?`, condition).
 			Updates(map[string]interface{}{
 				"content": models.SiteContent{
@@ -635,7 +656,10 @@ func (tx *txDataManager) signOutStations(userID string, req mcom.SignOutStations
 					},
 				},
 				"updated_by": userID,
-			}).Error
+			}).Error; err != nil {
+			return err
+		}
+		return tx.endOperatorSessions(condition, time.Now(), userID, "")
 	}
 	return nil
 }
@@ -697,12 +721,24 @@ func (dm *DataManager) SignOutStation(ctx context.Context, req mcom.SignOutStati
 	}
 
 	siteContents.Slot.Clear()
-	return session.db.
+
+	tx := dm.beginTx(ctx)
+	defer tx.Rollback() // nolint: errcheck
+	if err := tx.db.
 		Model(&models.SiteContents{}).
 		Where(" name = ? AND index = ? AND station = ?", req.Site.Name, req.Site.Index, req.Station).
 		Select("content", "updated_by").
 		Updates(&models.SiteContents{
 			Content:   siteContents,
 			UpdatedBy: userID,
-		}).Error
+		}).Error; err != nil {
+		return err
+	}
+	if err := tx.endOperatorSessions([][3]string{uniqueSiteCondition(models.UniqueSite{
+		SiteID:  req.Site,
+		Station: req.Station,
+	})}, time.Now(), userID, ""); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
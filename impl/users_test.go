@@ -462,7 +462,7 @@ func Test_ListUnauthorizedUsers(t *testing.T) {
 }
 
 func deleteSignInOutData(db *gorm.DB) error {
-	return newClearMaster(db, &models.User{}, &models.Station{}, &models.SiteContents{}, &models.Site{}, &models.Department{}, &models.Account{}).Clear()
+	return newClearMaster(db, &models.User{}, &models.Station{}, &models.SiteContents{}, &models.Site{}, &models.Department{}, &models.Account{}, &models.OperatorSession{}).Clear()
 }
 
 func Test_SignInStation(t *testing.T) {
@@ -334,11 +334,14 @@ func (dm *DataManager) WarehousingStock(ctx context.Context, req mcom.Warehousin
 	tx := dm.beginTx(ctx)
 	defer tx.Rollback() // nolint: errcheck
 
+	if err := tx.checkWarehouseLocation(req.Warehouse, resources); err != nil {
+		return err
+	}
 	if err := tx.updateWarehouseStocks(req.Warehouse, resources); err != nil {
 		return err
 	}
 
-	if err := tx.createResourceTransportRecords(req, commonsCtx.UserID(ctx), resources); err != nil {
+	if err := tx.createResourceTransportRecords(req.Warehouse, "", commonsCtx.UserID(ctx), resources); err != nil {
 		return err
 	}
 	return tx.Commit()
@@ -369,7 +372,7 @@ func (tx *txDataManager) updateWarehouseStocks(newWarehouse mcom.Warehouse, reso
 		newWarehouseKey := variationKey{
 			warehouseID:       newWarehouse.ID,
 			warehouseLocation: newWarehouse.Location,
-			productID:         resource.ID,
+			productID:         resource.ProductID,
 		}
 		val, ok = warehouseStockVariation[newWarehouseKey]
 		if !ok {
@@ -413,7 +416,8 @@ func (tx *txDataManager) updateWarehouseStocks(newWarehouse mcom.Warehouse, reso
 }
 
 func (tx *txDataManager) createResourceTransportRecords(
-	req mcom.WarehousingStockRequest,
+	newWarehouse mcom.Warehouse,
+	note string,
 	createdBy string,
 	resources []models.MaterialResource) error {
 	rows := make([]models.ResourceTransportRecord, len(resources))
@@ -421,10 +425,10 @@ func (tx *txDataManager) createResourceTransportRecords(
 		rows[i] = models.ResourceTransportRecord{
 			OldWarehouseID: resource.WarehouseID,
 			OldLocation:    resource.WarehouseLocation,
-			NewWarehouseID: req.Warehouse.ID,
-			NewLocation:    req.Warehouse.Location,
+			NewWarehouseID: newWarehouse.ID,
+			NewLocation:    newWarehouse.Location,
 			ResourceID:     resource.ID,
-			Note:           "",
+			Note:           note,
 			CreatedBy:      createdBy,
 		}
 	}
@@ -0,0 +1,309 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+)
+
+// CreateWarehouseLocations implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) CreateWarehouseLocations(ctx context.Context, req mcom.CreateWarehouseLocationsRequest) error {
+	if err := req.CheckInsufficiency(); err != nil {
+		return err
+	}
+
+	tx := dm.beginTx(ctx)
+	defer tx.Rollback() // nolint: errcheck
+
+	userID := commonsCtx.UserID(ctx)
+	warehouses := make(map[string]warehouseLocations)
+	rows := make([]models.WarehouseLocation, len(req.Locations))
+	for i, location := range req.Locations {
+		locations, ok := warehouses[location.WarehouseID]
+		if !ok {
+			if err := checkWarehouseExists(tx.db, location.WarehouseID); err != nil {
+				return err
+			}
+			var err error
+			if locations, err = listWarehouseLocations(tx.db, location.WarehouseID); err != nil {
+				return err
+			}
+			warehouses[location.WarehouseID] = locations
+		}
+
+		if _, ok := locations[location.ID]; ok {
+			return mcomErr.Error{
+				Code:    mcomErr.Code_WAREHOUSE_LOCATION_ALREADY_EXISTS,
+				Details: fmt.Sprintf("warehouse: %s, location: %s", location.WarehouseID, location.ID),
+			}
+		}
+		if location.ParentID != "" {
+			if _, ok := locations[location.ParentID]; !ok {
+				return mcomErr.Error{
+					Code:    mcomErr.Code_WAREHOUSE_LOCATION_NOT_FOUND,
+					Details: fmt.Sprintf("warehouse: %s, parent location: %s", location.WarehouseID, location.ParentID),
+				}
+			}
+		}
+
+		productTypes := location.ProductTypes
+		if productTypes == nil {
+			productTypes = []string{}
+		}
+		rows[i] = models.WarehouseLocation{
+			WarehouseID:  location.WarehouseID,
+			ID:           location.ID,
+			ParentID:     location.ParentID,
+			Type:         location.Type,
+			Capacity:     location.Capacity,
+			ProductTypes: productTypes,
+			UpdatedBy:    userID,
+			CreatedBy:    userID,
+		}
+		locations[location.ID] = rows[i]
+	}
+
+	result := tx.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows)
+	if err := result.Error; err != nil {
+		return err
+	}
+	if result.RowsAffected != int64(len(rows)) {
+		return mcomErr.Error{Code: mcomErr.Code_WAREHOUSE_LOCATION_ALREADY_EXISTS}
+	}
+	return tx.Commit()
+}
+
+// UpdateWarehouseLocation implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) UpdateWarehouseLocation(ctx context.Context, req mcom.UpdateWarehouseLocationRequest) error {
+	if err := req.CheckInsufficiency(); err != nil {
+		return err
+	}
+
+	productTypes := req.ProductTypes
+	if productTypes == nil {
+		productTypes = []string{}
+	}
+	session := dm.newSession(ctx)
+	result := session.db.Model(&models.WarehouseLocation{}).
+		Where(`warehouse_id = ? AND id = ?`, req.WarehouseID, req.ID).
+		Updates(map[string]interface{}{
+			"capacity":      req.Capacity,
+			"product_types": pq.StringArray(productTypes),
+			"updated_by":    commonsCtx.UserID(ctx),
+		})
+	if err := result.Error; err != nil {
+		return err
+	}
+	if result.RowsAffected == 0 {
+		return mcomErr.Error{
+			Code:    mcomErr.Code_WAREHOUSE_LOCATION_NOT_FOUND,
+			Details: fmt.Sprintf("warehouse: %s, location: %s", req.WarehouseID, req.ID),
+		}
+	}
+	return nil
+}
+
+// ListWarehouseLocations implements gitlab.kenda.com.tw/kenda/mcom DataManager interface.
+func (dm *DataManager) ListWarehouseLocations(ctx context.Context, req mcom.ListWarehouseLocationsRequest) (mcom.ListWarehouseLocationsReply, error) {
+	if err := req.CheckInsufficiency(); err != nil {
+		return mcom.ListWarehouseLocationsReply{}, err
+	}
+
+	session := dm.newSession(ctx)
+	var rows []models.WarehouseLocation
+	if err := session.db.Where(`warehouse_id = ?`, req.WarehouseID).Order(`id`).Find(&rows).Error; err != nil {
+		return mcom.ListWarehouseLocationsReply{}, err
+	}
+	stocks, err := listWarehouseLocationStocks(session.db, req.WarehouseID)
+	if err != nil {
+		return mcom.ListWarehouseLocationsReply{}, err
+	}
+	locations := make(warehouseLocations, len(rows))
+	for _, row := range rows {
+		locations[row.ID] = row
+	}
+	occupancies := locations.occupancies(stocks)
+
+	reply := make([]mcom.WarehouseLocationInformation, len(rows))
+	for i, row := range rows {
+		reply[i] = mcom.WarehouseLocationInformation{
+			WarehouseLocation: mcom.WarehouseLocation{
+				WarehouseID:  row.WarehouseID,
+				ID:           row.ID,
+				ParentID:     row.ParentID,
+				Type:         row.Type,
+				Capacity:     row.Capacity,
+				ProductTypes: row.ProductTypes,
+			},
+			Occupancy: occupancies[row.ID],
+		}
+	}
+	return mcom.ListWarehouseLocationsReply{Locations: reply}, nil
+}
+
+func checkWarehouseExists(db *gorm.DB, warehouseID string) error {
+	if err := db.Where(`id = ?`, warehouseID).Take(&models.Warehouse{}).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return mcomErr.Error{Code: mcomErr.Code_WAREHOUSE_NOT_FOUND, Details: "warehouse: " + warehouseID}
+		}
+		return err
+	}
+	return nil
+}
+
+// warehouseLocations are the locations of a warehouse by their IDs.
+type warehouseLocations map[string]models.WarehouseLocation
+
+func listWarehouseLocations(db *gorm.DB, warehouseID string) (warehouseLocations, error) {
+	var rows []models.WarehouseLocation
+	if err := db.Where(`warehouse_id = ?`, warehouseID).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	locations := make(warehouseLocations, len(rows))
+	for _, row := range rows {
+		locations[row.ID] = row
+	}
+	return locations, nil
+}
+
+// listWarehouseLocationStocks returns the total quantities of the stocks in
+// each location of the warehouse.
+func listWarehouseLocationStocks(db *gorm.DB, warehouseID string) (map[string]decimal.Decimal, error) {
+	var rows []struct {
+		Location string
+		Quantity decimal.Decimal
+	}
+	if err := db.Model(&models.WarehouseStock{}).
+		Select(`location, SUM(quantity) AS quantity`).
+		Where(`id = ?`, warehouseID).
+		Group(`location`).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	stocks := make(map[string]decimal.Decimal, len(rows))
+	for _, row := range rows {
+		stocks[row.Location] = row.Quantity
+	}
+	return stocks, nil
+}
+
+// lineage returns the location and its ancestors from the bottom up. It
+// returns false if the location or one of its ancestors is not found.
+func (locations warehouseLocations) lineage(id string) ([]models.WarehouseLocation, bool) {
+	var res []models.WarehouseLocation
+	// the length limit stops the cycles made by manual modifications.
+	for id != "" && len(res) <= len(locations) {
+		location, ok := locations[id]
+		if !ok {
+			return nil, false
+		}
+		res = append(res, location)
+		id = location.ParentID
+	}
+	return res, true
+}
+
+// contains returns true if the location is the specified ancestor or one of
+// its descendants.
+func (locations warehouseLocations) contains(ancestor, location string) bool {
+	lineage, _ := locations.lineage(location)
+	for _, l := range lineage {
+		if l.ID == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// occupancies returns the total quantities of the stocks in each location and
+// its descendants. The stocks in the unknown locations are ignored.
+func (locations warehouseLocations) occupancies(stocks map[string]decimal.Decimal) map[string]decimal.Decimal {
+	res := make(map[string]decimal.Decimal, len(locations))
+	for id, quantity := range stocks {
+		lineage, ok := locations.lineage(id)
+		if !ok {
+			continue
+		}
+		for _, location := range lineage {
+			res[location.ID] = res[location.ID].Add(quantity)
+		}
+	}
+	return res
+}
+
+// checkWarehouseLocation checks whether the resources can be stocked in the
+// specified warehouse location. The resources already in the location or its
+// descendants are not counted twice for the capacity.
+//
+// It is skipped if the warehouse has no location, so that the warehouses with
+// the legacy free-form locations keep working until their locations are
+// created.
+func (tx *txDataManager) checkWarehouseLocation(warehouse mcom.Warehouse, resources []models.MaterialResource) error {
+	locations, err := listWarehouseLocations(tx.db, warehouse.ID)
+	if err != nil {
+		return err
+	}
+	if len(locations) == 0 {
+		return nil
+	}
+	lineage, ok := locations.lineage(warehouse.Location)
+	if !ok {
+		return mcomErr.Error{
+			Code:    mcomErr.Code_WAREHOUSE_LOCATION_NOT_FOUND,
+			Details: fmt.Sprintf("warehouse: %s, location: %s", warehouse.ID, warehouse.Location),
+		}
+	}
+
+	ids := make([]string, len(lineage))
+	for i, location := range lineage {
+		ids[i] = location.ID
+	}
+	// serializes the stocking in the same locations.
+	if err := tx.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(`warehouse_id = ? AND id IN ?`, warehouse.ID, ids).
+		Find(&[]models.WarehouseLocation{}).Error; err != nil {
+		return err
+	}
+	stocks, err := listWarehouseLocationStocks(tx.db, warehouse.ID)
+	if err != nil {
+		return err
+	}
+	occupancies := locations.occupancies(stocks)
+
+	for _, location := range lineage {
+		quantity := occupancies[location.ID]
+		for _, resource := range resources {
+			if !location.IsProductTypeAllowed(resource.ProductType) {
+				return mcomErr.Error{
+					Code: mcomErr.Code_WAREHOUSE_LOCATION_PRODUCT_TYPE_NOT_ALLOWED,
+					Details: fmt.Sprintf("warehouse: %s, location: %s, product type: %s",
+						warehouse.ID, location.ID, resource.ProductType),
+				}
+			}
+			if !resource.Quantity.IsPositive() ||
+				(resource.WarehouseID == warehouse.ID && locations.contains(location.ID, resource.WarehouseLocation)) {
+				continue
+			}
+			quantity = quantity.Add(resource.Quantity)
+		}
+		if location.IsCapacityExceeded(quantity) {
+			return mcomErr.Error{
+				Code: mcomErr.Code_WAREHOUSE_LOCATION_CAPACITY_EXCEEDED,
+				Details: fmt.Sprintf("warehouse: %s, location: %s, capacity: %s, quantity: %s",
+					warehouse.ID, location.ID, location.Capacity, quantity),
+			}
+		}
+	}
+	return nil
+}
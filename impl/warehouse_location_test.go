@@ -0,0 +1,177 @@
+package impl
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	commonsCtx "gitlab.kenda.com.tw/kenda/commons/v2/utils/context"
+
+	"gitlab.kenda.com.tw/kenda/mcom"
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+)
+
+func TestDataManager_WarehouseLocations(t *testing.T) {
+	assert := assert.New(t)
+	ctx, dm, db := initializeDB(t)
+	defer dm.Close()
+	ctx = commonsCtx.WithUserID(ctx, testUser)
+	cm := newClearMaster(db,
+		&models.Warehouse{},
+		&models.WarehouseLocation{},
+		&models.WarehouseStock{},
+		&models.MaterialResource{},
+		&models.ResourceTransportRecord{},
+	)
+	assert.NoError(cm.Clear())
+	defer func() { assert.NoError(cm.Clear()) }()
+
+	assert.NoError(db.Create(&models.Warehouse{ID: "W", DepartmentID: testDepartmentA}).Error)
+
+	// Z1 > Z1-R1 > Z1-R1-B1
+	zone := mcom.WarehouseLocation{
+		WarehouseID: "W",
+		ID:          "Z1",
+		Type:        models.WarehouseLocationZone,
+		Capacity:    decimal.NewFromInt(100),
+	}
+	rack := mcom.WarehouseLocation{
+		WarehouseID:  "W",
+		ID:           "Z1-R1",
+		ParentID:     "Z1",
+		Type:         models.WarehouseLocationRack,
+		ProductTypes: []string{"RUBBER"},
+	}
+	bin := mcom.WarehouseLocation{
+		WarehouseID: "W",
+		ID:          "Z1-R1-B1",
+		ParentID:    "Z1-R1",
+		Type:        models.WarehouseLocationBin,
+		Capacity:    decimal.NewFromInt(60),
+	}
+	{ // warehouse not found.
+		assert.ErrorIs(dm.CreateWarehouseLocations(ctx, mcom.CreateWarehouseLocationsRequest{
+			Locations: []mcom.WarehouseLocation{{WarehouseID: "N", ID: "Z1", Type: models.WarehouseLocationZone}},
+		}), mcomErr.Error{Code: mcomErr.Code_WAREHOUSE_NOT_FOUND, Details: "warehouse: N"})
+	}
+	{ // parent not found.
+		assert.ErrorIs(dm.CreateWarehouseLocations(ctx, mcom.CreateWarehouseLocationsRequest{
+			Locations: []mcom.WarehouseLocation{rack},
+		}), mcomErr.Error{Code: mcomErr.Code_WAREHOUSE_LOCATION_NOT_FOUND, Details: "warehouse: W, parent location: Z1"})
+	}
+	{ // good case.
+		assert.NoError(dm.CreateWarehouseLocations(ctx, mcom.CreateWarehouseLocationsRequest{
+			Locations: []mcom.WarehouseLocation{zone, rack, bin},
+		}))
+	}
+	{ // already exists.
+		assert.ErrorIs(dm.CreateWarehouseLocations(ctx, mcom.CreateWarehouseLocationsRequest{
+			Locations: []mcom.WarehouseLocation{zone},
+		}), mcomErr.Error{Code: mcomErr.Code_WAREHOUSE_LOCATION_ALREADY_EXISTS, Details: "warehouse: W, location: Z1"})
+	}
+
+	_, err := dm.CreateMaterialResources(ctx, mcom.CreateMaterialResourcesRequest{
+		Materials: []mcom.CreateMaterialResourcesRequestDetail{
+			{Type: "RUBBER", ID: "P1", Quantity: decimal.NewFromInt(50), ResourceID: "R1"},
+			{Type: "RUBBER", ID: "P1", Quantity: decimal.NewFromInt(20), ResourceID: "R2"},
+			{Type: "STEEL", ID: "P2", Quantity: decimal.NewFromInt(10), ResourceID: "R3"},
+		},
+	})
+	assert.NoError(err)
+	stockIn := func(location string, resourceIDs ...string) error {
+		return dm.WarehousingStock(ctx, mcom.WarehousingStockRequest{
+			Warehouse:   mcom.Warehouse{ID: "W", Location: location},
+			ResourceIDs: resourceIDs,
+		})
+	}
+
+	{ // good case.
+		assert.NoError(stockIn("Z1-R1-B1", "R1"))
+		// stock in again without counting twice.
+		assert.NoError(stockIn("Z1-R1-B1", "R1"))
+
+		rep, err := dm.ListWarehouseLocations(ctx, mcom.ListWarehouseLocationsRequest{WarehouseID: "W"})
+		assert.NoError(err)
+		assert.Equal(mcom.ListWarehouseLocationsReply{
+			Locations: []mcom.WarehouseLocationInformation{{
+				WarehouseLocation: mcom.WarehouseLocation{
+					WarehouseID:  "W",
+					ID:           "Z1",
+					Type:         models.WarehouseLocationZone,
+					Capacity:     decimal.RequireFromString("100.000000"),
+					ProductTypes: []string{},
+				},
+				Occupancy: decimal.RequireFromString("50.000000"),
+			}, {
+				WarehouseLocation: mcom.WarehouseLocation{
+					WarehouseID:  "W",
+					ID:           "Z1-R1",
+					ParentID:     "Z1",
+					Type:         models.WarehouseLocationRack,
+					Capacity:     decimal.RequireFromString("0.000000"),
+					ProductTypes: []string{"RUBBER"},
+				},
+				Occupancy: decimal.RequireFromString("50.000000"),
+			}, {
+				WarehouseLocation: mcom.WarehouseLocation{
+					WarehouseID:  "W",
+					ID:           "Z1-R1-B1",
+					ParentID:     "Z1-R1",
+					Type:         models.WarehouseLocationBin,
+					Capacity:     decimal.RequireFromString("60.000000"),
+					ProductTypes: []string{},
+				},
+				Occupancy: decimal.RequireFromString("50.000000"),
+			}},
+		}, rep)
+	}
+	{ // capacity exceeded.
+		assert.ErrorIs(stockIn("Z1-R1-B1", "R2"), mcomErr.Error{
+			Code:    mcomErr.Code_WAREHOUSE_LOCATION_CAPACITY_EXCEEDED,
+			Details: "warehouse: W, location: Z1-R1-B1, capacity: 60, quantity: 70",
+		})
+	}
+	{ // product type not allowed.
+		assert.ErrorIs(stockIn("Z1-R1-B1", "R3"), mcomErr.Error{
+			Code:    mcomErr.Code_WAREHOUSE_LOCATION_PRODUCT_TYPE_NOT_ALLOWED,
+			Details: "warehouse: W, location: Z1-R1, product type: STEEL",
+		})
+	}
+	{ // location not found.
+		assert.ErrorIs(stockIn("00", "R2"), mcomErr.Error{
+			Code:    mcomErr.Code_WAREHOUSE_LOCATION_NOT_FOUND,
+			Details: "warehouse: W, location: 00",
+		})
+	}
+	{ // update the capacity.
+		assert.ErrorIs(dm.UpdateWarehouseLocation(ctx, mcom.UpdateWarehouseLocationRequest{
+			WarehouseID: "W",
+			ID:          "Z9",
+		}), mcomErr.Error{Code: mcomErr.Code_WAREHOUSE_LOCATION_NOT_FOUND, Details: "warehouse: W, location: Z9"})
+
+		assert.NoError(dm.UpdateWarehouseLocation(ctx, mcom.UpdateWarehouseLocationRequest{
+			WarehouseID: "W",
+			ID:          "Z1-R1-B1",
+		}))
+		assert.NoError(stockIn("Z1-R1-B1", "R2"))
+	}
+	{ // the stock in of the new resources is checked as well.
+		_, err := dm.CreateMaterialResources(ctx, mcom.CreateMaterialResourcesRequest{
+			Materials: []mcom.CreateMaterialResourcesRequestDetail{
+				{Type: "RUBBER", ID: "P1", Quantity: decimal.NewFromInt(31), ResourceID: "R4"},
+			},
+		}, mcom.WithStockIn(mcom.Warehouse{ID: "W", Location: "Z1-R1-B1"}))
+		assert.ErrorIs(err, mcomErr.Error{
+			Code:    mcomErr.Code_WAREHOUSE_LOCATION_CAPACITY_EXCEEDED,
+			Details: "warehouse: W, location: Z1, capacity: 100, quantity: 101",
+		})
+	}
+	{ // free-form locations of the warehouse without locations.
+		assert.NoError(dm.WarehousingStock(ctx, mcom.WarehousingStockRequest{
+			Warehouse:   mcom.Warehouse{ID: "X", Location: "00"},
+			ResourceIDs: []string{"R3"},
+		}))
+	}
+}
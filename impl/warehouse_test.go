@@ -1048,6 +1048,42 @@ func TestDataManager_WarehousingStock(t *testing.T) {
 			ResourceID:     "Resource1",
 		}}
 		assert.ElementsMatch(expected, actual)
+
+		assert.NoError(deleteAllMaterialResources(db))
+		assert.NoError(deleteAllWarehouseStocks(db))
+	}
+	{ // the stock of the new warehouse is keyed by the product id.
+		_, err := dm.CreateMaterialResources(ctx,
+			mcom.CreateMaterialResourcesRequest{
+				Materials: []mcom.CreateMaterialResourcesRequestDetail{{
+					Type:       "T1",
+					ID:         "Product1",
+					Quantity:   decimal.NewFromInt(10),
+					ResourceID: "Resource1",
+				}},
+			},
+			mcom.WithStockIn(mcom.Warehouse{
+				ID:       testWarehouseID,
+				Location: testWarehouseLocation,
+			}))
+		assert.NoError(err)
+
+		assert.NoError(dm.WarehousingStock(ctx, mcom.WarehousingStockRequest{
+			Warehouse: mcom.Warehouse{
+				ID:       testWarehouseIDNew,
+				Location: testWarehouseLocationNew,
+			},
+			ResourceIDs: []string{"Resource1"},
+		}))
+
+		var stocks []models.WarehouseStock
+		assert.NoError(db.Find(&stocks).Error)
+		assert.Equal([]models.WarehouseStock{{
+			ID:        testWarehouseIDNew,
+			Location:  testWarehouseLocationNew,
+			ProductID: "Product1",
+			Quantity:  decimal.RequireFromString("10.000000"),
+		}}, stocks)
 	}
 
 	assert.NoError(deleteAllWarehouseData(db))
@@ -46,6 +46,18 @@ func registerStructures() error {
 					ColumnName: "created_at",
 				}},
 		},
+		{
+			model: models.ResourceTransportRecord{},
+			fields: []OrderableField{
+				{
+					FieldName:  "ID",
+					ColumnName: "id",
+				},
+				{
+					FieldName:  "CreatedAt",
+					ColumnName: "created_at",
+				}},
+		},
 		{
 			model: models.Recipe{},
 			fields: []OrderableField{
@@ -9,108 +9,166 @@ import (
 
 //  function name list
 const (
-	FuncAddSubstitutions               FuncName = "AddSubstitutions"
-	FuncBindRecordsCheck               FuncName = "BindRecordsCheck"
-	FuncClose                          FuncName = "Close"
-	FuncCreateAccounts                 FuncName = "CreateAccounts"
-	FuncCreateBatch                    FuncName = "CreateBatch"
-	FuncCreateBlobResourceRecord       FuncName = "CreateBlobResourceRecord"
-	FuncCreateCarrier                  FuncName = "CreateCarrier"
-	FuncCreateCollectRecord            FuncName = "CreateCollectRecord"
-	FuncCreateDepartments              FuncName = "CreateDepartments"
-	FuncCreateLimitaryHour             FuncName = "CreateLimitaryHour"
-	FuncCreateMaterialResources        FuncName = "CreateMaterialResources"
-	FuncCreatePackRecords              FuncName = "CreatePackRecords"
-	FuncCreateProductPlan              FuncName = "CreateProductPlan"
-	FuncCreateRecipes                  FuncName = "CreateRecipes"
-	FuncCreateStation                  FuncName = "CreateStation"
-	FuncCreateStationGroup             FuncName = "CreateStationGroup"
-	FuncCreateUsers                    FuncName = "CreateUsers"
-	FuncCreateWorkOrders               FuncName = "CreateWorkOrders"
-	FuncDeleteAccount                  FuncName = "DeleteAccount"
-	FuncDeleteCarrier                  FuncName = "DeleteCarrier"
-	FuncDeleteDepartment               FuncName = "DeleteDepartment"
-	FuncDeleteRecipe                   FuncName = "DeleteRecipe"
-	FuncDeleteStation                  FuncName = "DeleteStation"
-	FuncDeleteStationGroup             FuncName = "DeleteStationGroup"
-	FuncDeleteSubstitutions            FuncName = "DeleteSubstitutions"
-	FuncDeleteUser                     FuncName = "DeleteUser"
-	FuncFeed                           FuncName = "Feed"
-	FuncGetBatch                       FuncName = "GetBatch"
-	FuncGetCarrier                     FuncName = "GetCarrier"
-	FuncGetCollectRecord               FuncName = "GetCollectRecord"
-	FuncGetLimitaryHour                FuncName = "GetLimitaryHour"
-	FuncGetMaterial                    FuncName = "GetMaterial"
-	FuncGetMaterialExtendDate          FuncName = "GetMaterialExtendDate"
-	FuncGetMaterialResource            FuncName = "GetMaterialResource"
-	FuncGetMaterialResourceIdentity    FuncName = "GetMaterialResourceIdentity"
-	FuncGetProcessDefinition           FuncName = "GetProcessDefinition"
-	FuncGetRecipe                      FuncName = "GetRecipe"
-	FuncGetResourceWarehouse           FuncName = "GetResourceWarehouse"
-	FuncGetSite                        FuncName = "GetSite"
-	FuncGetStation                     FuncName = "GetStation"
-	FuncGetStationConfiguration        FuncName = "GetStationConfiguration"
-	FuncGetTokenInfo                   FuncName = "GetTokenInfo"
-	FuncGetToolResource                FuncName = "GetToolResource"
-	FuncGetWorkOrder                   FuncName = "GetWorkOrder"
-	FuncIsProductExisted               FuncName = "IsProductExisted"
-	FuncListAllDepartment              FuncName = "ListAllDepartment"
-	FuncListAssociatedStations         FuncName = "ListAssociatedStations"
-	FuncListBatches                    FuncName = "ListBatches"
-	FuncListBlobURIs                   FuncName = "ListBlobURIs"
-	FuncListCarriers                   FuncName = "ListCarriers"
-	FuncListChangeableStatus           FuncName = "ListChangeableStatus"
-	FuncListCollectRecords             FuncName = "ListCollectRecords"
-	FuncListControlAreas               FuncName = "ListControlAreas"
-	FuncListControlReasons             FuncName = "ListControlReasons"
-	FuncListFeedRecords                FuncName = "ListFeedRecords"
-	FuncListMaterialResourceIdentities FuncName = "ListMaterialResourceIdentities"
-	FuncListMaterialResourceStatus     FuncName = "ListMaterialResourceStatus"
-	FuncListMaterialResources          FuncName = "ListMaterialResources"
-	FuncListMaterialResourcesById      FuncName = "ListMaterialResourcesById"
-	FuncListMultipleSubstitutions      FuncName = "ListMultipleSubstitutions"
-	FuncListPackRecords                FuncName = "ListPackRecords"
-	FuncListProductGroups              FuncName = "ListProductGroups"
-	FuncListProductIDs                 FuncName = "ListProductIDs"
-	FuncListProductPlans               FuncName = "ListProductPlans"
-	FuncListProductTypes               FuncName = "ListProductTypes"
-	FuncListRecipesByProduct           FuncName = "ListRecipesByProduct"
-	FuncListRoles                      FuncName = "ListRoles"
-	FuncListSiteMaterials              FuncName = "ListSiteMaterials"
-	FuncListSiteSubType                FuncName = "ListSiteSubType"
-	FuncListSiteType                   FuncName = "ListSiteType"
-	FuncListStationIDs                 FuncName = "ListStationIDs"
-	FuncListStationState               FuncName = "ListStationState"
-	FuncListStations                   FuncName = "ListStations"
-	FuncListSubstitutions              FuncName = "ListSubstitutions"
-	FuncListToolResources              FuncName = "ListToolResources"
-	FuncListUnauthorizedUsers          FuncName = "ListUnauthorizedUsers"
-	FuncListUserRoles                  FuncName = "ListUserRoles"
-	FuncListWorkOrders                 FuncName = "ListWorkOrders"
-	FuncListWorkOrdersByDuration       FuncName = "ListWorkOrdersByDuration"
-	FuncListWorkOrdersByIDs            FuncName = "ListWorkOrdersByIDs"
-	FuncMaterialResourceBind           FuncName = "MaterialResourceBind"
-	FuncMaterialResourceBindV2         FuncName = "MaterialResourceBindV2"
-	FuncSetStationConfiguration        FuncName = "SetStationConfiguration"
-	FuncSignIn                         FuncName = "SignIn"
-	FuncSignInStation                  FuncName = "SignInStation"
-	FuncSignOut                        FuncName = "SignOut"
-	FuncSignOutStation                 FuncName = "SignOutStation"
-	FuncSignOutStations                FuncName = "SignOutStations"
-	FuncSplitMaterialResource          FuncName = "SplitMaterialResource"
-	FuncToolResourceBind               FuncName = "ToolResourceBind"
-	FuncToolResourceBindV2             FuncName = "ToolResourceBindV2"
-	FuncUpdateAccount                  FuncName = "UpdateAccount"
-	FuncUpdateBatch                    FuncName = "UpdateBatch"
-	FuncUpdateCarrier                  FuncName = "UpdateCarrier"
-	FuncUpdateDepartment               FuncName = "UpdateDepartment"
-	FuncUpdateMaterial                 FuncName = "UpdateMaterial"
-	FuncUpdateStation                  FuncName = "UpdateStation"
-	FuncUpdateStationGroup             FuncName = "UpdateStationGroup"
-	FuncUpdateSubstitutions            FuncName = "UpdateSubstitutions"
-	FuncUpdateUser                     FuncName = "UpdateUser"
-	FuncUpdateWorkOrders               FuncName = "UpdateWorkOrders"
-	FuncWarehousingStock               FuncName = "WarehousingStock"
+	FuncAddSubstitutions                  FuncName = "AddSubstitutions"
+	FuncBindRecordsCheck                  FuncName = "BindRecordsCheck"
+	FuncCancelStockCount                  FuncName = "CancelStockCount"
+	FuncChangeStationState                FuncName = "ChangeStationState"
+	FuncCheckConsistency                  FuncName = "CheckConsistency"
+	FuncCheckProductionReadiness          FuncName = "CheckProductionReadiness"
+	FuncCloneStation                      FuncName = "CloneStation"
+	FuncClose                             FuncName = "Close"
+	FuncCreateAccounts                    FuncName = "CreateAccounts"
+	FuncCreateBatch                       FuncName = "CreateBatch"
+	FuncCreateBlobResourceRecord          FuncName = "CreateBlobResourceRecord"
+	FuncCreateCarrier                     FuncName = "CreateCarrier"
+	FuncCreateCollectRecord               FuncName = "CreateCollectRecord"
+	FuncCreateDepartments                 FuncName = "CreateDepartments"
+	FuncCreateLimitaryHour                FuncName = "CreateLimitaryHour"
+	FuncCreateMaterialResources           FuncName = "CreateMaterialResources"
+	FuncCreatePackRecords                 FuncName = "CreatePackRecords"
+	FuncCreateProductPlan                 FuncName = "CreateProductPlan"
+	FuncCreateRecipes                     FuncName = "CreateRecipes"
+	FuncCreateStation                     FuncName = "CreateStation"
+	FuncCreateStationFromTemplate         FuncName = "CreateStationFromTemplate"
+	FuncCreateStationGroup                FuncName = "CreateStationGroup"
+	FuncCreateStationTemplate             FuncName = "CreateStationTemplate"
+	FuncCreateStockCount                  FuncName = "CreateStockCount"
+	FuncCreateToolResources               FuncName = "CreateToolResources"
+	FuncCreateToolTypes                   FuncName = "CreateToolTypes"
+	FuncCreateUsers                       FuncName = "CreateUsers"
+	FuncCreateWarehouseLocations          FuncName = "CreateWarehouseLocations"
+	FuncCreateWorkOrders                  FuncName = "CreateWorkOrders"
+	FuncDeleteAccount                     FuncName = "DeleteAccount"
+	FuncDeleteCarrier                     FuncName = "DeleteCarrier"
+	FuncDeleteDepartment                  FuncName = "DeleteDepartment"
+	FuncDeleteRecipe                      FuncName = "DeleteRecipe"
+	FuncDeleteStation                     FuncName = "DeleteStation"
+	FuncDeleteStationGroup                FuncName = "DeleteStationGroup"
+	FuncDeleteStationTemplate             FuncName = "DeleteStationTemplate"
+	FuncDeleteSubstitutions               FuncName = "DeleteSubstitutions"
+	FuncDeleteUser                        FuncName = "DeleteUser"
+	FuncDeleteUserQualification           FuncName = "DeleteUserQualification"
+	FuncDiffStationConfigurationVersions  FuncName = "DiffStationConfigurationVersions"
+	FuncFeed                              FuncName = "Feed"
+	FuncGetBatch                          FuncName = "GetBatch"
+	FuncGetCarrier                        FuncName = "GetCarrier"
+	FuncGetCollectRecord                  FuncName = "GetCollectRecord"
+	FuncGetLimitaryHour                   FuncName = "GetLimitaryHour"
+	FuncGetMaterial                       FuncName = "GetMaterial"
+	FuncGetMaterialExtendDate             FuncName = "GetMaterialExtendDate"
+	FuncGetMaterialResource               FuncName = "GetMaterialResource"
+	FuncGetMaterialResourceIdentity       FuncName = "GetMaterialResourceIdentity"
+	FuncGetProcessDefinition              FuncName = "GetProcessDefinition"
+	FuncGetRecipe                         FuncName = "GetRecipe"
+	FuncGetResourceLocationAt             FuncName = "GetResourceLocationAt"
+	FuncGetResourceWarehouse              FuncName = "GetResourceWarehouse"
+	FuncGetShiftCalendar                  FuncName = "GetShiftCalendar"
+	FuncGetSite                           FuncName = "GetSite"
+	FuncGetStation                        FuncName = "GetStation"
+	FuncGetStationConfiguration           FuncName = "GetStationConfiguration"
+	FuncGetStationOEE                     FuncName = "GetStationOEE"
+	FuncGetStationTemplate                FuncName = "GetStationTemplate"
+	FuncGetTokenInfo                      FuncName = "GetTokenInfo"
+	FuncGetToolLife                       FuncName = "GetToolLife"
+	FuncGetToolResource                   FuncName = "GetToolResource"
+	FuncGetWorkOrder                      FuncName = "GetWorkOrder"
+	FuncHoldLotCascade                    FuncName = "HoldLotCascade"
+	FuncIsProductExisted                  FuncName = "IsProductExisted"
+	FuncListAllDepartment                 FuncName = "ListAllDepartment"
+	FuncListAssociatedStations            FuncName = "ListAssociatedStations"
+	FuncListBatches                       FuncName = "ListBatches"
+	FuncListBlobURIs                      FuncName = "ListBlobURIs"
+	FuncListCarriers                      FuncName = "ListCarriers"
+	FuncListChangeableStatus              FuncName = "ListChangeableStatus"
+	FuncListCollectRecords                FuncName = "ListCollectRecords"
+	FuncListControlAreas                  FuncName = "ListControlAreas"
+	FuncListControlReasons                FuncName = "ListControlReasons"
+	FuncListFeedRecords                   FuncName = "ListFeedRecords"
+	FuncListGroupStations                 FuncName = "ListGroupStations"
+	FuncListLaborHours                    FuncName = "ListLaborHours"
+	FuncListMaterialResourceIdentities    FuncName = "ListMaterialResourceIdentities"
+	FuncListMaterialResourceStatus        FuncName = "ListMaterialResourceStatus"
+	FuncListMaterialResources             FuncName = "ListMaterialResources"
+	FuncListMaterialResourcesById         FuncName = "ListMaterialResourcesById"
+	FuncListMultipleSubstitutions         FuncName = "ListMultipleSubstitutions"
+	FuncListOperatorSessions              FuncName = "ListOperatorSessions"
+	FuncListPackRecords                   FuncName = "ListPackRecords"
+	FuncListProcessStations               FuncName = "ListProcessStations"
+	FuncListProductGroups                 FuncName = "ListProductGroups"
+	FuncListProductIDs                    FuncName = "ListProductIDs"
+	FuncListProductPlans                  FuncName = "ListProductPlans"
+	FuncListProductTypes                  FuncName = "ListProductTypes"
+	FuncListQualifiedUsers                FuncName = "ListQualifiedUsers"
+	FuncListRecipesByProduct              FuncName = "ListRecipesByProduct"
+	FuncListResourceTransportRecords      FuncName = "ListResourceTransportRecords"
+	FuncListRoles                         FuncName = "ListRoles"
+	FuncListSiteBindHistory               FuncName = "ListSiteBindHistory"
+	FuncListSiteMaterials                 FuncName = "ListSiteMaterials"
+	FuncListSiteSubType                   FuncName = "ListSiteSubType"
+	FuncListSiteType                      FuncName = "ListSiteType"
+	FuncListStationAncestors              FuncName = "ListStationAncestors"
+	FuncListStationConfigurationVersions  FuncName = "ListStationConfigurationVersions"
+	FuncListStationIDs                    FuncName = "ListStationIDs"
+	FuncListStationRequiredQualifications FuncName = "ListStationRequiredQualifications"
+	FuncListStationState                  FuncName = "ListStationState"
+	FuncListStationStateHistory           FuncName = "ListStationStateHistory"
+	FuncListStations                      FuncName = "ListStations"
+	FuncListStockCountVariances           FuncName = "ListStockCountVariances"
+	FuncListSubstitutions                 FuncName = "ListSubstitutions"
+	FuncListToolBindHistory               FuncName = "ListToolBindHistory"
+	FuncListToolResourceHistory           FuncName = "ListToolResourceHistory"
+	FuncListToolResources                 FuncName = "ListToolResources"
+	FuncListToolTypes                     FuncName = "ListToolTypes"
+	FuncListToolsDueForMaintenance        FuncName = "ListToolsDueForMaintenance"
+	FuncListUnauthorizedUsers             FuncName = "ListUnauthorizedUsers"
+	FuncListUserQualifications            FuncName = "ListUserQualifications"
+	FuncListUserRoles                     FuncName = "ListUserRoles"
+	FuncListWarehouseLocations            FuncName = "ListWarehouseLocations"
+	FuncListWorkOrders                    FuncName = "ListWorkOrders"
+	FuncListWorkOrdersByDuration          FuncName = "ListWorkOrdersByDuration"
+	FuncListWorkOrdersByIDs               FuncName = "ListWorkOrdersByIDs"
+	FuncMaterialResourceBind              FuncName = "MaterialResourceBind"
+	FuncMaterialResourceBindV2            FuncName = "MaterialResourceBindV2"
+	FuncPostStockCount                    FuncName = "PostStockCount"
+	FuncRecordToolMaintenance             FuncName = "RecordToolMaintenance"
+	FuncReleaseHoldCase                   FuncName = "ReleaseHoldCase"
+	FuncResolveWorkDate                   FuncName = "ResolveWorkDate"
+	FuncRetireToolResource                FuncName = "RetireToolResource"
+	FuncRollbackStationConfiguration      FuncName = "RollbackStationConfiguration"
+	FuncSetQualificationRequirement       FuncName = "SetQualificationRequirement"
+	FuncSetShiftCalendar                  FuncName = "SetShiftCalendar"
+	FuncSetStationConfiguration           FuncName = "SetStationConfiguration"
+	FuncSetToolLifePolicy                 FuncName = "SetToolLifePolicy"
+	FuncSetUserQualification              FuncName = "SetUserQualification"
+	FuncSignIn                            FuncName = "SignIn"
+	FuncSignInStation                     FuncName = "SignInStation"
+	FuncSignOut                           FuncName = "SignOut"
+	FuncSignOutStaleOperators             FuncName = "SignOutStaleOperators"
+	FuncSignOutStation                    FuncName = "SignOutStation"
+	FuncSignOutStations                   FuncName = "SignOutStations"
+	FuncSplitMaterialResource             FuncName = "SplitMaterialResource"
+	FuncSubmitStockCount                  FuncName = "SubmitStockCount"
+	FuncToolResourceBind                  FuncName = "ToolResourceBind"
+	FuncToolResourceBindV2                FuncName = "ToolResourceBindV2"
+	FuncTraceBackward                     FuncName = "TraceBackward"
+	FuncTraceForward                      FuncName = "TraceForward"
+	FuncTransferSiteContents              FuncName = "TransferSiteContents"
+	FuncUpdateAccount                     FuncName = "UpdateAccount"
+	FuncUpdateBatch                       FuncName = "UpdateBatch"
+	FuncUpdateCarrier                     FuncName = "UpdateCarrier"
+	FuncUpdateDepartment                  FuncName = "UpdateDepartment"
+	FuncUpdateMaterial                    FuncName = "UpdateMaterial"
+	FuncUpdateSiteFeedPolicy              FuncName = "UpdateSiteFeedPolicy"
+	FuncUpdateSiteLimitation              FuncName = "UpdateSiteLimitation"
+	FuncUpdateStation                     FuncName = "UpdateStation"
+	FuncUpdateStationGroup                FuncName = "UpdateStationGroup"
+	FuncUpdateSubstitutions               FuncName = "UpdateSubstitutions"
+	FuncUpdateToolResource                FuncName = "UpdateToolResource"
+	FuncUpdateToolType                    FuncName = "UpdateToolType"
+	FuncUpdateUser                        FuncName = "UpdateUser"
+	FuncUpdateWarehouseLocation           FuncName = "UpdateWarehouseLocation"
+	FuncUpdateWorkOrders                  FuncName = "UpdateWorkOrders"
+	FuncWarehousingStock                  FuncName = "WarehousingStock"
 )
 
 func (dm *dataManager) AddSubstitutions(ctx context.Context, req mcom.BasicSubstitutionRequest) error {
@@ -129,6 +187,55 @@ func (dm *dataManager) BindRecordsCheck(ctx context.Context, req mcom.BindRecord
 	return nil
 }
 
+func (dm *dataManager) CancelStockCount(ctx context.Context, req mcom.CancelStockCountRequest) error {
+	_, err := dm.run(ctx, FuncCancelStockCount, req, noOptions, noReply)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (dm *dataManager) ChangeStationState(ctx context.Context, req mcom.ChangeStationStateRequest) error {
+	_, err := dm.run(ctx, FuncChangeStationState, req, noOptions, noReply)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (dm *dataManager) CheckConsistency(ctx context.Context, req mcom.CheckConsistencyRequest) (mcom.CheckConsistencyReply, error) {
+	reply, err := dm.run(ctx, FuncCheckConsistency, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.CheckConsistencyReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.CheckConsistencyReply{}, err
+	}
+	return reply.(mcom.CheckConsistencyReply), nil
+}
+
+func (dm *dataManager) CheckProductionReadiness(ctx context.Context, req mcom.CheckProductionReadinessRequest) (mcom.CheckProductionReadinessReply, error) {
+	reply, err := dm.run(ctx, FuncCheckProductionReadiness, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.CheckProductionReadinessReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.CheckProductionReadinessReply{}, err
+	}
+	return reply.(mcom.CheckProductionReadinessReply), nil
+}
+
+func (dm *dataManager) CloneStation(ctx context.Context, req mcom.CloneStationRequest) (mcom.CloneStationReply, error) {
+	reply, err := dm.run(ctx, FuncCloneStation, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.CloneStationReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.CloneStationReply{}, err
+	}
+	return reply.(mcom.CloneStationReply), nil
+}
+
 func (dm *dataManager) CreateAccounts(ctx context.Context, req mcom.CreateAccountsRequest) error {
 	_, err := dm.run(ctx, FuncCreateAccounts, req, noOptions, noReply)
 	if err != nil {
@@ -243,6 +350,17 @@ func (dm *dataManager) CreateStation(ctx context.Context, req mcom.CreateStation
 	return nil
 }
 
+func (dm *dataManager) CreateStationFromTemplate(ctx context.Context, req mcom.CreateStationFromTemplateRequest) (mcom.CreateStationFromTemplateReply, error) {
+	reply, err := dm.run(ctx, FuncCreateStationFromTemplate, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.CreateStationFromTemplateReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.CreateStationFromTemplateReply{}, err
+	}
+	return reply.(mcom.CreateStationFromTemplateReply), nil
+}
+
 func (dm *dataManager) CreateStationGroup(ctx context.Context, req mcom.StationGroupRequest) error {
 	_, err := dm.run(ctx, FuncCreateStationGroup, req, noOptions, noReply)
 	if err != nil {
@@ -251,6 +369,41 @@ func (dm *dataManager) CreateStationGroup(ctx context.Context, req mcom.StationG
 	return nil
 }
 
+func (dm *dataManager) CreateStationTemplate(ctx context.Context, req mcom.CreateStationTemplateRequest) error {
+	_, err := dm.run(ctx, FuncCreateStationTemplate, req, noOptions, noReply)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (dm *dataManager) CreateStockCount(ctx context.Context, req mcom.CreateStockCountRequest) (mcom.CreateStockCountReply, error) {
+	reply, err := dm.run(ctx, FuncCreateStockCount, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.CreateStockCountReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.CreateStockCountReply{}, err
+	}
+	return reply.(mcom.CreateStockCountReply), nil
+}
+
+func (dm *dataManager) CreateToolResources(ctx context.Context, req mcom.CreateToolResourcesRequest) error {
+	_, err := dm.run(ctx, FuncCreateToolResources, req, noOptions, noReply)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (dm *dataManager) CreateToolTypes(ctx context.Context, req mcom.CreateToolTypesRequest) error {
+	_, err := dm.run(ctx, FuncCreateToolTypes, req, noOptions, noReply)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (dm *dataManager) CreateUsers(ctx context.Context, req mcom.CreateUsersRequest) error {
 	_, err := dm.run(ctx, FuncCreateUsers, req, noOptions, noReply)
 	if err != nil {
@@ -259,6 +412,14 @@ func (dm *dataManager) CreateUsers(ctx context.Context, req mcom.CreateUsersRequ
 	return nil
 }
 
+func (dm *dataManager) CreateWarehouseLocations(ctx context.Context, req mcom.CreateWarehouseLocationsRequest) error {
+	_, err := dm.run(ctx, FuncCreateWarehouseLocations, req, noOptions, noReply)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (dm *dataManager) CreateWorkOrders(ctx context.Context, req mcom.CreateWorkOrdersRequest) (mcom.CreateWorkOrdersReply, error) {
 	reply, err := dm.run(ctx, FuncCreateWorkOrders, req, noOptions, func(i interface{}) bool {
 		_, ok := i.(mcom.CreateWorkOrdersReply)
@@ -318,6 +479,14 @@ func (dm *dataManager) DeleteStationGroup(ctx context.Context, req mcom.DeleteSt
 	return nil
 }
 
+func (dm *dataManager) DeleteStationTemplate(ctx context.Context, req mcom.DeleteStationTemplateRequest) error {
+	_, err := dm.run(ctx, FuncDeleteStationTemplate, req, noOptions, noReply)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (dm *dataManager) DeleteSubstitutions(ctx context.Context, req mcom.DeleteSubstitutionsRequest) error {
 	_, err := dm.run(ctx, FuncDeleteSubstitutions, req, noOptions, noReply)
 	if err != nil {
@@ -334,8 +503,42 @@ func (dm *dataManager) DeleteUser(ctx context.Context, req mcom.DeleteUserReques
 	return nil
 }
 
-func (dm *dataManager) Feed(ctx context.Context, req mcom.FeedRequest) (mcom.FeedReply, error) {
-	reply, err := dm.run(ctx, FuncFeed, req, noOptions, func(i interface{}) bool {
+func (dm *dataManager) DeleteUserQualification(ctx context.Context, req mcom.DeleteUserQualificationRequest) error {
+	_, err := dm.run(ctx, FuncDeleteUserQualification, req, noOptions, noReply)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (dm *dataManager) DiffStationConfigurationVersions(ctx context.Context, req mcom.DiffStationConfigurationVersionsRequest) (mcom.DiffStationConfigurationVersionsReply, error) {
+	reply, err := dm.run(ctx, FuncDiffStationConfigurationVersions, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.DiffStationConfigurationVersionsReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.DiffStationConfigurationVersionsReply{}, err
+	}
+	return reply.(mcom.DiffStationConfigurationVersionsReply), nil
+}
+
+func (dm *dataManager) Feed(ctx context.Context, req mcom.FeedRequest, opts ...mcom.FeedOption) (mcom.FeedReply, error) {
+	reply, err := dm.run(ctx, FuncFeed, req, func(expectedOpts []interface{}) (*parsedOptions, error) {
+		if len(opts) != len(expectedOpts) {
+			return nil, newMismatchInputOptionLengthError(len(expectedOpts), len(opts))
+		}
+		expectedOptions := make([]mcom.FeedOption, len(expectedOpts))
+		for i, inputOpt := range expectedOpts {
+			o, ok := inputOpt.(mcom.FeedOption)
+			if !ok {
+				return nil, badOptionType("mcom.FeedOption")
+			}
+			expectedOptions[i] = o
+		}
+		return &parsedOptions{
+			expected: mcom.ParseFeedOptions(expectedOptions), actual: mcom.ParseFeedOptions(opts),
+		}, nil
+	}, func(i interface{}) bool {
 		_, ok := i.(mcom.FeedReply)
 		return ok
 	})
@@ -455,6 +658,17 @@ func (dm *dataManager) GetRecipe(ctx context.Context, req mcom.GetRecipeRequest)
 	return reply.(mcom.GetRecipeReply), nil
 }
 
+func (dm *dataManager) GetResourceLocationAt(ctx context.Context, req mcom.GetResourceLocationAtRequest) (mcom.GetResourceLocationAtReply, error) {
+	reply, err := dm.run(ctx, FuncGetResourceLocationAt, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.GetResourceLocationAtReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.GetResourceLocationAtReply{}, err
+	}
+	return reply.(mcom.GetResourceLocationAtReply), nil
+}
+
 func (dm *dataManager) GetResourceWarehouse(ctx context.Context, req mcom.GetResourceWarehouseRequest) (mcom.GetResourceWarehouseReply, error) {
 	reply, err := dm.run(ctx, FuncGetResourceWarehouse, req, noOptions, func(i interface{}) bool {
 		_, ok := i.(mcom.GetResourceWarehouseReply)
@@ -466,6 +680,17 @@ func (dm *dataManager) GetResourceWarehouse(ctx context.Context, req mcom.GetRes
 	return reply.(mcom.GetResourceWarehouseReply), nil
 }
 
+func (dm *dataManager) GetShiftCalendar(ctx context.Context, req mcom.GetShiftCalendarRequest) (mcom.GetShiftCalendarReply, error) {
+	reply, err := dm.run(ctx, FuncGetShiftCalendar, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.GetShiftCalendarReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.GetShiftCalendarReply{}, err
+	}
+	return reply.(mcom.GetShiftCalendarReply), nil
+}
+
 func (dm *dataManager) GetSite(ctx context.Context, req mcom.GetSiteRequest) (mcom.GetSiteReply, error) {
 	reply, err := dm.run(ctx, FuncGetSite, req, noOptions, func(i interface{}) bool {
 		_, ok := i.(mcom.GetSiteReply)
@@ -499,6 +724,28 @@ func (dm *dataManager) GetStationConfiguration(ctx context.Context, req mcom.Get
 	return reply.(mcom.GetStationConfigurationReply), nil
 }
 
+func (dm *dataManager) GetStationOEE(ctx context.Context, req mcom.GetStationOEERequest) (mcom.GetStationOEEReply, error) {
+	reply, err := dm.run(ctx, FuncGetStationOEE, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.GetStationOEEReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.GetStationOEEReply{}, err
+	}
+	return reply.(mcom.GetStationOEEReply), nil
+}
+
+func (dm *dataManager) GetStationTemplate(ctx context.Context, req mcom.GetStationTemplateRequest) (mcom.GetStationTemplateReply, error) {
+	reply, err := dm.run(ctx, FuncGetStationTemplate, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.GetStationTemplateReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.GetStationTemplateReply{}, err
+	}
+	return reply.(mcom.GetStationTemplateReply), nil
+}
+
 func (dm *dataManager) GetTokenInfo(ctx context.Context, req mcom.GetTokenInfoRequest) (mcom.GetTokenInfoReply, error) {
 	reply, err := dm.run(ctx, FuncGetTokenInfo, req, noOptions, func(i interface{}) bool {
 		_, ok := i.(mcom.GetTokenInfoReply)
@@ -510,6 +757,17 @@ func (dm *dataManager) GetTokenInfo(ctx context.Context, req mcom.GetTokenInfoRe
 	return reply.(mcom.GetTokenInfoReply), nil
 }
 
+func (dm *dataManager) GetToolLife(ctx context.Context, req mcom.GetToolLifeRequest) (mcom.GetToolLifeReply, error) {
+	reply, err := dm.run(ctx, FuncGetToolLife, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.GetToolLifeReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.GetToolLifeReply{}, err
+	}
+	return reply.(mcom.GetToolLifeReply), nil
+}
+
 func (dm *dataManager) GetToolResource(ctx context.Context, req mcom.GetToolResourceRequest) (mcom.GetToolResourceReply, error) {
 	reply, err := dm.run(ctx, FuncGetToolResource, req, noOptions, func(i interface{}) bool {
 		_, ok := i.(mcom.GetToolResourceReply)
@@ -532,6 +790,17 @@ func (dm *dataManager) GetWorkOrder(ctx context.Context, req mcom.GetWorkOrderRe
 	return reply.(mcom.GetWorkOrderReply), nil
 }
 
+func (dm *dataManager) HoldLotCascade(ctx context.Context, req mcom.HoldLotCascadeRequest) (mcom.HoldLotCascadeReply, error) {
+	reply, err := dm.run(ctx, FuncHoldLotCascade, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.HoldLotCascadeReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.HoldLotCascadeReply{}, err
+	}
+	return reply.(mcom.HoldLotCascadeReply), nil
+}
+
 func (dm *dataManager) IsProductExisted(ctx context.Context, req string) (bool, error) {
 	reply, err := dm.run(ctx, FuncIsProductExisted, req, noOptions, func(i interface{}) bool {
 		_, ok := i.(bool)
@@ -653,6 +922,28 @@ func (dm *dataManager) ListFeedRecords(ctx context.Context, req mcom.ListRecords
 	return reply.(mcom.ListFeedRecordReply), nil
 }
 
+func (dm *dataManager) ListGroupStations(ctx context.Context, req mcom.ListGroupStationsRequest) (mcom.ListGroupStationsReply, error) {
+	reply, err := dm.run(ctx, FuncListGroupStations, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.ListGroupStationsReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.ListGroupStationsReply{}, err
+	}
+	return reply.(mcom.ListGroupStationsReply), nil
+}
+
+func (dm *dataManager) ListLaborHours(ctx context.Context, req mcom.ListLaborHoursRequest) (mcom.ListLaborHoursReply, error) {
+	reply, err := dm.run(ctx, FuncListLaborHours, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.ListLaborHoursReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.ListLaborHoursReply{}, err
+	}
+	return reply.(mcom.ListLaborHoursReply), nil
+}
+
 func (dm *dataManager) ListMaterialResourceIdentities(ctx context.Context, req mcom.ListMaterialResourceIdentitiesRequest) (mcom.ListMaterialResourceIdentitiesReply, error) {
 	reply, err := dm.run(ctx, FuncListMaterialResourceIdentities, req, noOptions, func(i interface{}) bool {
 		_, ok := i.(mcom.ListMaterialResourceIdentitiesReply)
@@ -708,6 +999,17 @@ func (dm *dataManager) ListMultipleSubstitutions(ctx context.Context, req mcom.L
 	return reply.(mcom.ListMultipleSubstitutionsReply), nil
 }
 
+func (dm *dataManager) ListOperatorSessions(ctx context.Context, req mcom.ListOperatorSessionsRequest) (mcom.ListOperatorSessionsReply, error) {
+	reply, err := dm.run(ctx, FuncListOperatorSessions, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.ListOperatorSessionsReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.ListOperatorSessionsReply{}, err
+	}
+	return reply.(mcom.ListOperatorSessionsReply), nil
+}
+
 func (dm *dataManager) ListPackRecords(ctx context.Context) (mcom.ListPackRecordsReply, error) {
 	reply, err := dm.run(ctx, FuncListPackRecords, nil, noOptions, func(i interface{}) bool {
 		_, ok := i.(mcom.ListPackRecordsReply)
@@ -719,6 +1021,17 @@ func (dm *dataManager) ListPackRecords(ctx context.Context) (mcom.ListPackRecord
 	return reply.(mcom.ListPackRecordsReply), nil
 }
 
+func (dm *dataManager) ListProcessStations(ctx context.Context, req mcom.ListProcessStationsRequest) (mcom.ListProcessStationsReply, error) {
+	reply, err := dm.run(ctx, FuncListProcessStations, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.ListProcessStationsReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.ListProcessStationsReply{}, err
+	}
+	return reply.(mcom.ListProcessStationsReply), nil
+}
+
 func (dm *dataManager) ListProductGroups(ctx context.Context, req mcom.ListProductGroupsRequest) (mcom.ListProductGroupsReply, error) {
 	reply, err := dm.run(ctx, FuncListProductGroups, req, noOptions, func(i interface{}) bool {
 		_, ok := i.(mcom.ListProductGroupsReply)
@@ -763,6 +1076,17 @@ func (dm *dataManager) ListProductTypes(ctx context.Context, req mcom.ListProduc
 	return reply.(mcom.ListProductTypesReply), nil
 }
 
+func (dm *dataManager) ListQualifiedUsers(ctx context.Context, req mcom.ListQualifiedUsersRequest) (mcom.ListQualifiedUsersReply, error) {
+	reply, err := dm.run(ctx, FuncListQualifiedUsers, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.ListQualifiedUsersReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.ListQualifiedUsersReply{}, err
+	}
+	return reply.(mcom.ListQualifiedUsersReply), nil
+}
+
 func (dm *dataManager) ListRecipesByProduct(ctx context.Context, req mcom.ListRecipesByProductRequest) (mcom.ListRecipesByProductReply, error) {
 	reply, err := dm.run(ctx, FuncListRecipesByProduct, req, noOptions, func(i interface{}) bool {
 		_, ok := i.(mcom.ListRecipesByProductReply)
@@ -774,6 +1098,17 @@ func (dm *dataManager) ListRecipesByProduct(ctx context.Context, req mcom.ListRe
 	return reply.(mcom.ListRecipesByProductReply), nil
 }
 
+func (dm *dataManager) ListResourceTransportRecords(ctx context.Context, req mcom.ListResourceTransportRecordsRequest) (mcom.ListResourceTransportRecordsReply, error) {
+	reply, err := dm.run(ctx, FuncListResourceTransportRecords, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.ListResourceTransportRecordsReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.ListResourceTransportRecordsReply{}, err
+	}
+	return reply.(mcom.ListResourceTransportRecordsReply), nil
+}
+
 func (dm *dataManager) ListRoles(ctx context.Context) (mcom.ListRolesReply, error) {
 	reply, err := dm.run(ctx, FuncListRoles, nil, noOptions, func(i interface{}) bool {
 		_, ok := i.(mcom.ListRolesReply)
@@ -785,6 +1120,17 @@ func (dm *dataManager) ListRoles(ctx context.Context) (mcom.ListRolesReply, erro
 	return reply.(mcom.ListRolesReply), nil
 }
 
+func (dm *dataManager) ListSiteBindHistory(ctx context.Context, req mcom.ListSiteBindHistoryRequest) (mcom.ListSiteBindHistoryReply, error) {
+	reply, err := dm.run(ctx, FuncListSiteBindHistory, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.ListSiteBindHistoryReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.ListSiteBindHistoryReply{}, err
+	}
+	return reply.(mcom.ListSiteBindHistoryReply), nil
+}
+
 func (dm *dataManager) ListSiteMaterials(ctx context.Context, req mcom.ListSiteMaterialsRequest) (mcom.ListSiteMaterialsReply, error) {
 	reply, err := dm.run(ctx, FuncListSiteMaterials, req, noOptions, func(i interface{}) bool {
 		_, ok := i.(mcom.ListSiteMaterialsReply)
@@ -818,6 +1164,28 @@ func (dm *dataManager) ListSiteType(ctx context.Context) (mcom.ListSiteTypeReply
 	return reply.(mcom.ListSiteTypeReply), nil
 }
 
+func (dm *dataManager) ListStationAncestors(ctx context.Context, req mcom.ListStationAncestorsRequest) (mcom.ListStationAncestorsReply, error) {
+	reply, err := dm.run(ctx, FuncListStationAncestors, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.ListStationAncestorsReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.ListStationAncestorsReply{}, err
+	}
+	return reply.(mcom.ListStationAncestorsReply), nil
+}
+
+func (dm *dataManager) ListStationConfigurationVersions(ctx context.Context, req mcom.ListStationConfigurationVersionsRequest) (mcom.ListStationConfigurationVersionsReply, error) {
+	reply, err := dm.run(ctx, FuncListStationConfigurationVersions, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.ListStationConfigurationVersionsReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.ListStationConfigurationVersionsReply{}, err
+	}
+	return reply.(mcom.ListStationConfigurationVersionsReply), nil
+}
+
 func (dm *dataManager) ListStationIDs(ctx context.Context, req mcom.ListStationIDsRequest) (mcom.ListStationIDsReply, error) {
 	reply, err := dm.run(ctx, FuncListStationIDs, req, noOptions, func(i interface{}) bool {
 		_, ok := i.(mcom.ListStationIDsReply)
@@ -829,6 +1197,17 @@ func (dm *dataManager) ListStationIDs(ctx context.Context, req mcom.ListStationI
 	return reply.(mcom.ListStationIDsReply), nil
 }
 
+func (dm *dataManager) ListStationRequiredQualifications(ctx context.Context, req mcom.ListStationRequiredQualificationsRequest) (mcom.ListStationRequiredQualificationsReply, error) {
+	reply, err := dm.run(ctx, FuncListStationRequiredQualifications, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.ListStationRequiredQualificationsReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.ListStationRequiredQualificationsReply{}, err
+	}
+	return reply.(mcom.ListStationRequiredQualificationsReply), nil
+}
+
 func (dm *dataManager) ListStationState(ctx context.Context) (mcom.ListStationStateReply, error) {
 	reply, err := dm.run(ctx, FuncListStationState, nil, noOptions, func(i interface{}) bool {
 		_, ok := i.(mcom.ListStationStateReply)
@@ -840,6 +1219,17 @@ func (dm *dataManager) ListStationState(ctx context.Context) (mcom.ListStationSt
 	return reply.(mcom.ListStationStateReply), nil
 }
 
+func (dm *dataManager) ListStationStateHistory(ctx context.Context, req mcom.ListStationStateHistoryRequest) (mcom.ListStationStateHistoryReply, error) {
+	reply, err := dm.run(ctx, FuncListStationStateHistory, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.ListStationStateHistoryReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.ListStationStateHistoryReply{}, err
+	}
+	return reply.(mcom.ListStationStateHistoryReply), nil
+}
+
 func (dm *dataManager) ListStations(ctx context.Context, req mcom.ListStationsRequest) (mcom.ListStationsReply, error) {
 	reply, err := dm.run(ctx, FuncListStations, req, noOptions, func(i interface{}) bool {
 		_, ok := i.(mcom.ListStationsReply)
@@ -851,6 +1241,17 @@ func (dm *dataManager) ListStations(ctx context.Context, req mcom.ListStationsRe
 	return reply.(mcom.ListStationsReply), nil
 }
 
+func (dm *dataManager) ListStockCountVariances(ctx context.Context, req mcom.ListStockCountVariancesRequest) (mcom.ListStockCountVariancesReply, error) {
+	reply, err := dm.run(ctx, FuncListStockCountVariances, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.ListStockCountVariancesReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.ListStockCountVariancesReply{}, err
+	}
+	return reply.(mcom.ListStockCountVariancesReply), nil
+}
+
 func (dm *dataManager) ListSubstitutions(ctx context.Context, req mcom.ListSubstitutionsRequest) (mcom.ListSubstitutionsReply, error) {
 	reply, err := dm.run(ctx, FuncListSubstitutions, req, noOptions, func(i interface{}) bool {
 		_, ok := i.(mcom.ListSubstitutionsReply)
@@ -862,6 +1263,28 @@ func (dm *dataManager) ListSubstitutions(ctx context.Context, req mcom.ListSubst
 	return reply.(mcom.ListSubstitutionsReply), nil
 }
 
+func (dm *dataManager) ListToolBindHistory(ctx context.Context, req mcom.ListToolBindHistoryRequest) (mcom.ListToolBindHistoryReply, error) {
+	reply, err := dm.run(ctx, FuncListToolBindHistory, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.ListToolBindHistoryReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.ListToolBindHistoryReply{}, err
+	}
+	return reply.(mcom.ListToolBindHistoryReply), nil
+}
+
+func (dm *dataManager) ListToolResourceHistory(ctx context.Context, req mcom.ListToolResourceHistoryRequest) (mcom.ListToolResourceHistoryReply, error) {
+	reply, err := dm.run(ctx, FuncListToolResourceHistory, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.ListToolResourceHistoryReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.ListToolResourceHistoryReply{}, err
+	}
+	return reply.(mcom.ListToolResourceHistoryReply), nil
+}
+
 func (dm *dataManager) ListToolResources(ctx context.Context, req mcom.ListToolResourcesRequest) (mcom.ListToolResourcesReply, error) {
 	reply, err := dm.run(ctx, FuncListToolResources, req, noOptions, func(i interface{}) bool {
 		_, ok := i.(mcom.ListToolResourcesReply)
@@ -873,6 +1296,28 @@ func (dm *dataManager) ListToolResources(ctx context.Context, req mcom.ListToolR
 	return reply.(mcom.ListToolResourcesReply), nil
 }
 
+func (dm *dataManager) ListToolTypes(ctx context.Context) (mcom.ListToolTypesReply, error) {
+	reply, err := dm.run(ctx, FuncListToolTypes, nil, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.ListToolTypesReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.ListToolTypesReply{}, err
+	}
+	return reply.(mcom.ListToolTypesReply), nil
+}
+
+func (dm *dataManager) ListToolsDueForMaintenance(ctx context.Context, req mcom.ListToolsDueForMaintenanceRequest) (mcom.ListToolsDueForMaintenanceReply, error) {
+	reply, err := dm.run(ctx, FuncListToolsDueForMaintenance, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.ListToolsDueForMaintenanceReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.ListToolsDueForMaintenanceReply{}, err
+	}
+	return reply.(mcom.ListToolsDueForMaintenanceReply), nil
+}
+
 func (dm *dataManager) ListUnauthorizedUsers(ctx context.Context, req mcom.ListUnauthorizedUsersRequest, opts ...mcom.ListUnauthorizedUsersOption) (mcom.ListUnauthorizedUsersReply, error) {
 	reply, err := dm.run(ctx, FuncListUnauthorizedUsers, req, func(expectedOpts []interface{}) (*parsedOptions, error) {
 		if len(opts) != len(expectedOpts) {
@@ -899,6 +1344,17 @@ func (dm *dataManager) ListUnauthorizedUsers(ctx context.Context, req mcom.ListU
 	return reply.(mcom.ListUnauthorizedUsersReply), nil
 }
 
+func (dm *dataManager) ListUserQualifications(ctx context.Context, req mcom.ListUserQualificationsRequest) (mcom.ListUserQualificationsReply, error) {
+	reply, err := dm.run(ctx, FuncListUserQualifications, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.ListUserQualificationsReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.ListUserQualificationsReply{}, err
+	}
+	return reply.(mcom.ListUserQualificationsReply), nil
+}
+
 func (dm *dataManager) ListUserRoles(ctx context.Context, req mcom.ListUserRolesRequest) (mcom.ListUserRolesReply, error) {
 	reply, err := dm.run(ctx, FuncListUserRoles, req, noOptions, func(i interface{}) bool {
 		_, ok := i.(mcom.ListUserRolesReply)
@@ -910,6 +1366,17 @@ func (dm *dataManager) ListUserRoles(ctx context.Context, req mcom.ListUserRoles
 	return reply.(mcom.ListUserRolesReply), nil
 }
 
+func (dm *dataManager) ListWarehouseLocations(ctx context.Context, req mcom.ListWarehouseLocationsRequest) (mcom.ListWarehouseLocationsReply, error) {
+	reply, err := dm.run(ctx, FuncListWarehouseLocations, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.ListWarehouseLocationsReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.ListWarehouseLocationsReply{}, err
+	}
+	return reply.(mcom.ListWarehouseLocationsReply), nil
+}
+
 func (dm *dataManager) ListWorkOrders(ctx context.Context, req mcom.ListWorkOrdersRequest) (mcom.ListWorkOrdersReply, error) {
 	reply, err := dm.run(ctx, FuncListWorkOrders, req, noOptions, func(i interface{}) bool {
 		_, ok := i.(mcom.ListWorkOrdersReply)
@@ -959,6 +1426,73 @@ func (dm *dataManager) MaterialResourceBindV2(ctx context.Context, req mcom.Mate
 	return nil
 }
 
+func (dm *dataManager) PostStockCount(ctx context.Context, req mcom.PostStockCountRequest) error {
+	_, err := dm.run(ctx, FuncPostStockCount, req, noOptions, noReply)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (dm *dataManager) RecordToolMaintenance(ctx context.Context, req mcom.RecordToolMaintenanceRequest) error {
+	_, err := dm.run(ctx, FuncRecordToolMaintenance, req, noOptions, noReply)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (dm *dataManager) ReleaseHoldCase(ctx context.Context, req mcom.ReleaseHoldCaseRequest) error {
+	_, err := dm.run(ctx, FuncReleaseHoldCase, req, noOptions, noReply)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (dm *dataManager) ResolveWorkDate(ctx context.Context, req mcom.ResolveWorkDateRequest) (mcom.ResolveWorkDateReply, error) {
+	reply, err := dm.run(ctx, FuncResolveWorkDate, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.ResolveWorkDateReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.ResolveWorkDateReply{}, err
+	}
+	return reply.(mcom.ResolveWorkDateReply), nil
+}
+
+func (dm *dataManager) RetireToolResource(ctx context.Context, req mcom.RetireToolResourceRequest) error {
+	_, err := dm.run(ctx, FuncRetireToolResource, req, noOptions, noReply)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (dm *dataManager) RollbackStationConfiguration(ctx context.Context, req mcom.RollbackStationConfigurationRequest) error {
+	_, err := dm.run(ctx, FuncRollbackStationConfiguration, req, noOptions, noReply)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (dm *dataManager) SetQualificationRequirement(ctx context.Context, req mcom.SetQualificationRequirementRequest) error {
+	_, err := dm.run(ctx, FuncSetQualificationRequirement, req, noOptions, noReply)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (dm *dataManager) SetShiftCalendar(ctx context.Context, req mcom.SetShiftCalendarRequest) error {
+	_, err := dm.run(ctx, FuncSetShiftCalendar, req, noOptions, noReply)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (dm *dataManager) SetStationConfiguration(ctx context.Context, req mcom.SetStationConfigurationRequest) error {
 	_, err := dm.run(ctx, FuncSetStationConfiguration, req, noOptions, noReply)
 	if err != nil {
@@ -967,6 +1501,22 @@ func (dm *dataManager) SetStationConfiguration(ctx context.Context, req mcom.Set
 	return nil
 }
 
+func (dm *dataManager) SetToolLifePolicy(ctx context.Context, req mcom.SetToolLifePolicyRequest) error {
+	_, err := dm.run(ctx, FuncSetToolLifePolicy, req, noOptions, noReply)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (dm *dataManager) SetUserQualification(ctx context.Context, req mcom.SetUserQualificationRequest) error {
+	_, err := dm.run(ctx, FuncSetUserQualification, req, noOptions, noReply)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (dm *dataManager) SignIn(ctx context.Context, req mcom.SignInRequest, opts ...mcom.SignInOption) (mcom.SignInReply, error) {
 	reply, err := dm.run(ctx, FuncSignIn, req, func(expectedOpts []interface{}) (*parsedOptions, error) {
 		if len(opts) != len(expectedOpts) {
@@ -1001,6 +1551,17 @@ func (dm *dataManager) SignOut(ctx context.Context, req mcom.SignOutRequest) err
 	return nil
 }
 
+func (dm *dataManager) SignOutStaleOperators(ctx context.Context, req mcom.SignOutStaleOperatorsRequest) (mcom.SignOutStaleOperatorsReply, error) {
+	reply, err := dm.run(ctx, FuncSignOutStaleOperators, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.SignOutStaleOperatorsReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.SignOutStaleOperatorsReply{}, err
+	}
+	return reply.(mcom.SignOutStaleOperatorsReply), nil
+}
+
 func (dm *dataManager) SignOutStation(ctx context.Context, req mcom.SignOutStationRequest) error {
 	_, err := dm.run(ctx, FuncSignOutStation, req, noOptions, noReply)
 	if err != nil {
@@ -1028,6 +1589,14 @@ func (dm *dataManager) SplitMaterialResource(ctx context.Context, req mcom.Split
 	return reply.(mcom.SplitMaterialResourceReply), nil
 }
 
+func (dm *dataManager) SubmitStockCount(ctx context.Context, req mcom.SubmitStockCountRequest) error {
+	_, err := dm.run(ctx, FuncSubmitStockCount, req, noOptions, noReply)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (dm *dataManager) ToolResourceBind(ctx context.Context, req mcom.ToolResourceBindRequest) error {
 	_, err := dm.run(ctx, FuncToolResourceBind, req, noOptions, noReply)
 	if err != nil {
@@ -1044,6 +1613,36 @@ func (dm *dataManager) ToolResourceBindV2(ctx context.Context, req mcom.ToolReso
 	return nil
 }
 
+func (dm *dataManager) TraceBackward(ctx context.Context, req mcom.TraceRequest) (mcom.TraceReply, error) {
+	reply, err := dm.run(ctx, FuncTraceBackward, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.TraceReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.TraceReply{}, err
+	}
+	return reply.(mcom.TraceReply), nil
+}
+
+func (dm *dataManager) TraceForward(ctx context.Context, req mcom.TraceRequest) (mcom.TraceReply, error) {
+	reply, err := dm.run(ctx, FuncTraceForward, req, noOptions, func(i interface{}) bool {
+		_, ok := i.(mcom.TraceReply)
+		return ok
+	})
+	if err != nil {
+		return mcom.TraceReply{}, err
+	}
+	return reply.(mcom.TraceReply), nil
+}
+
+func (dm *dataManager) TransferSiteContents(ctx context.Context, req mcom.TransferSiteContentsRequest) error {
+	_, err := dm.run(ctx, FuncTransferSiteContents, req, noOptions, noReply)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (dm *dataManager) UpdateAccount(ctx context.Context, req mcom.UpdateAccountRequest, opts ...mcom.UpdateAccountOption) error {
 	_, err := dm.run(ctx, FuncUpdateAccount, req, func(expectedOpts []interface{}) (*parsedOptions, error) {
 		if len(opts) != len(expectedOpts) {
@@ -1096,6 +1695,22 @@ func (dm *dataManager) UpdateMaterial(ctx context.Context, req mcom.UpdateMateri
 	return nil
 }
 
+func (dm *dataManager) UpdateSiteFeedPolicy(ctx context.Context, req mcom.UpdateSiteFeedPolicyRequest) error {
+	_, err := dm.run(ctx, FuncUpdateSiteFeedPolicy, req, noOptions, noReply)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (dm *dataManager) UpdateSiteLimitation(ctx context.Context, req mcom.UpdateSiteLimitationRequest) error {
+	_, err := dm.run(ctx, FuncUpdateSiteLimitation, req, noOptions, noReply)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (dm *dataManager) UpdateStation(ctx context.Context, req mcom.UpdateStationRequest) error {
 	_, err := dm.run(ctx, FuncUpdateStation, req, noOptions, noReply)
 	if err != nil {
@@ -1120,6 +1735,22 @@ func (dm *dataManager) UpdateSubstitutions(ctx context.Context, req mcom.BasicSu
 	return nil
 }
 
+func (dm *dataManager) UpdateToolResource(ctx context.Context, req mcom.UpdateToolResourceRequest) error {
+	_, err := dm.run(ctx, FuncUpdateToolResource, req, noOptions, noReply)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (dm *dataManager) UpdateToolType(ctx context.Context, req mcom.UpdateToolTypeRequest) error {
+	_, err := dm.run(ctx, FuncUpdateToolType, req, noOptions, noReply)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (dm *dataManager) UpdateUser(ctx context.Context, req mcom.UpdateUserRequest) error {
 	_, err := dm.run(ctx, FuncUpdateUser, req, noOptions, noReply)
 	if err != nil {
@@ -1128,6 +1759,14 @@ func (dm *dataManager) UpdateUser(ctx context.Context, req mcom.UpdateUserReques
 	return nil
 }
 
+func (dm *dataManager) UpdateWarehouseLocation(ctx context.Context, req mcom.UpdateWarehouseLocationRequest) error {
+	_, err := dm.run(ctx, FuncUpdateWarehouseLocation, req, noOptions, noReply)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (dm *dataManager) UpdateWorkOrders(ctx context.Context, req mcom.UpdateWorkOrdersRequest) error {
 	_, err := dm.run(ctx, FuncUpdateWorkOrders, req, noOptions, noReply)
 	if err != nil {
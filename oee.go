@@ -0,0 +1,99 @@
+package mcom
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/stations"
+)
+
+// MaxOEEPeriods is the max number of the periods in a GetStationOEE request.
+const MaxOEEPeriods = 10000
+
+// GetStationOEERequest definition.
+type GetStationOEERequest struct {
+	Station string    `validate:"required"`
+	From    time.Time `validate:"required"`
+	To      time.Time `validate:"required"`
+	// Granularity is the length of each period. The whole time range is a
+	// single period if it is zero. It is ignored if ByShift is true.
+	Granularity time.Duration `validate:"min=0"`
+	// ByShift splits the time range by the shifts of the shift calendar of
+	// the department of the station. The whole time range is a single period
+	// if the department has no shift calendar.
+	ByShift bool
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req GetStationOEERequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	if !req.To.After(req.From) {
+		return mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "invalid time range"}
+	}
+	if !req.ByShift && req.Granularity != 0 && req.To.Sub(req.From)/req.Granularity >= MaxOEEPeriods {
+		return mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "too many periods"}
+	}
+	return nil
+}
+
+// GetStationOEEReply definition.
+type GetStationOEEReply struct {
+	// Total is the OEE of the whole time range.
+	Total StationOEE
+	// Periods are split from the time range by the granularity or by the
+	// shifts in ascending order of time.
+	Periods []StationOEE
+}
+
+// StationOEE is the overall equipment effectiveness of a station in a period.
+//
+// The ratios are zero if their denominators are zero.
+type StationOEE struct {
+	From time.Time
+	To   time.Time
+	// WorkDate and Shift are the shift of the period if the periods are split
+	// by the shifts. WorkDate is the midnight of the work date in UTC.
+	WorkDate time.Time
+	Shift    string
+
+	// PlannedTime is the time when the station is not in SHUTDOWN,
+	// MAINTENANCE or DISPOSAL state.
+	PlannedTime time.Duration
+	// RunTime is the time when the station is in RUNNING state.
+	RunTime time.Duration
+	// Downtimes are the time when the station is not in RUNNING state, in
+	// ascending order of state and reason.
+	Downtimes []StationDowntime
+
+	// PlannedQuantity is the planned quantity of the produced batches. They
+	// are the batches of the work orders at the station created in the
+	// period, or the batch counts of the collect records if their work orders
+	// have no batches.
+	PlannedQuantity decimal.Decimal
+	// Quantity is the collected quantity.
+	Quantity decimal.Decimal
+	// DefectQuantity is the collected quantity whose resources are in HOLD or
+	// UNAVAILABLE status.
+	DefectQuantity decimal.Decimal
+
+	// Availability = RunTime / PlannedTime.
+	Availability decimal.Decimal
+	// Performance = Quantity / PlannedQuantity.
+	Performance decimal.Decimal
+	// Quality = (Quantity - DefectQuantity) / Quantity.
+	Quality decimal.Decimal
+	// OEE = Availability * Performance * Quality.
+	OEE decimal.Decimal
+}
+
+// StationDowntime definition.
+type StationDowntime struct {
+	State stations.State
+	// Reason is the reason code of the transition to the state.
+	Reason   string
+	Duration time.Duration
+}
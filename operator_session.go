@@ -0,0 +1,135 @@
+package mcom
+
+import (
+	"time"
+
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+)
+
+// ListOperatorSessionsRequest definition.
+type ListOperatorSessionsRequest struct {
+	// UserID and Station are optional filters.
+	UserID  string
+	Station string
+	// Since and Until are the time range the sessions overlap. Until is
+	// optional.
+	Since time.Time `validate:"required"`
+	Until time.Time
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req ListOperatorSessionsRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	if !req.Until.IsZero() && req.Until.Before(req.Since) {
+		return mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "invalid time range"}
+	}
+	return nil
+}
+
+// OperatorSession definition.
+type OperatorSession struct {
+	UserID   string
+	Station  string
+	Site     models.SiteID
+	Group    int32
+	WorkDate time.Time
+
+	StartedAt time.Time
+	// EndedAt is zero if the operator has not signed out.
+	EndedAt time.Time
+	EndedBy string
+	// EndReason is one of the SignOutReason constants if the system signs out
+	// the operator, otherwise it is empty.
+	EndReason string
+}
+
+// ListOperatorSessionsReply definition.
+type ListOperatorSessionsReply struct {
+	// Sessions are in ascending order of the start time.
+	Sessions []OperatorSession
+}
+
+// ListLaborHoursRequest definition.
+type ListLaborHoursRequest struct {
+	// Since and Until are the range of the work dates, both inclusive.
+	Since time.Time `validate:"required"`
+	Until time.Time `validate:"required"`
+	// UserID, Station and DepartmentID are optional filters. DepartmentID is
+	// the department of the users.
+	UserID       string
+	Station      string
+	DepartmentID string
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req ListLaborHoursRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	if req.Until.Before(req.Since) {
+		return mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "invalid time range"}
+	}
+	return nil
+}
+
+// LaborHours is the total duration a user operates a station on a work date.
+type LaborHours struct {
+	UserID   string
+	Station  string
+	WorkDate time.Time
+	Duration time.Duration
+}
+
+// ListLaborHoursReply definition.
+type ListLaborHoursReply struct {
+	// LaborHours are in ascending order of user ID, work date and station.
+	LaborHours []LaborHours
+}
+
+const (
+	// SignOutReasonShiftEnded means the operator is signed out by the system
+	// since the shift of the operator has ended.
+	SignOutReasonShiftEnded = "SHIFT_ENDED"
+	// SignOutReasonExpired means the operator is signed out by the system
+	// since the session has lasted longer than the max duration.
+	SignOutReasonExpired = "EXPIRED"
+)
+
+// SignOutStaleOperatorsRequest definition.
+type SignOutStaleOperatorsRequest struct {
+	// DepartmentID is optional to sign out the operators of the stations in
+	// the department only.
+	DepartmentID string
+	// GracePeriod is the period after the end of the shift before the
+	// operator is signed out.
+	GracePeriod time.Duration `validate:"min=0"`
+	// MaxDuration is the max duration of a session on a station whose
+	// department has no shift calendar. The operators of the station are not
+	// signed out if it is zero.
+	MaxDuration time.Duration `validate:"min=0"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req SignOutStaleOperatorsRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// SignedOutOperator definition.
+type SignedOutOperator struct {
+	Site   models.UniqueSite
+	UserID string
+	// Reason is one of the SignOutReason constants.
+	Reason string
+}
+
+// SignOutStaleOperatorsReply definition.
+type SignOutStaleOperatorsReply struct {
+	// Operators are in ascending order of station, site name and site index.
+	Operators []SignedOutOperator
+}
@@ -42,6 +42,9 @@ type ListProductPlansRequest struct {
 	DepartmentOID string
 	Date          time.Time
 	ProductType   string
+	// ResolveWorkDate resolves Date to the work date by the shift calendar of
+	// the department. Date is regarded as a date if ResolveWorkDate is false.
+	ResolveWorkDate bool
 }
 
 // CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
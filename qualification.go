@@ -0,0 +1,136 @@
+package mcom
+
+import (
+	"time"
+
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
+)
+
+// SetUserQualificationRequest definition.
+type SetUserQualificationRequest struct {
+	UserID        string `validate:"required"`
+	Qualification string `validate:"required"`
+	// ExpiresAt is optional, the qualification never expires if it is zero.
+	ExpiresAt time.Time
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req SetUserQualificationRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// DeleteUserQualificationRequest definition.
+type DeleteUserQualificationRequest struct {
+	UserID        string `validate:"required"`
+	Qualification string `validate:"required"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req DeleteUserQualificationRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// ListUserQualificationsRequest definition.
+type ListUserQualificationsRequest struct {
+	UserID string `validate:"required"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req ListUserQualificationsRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// UserQualification definition.
+type UserQualification struct {
+	Qualification string
+	// ExpiresAt is zero if the qualification never expires.
+	ExpiresAt time.Time
+	Expired   bool
+
+	CreatedAt types.TimeNano
+	CreatedBy string
+}
+
+// ListUserQualificationsReply definition.
+type ListUserQualificationsReply struct {
+	// Qualifications are in ascending order of the name.
+	Qualifications []UserQualification
+}
+
+// SetQualificationRequirementRequest definition.
+type SetQualificationRequirementRequest struct {
+	// one of StationID and GroupID is required.
+	StationID string
+	GroupID   string
+	// Qualifications are required to operate the station or the stations in
+	// the group. The requirement is removed if it is empty.
+	Qualifications []string
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req SetQualificationRequirementRequest) CheckInsufficiency() error {
+	if (req.StationID == "") == (req.GroupID == "") {
+		return mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "one of station id and group id is required",
+		}
+	}
+	for _, qualification := range req.Qualifications {
+		if qualification == "" {
+			return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: "empty qualification"}
+		}
+	}
+	return nil
+}
+
+// ListStationRequiredQualificationsRequest definition.
+type ListStationRequiredQualificationsRequest struct {
+	StationID string `validate:"required"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req ListStationRequiredQualificationsRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// ListStationRequiredQualificationsReply definition.
+type ListStationRequiredQualificationsReply struct {
+	// Qualifications are required by the station and its ancestor groups, in
+	// ascending order.
+	Qualifications []string
+}
+
+// ListQualifiedUsersRequest definition.
+type ListQualifiedUsersRequest struct {
+	StationID string `validate:"required"`
+	// DepartmentID is optional to list the users in the department only.
+	DepartmentID string
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req ListQualifiedUsersRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// ListQualifiedUsersReply definition.
+type ListQualifiedUsersReply struct {
+	// Users are the IDs of the in-service users who are qualified to operate
+	// the station and have not signed in to any station, in ascending order.
+	Users []string
+}
@@ -0,0 +1,90 @@
+package mcom
+
+import (
+	"strings"
+
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+)
+
+// ProductionBlockerType is the reason why a station is not ready for a
+// production.
+type ProductionBlockerType string
+
+// ProductionBlockerType enumeration.
+const (
+	// ProductionBlockerStationState the station is not in IDLE or RUNNING state.
+	ProductionBlockerStationState ProductionBlockerType = "STATION_STATE"
+	// ProductionBlockerStationNotInProcess the station is not one of the
+	// stations of the process of the work order.
+	ProductionBlockerStationNotInProcess ProductionBlockerType = "STATION_NOT_IN_PROCESS"
+	// ProductionBlockerOperatorNotSignedIn there is no operator signed in to
+	// the station.
+	ProductionBlockerOperatorNotSignedIn ProductionBlockerType = "OPERATOR_NOT_SIGNED_IN"
+	// ProductionBlockerToolNotMounted a required recipe tool is not bound to
+	// the station.
+	ProductionBlockerToolNotMounted ProductionBlockerType = "TOOL_NOT_MOUNTED"
+	// ProductionBlockerMaterialNotBound a recipe step material is not bound to
+	// the station.
+	ProductionBlockerMaterialNotBound ProductionBlockerType = "MATERIAL_NOT_BOUND"
+)
+
+// ProductionBlocker definition.
+type ProductionBlocker struct {
+	Type ProductionBlockerType
+	// Site is the site where the material is required from. It is empty for
+	// the other types of blockers.
+	Site models.UniqueSite
+	// Expected is the tool ID for ProductionBlockerToolNotMounted, or the
+	// material ID for ProductionBlockerMaterialNotBound.
+	Expected string
+	Details  string
+}
+
+// String implements fmt.Stringer interface.
+func (b ProductionBlocker) String() string {
+	if b.Details == "" {
+		return string(b.Type)
+	}
+	return string(b.Type) + ": " + b.Details
+}
+
+// ProductionBlockers definition.
+type ProductionBlockers []ProductionBlocker
+
+// Err returns a Code_PRODUCTION_NOT_READY error with the blockers in the
+// details, or nil if there is no blocker.
+func (blockers ProductionBlockers) Err() error {
+	if len(blockers) == 0 {
+		return nil
+	}
+	details := make([]string, len(blockers))
+	for i, blocker := range blockers {
+		details[i] = blocker.String()
+	}
+	return mcomErr.Error{
+		Code:    mcomErr.Code_PRODUCTION_NOT_READY,
+		Details: strings.Join(details, "; "),
+	}
+}
+
+// CheckProductionReadinessRequest definition.
+type CheckProductionReadinessRequest struct {
+	WorkOrder string `validate:"required"`
+	Station   string `validate:"required"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req CheckProductionReadinessRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// CheckProductionReadinessReply definition.
+type CheckProductionReadinessReply struct {
+	// Ready is true if there is no blocker.
+	Ready    bool
+	Blockers ProductionBlockers
+}
@@ -0,0 +1,33 @@
+package mcom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+)
+
+func TestProductionBlockers_Err(t *testing.T) {
+	assert := assert.New(t)
+	assert.NoError(ProductionBlockers{}.Err())
+	assert.ErrorIs(ProductionBlockers{{
+		Type: ProductionBlockerOperatorNotSignedIn,
+	}, {
+		Type:     ProductionBlockerToolNotMounted,
+		Expected: "MOLD",
+		Details:  "tool: MOLD",
+	}}.Err(), mcomErr.Error{
+		Code:    mcomErr.Code_PRODUCTION_NOT_READY,
+		Details: "OPERATOR_NOT_SIGNED_IN; TOOL_NOT_MOUNTED: tool: MOLD",
+	})
+}
+
+func Test_CheckProductionReadinessRequest(t *testing.T) {
+	assert := assert.New(t)
+	assert.ErrorIs(CheckProductionReadinessRequest{WorkOrder: "WO"}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+		Details: "Key: 'CheckProductionReadinessRequest.Station' Error:Field validation for 'Station' failed on the 'required' tag",
+	})
+	assert.NoError(CheckProductionReadinessRequest{WorkOrder: "WO", Station: "S"}.CheckInsufficiency())
+}
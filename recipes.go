@@ -196,6 +196,28 @@ type GetProcessDefinitionReply struct {
 	ProcessDefinition
 }
 
+// ListProcessStationsRequest definition.
+type ListProcessStationsRequest struct {
+	RecipeID    string `validate:"required"`
+	ProcessName string `validate:"required"`
+	ProcessType string `validate:"required"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req ListProcessStationsRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// ListProcessStationsReply definition.
+type ListProcessStationsReply struct {
+	// Stations are the stations allowed in the process configs, resolved
+	// through the station group hierarchy, in ascending order.
+	Stations []string
+}
+
 // RecipeOptionalFlow definition.
 type RecipeOptionalFlow struct {
 	Name           string
@@ -13,6 +13,7 @@ import (
 	"gitlab.kenda.com.tw/kenda/mcom/utils/resources"
 	"gitlab.kenda.com.tw/kenda/mcom/utils/roles"
 	"gitlab.kenda.com.tw/kenda/mcom/utils/sites"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/stations"
 	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
 )
 
@@ -1662,7 +1663,7 @@ func Test_StationGroupRequest(t *testing.T) {
 				Code: mcomErr.Code_INSUFFICIENT_REQUEST,
 			})
 	}
-	{ // missing stations.
+	{ // missing stations and groups.
 		req := StationGroupRequest{
 			ID: "id",
 		}
@@ -1678,6 +1679,13 @@ func Test_StationGroupRequest(t *testing.T) {
 		}
 		assert.NoError(req.CheckInsufficiency())
 	}
+	{ // good case: child groups only.
+		req := StationGroupRequest{
+			ID:     "id",
+			Groups: []string{"group"},
+		}
+		assert.NoError(req.CheckInsufficiency())
+	}
 }
 
 func Test_DeleteStationGroupRequest(t *testing.T) {
@@ -1709,6 +1717,78 @@ func Test_GetSiteRequest(t *testing.T) {
 	}
 }
 
+func Test_UpdateSiteLimitationRequest(t *testing.T) {
+	assert := assert.New(t)
+	{ // good case.
+		req := UpdateSiteLimitationRequest{
+			StationID:  "station",
+			SiteName:   "site",
+			Limitation: []string{"product"},
+			Enforced:   true,
+		}
+		assert.NoError(req.CheckInsufficiency())
+	}
+	{ // good case: shared site.
+		req := UpdateSiteLimitationRequest{
+			SiteName: "site",
+		}
+		assert.NoError(req.CheckInsufficiency())
+	}
+	{ // missing site name.
+		req := UpdateSiteLimitationRequest{
+			StationID: "station",
+		}
+		assert.ErrorIs(req.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "Key: 'UpdateSiteLimitationRequest.SiteName' Error:Field validation for 'SiteName' failed on the 'required' tag",
+		})
+	}
+}
+
+func Test_UpdateSiteFeedPolicyRequest(t *testing.T) {
+	assert := assert.New(t)
+	{ // good case.
+		req := UpdateSiteFeedPolicyRequest{
+			StationID: "station",
+			SiteName:  "site",
+			FeedPolicy: models.SiteFeedPolicy{
+				Order:   models.FeedOrderFEFO,
+				Expired: models.ExpiredResourceRejected,
+			},
+		}
+		assert.NoError(req.CheckInsufficiency())
+	}
+	{ // missing station id.
+		req := UpdateSiteFeedPolicyRequest{
+			SiteName: "site",
+		}
+		assert.ErrorIs(req.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "Key: 'UpdateSiteFeedPolicyRequest.StationID' Error:Field validation for 'StationID' failed on the 'required' tag",
+		})
+	}
+	{ // missing site name.
+		req := UpdateSiteFeedPolicyRequest{
+			StationID: "station",
+		}
+		assert.ErrorIs(req.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "Key: 'UpdateSiteFeedPolicyRequest.SiteName' Error:Field validation for 'SiteName' failed on the 'required' tag",
+		})
+	}
+	{ // undefined order.
+		req := UpdateSiteFeedPolicyRequest{
+			StationID:  "station",
+			SiteName:   "site",
+			FeedPolicy: models.SiteFeedPolicy{Order: models.FeedOrderExplicit + 1},
+		}
+		assert.ErrorIs(req.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_BAD_REQUEST,
+			Details: "invalid feed policy",
+		})
+	}
+}
+
 func Test_CreateStationRequest(t *testing.T) {
 	assert := assert.New(t)
 	{ // missing id.
@@ -1749,6 +1829,17 @@ func Test_UpdateStationRequest(t *testing.T) {
 				Code: mcomErr.Code_INSUFFICIENT_REQUEST,
 			})
 	}
+	{ // missing state reason.
+		req := UpdateStationRequest{
+			ID:    "id",
+			State: stations.State_MALFUNCTION,
+		}
+		assert.ErrorIs(req.CheckInsufficiency(),
+			mcomErr.Error{
+				Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+				Details: "missing reason for state: MALFUNCTION",
+			})
+	}
 	{ // good case.
 		req := UpdateStationRequest{ID: "id"}
 		assert.NoError(req.CheckInsufficiency())
@@ -2986,3 +3077,539 @@ func Test_ListBlobURIsRequest(t *testing.T) {
 		assert.NoError(req.CheckInsufficiency())
 	}
 }
+
+func Test_ListSiteBindHistoryRequest(t *testing.T) {
+	assert := assert.New(t)
+	{ // missing site name.
+		assert.ErrorIs(ListSiteBindHistoryRequest{
+			Station: "A",
+			Since:   time.Now(),
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "missing site name",
+		})
+	}
+	{ // missing since.
+		assert.ErrorIs(ListSiteBindHistoryRequest{
+			Station: "A",
+			Site:    models.SiteID{Name: "A"},
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "Key: 'ListSiteBindHistoryRequest.Since' Error:Field validation for 'Since' failed on the 'required' tag",
+		})
+	}
+	{ // invalid time range.
+		assert.ErrorIs(ListSiteBindHistoryRequest{
+			Station: "A",
+			Site:    models.SiteID{Name: "A"},
+			Since:   time.Now(),
+			Until:   time.Now().Add(-time.Hour),
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_BAD_REQUEST,
+			Details: "invalid time range",
+		})
+	}
+	{ // good case.
+		assert.NoError(ListSiteBindHistoryRequest{
+			Station: "A",
+			Site:    models.SiteID{Name: "A"},
+			Since:   time.Now(),
+		}.CheckInsufficiency())
+	}
+	{ // good case: shared site.
+		assert.NoError(ListSiteBindHistoryRequest{
+			Site:  models.SiteID{Name: "A"},
+			Since: time.Now(),
+		}.CheckInsufficiency())
+	}
+}
+
+func Test_TransferSiteContentsRequest(t *testing.T) {
+	assert := assert.New(t)
+	from := models.UniqueSite{SiteID: models.SiteID{Name: "A"}, Station: "A"}
+	to := models.UniqueSite{SiteID: models.SiteID{Name: "B"}, Station: "A"}
+	{ // missing station.
+		assert.ErrorIs(TransferSiteContentsRequest{
+			From: from,
+			To:   models.UniqueSite{SiteID: models.SiteID{Name: "B"}},
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "missing station of the sites",
+		})
+	}
+	{ // the same site.
+		assert.ErrorIs(TransferSiteContentsRequest{
+			From: from,
+			To:   from,
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_BAD_REQUEST,
+			Details: "transfer to the same site",
+		})
+	}
+	{ // good case.
+		assert.NoError(TransferSiteContentsRequest{
+			From:        from,
+			To:          to,
+			ResourceIDs: []string{"R"},
+		}.CheckInsufficiency())
+	}
+}
+
+func Test_ChangeStationStateRequest(t *testing.T) {
+	assert := assert.New(t)
+	{ // missing id.
+		assert.ErrorIs(ChangeStationStateRequest{
+			State: stations.State_IDLE,
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "Key: 'ChangeStationStateRequest.ID' Error:Field validation for 'ID' failed on the 'required' tag",
+		})
+	}
+	{ // missing reason.
+		assert.ErrorIs(ChangeStationStateRequest{
+			ID:    "A",
+			State: stations.State_REPAIRING,
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "missing reason for state: REPAIRING",
+		})
+	}
+	{ // good case.
+		assert.NoError(ChangeStationStateRequest{
+			ID:    "A",
+			State: stations.State_IDLE,
+		}.CheckInsufficiency())
+		assert.NoError(ChangeStationStateRequest{
+			ID:     "A",
+			State:  stations.State_MALFUNCTION,
+			Reason: "M01",
+		}.CheckInsufficiency())
+	}
+}
+
+func Test_ListStationStateHistoryRequest(t *testing.T) {
+	assert := assert.New(t)
+	{ // missing station.
+		assert.ErrorIs(ListStationStateHistoryRequest{
+			Since: time.Now(),
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "Key: 'ListStationStateHistoryRequest.Station' Error:Field validation for 'Station' failed on the 'required' tag",
+		})
+	}
+	{ // invalid time range.
+		assert.ErrorIs(ListStationStateHistoryRequest{
+			Station: "A",
+			Since:   time.Now(),
+			Until:   time.Now().Add(-time.Hour),
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_BAD_REQUEST,
+			Details: "invalid time range",
+		})
+	}
+	{ // good case.
+		assert.NoError(ListStationStateHistoryRequest{
+			Station: "A",
+			Since:   time.Now(),
+		}.CheckInsufficiency())
+	}
+}
+
+func Test_GetStationOEERequest(t *testing.T) {
+	assert := assert.New(t)
+	from := time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local)
+	{ // missing station.
+		assert.ErrorIs(GetStationOEERequest{
+			From: from,
+			To:   from.Add(24 * time.Hour),
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "Key: 'GetStationOEERequest.Station' Error:Field validation for 'Station' failed on the 'required' tag",
+		})
+	}
+	{ // invalid time range.
+		assert.ErrorIs(GetStationOEERequest{
+			Station: "A",
+			From:    from,
+			To:      from,
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_BAD_REQUEST,
+			Details: "invalid time range",
+		})
+	}
+	{ // too many periods.
+		assert.ErrorIs(GetStationOEERequest{
+			Station:     "A",
+			From:        from,
+			To:          from.Add(24 * time.Hour),
+			Granularity: time.Millisecond,
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_BAD_REQUEST,
+			Details: "too many periods",
+		})
+	}
+	{ // good case.
+		assert.NoError(GetStationOEERequest{
+			Station:     "A",
+			From:        from,
+			To:          from.Add(24 * time.Hour),
+			Granularity: 8 * time.Hour,
+		}.CheckInsufficiency())
+	}
+	{ // good case: by shift ignores the granularity.
+		assert.NoError(GetStationOEERequest{
+			Station:     "A",
+			From:        from,
+			To:          from.Add(24 * time.Hour),
+			Granularity: time.Millisecond,
+			ByShift:     true,
+		}.CheckInsufficiency())
+	}
+}
+
+func Test_ListGroupStationsRequest(t *testing.T) {
+	assert := assert.New(t)
+	assert.ErrorIs(ListGroupStationsRequest{}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+		Details: "Key: 'ListGroupStationsRequest.GroupID' Error:Field validation for 'GroupID' failed on the 'required' tag",
+	})
+	assert.NoError(ListGroupStationsRequest{GroupID: "G"}.CheckInsufficiency())
+}
+
+func Test_ListStationAncestorsRequest(t *testing.T) {
+	assert := assert.New(t)
+	assert.ErrorIs(ListStationAncestorsRequest{}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+		Details: "Key: 'ListStationAncestorsRequest.StationID' Error:Field validation for 'StationID' failed on the 'required' tag",
+	})
+	assert.NoError(ListStationAncestorsRequest{StationID: "A"}.CheckInsufficiency())
+}
+
+func Test_ListProcessStationsRequest(t *testing.T) {
+	assert := assert.New(t)
+	assert.ErrorIs(ListProcessStationsRequest{
+		RecipeID:    "R",
+		ProcessName: "P",
+	}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+		Details: "Key: 'ListProcessStationsRequest.ProcessType' Error:Field validation for 'ProcessType' failed on the 'required' tag",
+	})
+	assert.NoError(ListProcessStationsRequest{
+		RecipeID:    "R",
+		ProcessName: "P",
+		ProcessType: "T",
+	}.CheckInsufficiency())
+}
+
+func Test_CreateStationTemplateRequest(t *testing.T) {
+	assert := assert.New(t)
+	{ // missing id.
+		assert.ErrorIs(CreateStationTemplateRequest{}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "Key: 'CreateStationTemplateRequest.ID' Error:Field validation for 'ID' failed on the 'required' tag",
+		})
+	}
+	{ // missing site type.
+		assert.ErrorIs(CreateStationTemplateRequest{
+			ID: "T",
+			Sites: []SiteInformation{{
+				Name:    "S",
+				SubType: sites.SubType_MATERIAL,
+			}},
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "missing type or subtype of the site, name: S, index: 0",
+		})
+	}
+	{ // good case.
+		assert.NoError(CreateStationTemplateRequest{
+			ID: "T",
+			Sites: []SiteInformation{{
+				Name:    "S",
+				Type:    sites.Type_SLOT,
+				SubType: sites.SubType_MATERIAL,
+			}, {
+				// shared site.
+				Station: "A",
+				Name:    "S",
+			}},
+		}.CheckInsufficiency())
+	}
+}
+
+func Test_CreateStationFromTemplateRequest(t *testing.T) {
+	assert := assert.New(t)
+	assert.ErrorIs(CreateStationFromTemplateRequest{
+		TemplateID: "T",
+		ID:         "A",
+	}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+		Details: "Key: 'CreateStationFromTemplateRequest.DepartmentOID' Error:Field validation for 'DepartmentOID' failed on the 'required' tag",
+	})
+	assert.NoError(CreateStationFromTemplateRequest{
+		TemplateID:    "T",
+		ID:            "A",
+		DepartmentOID: "D",
+	}.CheckInsufficiency())
+}
+
+func Test_CloneStationRequest(t *testing.T) {
+	assert := assert.New(t)
+	{ // missing new id.
+		assert.ErrorIs(CloneStationRequest{
+			SourceID: "A",
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "Key: 'CloneStationRequest.NewID' Error:Field validation for 'NewID' failed on the 'required' tag",
+		})
+	}
+	{ // the same station.
+		assert.ErrorIs(CloneStationRequest{
+			SourceID: "A",
+			NewID:    "A",
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_BAD_REQUEST,
+			Details: "clone to the same station",
+		})
+	}
+	{ // good case.
+		assert.NoError(CloneStationRequest{
+			SourceID: "A",
+			NewID:    "B",
+			DryRun:   true,
+		}.CheckInsufficiency())
+	}
+}
+
+func Test_ListStationConfigurationVersionsRequest(t *testing.T) {
+	assert := assert.New(t)
+	assert.ErrorIs(ListStationConfigurationVersionsRequest{}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+		Details: "Key: 'ListStationConfigurationVersionsRequest.StationID' Error:Field validation for 'StationID' failed on the 'required' tag",
+	})
+	assert.NoError(ListStationConfigurationVersionsRequest{StationID: "A"}.CheckInsufficiency())
+}
+
+func Test_DiffStationConfigurationVersionsRequest(t *testing.T) {
+	assert := assert.New(t)
+	assert.ErrorIs(DiffStationConfigurationVersionsRequest{
+		StationID: "A",
+		ToVersion: 2,
+	}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+		Details: "Key: 'DiffStationConfigurationVersionsRequest.FromVersion' Error:Field validation for 'FromVersion' failed on the 'min' tag",
+	})
+	assert.NoError(DiffStationConfigurationVersionsRequest{
+		StationID:   "A",
+		FromVersion: 1,
+		ToVersion:   2,
+	}.CheckInsufficiency())
+}
+
+func Test_RollbackStationConfigurationRequest(t *testing.T) {
+	assert := assert.New(t)
+	assert.ErrorIs(RollbackStationConfigurationRequest{
+		StationID: "A",
+	}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+		Details: "Key: 'RollbackStationConfigurationRequest.Version' Error:Field validation for 'Version' failed on the 'min' tag",
+	})
+	assert.NoError(RollbackStationConfigurationRequest{
+		StationID: "A",
+		Version:   1,
+	}.CheckInsufficiency())
+}
+
+func Test_SetShiftCalendarRequest(t *testing.T) {
+	assert := assert.New(t)
+	shifts := []Shift{
+		{Name: "A", Start: 7*time.Hour + 30*time.Minute},
+		{Name: "B", Start: 15*time.Hour + 30*time.Minute},
+		{Name: "C", Start: 23*time.Hour + 30*time.Minute},
+	}
+	{ // insufficient request.
+		assert.ErrorIs(SetShiftCalendarRequest{
+			DepartmentID: "D",
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "Key: 'SetShiftCalendarRequest.Shifts' Error:Field validation for 'Shifts' failed on the 'min' tag",
+		})
+	}
+	{ // unknown time zone.
+		assert.ErrorIs(SetShiftCalendarRequest{
+			DepartmentID: "D",
+			TimeZone:     "Mars/Olympus",
+			Shifts:       shifts,
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_BAD_REQUEST,
+			Details: "unknown time zone: Mars/Olympus",
+		})
+	}
+	{ // shifts not in order.
+		assert.ErrorIs(SetShiftCalendarRequest{
+			DepartmentID: "D",
+			Shifts:       []Shift{shifts[0], shifts[2], shifts[1]},
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_BAD_REQUEST,
+			Details: "shifts not in order",
+		})
+	}
+	{ // missing rotation since.
+		assert.ErrorIs(SetShiftCalendarRequest{
+			DepartmentID: "D",
+			Shifts:       shifts,
+			Rotation: ShiftRotation{
+				Groups: [][]int32{{1, 2, 3}},
+			},
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "missing rotation since",
+		})
+	}
+	{ // rotation groups mismatch the shifts.
+		assert.ErrorIs(SetShiftCalendarRequest{
+			DepartmentID: "D",
+			Shifts:       shifts,
+			Rotation: ShiftRotation{
+				Since:  time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+				Groups: [][]int32{{1, 2}},
+			},
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_BAD_REQUEST,
+			Details: "rotation groups mismatch the shifts",
+		})
+	}
+	{ // good case.
+		assert.NoError(SetShiftCalendarRequest{
+			DepartmentID: "D",
+			TimeZone:     "Asia/Taipei",
+			Shifts:       shifts,
+			Rotation: ShiftRotation{
+				Since:  time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+				Groups: [][]int32{{1, 2, 3}, {4, 1, 2}, {3, 4, 1}, {2, 3, 4}},
+			},
+		}.CheckInsufficiency())
+	}
+}
+
+func Test_GetShiftCalendarRequest(t *testing.T) {
+	assert := assert.New(t)
+	assert.ErrorIs(GetShiftCalendarRequest{}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+		Details: "Key: 'GetShiftCalendarRequest.DepartmentID' Error:Field validation for 'DepartmentID' failed on the 'required' tag",
+	})
+	assert.NoError(GetShiftCalendarRequest{DepartmentID: "D"}.CheckInsufficiency())
+}
+
+func Test_ResolveWorkDateRequest(t *testing.T) {
+	assert := assert.New(t)
+	assert.ErrorIs(ResolveWorkDateRequest{Station: "A"}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+		Details: "Key: 'ResolveWorkDateRequest.Time' Error:Field validation for 'Time' failed on the 'required' tag",
+	})
+	assert.NoError(ResolveWorkDateRequest{Station: "A", Time: time.Now()}.CheckInsufficiency())
+}
+
+func Test_SetUserQualificationRequest(t *testing.T) {
+	assert := assert.New(t)
+	assert.ErrorIs(SetUserQualificationRequest{UserID: "U"}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+		Details: "Key: 'SetUserQualificationRequest.Qualification' Error:Field validation for 'Qualification' failed on the 'required' tag",
+	})
+	assert.NoError(SetUserQualificationRequest{UserID: "U", Qualification: "Q"}.CheckInsufficiency())
+}
+
+func Test_DeleteUserQualificationRequest(t *testing.T) {
+	assert := assert.New(t)
+	assert.ErrorIs(DeleteUserQualificationRequest{Qualification: "Q"}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+		Details: "Key: 'DeleteUserQualificationRequest.UserID' Error:Field validation for 'UserID' failed on the 'required' tag",
+	})
+	assert.NoError(DeleteUserQualificationRequest{UserID: "U", Qualification: "Q"}.CheckInsufficiency())
+}
+
+func Test_SetQualificationRequirementRequest(t *testing.T) {
+	assert := assert.New(t)
+	{ // neither station nor group.
+		assert.ErrorIs(SetQualificationRequirementRequest{}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "one of station id and group id is required",
+		})
+	}
+	{ // both station and group.
+		assert.ErrorIs(SetQualificationRequirementRequest{
+			StationID: "S",
+			GroupID:   "G",
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "one of station id and group id is required",
+		})
+	}
+	{ // empty qualification.
+		assert.ErrorIs(SetQualificationRequirementRequest{
+			StationID:      "S",
+			Qualifications: []string{"Q", ""},
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "empty qualification",
+		})
+	}
+	{ // good case.
+		assert.NoError(SetQualificationRequirementRequest{
+			GroupID:        "G",
+			Qualifications: []string{"Q"},
+		}.CheckInsufficiency())
+	}
+}
+
+func Test_ListQualifiedUsersRequest(t *testing.T) {
+	assert := assert.New(t)
+	assert.ErrorIs(ListQualifiedUsersRequest{DepartmentID: "D"}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+		Details: "Key: 'ListQualifiedUsersRequest.StationID' Error:Field validation for 'StationID' failed on the 'required' tag",
+	})
+	assert.NoError(ListQualifiedUsersRequest{StationID: "S"}.CheckInsufficiency())
+}
+
+func Test_ListOperatorSessionsRequest(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Now()
+	assert.ErrorIs(ListOperatorSessionsRequest{UserID: "U"}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+		Details: "Key: 'ListOperatorSessionsRequest.Since' Error:Field validation for 'Since' failed on the 'required' tag",
+	})
+	assert.ErrorIs(ListOperatorSessionsRequest{
+		Since: now,
+		Until: now.Add(-time.Hour),
+	}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_BAD_REQUEST,
+		Details: "invalid time range",
+	})
+	assert.NoError(ListOperatorSessionsRequest{Since: now}.CheckInsufficiency())
+}
+
+func Test_ListLaborHoursRequest(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Now()
+	assert.ErrorIs(ListLaborHoursRequest{Since: now}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+		Details: "Key: 'ListLaborHoursRequest.Until' Error:Field validation for 'Until' failed on the 'required' tag",
+	})
+	assert.ErrorIs(ListLaborHoursRequest{
+		Since: now,
+		Until: now.AddDate(0, 0, -1),
+	}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_BAD_REQUEST,
+		Details: "invalid time range",
+	})
+	assert.NoError(ListLaborHoursRequest{Since: now, Until: now}.CheckInsufficiency())
+}
+
+func Test_SignOutStaleOperatorsRequest(t *testing.T) {
+	assert := assert.New(t)
+	assert.ErrorIs(SignOutStaleOperatorsRequest{GracePeriod: -time.Minute}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+		Details: "Key: 'SignOutStaleOperatorsRequest.GracePeriod' Error:Field validation for 'GracePeriod' failed on the 'min' tag",
+	})
+	assert.NoError(SignOutStaleOperatorsRequest{}.CheckInsufficiency())
+}
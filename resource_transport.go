@@ -0,0 +1,124 @@
+package mcom
+
+import (
+	"time"
+
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+)
+
+// ListResourceTransportRecordsRequest definition.
+//
+// All the conditions are optional. The records between Since and Until will
+// be listed, and all the records after Since will be listed if Until is zero.
+type ListResourceTransportRecordsRequest struct {
+	ResourceID string
+	// WarehouseID and Location match either the old or the new warehouse of
+	// the records. Location requires WarehouseID.
+	WarehouseID string `validate:"required_with=Location"`
+	Location    string
+	// CreatedBy is the user who transported the resources.
+	CreatedBy string
+	Since     time.Time
+	Until     time.Time
+
+	paginationRequest PaginationRequest
+	orderRequest      OrderRequest
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req ListResourceTransportRecordsRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	if !req.Until.IsZero() && req.Until.Before(req.Since) {
+		return mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "invalid time range"}
+	}
+	return nil
+}
+
+func (req ListResourceTransportRecordsRequest) WithPagination(p PaginationRequest) ListResourceTransportRecordsRequest {
+	req.paginationRequest = p
+	return req
+}
+
+// NeedPagination implements gitlab.kenda.com.tw/kenda/mcom Sliceable interface.
+func (req ListResourceTransportRecordsRequest) NeedPagination() bool {
+	return req.paginationRequest != PaginationRequest{}
+}
+
+// GetOffset implements gitlab.kenda.com.tw/kenda/mcom Sliceable interface.
+func (req ListResourceTransportRecordsRequest) GetOffset() int {
+	return (int(req.paginationRequest.PageCount) - 1) * int(req.paginationRequest.ObjectsPerPage)
+}
+
+// GetLimit implements gitlab.kenda.com.tw/kenda/mcom Sliceable interface.
+func (req ListResourceTransportRecordsRequest) GetLimit() int {
+	return int(req.paginationRequest.ObjectsPerPage)
+}
+
+// ValidatePagination implements gitlab.kenda.com.tw/kenda/mcom Sliceable interface.
+func (req ListResourceTransportRecordsRequest) ValidatePagination() error {
+	return req.paginationRequest.Validate()
+}
+
+func (req ListResourceTransportRecordsRequest) WithOrder(os ...Order) ListResourceTransportRecordsRequest {
+	req.orderRequest.OrderBy = append(req.orderRequest.OrderBy, os...)
+	return req
+}
+
+// NeedOrder implements gitlab.kenda.com.tw/kenda/mcom Orderable interface.
+func (req ListResourceTransportRecordsRequest) NeedOrder() bool {
+	return len(req.orderRequest.OrderBy) != 0
+}
+
+// ValidateOrder implements gitlab.kenda.com.tw/kenda/mcom Orderable interface.
+func (req ListResourceTransportRecordsRequest) ValidateOrder() error {
+	for _, order := range req.orderRequest.OrderBy {
+		if !isValidName[models.ResourceTransportRecord](order.Name) {
+			return mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "order: invalid field name"}
+		}
+	}
+	return nil
+}
+
+// GetOrder implements gitlab.kenda.com.tw/kenda/mcom Orderable interface.
+func (req ListResourceTransportRecordsRequest) GetOrder() []Order {
+	return req.orderRequest.OrderBy
+}
+
+// ResourceTransportRecord definition.
+type ResourceTransportRecord struct {
+	ID         int64
+	ResourceID string
+	// From is empty if the resource had not been stocked in.
+	From      Warehouse
+	To        Warehouse
+	Note      string
+	CreatedAt time.Time
+	CreatedBy string
+}
+
+// ListResourceTransportRecordsReply definition.
+type ListResourceTransportRecordsReply struct {
+	Records []ResourceTransportRecord
+	PaginationReply
+}
+
+// GetResourceLocationAtRequest definition.
+type GetResourceLocationAtRequest struct {
+	ResourceID string    `validate:"required"`
+	Time       time.Time `validate:"required"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req GetResourceLocationAtRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// GetResourceLocationAtReply is the warehouse where the resource was, it is
+// empty if the resource had not been stocked in at the time.
+type GetResourceLocationAtReply Warehouse
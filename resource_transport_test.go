@@ -0,0 +1,50 @@
+package mcom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+)
+
+func Test_ListResourceTransportRecordsRequest(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Now()
+	{ // good case.
+		assert.NoError(ListResourceTransportRecordsRequest{}.CheckInsufficiency())
+		assert.NoError(ListResourceTransportRecordsRequest{
+			WarehouseID: "A",
+			Location:    "00",
+			Since:       now.Add(-time.Hour),
+			Until:       now,
+		}.CheckInsufficiency())
+	}
+	{ // location without warehouse.
+		assert.ErrorIs(ListResourceTransportRecordsRequest{Location: "00"}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "Key: 'ListResourceTransportRecordsRequest.WarehouseID' Error:Field validation for 'WarehouseID' failed on the 'required_with' tag",
+		})
+	}
+	{ // invalid time range.
+		assert.ErrorIs(ListResourceTransportRecordsRequest{Since: now, Until: now.Add(-time.Hour)}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_BAD_REQUEST,
+			Details: "invalid time range",
+		})
+	}
+	{ // invalid order.
+		req := ListResourceTransportRecordsRequest{}.WithOrder(Order{Name: "note"})
+		assert.ErrorIs(req.ValidateOrder(), mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "order: invalid field name"})
+		assert.NoError(ListResourceTransportRecordsRequest{}.WithOrder(Order{Name: "created_at"}).ValidateOrder())
+	}
+}
+
+func Test_GetResourceLocationAtRequest(t *testing.T) {
+	assert := assert.New(t)
+	assert.NoError(GetResourceLocationAtRequest{ResourceID: "R", Time: time.Now()}.CheckInsufficiency())
+	assert.ErrorIs(GetResourceLocationAtRequest{ResourceID: "R"}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+		Details: "Key: 'GetResourceLocationAtRequest.Time' Error:Field validation for 'Time' failed on the 'required' tag",
+	})
+}
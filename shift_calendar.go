@@ -0,0 +1,122 @@
+package mcom
+
+import (
+	"time"
+
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
+)
+
+// Shift definition.
+type Shift struct {
+	Name string `validate:"required"`
+	// Start is the start time of the shift since the midnight, e.g. 7h30m.
+	Start time.Duration
+}
+
+// ShiftRotation is the rotation of the groups on the shifts.
+type ShiftRotation struct {
+	// Since is the work date of the first day in Groups.
+	Since time.Time
+	// Groups[i][j] is the group on the j-th shift of the i-th day of a
+	// cycle, e.g. [][]int32{{1, 2, 3}, {4, 1, 2}, {3, 4, 1}, {2, 3, 4}} for
+	// four groups on three shifts.
+	Groups [][]int32
+}
+
+// SetShiftCalendarRequest definition.
+type SetShiftCalendarRequest struct {
+	DepartmentID string `validate:"required"`
+	// TimeZone is the IANA time zone name of the plant, e.g. "Asia/Taipei".
+	// It is the local time zone if empty.
+	TimeZone string
+	// Shifts are in ascending order of the start time beginning with the
+	// first shift of a work date. A work date begins at the start of the
+	// first shift.
+	Shifts   []Shift `validate:"min=1,dive"`
+	Rotation ShiftRotation
+	// Holidays are the work dates of the holidays.
+	Holidays []time.Time
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req SetShiftCalendarRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+
+	if _, err := (models.ShiftCalendar{TimeZone: req.TimeZone}).Location(); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "unknown time zone: " + req.TimeZone}
+	}
+
+	shifts := make([]models.ShiftDefinition, len(req.Shifts))
+	for i, shift := range req.Shifts {
+		shifts[i] = models.ShiftDefinition{Name: shift.Name, Start: shift.Start}
+	}
+	if !models.IsShiftsInOrder(shifts) {
+		return mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "shifts not in order"}
+	}
+
+	if len(req.Rotation.Groups) != 0 {
+		if req.Rotation.Since.IsZero() {
+			return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: "missing rotation since"}
+		}
+		for _, groups := range req.Rotation.Groups {
+			if len(groups) != len(req.Shifts) {
+				return mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "rotation groups mismatch the shifts"}
+			}
+		}
+	}
+	return nil
+}
+
+// GetShiftCalendarRequest definition.
+type GetShiftCalendarRequest struct {
+	DepartmentID string `validate:"required"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req GetShiftCalendarRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// GetShiftCalendarReply definition.
+type GetShiftCalendarReply struct {
+	DepartmentID string
+	TimeZone     string
+	Shifts       []Shift
+	Rotation     ShiftRotation
+	// Holidays are the midnights of the work dates in UTC.
+	Holidays []time.Time
+
+	UpdatedAt types.TimeNano
+	UpdatedBy string
+}
+
+// ResolveWorkDateRequest definition.
+type ResolveWorkDateRequest struct {
+	Station string    `validate:"required"`
+	Time    time.Time `validate:"required"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req ResolveWorkDateRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// ResolveWorkDateReply definition.
+type ResolveWorkDateReply struct {
+	// WorkDate is the midnight of the work date in UTC.
+	WorkDate time.Time
+	Shift    string
+	// Group is the group on the shift. It is zero if there is no rotation.
+	Group   int32
+	Holiday bool
+}
@@ -553,3 +553,76 @@ func (req BindRecordsCheckRequest) CheckInsufficiency() error {
 	}
 	return nil
 }
+
+// ListSiteBindHistoryRequest definition.
+//
+// The histories between Since and Until will be listed.
+// If Until is zero, all the histories after Since will be listed.
+type ListSiteBindHistoryRequest struct {
+	// Station is empty for a shared site.
+	Station string
+	Site    models.SiteID
+	Since   time.Time `validate:"required"`
+	Until   time.Time
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req ListSiteBindHistoryRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	if req.Site.Name == "" {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: "missing site name"}
+	}
+	if !req.Until.IsZero() && req.Until.Before(req.Since) {
+		return mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "invalid time range"}
+	}
+	return nil
+}
+
+// ListSiteBindHistoryReply definition.
+type ListSiteBindHistoryReply struct {
+	Histories []SiteBindHistory
+}
+
+// SiteBindHistory definition.
+type SiteBindHistory struct {
+	Site     models.UniqueSite
+	BindType bindtype.BindType
+	// Resources are the resources in the bind request with their quantities.
+	Resources []models.MaterialSite
+	// Before and After are the contents of the site before and after binding.
+	Before models.SiteContent
+	After  models.SiteContent
+
+	CreatedAt time.Time
+	CreatedBy string
+}
+
+// TransferSiteContentsRequest definition.
+type TransferSiteContentsRequest struct {
+	From models.UniqueSite
+	To   models.UniqueSite
+	// ResourceIDs selects the resources to transfer. All the resources in the
+	// From site will be transferred if it is empty.
+	ResourceIDs []string
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req TransferSiteContentsRequest) CheckInsufficiency() error {
+	if req.From.Station == "" || req.To.Station == "" {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: "missing station of the sites"}
+	}
+	if req.From == req.To {
+		return mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "transfer to the same site"}
+	}
+	return nil
+}
+
+// GetDetails implements gitlab.kenda.com.tw/kenda/mcom ResourceBindRequest interface.
+func (req TransferSiteContentsRequest) GetDetails() []BindRequestDetail {
+	return []BindRequestDetail{
+		MaterialBindRequestDetailV2{Site: req.From},
+		MaterialBindRequestDetailV2{Site: req.To},
+	}
+}
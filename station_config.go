@@ -1,6 +1,8 @@
 package mcom
 
 import (
+	"reflect"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/shopspring/decimal"
 
@@ -63,6 +65,127 @@ type GetStationConfigurationReply struct {
 
 	Collect StationCollectConfigs
 
+	// Version is the current version of the configuration.
+	Version int32
+
 	UpdatedAt types.TimeNano
 	UpdatedBy string
 }
+
+// StationConfiguration is the configuration of a station, see
+// SetStationConfigurationRequest.
+type StationConfiguration struct {
+	SplitFeedAndCollect bool
+
+	Feed    StationFeedConfigs
+	Collect StationCollectConfigs
+}
+
+// StationConfigurationDifference is a different field between two station
+// configurations.
+type StationConfigurationDifference struct {
+	// Field is the path of the field, e.g. "Feed.ProductTypes".
+	Field string
+	From  interface{}
+	To    interface{}
+}
+
+var decimalType = reflect.TypeOf(decimal.Decimal{})
+
+// Diff returns the different fields from c to other in the order of the
+// field declarations.
+func (c StationConfiguration) Diff(other StationConfiguration) []StationConfigurationDifference {
+	return diffConfigurationFields("", reflect.ValueOf(c), reflect.ValueOf(other))
+}
+
+func diffConfigurationFields(prefix string, from, to reflect.Value) []StationConfigurationDifference {
+	res := []StationConfigurationDifference{}
+	for i := 0; i < from.NumField(); i++ {
+		field := from.Type().Field(i)
+		name := prefix + field.Name
+		f, t := from.Field(i), to.Field(i)
+		if field.Type.Kind() == reflect.Struct && field.Type != decimalType {
+			res = append(res, diffConfigurationFields(name+".", f, t)...)
+			continue
+		}
+		if !isConfigurationFieldEqual(f, t) {
+			res = append(res, StationConfigurationDifference{
+				Field: name,
+				From:  f.Interface(),
+				To:    t.Interface(),
+			})
+		}
+	}
+	return res
+}
+
+func isConfigurationFieldEqual(from, to reflect.Value) bool {
+	switch {
+	case from.Type() == decimalType:
+		return from.Interface().(decimal.Decimal).Equal(to.Interface().(decimal.Decimal))
+	case from.Kind() == reflect.Slice && from.Len() == 0 && to.Len() == 0:
+		return true
+	}
+	return reflect.DeepEqual(from.Interface(), to.Interface())
+}
+
+// ListStationConfigurationVersionsRequest definition.
+type ListStationConfigurationVersionsRequest struct {
+	StationID string `validate:"required"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req ListStationConfigurationVersionsRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// ListStationConfigurationVersionsReply definition.
+type ListStationConfigurationVersionsReply struct {
+	// Versions are in descending order of version.
+	Versions []StationConfigurationVersion
+}
+
+// StationConfigurationVersion definition.
+type StationConfigurationVersion struct {
+	Version       int32
+	Configuration StationConfiguration
+	CreatedAt     types.TimeNano
+	CreatedBy     string
+}
+
+// DiffStationConfigurationVersionsRequest definition.
+type DiffStationConfigurationVersionsRequest struct {
+	StationID   string `validate:"required"`
+	FromVersion int32  `validate:"min=1"`
+	ToVersion   int32  `validate:"min=1"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req DiffStationConfigurationVersionsRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// DiffStationConfigurationVersionsReply definition.
+type DiffStationConfigurationVersionsReply struct {
+	Differences []StationConfigurationDifference
+}
+
+// RollbackStationConfigurationRequest definition.
+type RollbackStationConfigurationRequest struct {
+	StationID string `validate:"required"`
+	Version   int32  `validate:"min=1"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req RollbackStationConfigurationRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package mcom
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/stations"
+)
+
+func TestStationConfiguration_Diff(t *testing.T) {
+	assert := assert.New(t)
+
+	from := StationConfiguration{
+		Feed: StationFeedConfigs{
+			ProductTypes:   []string{"A"},
+			QuantitySource: stations.FeedQuantitySource_FROM_RECIPE,
+		},
+		Collect: StationCollectConfigs{
+			DefaultQuantity: decimal.RequireFromString("15.0"),
+		},
+	}
+	{ // no difference.
+		to := from
+		to.Collect.DefaultQuantity = decimal.NewFromInt(15)
+		to.Collect.OperatorSites = []models.UniqueSite{}
+		assert.Equal([]StationConfigurationDifference{}, from.Diff(to))
+	}
+	{ // differences.
+		sites := []models.UniqueSite{{
+			SiteID:  models.SiteID{Name: "site"},
+			Station: "station",
+		}}
+		to := StationConfiguration{
+			SplitFeedAndCollect: true,
+			Feed: StationFeedConfigs{
+				ProductTypes:   []string{"A"},
+				QuantitySource: stations.FeedQuantitySource_USER_DEFINITION,
+			},
+			Collect: StationCollectConfigs{
+				OperatorSites:   sites,
+				DefaultQuantity: decimal.NewFromInt(20),
+			},
+		}
+		assert.Equal([]StationConfigurationDifference{{
+			Field: "SplitFeedAndCollect",
+			From:  false,
+			To:    true,
+		}, {
+			Field: "Feed.QuantitySource",
+			From:  stations.FeedQuantitySource_FROM_RECIPE,
+			To:    stations.FeedQuantitySource_USER_DEFINITION,
+		}, {
+			Field: "Collect.OperatorSites",
+			From:  []models.UniqueSite(nil),
+			To:    sites,
+		}, {
+			Field: "Collect.DefaultQuantity",
+			From:  decimal.RequireFromString("15.0"),
+			To:    decimal.NewFromInt(20),
+		}}, from.Diff(to))
+	}
+}
@@ -0,0 +1,128 @@
+package mcom
+
+import (
+	"fmt"
+
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/sites"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/stations"
+	"gitlab.kenda.com.tw/kenda/mcom/utils/types"
+)
+
+// CreateStationTemplateRequest definition.
+type CreateStationTemplateRequest struct {
+	ID string `validate:"required"`
+	// Sites.Station is empty for the sites owned by the created stations,
+	// otherwise it is the station ID of the shared site.
+	Sites []SiteInformation
+	// Configuration is optional. The OperatorSites.Station in the
+	// configuration is empty for the sites of the created stations.
+	Configuration *StationConfiguration
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req CreateStationTemplateRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	for _, site := range req.Sites {
+		if site.Station == "" && (site.Type == sites.Type_TYPE_UNSPECIFIED || site.SubType == sites.SubType_SUB_TYPE_UNSPECIFIED) {
+			return mcomErr.Error{
+				Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+				Details: fmt.Sprintf("missing type or subtype of the site, name: %v, index: %d", site.Name, site.Index),
+			}
+		}
+	}
+	return nil
+}
+
+// GetStationTemplateRequest definition.
+type GetStationTemplateRequest struct {
+	ID string `validate:"required"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req GetStationTemplateRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// GetStationTemplateReply definition.
+type GetStationTemplateReply struct {
+	ID            string
+	Sites         []SiteInformation
+	Configuration *StationConfiguration
+
+	CreatedAt types.TimeNano
+	CreatedBy string
+}
+
+// DeleteStationTemplateRequest definition.
+type DeleteStationTemplateRequest struct {
+	ID string `validate:"required"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req DeleteStationTemplateRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// CreateStationFromTemplateRequest definition.
+type CreateStationFromTemplateRequest struct {
+	TemplateID    string `validate:"required"`
+	ID            string `validate:"required"`
+	DepartmentOID string `validate:"required"`
+	State         stations.State
+	Information   StationInformation
+	// DryRun returns what will be created without creating anything.
+	DryRun bool
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req CreateStationFromTemplateRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// CloneStationRequest definition.
+type CloneStationRequest struct {
+	SourceID string `validate:"required"`
+	NewID    string `validate:"required"`
+	// DepartmentOID is optional, the department of the source station by
+	// default.
+	DepartmentOID string
+	// DryRun returns what will be created without creating anything.
+	DryRun bool
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req CloneStationRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	if req.SourceID == req.NewID {
+		return mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "clone to the same station"}
+	}
+	return nil
+}
+
+// StationCreationPlan is what will be created, or has been created, for a new
+// station.
+type StationCreationPlan struct {
+	Station CreateStationRequest
+	// Configuration is nil if there is no configuration to set.
+	Configuration *SetStationConfigurationRequest
+}
+
+// CreateStationFromTemplateReply definition.
+type CreateStationFromTemplateReply StationCreationPlan
+
+// CloneStationReply definition.
+type CloneStationReply StationCreationPlan
@@ -122,11 +122,13 @@ type StationInformation struct {
 type StationGroupRequest struct {
 	ID       string
 	Stations []string
+	// Groups are the IDs of the child groups, e.g. the lines of an area.
+	Groups []string
 }
 
 // CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
 func (req StationGroupRequest) CheckInsufficiency() error {
-	if req.ID == "" || len(req.Stations) == 0 {
+	if req.ID == "" || (len(req.Stations) == 0 && len(req.Groups) == 0) {
 		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST}
 	}
 	return nil
@@ -145,6 +147,53 @@ func (req DeleteStationGroupRequest) CheckInsufficiency() error {
 	return nil
 }
 
+// ListGroupStationsRequest definition.
+type ListGroupStationsRequest struct {
+	GroupID string `validate:"required"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req ListGroupStationsRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// ListGroupStationsReply definition.
+type ListGroupStationsReply struct {
+	// Stations are all the stations under the group and its descendant
+	// groups in ascending order.
+	Stations []string
+}
+
+// ListStationAncestorsRequest definition.
+type ListStationAncestorsRequest struct {
+	StationID string `validate:"required"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req ListStationAncestorsRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// ListStationAncestorsReply definition.
+type ListStationAncestorsReply struct {
+	// Ancestors are in ascending order of depth and ID.
+	Ancestors []StationGroupAncestor
+}
+
+// StationGroupAncestor definition.
+type StationGroupAncestor struct {
+	GroupID string
+	// Depth is 1 for the groups containing the station directly, 2 for their
+	// parent groups and so on.
+	Depth int
+}
+
 type ListAssociatedStationsRequest struct {
 	Site models.UniqueSite `validate:"required"`
 }
@@ -191,31 +240,77 @@ type SiteAttributes struct {
 	SubType sites.SubType
 
 	// LimitHandler returns whether the product can be bound into this site or not.
+	// The children of the product groups and the substitutions of the allowed
+	// products are allowed as well if the attributes are returned by GetSite.
 	// The returned USER_ERROR would be as below:
 	//  - mcomErr.Code_PRODUCT_ID_MISMATCH
 	LimitHandler func(productID string) error
+	// LimitationEnforced indicates whether the LimitHandler is applied while
+	// binding materials or not.
+	LimitationEnforced bool
+
+	Capacity models.SiteCapacity
+	// FeedPolicy is the order and the expiry rule to feed the resources.
+	FeedPolicy models.SiteFeedPolicy
 }
 
+// NewSiteAttributes returns the site attributes whose LimitHandler matches the
+// limitation as is, the product groups and the substitutions are not resolved.
 func NewSiteAttributes(sa models.SiteAttributes) SiteAttributes {
-	limitationMapping := make(map[string]bool, len(sa.Limitation))
-	for _, limit := range sa.Limitation {
-		limitationMapping[limit] = true
-	}
-
 	return SiteAttributes{
 		Type:    sa.Type,
 		SubType: sa.SubType,
 		LimitHandler: func(productID string) error {
-			if len(limitationMapping) == 0 {
-				return nil
-			}
-			_, ok := limitationMapping[productID]
-			if !ok {
+			if !models.MatchLimitation(sa.Limitation, productID) {
 				return mcomErr.Error{Code: mcomErr.Code_PRODUCT_ID_MISMATCH, Details: "product id: " + productID}
 			}
 			return nil
 		},
+		LimitationEnforced: sa.LimitationEnforced,
+		Capacity:           sa.Capacity,
+		FeedPolicy:         sa.FeedPolicy,
+	}
+}
+
+// UpdateSiteLimitationRequest definition.
+type UpdateSiteLimitationRequest struct {
+	// StationID is empty for a shared site.
+	StationID string
+	SiteName  string `validate:"required"`
+	SiteIndex int16
+	// Limitation is the list of the allowed product ids, patterns or product
+	// groups. An empty list means no limitation.
+	Limitation []string
+	// Enforced indicates whether the limitation is checked while binding
+	// materials or not.
+	Enforced bool
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req UpdateSiteLimitationRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// UpdateSiteFeedPolicyRequest definition.
+type UpdateSiteFeedPolicyRequest struct {
+	StationID  string `validate:"required"`
+	SiteName   string `validate:"required"`
+	SiteIndex  int16
+	FeedPolicy models.SiteFeedPolicy
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req UpdateSiteFeedPolicyRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	if !req.FeedPolicy.IsValid() {
+		return mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "invalid feed policy"}
 	}
+	return nil
 }
 
 // SiteInformation definition.
@@ -227,6 +322,14 @@ type SiteInformation struct {
 	Type       sites.Type
 	SubType    sites.SubType
 	Limitation []string
+	// LimitationEnforced is optional, the Limitation is not checked while
+	// binding materials by default.
+	LimitationEnforced bool
+	// Capacity is optional, the site is unlimited by default.
+	Capacity models.SiteCapacity
+	// FeedPolicy is optional, the resources are fed in the order they are
+	// bound regardless of their expiry time by default.
+	FeedPolicy models.SiteFeedPolicy
 }
 
 // UpdateStationSite definition.
@@ -246,6 +349,10 @@ type ListStationSitesInformation struct {
 	Type       sites.Type
 	SubType    sites.SubType
 	Limitation []string
+	Capacity   models.SiteCapacity
+	FeedPolicy models.SiteFeedPolicy
+	// RemainingCapacity is the remaining capacity of the site according to Capacity.
+	RemainingCapacity models.SiteRemainingCapacity
 }
 
 // CreateStationRequest definition.
@@ -286,7 +393,10 @@ type UpdateStationRequest struct {
 	DepartmentOID string
 	Sites         []UpdateStationSite
 	State         stations.State
-	Information   StationInformation
+	// StateReason is the reason code of the state change, it is required for
+	// MALFUNCTION and REPAIRING states, see ChangeStationStateRequest.
+	StateReason string
+	Information StationInformation
 }
 
 // CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
@@ -296,6 +406,12 @@ func (req UpdateStationRequest) CheckInsufficiency() error {
 			Code: mcomErr.Code_INSUFFICIENT_REQUEST,
 		}
 	}
+	if req.StateReason == "" && (req.State == stations.State_MALFUNCTION || req.State == stations.State_REPAIRING) {
+		return mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "missing reason for state: " + req.State.String(),
+		}
+	}
 	return nil
 }
 
@@ -347,6 +463,64 @@ type StationState struct {
 // ListStationStateReply definition.
 type ListStationStateReply []StationState
 
+// ChangeStationStateRequest definition.
+type ChangeStationStateRequest struct {
+	ID    string         `validate:"required"`
+	State stations.State `validate:"required"`
+	// Reason is the reason code of the transition, it is required while
+	// changing to MALFUNCTION or REPAIRING.
+	Reason string
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req ChangeStationStateRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	if req.Reason == "" && (req.State == stations.State_MALFUNCTION || req.State == stations.State_REPAIRING) {
+		return mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "missing reason for state: " + req.State.String(),
+		}
+	}
+	return nil
+}
+
+// ListStationStateHistoryRequest definition.
+//
+// The histories between Since and Until will be listed.
+// If Until is zero, all the histories after Since will be listed.
+type ListStationStateHistoryRequest struct {
+	Station string    `validate:"required"`
+	Since   time.Time `validate:"required"`
+	Until   time.Time
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req ListStationStateHistoryRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	if !req.Until.IsZero() && req.Until.Before(req.Since) {
+		return mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "invalid time range"}
+	}
+	return nil
+}
+
+// ListStationStateHistoryReply definition.
+type ListStationStateHistoryReply struct {
+	Histories []StationStateHistory
+}
+
+// StationStateHistory definition.
+type StationStateHistory struct {
+	From      stations.State
+	To        stations.State
+	Reason    string
+	CreatedAt time.Time
+	CreatedBy string
+}
+
 type ListStationIDsRequest struct {
 	DepartmentOID string
 }
@@ -0,0 +1,137 @@
+package mcom
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+)
+
+// CreateStockCountRequest definition.
+type CreateStockCountRequest struct {
+	WarehouseID string `validate:"required"`
+	// Location is optional, the whole warehouse is counted if it is empty.
+	Location string
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req CreateStockCountRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// CreateStockCountReply definition.
+type CreateStockCountReply struct {
+	CountID string
+	// Resources are the resources in the snapshot.
+	Resources []models.UniqueMaterialResource
+}
+
+// StockCountEntry is a resource scanned by a PDA.
+type StockCountEntry struct {
+	ResourceID string `validate:"required"`
+	// ProductType is optional if only one resource with the ResourceID is in
+	// the snapshot or in the system.
+	ProductType string
+	Quantity    decimal.Decimal
+	// Location is where the resource is found, it is the location of the stock
+	// count if empty, and it is required if the whole warehouse is counted.
+	Location string
+}
+
+// SubmitStockCountRequest definition.
+type SubmitStockCountRequest struct {
+	CountID string            `validate:"required"`
+	Entries []StockCountEntry `validate:"min=1,dive"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req SubmitStockCountRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	for _, entry := range req.Entries {
+		if entry.Quantity.IsNegative() {
+			return mcomErr.Error{Code: mcomErr.Code_INVALID_NUMBER, Details: "negative quantity, resource: " + entry.ResourceID}
+		}
+	}
+	return nil
+}
+
+// ListStockCountVariancesRequest definition.
+type ListStockCountVariancesRequest struct {
+	CountID string `validate:"required"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req ListStockCountVariancesRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// StockCountVariance definition.
+type StockCountVariance struct {
+	Type models.StockCountVarianceType
+	models.UniqueMaterialResource
+	ProductID string
+	// Recorded is where the resource was recorded, in the snapshot or when it
+	// was counted if it is UNEXPECTED.
+	Recorded         Warehouse
+	RecordedQuantity decimal.Decimal
+	// Counted is empty if the resource is MISSING.
+	Counted         Warehouse
+	CountedQuantity decimal.Decimal
+	CountedAt       time.Time
+	CountedBy       string
+	// Adjusted is true if the variance has been posted.
+	Adjusted bool
+}
+
+// ListStockCountVariancesReply definition.
+type ListStockCountVariancesReply struct {
+	WarehouseID string
+	Location    string
+	Status      models.StockCountStatus
+	// Counted is the number of the counted resources.
+	Counted   int
+	Variances []StockCountVariance
+}
+
+// PostStockCountRequest definition.
+type PostStockCountRequest struct {
+	CountID string `validate:"required"`
+	// Resources are the resources which variances are approved, or all the
+	// variances are approved if All is true.
+	Resources []models.UniqueMaterialResource
+	All       bool
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req PostStockCountRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	if !req.All && len(req.Resources) == 0 {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: "no approved resource"}
+	}
+	return nil
+}
+
+// CancelStockCountRequest definition.
+type CancelStockCountRequest struct {
+	CountID string `validate:"required"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req CancelStockCountRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
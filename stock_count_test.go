@@ -0,0 +1,78 @@
+package mcom
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+)
+
+func Test_CreateStockCountRequest(t *testing.T) {
+	assert := assert.New(t)
+	assert.NoError(CreateStockCountRequest{WarehouseID: "A"}.CheckInsufficiency())
+	assert.ErrorIs(CreateStockCountRequest{Location: "00"}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+		Details: "Key: 'CreateStockCountRequest.WarehouseID' Error:Field validation for 'WarehouseID' failed on the 'required' tag",
+	})
+}
+
+func Test_SubmitStockCountRequest(t *testing.T) {
+	assert := assert.New(t)
+	{ // good case.
+		assert.NoError(SubmitStockCountRequest{
+			CountID: "C",
+			Entries: []StockCountEntry{{ResourceID: "R", Quantity: decimal.NewFromInt(10)}},
+		}.CheckInsufficiency())
+	}
+	{ // no entry.
+		assert.ErrorIs(SubmitStockCountRequest{CountID: "C"}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "Key: 'SubmitStockCountRequest.Entries' Error:Field validation for 'Entries' failed on the 'min' tag",
+		})
+	}
+	{ // missing resource id.
+		assert.ErrorIs(SubmitStockCountRequest{CountID: "C", Entries: []StockCountEntry{{}}}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "Key: 'SubmitStockCountRequest.Entries[0].ResourceID' Error:Field validation for 'ResourceID' failed on the 'required' tag",
+		})
+	}
+	{ // negative quantity.
+		assert.ErrorIs(SubmitStockCountRequest{
+			CountID: "C",
+			Entries: []StockCountEntry{{ResourceID: "R", Quantity: decimal.NewFromInt(-1)}},
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INVALID_NUMBER,
+			Details: "negative quantity, resource: R",
+		})
+	}
+}
+
+func Test_PostStockCountRequest(t *testing.T) {
+	assert := assert.New(t)
+	assert.NoError(PostStockCountRequest{CountID: "C", All: true}.CheckInsufficiency())
+	assert.NoError(PostStockCountRequest{
+		CountID:   "C",
+		Resources: []models.UniqueMaterialResource{{ResourceID: "R", ProductType: "T"}},
+	}.CheckInsufficiency())
+	assert.ErrorIs(PostStockCountRequest{CountID: "C"}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+		Details: "no approved resource",
+	})
+}
+
+func Test_StockCountRequests(t *testing.T) {
+	assert := assert.New(t)
+	assert.NoError(ListStockCountVariancesRequest{CountID: "C"}.CheckInsufficiency())
+	assert.ErrorIs(ListStockCountVariancesRequest{}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+		Details: "Key: 'ListStockCountVariancesRequest.CountID' Error:Field validation for 'CountID' failed on the 'required' tag",
+	})
+	assert.NoError(CancelStockCountRequest{CountID: "C"}.CheckInsufficiency())
+	assert.ErrorIs(CancelStockCountRequest{}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+		Details: "Key: 'CancelStockCountRequest.CountID' Error:Field validation for 'CountID' failed on the 'required' tag",
+	})
+}
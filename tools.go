@@ -1,6 +1,10 @@
 package mcom
 
 import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
 	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
 	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
 	"gitlab.kenda.com.tw/kenda/mcom/utils/bindtype"
@@ -25,6 +29,8 @@ type GetToolResourceReply struct {
 	BindingSite models.UniqueSite
 	CreatedBy   string
 	CreatedAt   types.TimeNano
+	// RetiredAt is zero if the tool is in service.
+	RetiredAt types.TimeNano
 }
 
 // Deprecated: use V2 instead
@@ -127,3 +133,277 @@ func (req ListToolResourcesRequest) CheckInsufficiency() error {
 type ListToolResourcesReply struct {
 	Resources map[string]GetToolResourceReply
 }
+
+// SetToolLifePolicyRequest definition.
+type SetToolLifePolicyRequest struct {
+	ToolID string `validate:"required"`
+	// LifeLimit is the max usage cycles of a tool. It is unlimited if zero.
+	LifeLimit int64 `validate:"min=0"`
+	// MaintenanceInterval is the usage cycles between two maintenances. There
+	// is no maintenance if zero.
+	MaintenanceInterval int64 `validate:"min=0"`
+	// WarningCycles is the remaining cycles to warn before reaching the life
+	// limit or the maintenance interval.
+	WarningCycles int64 `validate:"min=0"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req SetToolLifePolicyRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// RecordToolMaintenanceRequest definition.
+type RecordToolMaintenanceRequest struct {
+	ResourceID string `validate:"required"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req RecordToolMaintenanceRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// ToolLife is the usage and the life status of a tool resource.
+type ToolLife struct {
+	ResourceID  string
+	ToolID      string
+	BindingSite models.UniqueSite
+
+	Cycles                 int64
+	CyclesSinceMaintenance int64
+	// MaintainedAt is zero if the tool has never been maintained.
+	MaintainedAt time.Time
+
+	// LifeLimit and MaintenanceInterval are from the life policy of the tool
+	// ID, they are zero if there is no limit.
+	LifeLimit           int64
+	MaintenanceInterval int64
+
+	// LifeWarning is true if the tool is within the warning cycles of the life
+	// limit or LifeExceeded is true.
+	LifeWarning  bool
+	LifeExceeded bool
+	// MaintenanceWarning is true if the tool is within the warning cycles of
+	// the maintenance interval or MaintenanceDue is true.
+	MaintenanceWarning bool
+	MaintenanceDue     bool
+}
+
+// GetToolLifeRequest definition.
+type GetToolLifeRequest struct {
+	ResourceID string `validate:"required"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req GetToolLifeRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// GetToolLifeReply definition.
+type GetToolLifeReply ToolLife
+
+// ListToolsDueForMaintenanceRequest definition.
+type ListToolsDueForMaintenanceRequest struct {
+	// ToolID is optional to list the tools of the tool ID only.
+	ToolID string
+	// IncludeWarnings lists the tools within the warning cycles as well.
+	IncludeWarnings bool
+}
+
+// ListToolsDueForMaintenanceReply definition.
+type ListToolsDueForMaintenanceReply struct {
+	// Tools are in ascending order of the resource ID.
+	Tools []ToolLife
+}
+
+// ToolType definition.
+type ToolType struct {
+	ID          string `validate:"required"`
+	Description string
+}
+
+// CreateToolTypesRequest definition.
+type CreateToolTypesRequest struct {
+	Types []ToolType `validate:"min=1,dive"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req CreateToolTypesRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// UpdateToolTypeRequest definition.
+type UpdateToolTypeRequest ToolType
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req UpdateToolTypeRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// ListToolTypesReply definition.
+type ListToolTypesReply struct {
+	// Types are in ascending order of the ID.
+	Types []ToolType
+}
+
+// CreateToolResource definition.
+type CreateToolResource struct {
+	ResourceID string `validate:"required"`
+	ToolID     string `validate:"required"`
+}
+
+// CreateToolResourcesRequest definition.
+type CreateToolResourcesRequest struct {
+	Resources []CreateToolResource `validate:"min=1,dive"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req CreateToolResourcesRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+
+	ids := make(map[string]struct{}, len(req.Resources))
+	for _, resource := range req.Resources {
+		if _, ok := ids[resource.ResourceID]; ok {
+			return mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "duplicate resource id: " + resource.ResourceID}
+		}
+		ids[resource.ResourceID] = struct{}{}
+	}
+	return nil
+}
+
+// UpdateToolResourceRequest definition.
+type UpdateToolResourceRequest struct {
+	ResourceID string `validate:"required"`
+	ToolID     string `validate:"required"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req UpdateToolResourceRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// RetireToolResourceRequest definition.
+type RetireToolResourceRequest struct {
+	ResourceID string `validate:"required"`
+	Reason     string
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req RetireToolResourceRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// ListToolResourceHistoryRequest definition.
+type ListToolResourceHistoryRequest struct {
+	ResourceID string `validate:"required"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req ListToolResourceHistoryRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// ToolResourceHistory definition.
+type ToolResourceHistory struct {
+	Action models.ToolResourceAction
+	// ToolID is the tool ID after the action.
+	ToolID    string
+	Reason    string
+	CreatedAt time.Time
+	CreatedBy string
+}
+
+// ListToolResourceHistoryReply definition.
+type ListToolResourceHistoryReply struct {
+	// Histories are in ascending order of the created time.
+	Histories []ToolResourceHistory
+}
+
+// ListToolBindHistoryRequest definition.
+type ListToolBindHistoryRequest struct {
+	// One of ResourceID, ToolID and Site is required to list the bind
+	// histories of the tool, of the tools of the tool ID or of the site.
+	ResourceID string
+	ToolID     string
+	Site       *models.UniqueSite
+	// Since and Until are optional to list the histories overlapping the time
+	// range only.
+	Since time.Time
+	Until time.Time
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req ListToolBindHistoryRequest) CheckInsufficiency() error {
+	if req.ResourceID == "" && req.ToolID == "" && req.Site == nil {
+		return mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "one of resource id, tool id and site is required",
+		}
+	}
+	if !req.Until.IsZero() && req.Until.Before(req.Since) {
+		return mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "until is before since"}
+	}
+	return nil
+}
+
+// ToolBindProduction is a collect record or a batch at the station while the
+// tool is bound to the site.
+type ToolBindProduction struct {
+	WorkOrder string
+	// Sequence is the batch number of the work order.
+	Sequence int16
+	// Batch reports whether the production is a batch of the work order
+	// rather than a collect record. LotNumber and Quantity are empty for a
+	// batch.
+	Batch     bool
+	LotNumber string
+	Quantity  decimal.Decimal
+	// CollectedAt is the creation time of the collect record or the batch.
+	CollectedAt time.Time
+}
+
+// ToolBindHistory definition.
+type ToolBindHistory struct {
+	ResourceID string
+	ToolID     string
+	Site       models.UniqueSite
+	BoundAt    time.Time
+	BoundBy    string
+	// UnboundAt is zero if the tool is still bound to the site.
+	UnboundAt time.Time
+	UnboundBy string
+	// Productions are in ascending order of the collected time. They are
+	// empty for the shared sites.
+	Productions []ToolBindProduction
+}
+
+// ListToolBindHistoryReply definition.
+type ListToolBindHistoryReply struct {
+	// Histories are in ascending order of the bound time.
+	Histories []ToolBindHistory
+}
@@ -2,6 +2,7 @@ package mcom
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -40,3 +41,51 @@ func Test_ToModelResource(t *testing.T) {
 	tr := ToolResource{}
 	assert.Equal(tr.ToModelResource(), models.ToolResource{})
 }
+
+func Test_SetToolLifePolicyRequest(t *testing.T) {
+	assert := assert.New(t)
+	assert.ErrorIs(SetToolLifePolicyRequest{LifeLimit: 10}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+		Details: "Key: 'SetToolLifePolicyRequest.ToolID' Error:Field validation for 'ToolID' failed on the 'required' tag",
+	})
+	assert.ErrorIs(SetToolLifePolicyRequest{ToolID: "T", LifeLimit: -1}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+		Details: "Key: 'SetToolLifePolicyRequest.LifeLimit' Error:Field validation for 'LifeLimit' failed on the 'min' tag",
+	})
+	assert.NoError(SetToolLifePolicyRequest{ToolID: "T", LifeLimit: 10, MaintenanceInterval: 5}.CheckInsufficiency())
+}
+
+func Test_CreateToolResourcesRequest(t *testing.T) {
+	assert := assert.New(t)
+	assert.ErrorIs(CreateToolResourcesRequest{}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+		Details: "Key: 'CreateToolResourcesRequest.Resources' Error:Field validation for 'Resources' failed on the 'min' tag",
+	})
+	assert.ErrorIs(CreateToolResourcesRequest{
+		Resources: []CreateToolResource{{ResourceID: "A", ToolID: "T"}, {ResourceID: "A", ToolID: "T"}},
+	}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_BAD_REQUEST,
+		Details: "duplicate resource id: A",
+	})
+	assert.NoError(CreateToolResourcesRequest{
+		Resources: []CreateToolResource{{ResourceID: "A", ToolID: "T"}, {ResourceID: "B", ToolID: "T"}},
+	}.CheckInsufficiency())
+}
+
+func Test_ListToolBindHistoryRequest(t *testing.T) {
+	assert := assert.New(t)
+	assert.ErrorIs(ListToolBindHistoryRequest{}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+		Details: "one of resource id, tool id and site is required",
+	})
+	now := time.Now()
+	assert.ErrorIs(ListToolBindHistoryRequest{
+		ResourceID: "A",
+		Since:      now,
+		Until:      now.Add(-time.Hour),
+	}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_BAD_REQUEST,
+		Details: "until is before since",
+	})
+	assert.NoError(ListToolBindHistoryRequest{Site: &models.UniqueSite{Station: "S"}}.CheckInsufficiency())
+}
@@ -252,6 +252,9 @@ type SignInStationOptions struct {
 	VerifyWorkDate        func(time.Time) bool
 	Force                 bool
 	CreateSiteIfNotExists bool
+	// VerifyWorkDateByShiftCalendar verifies the work date by the shift
+	// calendar of the station department.
+	VerifyWorkDateByShiftCalendar bool
 }
 
 func newSignInStationOptions() SignInStationOptions {
@@ -278,6 +281,14 @@ func WithVerifyWorkDateHandler(f func(time.Time) bool) SignInStationOption {
 	}
 }
 
+// WithShiftCalendarWorkDateVerification verifies if the work date is the work
+// date of now in the shift calendar of the station department.
+func WithShiftCalendarWorkDateVerification() SignInStationOption {
+	return func(o *SignInStationOptions) {
+		o.VerifyWorkDateByShiftCalendar = true
+	}
+}
+
 func ForceSignIn() SignInStationOption {
 	return func(siso *SignInStationOptions) {
 		siso.Force = true
@@ -61,5 +61,11 @@ func Test_ParseSignInStationOptions(t *testing.T) {
 		})})
 		assert.False(opts.VerifyWorkDate(time.Now()))
 		assert.True(opts.VerifyWorkDate(time.Date(2022, 2, 22, 22, 22, 22, 22, time.Local)))
+		assert.False(opts.VerifyWorkDateByShiftCalendar)
+	}
+	{ // WithShiftCalendarWorkDateVerification.
+		opts := ParseSignInStationOptions([]SignInStationOption{WithShiftCalendarWorkDateVerification()})
+		assert.True(opts.VerifyWorkDateByShiftCalendar)
+		assert.True(opts.VerifyWorkDate(time.Now()))
 	}
 }
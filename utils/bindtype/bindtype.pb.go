@@ -66,6 +66,10 @@ const (
 	//
 	// an empty collection will be left after the object is removed
 	BindType_RESOURCE_BINDING_COLQUEUE_REMOVE BindType = 2224
+	// resources are moved into the site from another site
+	BindType_RESOURCE_BINDING_TRANSFER_IN BindType = 3001
+	// resources are moved out of the site to another site
+	BindType_RESOURCE_BINDING_TRANSFER_OUT BindType = 3010
 )
 
 var BindType_name = map[int32]string{
@@ -92,6 +96,8 @@ var BindType_name = map[int32]string{
 	2222: "RESOURCE_BINDING_COLQUEUE_PUSHPOP",
 	2223: "RESOURCE_BINDING_COLQUEUE_POP",
 	2224: "RESOURCE_BINDING_COLQUEUE_REMOVE",
+	3001: "RESOURCE_BINDING_TRANSFER_IN",
+	3010: "RESOURCE_BINDING_TRANSFER_OUT",
 }
 
 var BindType_value = map[string]int32{
@@ -118,6 +124,8 @@ var BindType_value = map[string]int32{
 	"RESOURCE_BINDING_COLQUEUE_PUSHPOP":          2222,
 	"RESOURCE_BINDING_COLQUEUE_POP":              2223,
 	"RESOURCE_BINDING_COLQUEUE_REMOVE":           2224,
+	"RESOURCE_BINDING_TRANSFER_IN":               3001,
+	"RESOURCE_BINDING_TRANSFER_OUT":              3010,
 }
 
 func (x BindType) String() string {
@@ -135,26 +143,28 @@ func init() {
 func init() { proto.RegisterFile("bindtype.proto", fileDescriptor_909dbdd80987cb9a) }
 
 var fileDescriptor_909dbdd80987cb9a = []byte{
-	// 332 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x4b, 0xca, 0xcc, 0x4b,
-	0x29, 0xa9, 0x2c, 0x48, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x80, 0xf1, 0xb5, 0x6e,
-	0xb0, 0x71, 0x71, 0x38, 0x65, 0xe6, 0xa5, 0x84, 0x54, 0x16, 0xa4, 0x0a, 0x49, 0x72, 0x89, 0x06,
-	0xb9, 0x06, 0xfb, 0x87, 0x06, 0x39, 0xbb, 0xc6, 0x3b, 0x79, 0xfa, 0xb9, 0x78, 0xfa, 0xb9, 0xc7,
-	0xfb, 0xf9, 0xfb, 0xb9, 0x0a, 0x30, 0x08, 0xa9, 0x70, 0xc9, 0x63, 0x48, 0x39, 0xfb, 0xfb, 0x85,
-	0x38, 0x7a, 0xfa, 0xb9, 0x06, 0x81, 0x45, 0x04, 0x5e, 0xb2, 0x0b, 0x29, 0x73, 0xc9, 0xe1, 0x51,
-	0xe5, 0xe8, 0xe2, 0x22, 0xf0, 0x8a, 0x5d, 0x48, 0x95, 0x4b, 0x01, 0x8f, 0x22, 0x67, 0x1f, 0x57,
-	0xc7, 0x20, 0x81, 0x4f, 0xec, 0x42, 0xfa, 0x5c, 0x5a, 0x04, 0x94, 0xf9, 0xc5, 0xbb, 0xb8, 0x86,
-	0x79, 0x3a, 0x86, 0x78, 0xfa, 0xfb, 0x09, 0x7c, 0x66, 0x17, 0x92, 0xe7, 0x92, 0xc2, 0xd0, 0x10,
-	0xec, 0xe3, 0x1f, 0x02, 0x71, 0xdd, 0x59, 0x0e, 0x21, 0x05, 0x2e, 0x69, 0xec, 0x0a, 0x20, 0x76,
-	0x5e, 0xe3, 0xc0, 0xe1, 0x34, 0x1f, 0x1f, 0x57, 0x67, 0x90, 0x25, 0x10, 0x83, 0x36, 0x72, 0xe2,
-	0x08, 0x0c, 0xb8, 0x32, 0x90, 0x3f, 0x37, 0x71, 0x0a, 0xa9, 0x71, 0x29, 0xe2, 0x53, 0x05, 0xb1,
-	0x74, 0x17, 0x27, 0x56, 0x67, 0x05, 0x86, 0xba, 0x86, 0x42, 0x78, 0x02, 0x5b, 0x05, 0x84, 0x14,
-	0xb9, 0x64, 0x70, 0xa8, 0x80, 0x18, 0xb2, 0x4f, 0x00, 0x8f, 0x21, 0x01, 0xa1, 0xc1, 0x1e, 0x02,
-	0x27, 0x05, 0xb0, 0xc6, 0x0d, 0x42, 0x45, 0x80, 0x7f, 0x80, 0xc0, 0x29, 0x01, 0xac, 0x61, 0x08,
-	0x55, 0xe4, 0x1f, 0x20, 0x70, 0x5a, 0x40, 0x48, 0x89, 0x4b, 0x16, 0x87, 0x82, 0x20, 0x57, 0x5f,
-	0xff, 0x30, 0x57, 0x81, 0x33, 0x02, 0x38, 0x52, 0x81, 0x0f, 0x92, 0x9f, 0x66, 0x0a, 0x62, 0x35,
-	0x08, 0xae, 0x08, 0x14, 0x82, 0xb3, 0x04, 0x71, 0x85, 0x33, 0xb2, 0xd7, 0x17, 0x09, 0xe2, 0xb7,
-	0x0e, 0xec, 0xfb, 0xb5, 0x82, 0xb8, 0x22, 0x03, 0x35, 0x00, 0xd6, 0x11, 0x70, 0x16, 0x48, 0xcd,
-	0x7a, 0x41, 0x5c, 0xa9, 0x04, 0x25, 0x18, 0x36, 0x08, 0x26, 0xb1, 0x81, 0xf3, 0x9a, 0x31, 0x20,
-	0x00, 0x00, 0xff, 0xff, 0x5c, 0xd7, 0x2e, 0x4f, 0x7d, 0x03, 0x00, 0x00,
+	// 356 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x93, 0xbb, 0x4e, 0xf3, 0x30,
+	0x14, 0xc7, 0xbf, 0xe9, 0x4b, 0x7a, 0x06, 0x74, 0x62, 0x09, 0x55, 0x5c, 0xdb, 0x72, 0x1b, 0x3a,
+	0xc0, 0xc0, 0x13, 0xa4, 0x89, 0x81, 0x48, 0xc1, 0x0e, 0x4e, 0xd2, 0x35, 0x52, 0xd5, 0x0e, 0x5d,
+	0xda, 0x0a, 0x75, 0xe9, 0xa3, 0xc0, 0x82, 0xc4, 0xce, 0x55, 0x62, 0x80, 0x81, 0x81, 0x8d, 0xdb,
+	0xca, 0x3b, 0xc0, 0x08, 0x2f, 0x80, 0x52, 0x43, 0x68, 0x55, 0x3b, 0x1d, 0x9d, 0xfc, 0x72, 0xfe,
+	0x97, 0xe3, 0xc0, 0x4c, 0xa3, 0xdd, 0x69, 0xf6, 0x07, 0xbd, 0xd6, 0x66, 0xef, 0xb0, 0xdb, 0xef,
+	0x12, 0xf3, 0xf7, 0x5c, 0x3d, 0x31, 0xc0, 0xac, 0xb5, 0x3b, 0xcd, 0x68, 0xd0, 0x6b, 0x91, 0x39,
+	0x98, 0x15, 0x34, 0xe4, 0xb1, 0x70, 0x68, 0x52, 0xf3, 0x98, 0xeb, 0xb1, 0xdd, 0x84, 0x71, 0x46,
+	0xf1, 0x1f, 0x59, 0x83, 0xd2, 0xc4, 0x2b, 0x87, 0xb3, 0xc8, 0xf6, 0x18, 0x15, 0xc3, 0x27, 0xf8,
+	0x6e, 0x90, 0x55, 0x58, 0xce, 0xa1, 0x6c, 0xd7, 0xc5, 0x0f, 0x83, 0xac, 0x43, 0x39, 0x07, 0x72,
+	0x7c, 0x6a, 0x0b, 0xfc, 0x34, 0xc8, 0x16, 0x54, 0xa7, 0x60, 0x2c, 0x71, 0x69, 0xdd, 0xb3, 0x23,
+	0x8f, 0x33, 0xfc, 0x32, 0x48, 0x09, 0xe6, 0x27, 0x3e, 0x08, 0x7d, 0x1e, 0x49, 0x77, 0xaf, 0x26,
+	0x29, 0xc3, 0x82, 0x1a, 0x90, 0x9a, 0x6f, 0xa6, 0xc6, 0x9a, 0xef, 0x53, 0x27, 0x15, 0x91, 0x83,
+	0xae, 0x0a, 0x9a, 0x32, 0x32, 0x2c, 0xcd, 0x79, 0x5d, 0x20, 0x1b, 0x50, 0xc9, 0xa3, 0xa4, 0xe8,
+	0x5d, 0x41, 0x69, 0xeb, 0x20, 0xa6, 0xb1, 0x3c, 0xe1, 0x0d, 0x92, 0x0a, 0x2c, 0x6a, 0x08, 0x39,
+	0xe4, 0x1e, 0x73, 0x86, 0x04, 0x71, 0xb8, 0x87, 0x8f, 0xa8, 0xdc, 0xcd, 0x1f, 0x11, 0xf0, 0x00,
+	0x9f, 0x50, 0xd9, 0xe1, 0x0f, 0xc4, 0x03, 0x7c, 0x46, 0xb2, 0x02, 0x4b, 0x1a, 0x40, 0xd0, 0x7d,
+	0x5e, 0xa7, 0xf8, 0x82, 0x9a, 0x5b, 0xe0, 0x8f, 0x64, 0x3a, 0xb2, 0x94, 0x83, 0x32, 0x28, 0x6d,
+	0xf0, 0xd8, 0xd2, 0xf5, 0x3c, 0x1a, 0xfd, 0xd4, 0xca, 0x97, 0x1b, 0xa6, 0x3f, 0xb3, 0x74, 0xcb,
+	0x18, 0x2f, 0xe0, 0x7c, 0x8a, 0xad, 0x94, 0xb9, 0xb0, 0x74, 0xb7, 0x64, 0xac, 0x86, 0x4b, 0x4b,
+	0xb9, 0xb5, 0x48, 0xd8, 0x2c, 0xdc, 0xa1, 0x22, 0xf1, 0x18, 0xde, 0x16, 0x95, 0x6a, 0x19, 0xc2,
+	0xe3, 0x08, 0x1f, 0x8a, 0x8d, 0xff, 0xc3, 0x5f, 0x76, 0xfb, 0x7b, 0x00, 0x18, 0xb6, 0x8f, 0x2f,
+	0xc4, 0x03, 0x00, 0x00,
 }
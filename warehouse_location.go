@@ -0,0 +1,106 @@
+package mcom
+
+import (
+	"github.com/shopspring/decimal"
+
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+)
+
+// WarehouseLocation definition.
+type WarehouseLocation struct {
+	WarehouseID string `validate:"required"`
+	// ID is the location code, which is used as Warehouse.Location.
+	ID string `validate:"required,max=32"`
+	// ParentID is the location containing this one in the same warehouse, it
+	// is empty for the top-level locations.
+	ParentID string `validate:"max=32"`
+	Type     models.WarehouseLocationType
+	// Capacity is the max total quantity of the stocks in the location and
+	// its descendants, it is unlimited if zero.
+	Capacity decimal.Decimal
+	// ProductTypes are the product types allowed in the location and its
+	// descendants, all the product types are allowed if empty.
+	ProductTypes []string
+}
+
+func (l WarehouseLocation) check() error {
+	if !l.Type.IsValid() {
+		return mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "invalid location type, location: " + l.ID}
+	}
+	if l.ParentID == l.ID {
+		return mcomErr.Error{Code: mcomErr.Code_BAD_REQUEST, Details: "the location cannot be its own parent, location: " + l.ID}
+	}
+	if l.Capacity.IsNegative() {
+		return mcomErr.Error{Code: mcomErr.Code_INVALID_NUMBER, Details: "negative capacity, location: " + l.ID}
+	}
+	return nil
+}
+
+// CreateWarehouseLocationsRequest definition.
+type CreateWarehouseLocationsRequest struct {
+	// Locations are created in order, so that a parent can be created with
+	// its children in the same request.
+	Locations []WarehouseLocation `validate:"min=1,dive"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req CreateWarehouseLocationsRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	for _, location := range req.Locations {
+		if err := location.check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateWarehouseLocationRequest definition.
+type UpdateWarehouseLocationRequest struct {
+	WarehouseID string `validate:"required"`
+	ID          string `validate:"required"`
+	// Capacity and ProductTypes replace the current ones, see
+	// WarehouseLocation for their definitions.
+	Capacity     decimal.Decimal
+	ProductTypes []string
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req UpdateWarehouseLocationRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	if req.Capacity.IsNegative() {
+		return mcomErr.Error{Code: mcomErr.Code_INVALID_NUMBER, Details: "negative capacity, location: " + req.ID}
+	}
+	return nil
+}
+
+// ListWarehouseLocationsRequest definition.
+type ListWarehouseLocationsRequest struct {
+	WarehouseID string `validate:"required"`
+}
+
+// CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.
+func (req ListWarehouseLocationsRequest) CheckInsufficiency() error {
+	if err := validate.Struct(req); err != nil {
+		return mcomErr.Error{Code: mcomErr.Code_INSUFFICIENT_REQUEST, Details: err.Error()}
+	}
+	return nil
+}
+
+// WarehouseLocationInformation definition.
+type WarehouseLocationInformation struct {
+	WarehouseLocation
+	// Occupancy is the total quantity of the stocks in the location and its
+	// descendants.
+	Occupancy decimal.Decimal
+}
+
+// ListWarehouseLocationsReply definition.
+type ListWarehouseLocationsReply struct {
+	// Locations are ordered by ID.
+	Locations []WarehouseLocationInformation
+}
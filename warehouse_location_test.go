@@ -0,0 +1,97 @@
+package mcom
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	mcomErr "gitlab.kenda.com.tw/kenda/mcom/errors"
+	"gitlab.kenda.com.tw/kenda/mcom/impl/orm/models"
+)
+
+func Test_CreateWarehouseLocationsRequest(t *testing.T) {
+	assert := assert.New(t)
+	{ // good case.
+		assert.NoError(CreateWarehouseLocationsRequest{
+			Locations: []WarehouseLocation{{
+				WarehouseID: "A",
+				ID:          "Z1",
+				Type:        models.WarehouseLocationZone,
+			}, {
+				WarehouseID:  "A",
+				ID:           "Z1-R1",
+				ParentID:     "Z1",
+				Type:         models.WarehouseLocationRack,
+				Capacity:     decimal.NewFromInt(100),
+				ProductTypes: []string{"RUBBER"},
+			}},
+		}.CheckInsufficiency())
+	}
+	{ // no location.
+		assert.ErrorIs(CreateWarehouseLocationsRequest{}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "Key: 'CreateWarehouseLocationsRequest.Locations' Error:Field validation for 'Locations' failed on the 'min' tag",
+		})
+	}
+	{ // missing location id.
+		assert.ErrorIs(CreateWarehouseLocationsRequest{
+			Locations: []WarehouseLocation{{WarehouseID: "A", Type: models.WarehouseLocationBin}},
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "Key: 'CreateWarehouseLocationsRequest.Locations[0].ID' Error:Field validation for 'ID' failed on the 'required' tag",
+		})
+	}
+	{ // invalid type.
+		assert.ErrorIs(CreateWarehouseLocationsRequest{
+			Locations: []WarehouseLocation{{WarehouseID: "A", ID: "B1"}},
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_BAD_REQUEST,
+			Details: "invalid location type, location: B1",
+		})
+	}
+	{ // its own parent.
+		assert.ErrorIs(CreateWarehouseLocationsRequest{
+			Locations: []WarehouseLocation{{WarehouseID: "A", ID: "B1", ParentID: "B1", Type: models.WarehouseLocationBin}},
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_BAD_REQUEST,
+			Details: "the location cannot be its own parent, location: B1",
+		})
+	}
+	{ // negative capacity.
+		assert.ErrorIs(CreateWarehouseLocationsRequest{
+			Locations: []WarehouseLocation{{WarehouseID: "A", ID: "B1", Type: models.WarehouseLocationBin, Capacity: decimal.NewFromInt(-1)}},
+		}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INVALID_NUMBER,
+			Details: "negative capacity, location: B1",
+		})
+	}
+}
+
+func Test_UpdateWarehouseLocationRequest(t *testing.T) {
+	assert := assert.New(t)
+	{ // good case.
+		assert.NoError(UpdateWarehouseLocationRequest{WarehouseID: "A", ID: "B1"}.CheckInsufficiency())
+	}
+	{ // missing location id.
+		assert.ErrorIs(UpdateWarehouseLocationRequest{WarehouseID: "A"}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+			Details: "Key: 'UpdateWarehouseLocationRequest.ID' Error:Field validation for 'ID' failed on the 'required' tag",
+		})
+	}
+	{ // negative capacity.
+		assert.ErrorIs(UpdateWarehouseLocationRequest{WarehouseID: "A", ID: "B1", Capacity: decimal.NewFromInt(-1)}.CheckInsufficiency(), mcomErr.Error{
+			Code:    mcomErr.Code_INVALID_NUMBER,
+			Details: "negative capacity, location: B1",
+		})
+	}
+}
+
+func Test_ListWarehouseLocationsRequest(t *testing.T) {
+	assert := assert.New(t)
+	assert.NoError(ListWarehouseLocationsRequest{WarehouseID: "A"}.CheckInsufficiency())
+	assert.ErrorIs(ListWarehouseLocationsRequest{}.CheckInsufficiency(), mcomErr.Error{
+		Code:    mcomErr.Code_INSUFFICIENT_REQUEST,
+		Details: "Key: 'ListWarehouseLocationsRequest.WarehouseID' Error:Field validation for 'WarehouseID' failed on the 'required' tag",
+	})
+}
@@ -169,6 +169,10 @@ type ListWorkOrdersByDurationRequest struct {
 	Limit        int
 	Station      string
 	DepartmentID string
+	// ResolveWorkDate resolves Since and Until to the work dates by the shift
+	// calendar of the department, or the department of the station. They are
+	// regarded as dates if ResolveWorkDate is false.
+	ResolveWorkDate bool
 }
 
 // CheckInsufficiency implements gitlab.kenda.com.tw/kenda/mcom Request interface.